@@ -0,0 +1,436 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// AbsenceController handles requests for absence endpoints.
+type AbsenceController struct {
+	aServ *service.AbsenceService
+}
+
+// NewAbsenceController create a new absence controller.
+func NewAbsenceController(as *service.AbsenceService) *AbsenceController {
+	return &AbsenceController{as}
+}
+
+// --- Parameters ---
+
+// swagger:parameters getAbsence
+type GetAbsenceParameters struct {
+	// The ID of the absence.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createAbsence
+type CreateAbsenceParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateAbsence
+}
+
+// swagger:parameters updateAbsence
+type UpdateAbsenceParameters struct {
+	// The ID of the absence.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+	// in: body
+	// required: true
+	Body model.UpdateAbsence
+}
+
+// swagger:parameters deleteAbsence
+type DeleteAbsenceParameters struct {
+	// The ID of the absence.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// --- Responses ---
+
+// The absence.
+// swagger:response GetAbsenceResponse
+type GetAbsenceResponse struct {
+	// in: body
+	Body model.Absence
+}
+
+// The created absence.
+// swagger:response CreateAbsenceResponse
+type CreateAbsenceResponse struct {
+	// in: body
+	Body model.Absence
+}
+
+// The updated absence.
+// swagger:response UpdateAbsenceResponse
+type UpdateAbsenceResponse struct {
+	// in: body
+	Body model.Absence
+}
+
+// --- Endpoints ---
+
+// CreateAbsenceHandler returns a handler for "POST /absences".
+func (c *AbsenceController) CreateAbsenceHandler() echo.HandlerFunc {
+	// swagger:operation POST /absences absences createAbsence
+	//
+	// Create an absence (vacation or illness) over a date range. For every working day of the range
+	// an entry is created. The duration of the entries is derived from the daily working hours of
+	// the user's contract. Weekends and days with holiday entries are skipped.
+	//
+	// # Input Rules
+	//
+	// __Day fraction:__
+	//
+	// ⦁ Allowed values: `full`, `half`
+	//
+	// __Description:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateAbsenceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-326]: Invalid day fraction\n
+	//       ⦁ [-406]: Invalid date interval\n
+	//       ⦁ [-418]: Entry type not allowed for absences\n
+	//       ⦁ [-419]: Absence without working days"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to create entries for other users\n
+	//       ⦁ [-210]: No right to create own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var aca model.CreateAbsence
+		if err := readRequestBody(eCtx, &aca); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateAbsence(&aca); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		absence := mapper.FromCreateAbsence(&aca)
+
+		// Execute action
+		if err := c.aServ.CreateAbsence(getContext(eCtx), absence); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aa := mapper.ToAbsence(absence)
+		return writeResponse(eCtx, http.StatusOK, aa)
+	}
+}
+
+// GetAbsenceHandler returns a handler for "GET /absences/{id}".
+func (c *AbsenceController) GetAbsenceHandler() echo.HandlerFunc {
+	// swagger:operation GET /absences/{id} absences getAbsence
+	//
+	// Get an absence by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetAbsenceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-417]: Absence not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		absence, err := c.aServ.GetAbsenceById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Check if a absence was found
+		if absence == nil {
+			err := e.NewError(e.LogicAbsenceNotFound, fmt.Sprintf("Could not find absence %d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		aa := mapper.ToAbsence(absence)
+		return writeResponse(eCtx, http.StatusOK, aa)
+	}
+}
+
+// UpdateAbsenceHandler returns a handler for "PUT /absences/{id}".
+func (c *AbsenceController) UpdateAbsenceHandler() echo.HandlerFunc {
+	// swagger:operation PUT /absences/{id} absences updateAbsence
+	//
+	// Update an absence by its ID. The entries of the absence are replaced by new entries for the
+	// updated date range.
+	//
+	// # Input Rules
+	//
+	// __Day fraction:__
+	//
+	// ⦁ Allowed values: `full`, `half`
+	//
+	// __Description:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateAbsenceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-326]: Invalid day fraction\n
+	//       ⦁ [-406]: Invalid date interval\n
+	//       ⦁ [-418]: Entry type not allowed for absences\n
+	//       ⦁ [-419]: Absence without working days"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to update entries of other users"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-417]: Absence not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var aua model.UpdateAbsence
+		if err := readRequestBody(eCtx, &aua); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateAbsence(&aua); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		absence := mapper.FromUpdateAbsence(id, &aua)
+
+		// Execute action
+		if err := c.aServ.UpdateAbsence(getContext(eCtx), absence); err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Convert to API model and write response
+		aa := mapper.ToAbsence(absence)
+		return writeResponse(eCtx, http.StatusOK, aa)
+	}
+}
+
+// DeleteAbsenceHandler returns a handler for "DELETE /absences/{id}".
+func (c *AbsenceController) DeleteAbsenceHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /absences/{id} absences deleteAbsence
+	//
+	// Delete an absence and all of its entries by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to delete entries of other users"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-417]: Absence not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.aServ.DeleteAbsenceById(getContext(eCtx), id); err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Permission helper functions ---
+
+func (c *AbsenceController) convertPermissionError(ctx context.Context, id int, err error) error {
+	er, ok := err.(*e.Error)
+	if ok && er.IsPermissionError() && !hasCurrentUserRight(ctx, m.RightGetAllEntries) {
+		return e.WrapError(e.LogicAbsenceNotFound, fmt.Sprintf("Could not find absence %d.", id),
+			err)
+	}
+	return err
+}
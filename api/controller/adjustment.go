@@ -0,0 +1,412 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// AdjustmentController handles requests for balance adjustment endpoints.
+type AdjustmentController struct {
+	bServ *service.AdjustmentService
+}
+
+// NewAdjustmentController create a new adjustment controller.
+func NewAdjustmentController(bs *service.AdjustmentService) *AdjustmentController {
+	return &AdjustmentController{bs}
+}
+
+// --- Parameters ---
+
+// swagger:parameters listUserBalanceAdjustments
+type ListUserBalanceAdjustmentsParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createUserBalanceAdjustment
+type CreateUserBalanceAdjustmentParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.CreateBalanceAdjustment
+}
+
+// swagger:parameters getBalanceAdjustment
+type GetBalanceAdjustmentParameters struct {
+	// The ID of the balance adjustment.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters deleteBalanceAdjustment
+type DeleteBalanceAdjustmentParameters struct {
+	// The ID of the balance adjustment.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// --- Responses ---
+
+// The list of balance adjustments.
+// swagger:response ListBalanceAdjustmentsResponse
+type ListBalanceAdjustmentsResponse struct {
+	// in: body
+	Body model.BalanceAdjustmentList
+}
+
+// The balance adjustment.
+// swagger:response GetBalanceAdjustmentResponse
+type GetBalanceAdjustmentResponse struct {
+	// in: body
+	Body model.BalanceAdjustment
+}
+
+// The created balance adjustment.
+// swagger:response CreateBalanceAdjustmentResponse
+type CreateBalanceAdjustmentResponse struct {
+	// in: body
+	Body model.BalanceAdjustment
+}
+
+// --- Endpoints ---
+
+// GetUserBalanceAdjustmentsHandler returns a handler for "GET /users/{id}/balance_adjustments".
+func (c *AdjustmentController) GetUserBalanceAdjustmentsHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/balance_adjustments adjustments listUserBalanceAdjustments
+	//
+	// Lists the balance adjustments of a user (ordered by date).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListBalanceAdjustmentsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		adjustments, err := c.bServ.GetBalanceAdjustmentsByUserId(getContext(eCtx), userId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		abal := mapper.ToBalanceAdjustments(adjustments)
+		return writeResponse(eCtx, http.StatusOK, abal)
+	}
+}
+
+// CreateUserBalanceAdjustmentHandler returns a handler for "POST /users/{id}/balance_adjustments".
+func (c *AdjustmentController) CreateUserBalanceAdjustmentHandler() echo.HandlerFunc {
+	// swagger:operation POST /users/{id}/balance_adjustments adjustments createUserBalanceAdjustment
+	//
+	// Create a balance adjustment for a user. Overtime adjustments (in hours) are added to the
+	// total overtime, vacation adjustments (in days) are added to the vacation ledger. Adjustments
+	// are only considered from their effective date. The current user is recorded as creator.
+	//
+	// # Input Rules
+	//
+	// __Type:__
+	//
+	// ⦁ Allowed values: `overtime`, `vacation`
+	//
+	// __Reason:__
+	//
+	// ⦁ Minimum length: 1
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateBalanceAdjustmentResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-328]: Invalid adjustment type"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-202]: No right to create balance adjustments"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-408]: User not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		acba := model.CreateBalanceAdjustment{}
+		if err := readRequestBody(eCtx, &acba); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateBalanceAdjustment(&acba); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		adjustment := mapper.FromCreateBalanceAdjustment(userId, &acba)
+
+		// Execute action
+		if err := c.bServ.CreateBalanceAdjustment(getContext(eCtx), adjustment); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aba := mapper.ToBalanceAdjustment(adjustment)
+		return writeResponse(eCtx, http.StatusOK, aba)
+	}
+}
+
+// GetBalanceAdjustmentHandler returns a handler for "GET /balance_adjustments/{id}".
+func (c *AdjustmentController) GetBalanceAdjustmentHandler() echo.HandlerFunc {
+	// swagger:operation GET /balance_adjustments/{id} adjustments getBalanceAdjustment
+	//
+	// Get a balance adjustment by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetBalanceAdjustmentResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-423]: Balance adjustment not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		adjustment, err := c.bServ.GetBalanceAdjustmentById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Check if an adjustment was found
+		if adjustment == nil {
+			err := e.NewError(e.LogicBalanceAdjustmentNotFound, fmt.Sprintf("Could not find "+
+				"balance adjustment %d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		aba := mapper.ToBalanceAdjustment(adjustment)
+		return writeResponse(eCtx, http.StatusOK, aba)
+	}
+}
+
+// DeleteBalanceAdjustmentHandler returns a handler for "DELETE /balance_adjustments/{id}".
+func (c *AdjustmentController) DeleteBalanceAdjustmentHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /balance_adjustments/{id} adjustments deleteBalanceAdjustment
+	//
+	// Delete a balance adjustment.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-202]: No right to delete balance adjustments"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-423]: Balance adjustment not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.bServ.DeleteBalanceAdjustmentById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Permission helper functions ---
+
+func (c *AdjustmentController) convertPermissionError(ctx context.Context, id int,
+	err error) error {
+	er, ok := err.(*e.Error)
+	if ok && er.IsPermissionError() && !hasCurrentUserRight(ctx, m.RightGetAllEntries) {
+		return e.WrapError(e.LogicBalanceAdjustmentNotFound, fmt.Sprintf("Could not find balance "+
+			"adjustment %d.", id), err)
+	}
+	return err
+}
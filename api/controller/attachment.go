@@ -0,0 +1,548 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// AttachmentController handles requests for attachment endpoints.
+type AttachmentController struct {
+	fServ *service.AttachmentService
+}
+
+// NewAttachmentController create a new attachment controller.
+func NewAttachmentController(fs *service.AttachmentService) *AttachmentController {
+	return &AttachmentController{fs}
+}
+
+// --- Parameters ---
+
+// swagger:parameters listEntryAttachments
+type ListEntryAttachmentsParameters struct {
+	// The ID of the entry.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createEntryAttachment
+type CreateEntryAttachmentParameters struct {
+	// The ID of the entry.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The file to attach.
+	//
+	// in: formData
+	// required: true
+	// swagger:file
+	File any `json:"file"`
+}
+
+// swagger:parameters getAttachment
+type GetAttachmentParameters struct {
+	// The ID of the attachment.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters getAttachmentContent
+type GetAttachmentContentParameters struct {
+	// The ID of the attachment.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters deleteAttachment
+type DeleteAttachmentParameters struct {
+	// The ID of the attachment.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// --- Responses ---
+
+// The list of attachments.
+// swagger:response ListAttachmentsResponse
+type ListAttachmentsResponse struct {
+	// in: body
+	Body model.AttachmentList
+}
+
+// The attachment.
+// swagger:response GetAttachmentResponse
+type GetAttachmentResponse struct {
+	// in: body
+	Body model.Attachment
+}
+
+// The created attachment.
+// swagger:response CreateAttachmentResponse
+type CreateAttachmentResponse struct {
+	// in: body
+	Body model.Attachment
+}
+
+// --- Endpoints ---
+
+// GetEntryAttachmentsHandler returns a handler for "GET /entries/{id}/attachments".
+func (c *AttachmentController) GetEntryAttachmentsHandler() echo.HandlerFunc {
+	// swagger:operation GET /entries/{id}/attachments attachments listEntryAttachments
+	//
+	// Lists the attachments of an entry.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListAttachmentsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-401]: Entry not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get entry ID from request
+		entryId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		attachments, err := c.fServ.GetAttachmentsByEntryId(getContext(eCtx), entryId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aal := mapper.ToAttachments(attachments)
+		return writeResponse(eCtx, http.StatusOK, aal)
+	}
+}
+
+// CreateEntryAttachmentHandler returns a handler for "POST /entries/{id}/attachments".
+func (c *AttachmentController) CreateEntryAttachmentHandler() echo.HandlerFunc {
+	// swagger:operation POST /entries/{id}/attachments attachments createEntryAttachment
+	//
+	// Upload a file and attach it to an entry. The MIME type is detected from the content of the
+	// file. Attachments of invoiced entries and entries in closed years can not be changed.
+	//
+	// # Input Rules
+	//
+	// __File:__
+	//
+	// ⦁ Must not exceed the configured maximum size
+	//
+	// ⦁ Must have one of the configured MIME types
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - multipart/form-data
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateAttachmentResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-335]: File missing"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to change entries of other users\n
+	//       ⦁ [-210]: No right to change own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-401]: Entry not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed\n
+	//       ⦁ [-438]: Entry already invoiced"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '413':
+	//     description: "__Payload Too Large__\n\n
+	//       ⦁ [-436]: File too large"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '415':
+	//     description: "__Unsupported Media Type__\n\n
+	//       ⦁ [-437]: File type not allowed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get entry ID from request
+		entryId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Limit request size (too large requests are aborted while the form is read)
+		eCtx.Request().Body = http.MaxBytesReader(eCtx.Response(), eCtx.Request().Body,
+			c.fServ.MaxRequestSize())
+
+		// Get file from request
+		fh, fErr := eCtx.FormFile("file")
+		if fErr != nil {
+			return createAttachmentFormError(fErr)
+		}
+		f, oErr := fh.Open()
+		if oErr != nil {
+			err := e.WrapError(e.ValFileMissing, "Invalid file. (File not readable.)", oErr)
+			log.Debug(err.StackTrace())
+			return err
+		}
+		defer f.Close()
+
+		// Create logic model
+		attachment := m.NewAttachment()
+		attachment.EntryId = entryId
+		attachment.FileName = fh.Filename
+
+		// Execute action
+		if err := c.fServ.CreateAttachment(getContext(eCtx), attachment, f); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aa := mapper.ToAttachment(attachment)
+		return writeResponse(eCtx, http.StatusOK, aa)
+	}
+}
+
+// GetAttachmentHandler returns a handler for "GET /attachments/{id}".
+func (c *AttachmentController) GetAttachmentHandler() echo.HandlerFunc {
+	// swagger:operation GET /attachments/{id} attachments getAttachment
+	//
+	// Get information about an attachment by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetAttachmentResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-435]: Attachment not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		attachment, err := c.fServ.GetAttachmentById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Check if an attachment was found
+		if attachment == nil {
+			err := e.NewError(e.LogicAttachmentNotFound, fmt.Sprintf("Could not find attachment "+
+				"%d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		aa := mapper.ToAttachment(attachment)
+		return writeResponse(eCtx, http.StatusOK, aa)
+	}
+}
+
+// GetAttachmentContentHandler returns a handler for "GET /attachments/{id}/content".
+func (c *AttachmentController) GetAttachmentContentHandler() echo.HandlerFunc {
+	// swagger:operation GET /attachments/{id}/content attachments getAttachmentContent
+	//
+	// Download the file of an attachment by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/octet-stream
+	//
+	// responses:
+	//   '200':
+	//     description: "The file."
+	//     schema:
+	//       type: file
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-435]: Attachment not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		attachment, r, err := c.fServ.OpenAttachmentById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+		defer r.Close()
+
+		// Write response
+		return writeAttachmentResponse(eCtx, attachment, r)
+	}
+}
+
+// DeleteAttachmentHandler returns a handler for "DELETE /attachments/{id}".
+func (c *AttachmentController) DeleteAttachmentHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /attachments/{id} attachments deleteAttachment
+	//
+	// Delete an attachment and its file by its ID. Attachments of invoiced entries and entries in
+	// closed years can not be deleted.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to change entries of other users\n
+	//       ⦁ [-210]: No right to change own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-435]: Attachment not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed\n
+	//       ⦁ [-438]: Entry already invoiced"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.fServ.DeleteAttachmentById(getContext(eCtx), id); err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Helper functions ---
+
+func writeAttachmentResponse(eCtx echo.Context, attachment *m.Attachment, r io.Reader) error {
+	res := eCtx.Response()
+
+	res.Header().Set(echo.HeaderContentType, attachment.MimeType)
+	res.Header().Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment",
+		map[string]string{"filename": attachment.FileName}))
+	res.WriteHeader(http.StatusOK)
+
+	_, wErr := io.Copy(res.Writer, r)
+	if wErr != nil {
+		err := e.WrapError(e.SysUnknown, "Could not write response.", wErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func createAttachmentFormError(fErr error) error {
+	var mbErr *http.MaxBytesError
+	if errors.As(fErr, &mbErr) {
+		err := e.WrapError(e.LogicAttachmentTooLarge, fmt.Sprintf("Attachment too large. "+
+			"(Request must not be larger than %d bytes.)", mbErr.Limit), fErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	err := e.WrapError(e.ValFileMissing, "Invalid file. (File missing.)", fErr)
+	log.Debug(err.StackTrace())
+	return err
+}
+
+// --- Permission helper functions ---
+
+func (c *AttachmentController) convertPermissionError(ctx context.Context, id int,
+	err error) error {
+	er, ok := err.(*e.Error)
+	if ok && er.IsPermissionError() && !hasCurrentUserRight(ctx, m.RightGetAllEntries) {
+		return e.WrapError(e.LogicAttachmentNotFound, fmt.Sprintf("Could not find attachment %d.",
+			id), err)
+	}
+	return err
+}
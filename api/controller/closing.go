@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// ClosingController handles requests for year closing endpoints.
+type ClosingController struct {
+	yServ *service.ClosingService
+}
+
+// NewClosingController create a new closing controller.
+func NewClosingController(ys *service.ClosingService) *ClosingController {
+	return &ClosingController{ys}
+}
+
+// --- Parameters ---
+
+// swagger:parameters listUserYearClosings
+type ListUserYearClosingsParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters closeUserYear
+type CloseUserYearParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The year to close.
+	//
+	// in: path
+	// required: true
+	Year int `json:"year"`
+}
+
+// swagger:parameters reopenUserYear
+type ReopenUserYearParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The year to reopen.
+	//
+	// in: path
+	// required: true
+	Year int `json:"year"`
+}
+
+// --- Responses ---
+
+// The list of year closings.
+// swagger:response ListYearClosingsResponse
+type ListYearClosingsResponse struct {
+	// in: body
+	Body model.YearClosingList
+}
+
+// The created year closing.
+// swagger:response CloseYearResponse
+type CloseYearResponse struct {
+	// in: body
+	Body model.YearClosing
+}
+
+// --- Endpoints ---
+
+// GetUserYearClosingsHandler returns a handler for "GET /users/{id}/year_closings".
+func (c *ClosingController) GetUserYearClosingsHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/year_closings closings listUserYearClosings
+	//
+	// Lists the closed years of a user (ordered by year).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListYearClosingsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		closings, err := c.yServ.GetYearClosingsByUserId(getContext(eCtx), userId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aycl := mapper.ToYearClosings(closings)
+		return writeResponse(eCtx, http.StatusOK, aycl)
+	}
+}
+
+// CloseUserYearHandler returns a handler for "POST /users/{id}/year_closings/{year}".
+func (c *ClosingController) CloseUserYearHandler() echo.HandlerFunc {
+	// swagger:operation POST /users/{id}/year_closings/{year} closings closeUserYear
+	//
+	// Close a year of a user. The overtime and vacation balances at the end of the year are stored
+	// and later calculations start from them. Entries, absences, vacation requests and balance
+	// adjustments inside a closed year can no longer be changed. Years must be closed in order,
+	// starting with the year of the first work day, and only after they have ended.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CloseYearResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-329]: Invalid year\n
+	//       ⦁ [-424]: Year can not be closed or reopened"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-202]: No right to close years"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID and year from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+		year, err := getYearPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		closing, err := c.yServ.CloseYear(getContext(eCtx), userId, year)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ayc := mapper.ToYearClosing(closing)
+		return writeResponse(eCtx, http.StatusOK, ayc)
+	}
+}
+
+// ReopenUserYearHandler returns a handler for "DELETE /users/{id}/year_closings/{year}".
+func (c *ClosingController) ReopenUserYearHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /users/{id}/year_closings/{year} closings reopenUserYear
+	//
+	// Reopen a closed year of a user. Only the latest closed year can be reopened.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-329]: Invalid year\n
+	//       ⦁ [-424]: Year can not be closed or reopened"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-202]: No right to reopen years"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-426]: Year closing not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID and year from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+		year, err := getYearPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.yServ.ReopenYear(getContext(eCtx), userId, year); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Helper functions ---
+
+func getYearPathVar(eCtx echo.Context) (int, error) {
+	v := eCtx.Param("year")
+	year, pErr := strconv.Atoi(v)
+	if pErr != nil || year < 1 || year > 9999 {
+		err := e.NewError(e.ValYearInvalid, "Invalid year variable. (Variable must be a year "+
+			"between 1 and 9999.)")
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+	return year, nil
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/pkg/constant"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+const complianceReportMonthFormat = "2006-01"
+
+// ComplianceController handles requests for compliance endpoints.
+type ComplianceController struct {
+	cServ *service.ComplianceService
+}
+
+// NewComplianceController create a new compliance controller.
+func NewComplianceController(cs *service.ComplianceService) *ComplianceController {
+	return &ComplianceController{cs}
+}
+
+// --- Parameters ---
+
+// swagger:parameters getUserCompliance
+type GetUserComplianceParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The first day of the period. (default=first day of the current month)
+	//
+	// in: query
+	// required: false
+	Start string `json:"start"`
+
+	// The last day of the period. (default=last day of the current month)
+	//
+	// in: query
+	// required: false
+	End string `json:"end"`
+}
+
+// swagger:parameters getComplianceReport
+type GetComplianceReportParameters struct {
+	// The month of the report (format "YYYY-MM"). (default=previous month)
+	//
+	// in: query
+	// required: false
+	Month string `json:"month"`
+}
+
+// --- Responses ---
+
+// The list of compliance violations.
+// swagger:response GetUserComplianceResponse
+type GetUserComplianceResponse struct {
+	// in: body
+	Body model.ComplianceViolationList
+}
+
+// The compliance report.
+// swagger:response GetComplianceReportResponse
+type GetComplianceReportResponse struct {
+	// in: body
+	Body model.ComplianceReport
+}
+
+// --- Endpoints ---
+
+// GetUserComplianceHandler returns a handler for "GET /users/{id}/compliance".
+func (c *ComplianceController) GetUserComplianceHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/compliance compliance getUserCompliance
+	//
+	// Checks the entries of a user against the working time compliance rules (maximum daily and
+	// weekly hours, minimum rest period, Sunday work and night work).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetUserComplianceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-313]: Invalid date"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Get period from request
+		now := time.Now().In(getCurrentUserLocation(getContext(eCtx)))
+		defStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		start, err := getDateQueryParam(eCtx, "start", defStart)
+		if err != nil {
+			return err
+		}
+		end, err := getDateQueryParam(eCtx, "end", defStart.AddDate(0, 1, -1))
+		if err != nil {
+			return err
+		}
+		if end.Before(start) {
+			err := e.NewError(e.ValDateInvalid, "Invalid end date. (End must not be before start.)")
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Execute action
+		violations, err := c.cServ.GetComplianceViolationsByUserId(getContext(eCtx), userId, start,
+			end.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		acvs := mapper.ToComplianceViolations(violations)
+		return writeResponse(eCtx, http.StatusOK, acvs)
+	}
+}
+
+// GetComplianceReportHandler returns a handler for "GET /compliance/report".
+func (c *ComplianceController) GetComplianceReportHandler() echo.HandlerFunc {
+	// swagger:operation GET /compliance/report compliance getComplianceReport
+	//
+	// Gets a monthly report of the working time compliance violations of all users.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetComplianceReportResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-313]: Invalid date"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get month from request
+		month, err := getMonthQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		report, err := c.cServ.GetMonthComplianceReport(getContext(eCtx), month.Year(),
+			month.Month())
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		acr := mapper.ToComplianceReport(report)
+		return writeResponse(eCtx, http.StatusOK, acr)
+	}
+}
+
+// getDateQueryParam gets a date query parameter as civil date (midnight UTC).
+func getDateQueryParam(eCtx echo.Context, name string, def time.Time) (time.Time, error) {
+	qv := eCtx.QueryParam(name)
+	if qv == "" {
+		return def, nil
+	}
+	d, pErr := time.ParseInLocation(constant.ApiDateFormat, qv, time.UTC)
+	if pErr != nil {
+		err := e.WrapError(e.ValDateInvalid, "Invalid '"+name+"' date. (Date must be in format "+
+			"'YYYY-MM-DD'.)", pErr)
+		log.Debug(err.StackTrace())
+		return time.Time{}, err
+	}
+	return d, nil
+}
+
+func getMonthQueryParam(eCtx echo.Context) (time.Time, error) {
+	qv := eCtx.QueryParam("month")
+	if qv == "" {
+		now := time.Now().In(getCurrentUserLocation(getContext(eCtx)))
+		return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	m, pErr := time.ParseInLocation(complianceReportMonthFormat, qv, time.UTC)
+	if pErr != nil {
+		err := e.WrapError(e.ValDateInvalid, "Invalid 'month'. (Month must be in format "+
+			"'YYYY-MM'.)", pErr)
+		log.Debug(err.StackTrace())
+		return time.Time{}, err
+	}
+	return m, nil
+}
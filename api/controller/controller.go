@@ -35,6 +35,10 @@ func hasCurrentUserRight(ctx context.Context, right m.Right) bool {
 	return security.HasCurrentUserRight(ctx, right)
 }
 
+func getCurrentUserLocation(ctx context.Context) *time.Location {
+	return security.GetCurrentUserLocation(ctx)
+}
+
 func getIdPathVar(eCtx echo.Context) (int, error) {
 	v := eCtx.Param("id")
 	if v == "" {
@@ -106,7 +110,7 @@ func getIntQueryParam(eCtx echo.Context, name string) (int, error) {
 }
 
 func parseTimestamp(ts string) (time.Time, error) {
-	return time.ParseInLocation(constant.ApiTimestampFormat, ts, time.Local)
+	return time.Parse(constant.ApiTimestampFormat, ts)
 }
 
 func readRequestBody(eCtx echo.Context, data any) error {
@@ -117,10 +121,11 @@ func writeResponse(eCtx echo.Context, statusCode int, data any) error {
 	return httputil.WriteHttpResponse(eCtx.Response(), statusCode, data)
 }
 
-func writeFileResponse(ctx echo.Context, fileName string, file io.WriterTo) error {
+func writeFileResponse(ctx echo.Context, contentType string, fileName string,
+	file io.WriterTo) error {
 	res := ctx.Response()
 
-	res.Header().Set(echo.HeaderContentType, "text/csv")
+	res.Header().Set(echo.HeaderContentType, contentType)
 	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"",
 		fileName))
 
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// --- Entry change cursor functions ---
+
+func getEntryChangeCursor(str string) (*model.EntryChangeCursor, error) {
+	// If cursor string is empty: Abort
+	if str == "" {
+		return nil, nil
+	}
+
+	// Decode cursor string
+	cursorParts, err := decodeCursor(str, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract change time and ID from cursor parts
+	updatedAt, err := parseCursorTime(cursorParts[0])
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseCursorId(cursorParts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewEntryChangeCursor(updatedAt, id), nil
+}
+
+// --- Entry cursor functions ---
+
+func getEntryCursor(str string) (*model.EntryCursor, error) {
+	// If cursor string is empty: Abort
+	if str == "" {
+		return nil, nil
+	}
+
+	// Decode cursor string
+	cursorParts, err := decodeCursor(str, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract start time, end time and ID from cursor parts
+	startTime, err := parseCursorTime(cursorParts[0])
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := parseCursorTime(cursorParts[1])
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseCursorId(cursorParts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewEntryCursor(startTime, endTime, id), nil
+}
+
+// --- Helper functions ---
+
+func decodeCursor(str string, partCount int) ([]string, error) {
+	decStr, dErr := util.DecodeBase64(str)
+	if dErr != nil {
+		err := e.WrapError(e.ValCursorInvalid, "Invalid cursor. (Cursor could not be decoded.)",
+			dErr)
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+
+	cursorParts := strings.Split(decStr, ";")
+	if len(cursorParts) != partCount {
+		err := e.NewError(e.ValCursorInvalid, "Invalid cursor. (Cursor has wrong format.)")
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return cursorParts, nil
+}
+
+func parseCursorTime(str string) (time.Time, error) {
+	t, pErr := parseTimestamp(str)
+	if pErr != nil {
+		err := e.WrapError(e.ValCursorInvalid, "Invalid cursor. (Cursor has invalid time.)", pErr)
+		log.Debug(err.StackTrace())
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+func parseCursorId(str string) (int, error) {
+	id, pErr := strconv.Atoi(str)
+	if pErr != nil || id <= 0 {
+		err := e.NewError(e.ValCursorInvalid, "Invalid cursor. (Cursor has invalid ID.)")
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+	return id, nil
+}
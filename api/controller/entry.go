@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
@@ -51,6 +52,12 @@ type UpdateEntryParameters struct {
 	// in: path
 	// required: true
 	Id int `json:"id"`
+	// The entity tag of the entry (as returned in the `ETag` header). If supplied, the entry is only
+	// updated if it was not changed in the meantime.
+	//
+	// in: header
+	// required: false
+	IfMatch string `json:"If-Match"`
 	// in: body
 	// required: true
 	Body model.UpdateEntry
@@ -65,6 +72,34 @@ type DeleteEntryParameters struct {
 	Id int `json:"id"`
 }
 
+// swagger:parameters createEntryType
+type CreateEntryTypeParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateEntryType
+}
+
+// swagger:parameters updateEntryType
+type UpdateEntryTypeParameters struct {
+	// The ID of the entry type.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+	// in: body
+	// required: true
+	Body model.UpdateEntryType
+}
+
+// swagger:parameters deleteEntryType
+type DeleteEntryTypeParameters struct {
+	// The ID of the entry type.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
 // swagger:parameters createEntryActivity
 type CreateEntryActivityParameters struct {
 	// in: body
@@ -93,6 +128,34 @@ type DeleteEntryActivityParameters struct {
 	Id int `json:"id"`
 }
 
+// swagger:parameters mergeEntryActivity
+type MergeEntryActivityParameters struct {
+	// The ID of the entry activity.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+	// in: body
+	// required: true
+	Body model.MergeEntryActivity
+}
+
+// swagger:parameters setProjectRoundingPolicy
+type SetProjectRoundingPolicyParameters struct {
+	// in: body
+	// required: true
+	Body model.ProjectRoundingPolicy
+}
+
+// swagger:parameters deleteProjectRoundingPolicy
+type DeleteProjectRoundingPolicyParameters struct {
+	// The name of the project.
+	//
+	// in: query
+	// required: true
+	Project string `json:"project"`
+}
+
 // --- Responses ---
 
 // The list of entries.
@@ -102,9 +165,18 @@ type GetEntriesResponse struct {
 	Body model.EntryList
 }
 
+// The list of entry changes.
+// swagger:response GetEntryChangesResponse
+type GetEntryChangesResponse struct {
+	// in: body
+	Body model.EntryChangeList
+}
+
 // The entry.
 // swagger:response GetEntryResponse
 type GetEntryResponse struct {
+	// The entity tag of the entry.
+	ETag string
 	// in: body
 	Body model.Entry
 }
@@ -119,6 +191,8 @@ type CreateEntryResponse struct {
 // The updated entry.
 // swagger:response UpdateEntryResponse
 type UpdateEntryResponse struct {
+	// The entity tag of the entry.
+	ETag string
 	// in: body
 	Body model.Entry
 }
@@ -130,6 +204,20 @@ type GetEntryTypesResponse struct {
 	Body []model.EntryType
 }
 
+// The created entry type.
+// swagger:response CreateEntryTypeResponse
+type CreateEntryTypeResponse struct {
+	// in: body
+	Body model.EntryType
+}
+
+// The updated entry type.
+// swagger:response UpdateEntryTypeResponse
+type UpdateEntryTypeResponse struct {
+	// in: body
+	Body model.EntryType
+}
+
 // The list of entry activities.
 // swagger:response GetEntryActivitiesResponse
 type GetEntryActivitiesResponse struct {
@@ -151,6 +239,20 @@ type UpdateEntryActivityResponse struct {
 	Body model.EntryActivity
 }
 
+// The list of project rounding policies.
+// swagger:response GetProjectRoundingPoliciesResponse
+type GetProjectRoundingPoliciesResponse struct {
+	// in: body
+	Body []model.ProjectRoundingPolicy
+}
+
+// The project rounding policy.
+// swagger:response SetProjectRoundingPolicyResponse
+type SetProjectRoundingPolicyResponse struct {
+	// in: body
+	Body model.ProjectRoundingPolicy
+}
+
 // --- Endpoints ---
 
 // GetEntriesHandler returns a handler for "GET /entries".
@@ -164,26 +266,41 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 	// # Filtering
 	//
 	// The result can be filtered via following fields:
-	// | field name  | operators             | data type / allowed values |
-	// | ----------- | --------------------- | -------------------------- |
-	// | userId      | eq (equal)            | int                        |
-	// | typeId      | eq (equal)            | int                        |
-	// | startTime   | bt (between)          | datetime strings           |
-	// | activityId  | i (is), eq (equal)    | null, int                  |
-	// | project     | i (is), cn (contains) | null, string               |
-	// | description | i (is), cn (contains) | null, string               |
-	// | labels      | i (is), in (in)       | null, strings              |
-	// &#9432; Filters are connected via logical conjunction (AND).
+	// | field name  | operators                                         | data type / allowed values      |
+	// | ----------- | ------------------------------------------------- | ------------------------------- |
+	// | userId      | eq (equal)                                        | int                             |
+	// | typeId      | eq (equal), in (in)                               | int                             |
+	// | startTime   | bt (between), gt (greater), lt (less)             | datetime strings                |
+	// | endTime     | bt (between), gt (greater), lt (less)             | datetime strings                |
+	// | duration    | eq (equal), bt (between), gt (greater), lt (less) | int (minutes)                   |
+	// | weekday     | eq (equal), in (in)                               | int (1 = Monday ... 7 = Sunday) |
+	// | activityId  | i (is), eq (equal), in (in)                       | null, int                       |
+	// | project     | i (is), cn (contains)                             | null, string                    |
+	// | description | i (is), cn (contains)                             | null, string                    |
+	// | labels      | i (is), in (in)                                   | null, strings                   |
+	// &#9432; Filters are connected via logical conjunction (AND). Filters can be grouped with
+	// `and(...)`, `or(...)` and `not(...)`. The `userId` filter is not supported within groups.
 	//
 	// __Filter Syntax:__
 	// [field name];[operator];[value-1];...;[value-n]
 	//
+	// [filter-1]|...|[filter-n]
+	//
+	// or([filter-1]|...|[filter-n])
+	//
+	// &#9432; The characters `\`, `|`, `;` and `)` can be escaped with a backslash.
+	//
 	// __Examples:__
 	//
-	// Get entries for a specific time interval: startTime;bt;2019-01-01T00:00:00;2019-01-05T00:00:00
+	// Get entries for a specific time interval:
+	// startTime;bt;2019-01-01T00:00:00Z;2019-01-05T00:00:00Z
 	//
 	// Get entries with specific labels (OR logic): labels;in;bug;frontend
 	//
+	// Get work or travel entries on weekends: or(typeId;eq;1|typeId;eq;2)|weekday;in;6;7
+	//
+	// Get entries longer than 8 hours without label "overtime": duration;gt;480|not(labels;in;overtime)
+	//
 	// # Sorting
 	//
 	// The result can be sorted via following fields:
@@ -198,6 +315,13 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 	// __Example:__
 	// Sort entries descending by their start time: startTime;desc
 	//
+	// # Paging
+	//
+	// The result can be paged either via `offset` and `limit` or via `cursor` and `limit`. Each
+	// response contains a cursor to request the next page with. In contrast to an offset, a cursor
+	// does not skip or repeat entries if entries are created or deleted between two requests. The
+	// same filter and sort must be used for all pages. A cursor must not be combined with an offset.
+	//
 	// ---
 	//
 	// security:
@@ -218,6 +342,11 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 	//   description: Sorting applied to the entries result.
 	//   required: false
 	//   type: string
+	// - name: cursor
+	//   in: query
+	//   description: Cursor of the previous entries result page.
+	//   required: false
+	//   type: string
 	// - name: offset
 	//   in: query
 	//   description: Start of the entries result page.
@@ -240,7 +369,8 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 	//       ⦁ [-304]: Invalid filter\n
 	//       ⦁ [-305]: Invalid sort\n
 	//       ⦁ [-306]: Invalid offset\n
-	//       ⦁ [-307]: Invalid limit"
+	//       ⦁ [-307]: Invalid limit\n
+	//       ⦁ [-324]: Invalid cursor"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
@@ -264,7 +394,8 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 	//     "$ref": "#/responses/ErrorResponse"
 	return func(eCtx echo.Context) error {
 		// Get filter from request
-		f, err := getEntryFilter(getFilterQueryParam(eCtx))
+		f, err := getEntryFilter(getFilterQueryParam(eCtx),
+			getCurrentUserLocation(getContext(eCtx)))
 		if err != nil {
 			return err
 		}
@@ -275,11 +406,21 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 			return err
 		}
 
-		// Get offset and limit from request
+		// Get cursor, offset and limit from request
+		cursor, err := getEntryCursor(eCtx.QueryParam("cursor"))
+		if err != nil {
+			return err
+		}
 		var o, l int
 		if o, err = getOffsetQueryParam(eCtx); err != nil {
 			return err
 		}
+		if cursor != nil && o != 0 {
+			err := e.NewError(e.ValOffsetInvalid, "Invalid offset. (Offset must not be combined "+
+				"with a cursor.)")
+			log.Debug(err.StackTrace())
+			return err
+		}
 		if l, err = getLimitQueryParam(eCtx); err != nil {
 			return err
 		}
@@ -288,17 +429,113 @@ func (c *EntryController) GetEntriesHandler() echo.HandlerFunc {
 		}
 
 		// Execute action
-		entries, cnt, err := c.eServ.GetEntries(getContext(eCtx), f, s, o, l)
+		page, err := c.eServ.GetEntries(getContext(eCtx), f, s, cursor, o, l)
 		if err != nil {
 			return err
 		}
 
 		// Convert to API model and write response
-		aes := mapper.ToEntries(entries, o, l, cnt)
+		aes := mapper.ToEntries(page, o, l)
 		return writeResponse(eCtx, http.StatusOK, aes)
 	}
 }
 
+// GetEntryChangesHandler returns a handler for "GET /entries/changes".
+func (c *EntryController) GetEntryChangesHandler() echo.HandlerFunc {
+	// swagger:operation GET /entries/changes entries listEntryChanges
+	//
+	// Lists entry changes.
+	//
+	// Returns the entries that were created, updated or deleted after the supplied cursor, ordered
+	// by their change time. Deleted entries are only returned with their ID and change time. Only
+	// changes of entries a user can see are returned.
+	//
+	// # Synchronization
+	//
+	// To start a synchronization, request the changes without a cursor (this returns all
+	// entries). Afterwards, request the changes with the cursor of the previous response until
+	// `hasMore` is false. Store the last cursor and use it to request the next changes later.
+	//
+	// Changes of the last few seconds are only returned with a short delay.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// parameters:
+	// - name: since
+	//   in: query
+	//   description: Cursor of a previous response. (If omitted, all entries are returned.)
+	//   required: false
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of returned changes. (default=50)
+	//   required: false
+	//   type: integer
+	//   format: int32
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetEntryChangesResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-307]: Invalid limit\n
+	//       ⦁ [-324]: Invalid cursor"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get cursor from request
+		cursor, err := getEntryChangeCursor(eCtx.QueryParam("since"))
+		if err != nil {
+			return err
+		}
+
+		// Get limit from request
+		l, err := getLimitQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+		if l == 0 {
+			l = defaultPageSize
+		}
+
+		// Execute action
+		changes, err := c.eServ.GetEntryChanges(getContext(eCtx), cursor, l)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aecs := mapper.ToEntryChanges(changes)
+		return writeResponse(eCtx, http.StatusOK, aecs)
+	}
+}
+
 // CreateEntryHandler returns a handler for "POST /entries".
 func (c *EntryController) CreateEntryHandler() echo.HandlerFunc {
 	// swagger:operation POST /entries entries createEntry
@@ -364,6 +601,11 @@ func (c *EntryController) CreateEntryHandler() echo.HandlerFunc {
 	//       ⦁ [-403]: Entry activity not found"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
 	//   '412':
 	//     description: "__Precondition Failed__\n\n
 	//       ⦁ [-103]: User not activated"
@@ -461,6 +703,7 @@ func (c *EntryController) GetEntryHandler() echo.HandlerFunc {
 
 		// Convert to API model and write response
 		ae := mapper.ToEntry(entry)
+		setETagHeader(eCtx, entry.Version)
 		return writeResponse(eCtx, http.StatusOK, ae)
 	}
 }
@@ -508,6 +751,7 @@ func (c *EntryController) UpdateEntryHandler() echo.HandlerFunc {
 	//       ⦁ [-312]: Too long string\n
 	//       ⦁ [-314]: Invalid timestamp\n
 	//       ⦁ [-319]: Invalid label\n
+	//       ⦁ [-325]: Invalid version\n
 	//       ⦁ [-405]: Invalid time interval\n
 	//       ⦁ [-412]: Entry activity not allowed"
 	//     schema:
@@ -531,9 +775,17 @@ func (c *EntryController) UpdateEntryHandler() echo.HandlerFunc {
 	//       ⦁ [-403]: Entry activity not found"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed\n
+	//       ⦁ [-438]: Entry already invoiced\n
+	//       ⦁ [-449]: Entry belongs to an absence"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
 	//   '412':
 	//     description: "__Precondition Failed__\n\n
-	//       ⦁ [-103]: User not activated"
+	//       ⦁ [-103]: User not activated\n
+	//       ⦁ [-415]: Entry was changed in the meantime"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   default:
@@ -545,6 +797,12 @@ func (c *EntryController) UpdateEntryHandler() echo.HandlerFunc {
 			return err
 		}
 
+		// Get version from request
+		version, err := getIfMatchVersion(eCtx)
+		if err != nil {
+			return err
+		}
+
 		// Read API model from request
 		var aue model.UpdateEntry
 		if err := readRequestBody(eCtx, &aue); err != nil {
@@ -558,6 +816,7 @@ func (c *EntryController) UpdateEntryHandler() echo.HandlerFunc {
 
 		// Convert to logic model
 		entry := mapper.FromUpdateEntry(id, &aue)
+		entry.Version = version
 
 		// Execute action
 		if err := c.eServ.UpdateEntry(getContext(eCtx), entry); err != nil {
@@ -566,6 +825,7 @@ func (c *EntryController) UpdateEntryHandler() echo.HandlerFunc {
 
 		// Convert to API model and write response
 		ae := mapper.ToEntry(entry)
+		setETagHeader(eCtx, entry.Version)
 		return writeResponse(eCtx, http.StatusOK, ae)
 	}
 }
@@ -610,6 +870,13 @@ func (c *EntryController) DeleteEntryHandler() echo.HandlerFunc {
 	//       ⦁ [-401]: Entry not found"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed\n
+	//       ⦁ [-438]: Entry already invoiced\n
+	//       ⦁ [-449]: Entry belongs to an absence"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
 	//   '412':
 	//     description: "__Precondition Failed__\n\n
 	//       ⦁ [-103]: User not activated"
@@ -679,56 +946,11 @@ func (c *EntryController) GetEntryTypesHandler() echo.HandlerFunc {
 	}
 }
 
-// GetEntryActivitiesHandler returns a handler for "GET /entry_activites".
-func (c *EntryController) GetEntryActivitiesHandler() echo.HandlerFunc {
-	// swagger:operation GET /entry_activities entry_activities listEntryActivities
-	//
-	// Lists all entry activities.
-	//
-	// ---
-	//
-	// security:
-	// - Basic: []
-	// - Bearer Token: []
-	//
-	// produces:
-	// - application/json
-	//
-	// responses:
-	//   '200':
-	//     "$ref": "#/responses/GetEntryActivitiesResponse"
-	//   '401':
-	//     description: "__Unauthorized__\n\n
-	//       ⦁ [-101]: Invalid authentication data\n
-	//       ⦁ [-102]: Invalid credentials\n
-	//       ⦁ [-104]: Invalid token"
-	//     schema:
-	//       "$ref": "#/definitions/Error"
-	//   '412':
-	//     description: "__Precondition Failed__\n\n
-	//       ⦁ [-103]: User not activated"
-	//     schema:
-	//       "$ref": "#/definitions/Error"
-	//   default:
-	//     "$ref": "#/responses/ErrorResponse"
-	return func(eCtx echo.Context) error {
-		// Execute action
-		entryActivities, err := c.eServ.GetEntryActivities(getContext(eCtx))
-		if err != nil {
-			return err
-		}
-
-		// Convert to API model and write response
-		aeas := mapper.ToEntryActivities(entryActivities)
-		return writeResponse(eCtx, http.StatusOK, aeas)
-	}
-}
-
-// CreateEntryActivityHandler returns a handler for "POST /entry_activites".
-func (c *EntryController) CreateEntryActivityHandler() echo.HandlerFunc {
-	// swagger:operation POST /entry_activities entry_activities createEntryActivity
+// CreateEntryTypeHandler returns a handler for "POST /entry_types".
+func (c *EntryController) CreateEntryTypeHandler() echo.HandlerFunc {
+	// swagger:operation POST /entry_types entry_types createEntryType
 	//
-	// Create a entry activity.
+	// Create a entry type.
 	//
 	// ---
 	//
@@ -743,11 +965,13 @@ func (c *EntryController) CreateEntryActivityHandler() echo.HandlerFunc {
 	//
 	// responses:
 	//   '200':
-	//     "$ref": "#/responses/CreateEntryActivityResponse"
+	//     "$ref": "#/responses/CreateEntryTypeResponse"
 	//   '400':
 	//     description: "__Bad Request__\n\n
+	//       ⦁ [-308]: Null field\n
 	//       ⦁ [-311]: Empty string\n
-	//       ⦁ [-312]: Too long string"
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-330]: Invalid color"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
@@ -759,7 +983,7 @@ func (c *EntryController) CreateEntryActivityHandler() echo.HandlerFunc {
 	//       "$ref": "#/definitions/Error"
 	//   '403':
 	//     description: "__Forbidden__\n\n
-	//       ⦁ [-206]: No right to create entry activities"
+	//       ⦁ [-206]: No right to create entry types"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '412':
@@ -771,35 +995,35 @@ func (c *EntryController) CreateEntryActivityHandler() echo.HandlerFunc {
 	//     "$ref": "#/responses/ErrorResponse"
 	return func(eCtx echo.Context) error {
 		// Read API model from request
-		var acea model.CreateEntryActivity
-		if err := readRequestBody(eCtx, &acea); err != nil {
+		var acet model.CreateEntryType
+		if err := readRequestBody(eCtx, &acet); err != nil {
 			return err
 		}
 
 		// Validate model
-		if err := validator.ValidateCreateEntryActivity(&acea); err != nil {
+		if err := validator.ValidateCreateEntryType(&acet); err != nil {
 			return err
 		}
 
 		// Convert to logic model
-		entryActivity := mapper.FromCreateEntryActivity(&acea)
+		entryType := mapper.FromCreateEntryType(&acet)
 
 		// Execute action
-		if err := c.eServ.CreateEntryActivity(getContext(eCtx), entryActivity); err != nil {
+		if err := c.eServ.CreateEntryType(getContext(eCtx), entryType); err != nil {
 			return err
 		}
 
 		// Convert to API model and write response
-		aea := mapper.ToEntryActivity(entryActivity)
-		return writeResponse(eCtx, http.StatusOK, aea)
+		aet := mapper.ToEntryType(entryType)
+		return writeResponse(eCtx, http.StatusOK, aet)
 	}
 }
 
-// UpdateEntryActivityHandler returns a handler for "PUT /entry_activites/{id}".
-func (c *EntryController) UpdateEntryActivityHandler() echo.HandlerFunc {
-	// swagger:operation PUT /entry_activities/{id} entry_activities updateEntryActivity
+// UpdateEntryTypeHandler returns a handler for "PUT /entry_types/{id}".
+func (c *EntryController) UpdateEntryTypeHandler() echo.HandlerFunc {
+	// swagger:operation PUT /entry_types/{id} entry_types updateEntryType
 	//
-	// Update a entry activity by its ID.
+	// Update a entry type by its ID.
 	//
 	// ---
 	//
@@ -814,12 +1038,14 @@ func (c *EntryController) UpdateEntryActivityHandler() echo.HandlerFunc {
 	//
 	// responses:
 	//   '200':
-	//     "$ref": "#/responses/UpdateEntryActivityResponse"
+	//     "$ref": "#/responses/UpdateEntryTypeResponse"
 	//   '400':
 	//     description: "__Bad Request__\n\n
 	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-308]: Null field\n
 	//       ⦁ [-311]: Empty string\n
-	//       ⦁ [-312]: Too long string"
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-330]: Invalid color"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
@@ -831,12 +1057,12 @@ func (c *EntryController) UpdateEntryActivityHandler() echo.HandlerFunc {
 	//       "$ref": "#/definitions/Error"
 	//   '403':
 	//     description: "__Forbidden__\n\n
-	//       ⦁ [-206]: No right to update entry activities"
+	//       ⦁ [-206]: No right to update entry types"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '404':
 	//     description: "__Not Found__\n\n
-	//       ⦁ [-403]: Entry activity not found"
+	//       ⦁ [-402]: Entry type not found"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '412':
@@ -854,35 +1080,35 @@ func (c *EntryController) UpdateEntryActivityHandler() echo.HandlerFunc {
 		}
 
 		// Read API model from request
-		var auea model.UpdateEntryActivity
-		if err := readRequestBody(eCtx, &auea); err != nil {
+		var auet model.UpdateEntryType
+		if err := readRequestBody(eCtx, &auet); err != nil {
 			return err
 		}
 
 		// Validate model
-		if err := validator.ValidateUpdateEntryActivity(&auea); err != nil {
+		if err := validator.ValidateUpdateEntryType(&auet); err != nil {
 			return err
 		}
 
 		// Convert to logic model
-		entryActivity := mapper.FromUpdateEntryActivity(id, &auea)
+		entryType := mapper.FromUpdateEntryType(id, &auet)
 
 		// Execute action
-		if err := c.eServ.UpdateEntryActivity(getContext(eCtx), entryActivity); err != nil {
+		if err := c.eServ.UpdateEntryType(getContext(eCtx), entryType); err != nil {
 			return err
 		}
 
 		// Convert to API model and write response
-		aea := mapper.ToEntryActivity(entryActivity)
-		return writeResponse(eCtx, http.StatusOK, aea)
+		aet := mapper.ToEntryType(entryType)
+		return writeResponse(eCtx, http.StatusOK, aet)
 	}
 }
 
-// DeleteEntryActivityHandler returns a handler for "DELETE /entry_activites/{id}".
-func (c *EntryController) DeleteEntryActivityHandler() echo.HandlerFunc {
-	// swagger:operation DELETE /entry_activities/{id} entry_activities deleteEntryActivity
+// DeleteEntryTypeHandler returns a handler for "DELETE /entry_types/{id}".
+func (c *EntryController) DeleteEntryTypeHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /entry_types/{id} entry_types deleteEntryType
 	//
-	// Delete a entry activity by its ID.
+	// Delete a entry type by its ID.
 	//
 	// ---
 	//
@@ -910,17 +1136,17 @@ func (c *EntryController) DeleteEntryActivityHandler() echo.HandlerFunc {
 	//       "$ref": "#/definitions/Error"
 	//   '403':
 	//     description: "__Forbidden__\n\n
-	//       ⦁ [-206]: No right to delete entry activities"
+	//       ⦁ [-206]: No right to delete entry types"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '404':
 	//     description: "__Not Found__\n\n
-	//       ⦁ [-403]: Entry activity not found"
+	//       ⦁ [-402]: Entry type not found"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '409':
 	//     description: "__Conflict__\n\n
-	//       ⦁ [-404]: Entry activity still used"
+	//       ⦁ [-427]: Entry type is built-in or still used"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '412':
@@ -938,7 +1164,555 @@ func (c *EntryController) DeleteEntryActivityHandler() echo.HandlerFunc {
 		}
 
 		// Execute action
-		if err := c.eServ.DeleteEntryActivityById(getContext(eCtx), id); err != nil {
+		if err := c.eServ.DeleteEntryTypeById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// GetEntryActivitiesHandler returns a handler for "GET /entry_activites".
+func (c *EntryController) GetEntryActivitiesHandler() echo.HandlerFunc {
+	// swagger:operation GET /entry_activities entry_activities listEntryActivities
+	//
+	// Lists all entry activities.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetEntryActivitiesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		entryActivities, err := c.eServ.GetEntryActivities(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aeas := mapper.ToEntryActivities(entryActivities)
+		return writeResponse(eCtx, http.StatusOK, aeas)
+	}
+}
+
+// CreateEntryActivityHandler returns a handler for "POST /entry_activites".
+func (c *EntryController) CreateEntryActivityHandler() echo.HandlerFunc {
+	// swagger:operation POST /entry_activities entry_activities createEntryActivity
+	//
+	// Create a entry activity.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateEntryActivityResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to create entry activities"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-402]: Entry type not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acea model.CreateEntryActivity
+		if err := readRequestBody(eCtx, &acea); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateEntryActivity(&acea); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		entryActivity := mapper.FromCreateEntryActivity(&acea)
+
+		// Execute action
+		if err := c.eServ.CreateEntryActivity(getContext(eCtx), entryActivity); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aea := mapper.ToEntryActivity(entryActivity)
+		return writeResponse(eCtx, http.StatusOK, aea)
+	}
+}
+
+// UpdateEntryActivityHandler returns a handler for "PUT /entry_activites/{id}".
+func (c *EntryController) UpdateEntryActivityHandler() echo.HandlerFunc {
+	// swagger:operation PUT /entry_activities/{id} entry_activities updateEntryActivity
+	//
+	// Update a entry activity by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateEntryActivityResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-308]: Null field\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to update entry activities"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-402]: Entry type not found\n
+	//       ⦁ [-403]: Entry activity not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var auea model.UpdateEntryActivity
+		if err := readRequestBody(eCtx, &auea); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateEntryActivity(&auea); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		entryActivity := mapper.FromUpdateEntryActivity(id, &auea)
+
+		// Execute action
+		if err := c.eServ.UpdateEntryActivity(getContext(eCtx), entryActivity); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aea := mapper.ToEntryActivity(entryActivity)
+		return writeResponse(eCtx, http.StatusOK, aea)
+	}
+}
+
+// DeleteEntryActivityHandler returns a handler for "DELETE /entry_activites/{id}".
+func (c *EntryController) DeleteEntryActivityHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /entry_activities/{id} entry_activities deleteEntryActivity
+	//
+	// Delete a entry activity by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to delete entry activities"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-403]: Entry activity not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-404]: Entry activity still used"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.eServ.DeleteEntryActivityById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// MergeEntryActivityHandler returns a handler for "POST /entry_activites/{id}/merge".
+func (c *EntryController) MergeEntryActivityHandler() echo.HandlerFunc {
+	// swagger:operation POST /entry_activities/{id}/merge entry_activities mergeEntryActivity
+	//
+	// Merge a entry activity into another entry activity. All entries of the entry activity are
+	// reassigned to the target entry activity and the entry activity is deleted afterwards. The
+	// merge is rejected if an entry was invoiced, lies in a closed year or has a type the target
+	// entry activity is not allowed for.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-412]: Entry activity not allowed\n
+	//       ⦁ [-428]: Entry activity can not be merged into itself"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to merge entry activities"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-403]: Entry activity not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed\n
+	//       ⦁ [-438]: Entry already invoiced"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var amea model.MergeEntryActivity
+		if err := readRequestBody(eCtx, &amea); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateMergeEntryActivity(&amea); err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.eServ.MergeEntryActivities(getContext(eCtx), id, amea.TargetActivityId); err !=
+			nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// GetProjectRoundingPoliciesHandler returns a handler for "GET /project_rounding_policies".
+func (c *EntryController) GetProjectRoundingPoliciesHandler() echo.HandlerFunc {
+	// swagger:operation GET /project_rounding_policies project_rounding_policies listProjectRoundingPolicies
+	//
+	// Lists all project rounding policies.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetProjectRoundingPoliciesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		policies, err := c.eServ.GetProjectRoundingPolicies(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aprps := mapper.ToProjectRoundingPolicies(policies)
+		return writeResponse(eCtx, http.StatusOK, aprps)
+	}
+}
+
+// SetProjectRoundingPolicyHandler returns a handler for "PUT /project_rounding_policies".
+func (c *EntryController) SetProjectRoundingPolicyHandler() echo.HandlerFunc {
+	// swagger:operation PUT /project_rounding_policies project_rounding_policies setProjectRoundingPolicy
+	//
+	// Create or replace the rounding policy of a project.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/SetProjectRoundingPolicyResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-331]: Invalid rounding policy"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to change project rounding policies"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var aprp model.ProjectRoundingPolicy
+		if err := readRequestBody(eCtx, &aprp); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateProjectRoundingPolicy(&aprp); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		policy := mapper.FromProjectRoundingPolicy(&aprp)
+
+		// Execute action
+		if err := c.eServ.SetProjectRoundingPolicy(getContext(eCtx), policy); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aprpr := mapper.ToProjectRoundingPolicy(policy)
+		return writeResponse(eCtx, http.StatusOK, aprpr)
+	}
+}
+
+// DeleteProjectRoundingPolicyHandler returns a handler for "DELETE /project_rounding_policies".
+func (c *EntryController) DeleteProjectRoundingPolicyHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /project_rounding_policies project_rounding_policies deleteProjectRoundingPolicy
+	//
+	// Delete the rounding policy of a project.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-311]: Empty string"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to change project rounding policies"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-429]: Project rounding policy not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get project from request
+		project := strings.TrimSpace(eCtx.QueryParam("project"))
+		if project == "" {
+			err := e.NewError(e.ValStringEmpty, "'project' must not be empty.")
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Execute action
+		if err := c.eServ.DeleteProjectRoundingPolicy(getContext(eCtx), project); err != nil {
 			return err
 		}
 
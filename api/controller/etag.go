@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+)
+
+// --- Entity tag functions ---
+
+func setETagHeader(eCtx echo.Context, version int) {
+	eCtx.Response().Header().Set("ETag", "\""+strconv.Itoa(version)+"\"")
+}
+
+func getIfMatchVersion(eCtx echo.Context) (int, error) {
+	// If header is missing or matches any version: Abort
+	str := strings.TrimSpace(eCtx.Request().Header.Get("If-Match"))
+	if str == "" || str == "*" {
+		return 0, nil
+	}
+
+	// Extract version from entity tag
+	if !strings.HasPrefix(str, "\"") || !strings.HasSuffix(str, "\"") || len(str) < 2 {
+		err := e.NewError(e.ValVersionInvalid, "Invalid If-Match header. (Entity tag must be "+
+			"quoted.)")
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+	version, pErr := strconv.Atoi(str[1 : len(str)-1])
+	if pErr != nil || version <= 0 {
+		err := e.NewError(e.ValVersionInvalid, "Invalid If-Match header. (Entity tag has invalid "+
+			"version.)")
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+
+	return version, nil
+}
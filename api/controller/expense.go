@@ -0,0 +1,1029 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// ExpenseController handles requests for expense and expense rate endpoints.
+type ExpenseController struct {
+	xServ *service.ExpenseService
+}
+
+// NewExpenseController create a new expense controller.
+func NewExpenseController(xs *service.ExpenseService) *ExpenseController {
+	return &ExpenseController{xs}
+}
+
+// --- Parameters ---
+
+// swagger:parameters createExpenseRate
+type CreateExpenseRateParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateExpenseRate
+}
+
+// swagger:parameters updateExpenseRate
+type UpdateExpenseRateParameters struct {
+	// The ID of the expense rate.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateExpenseRate
+}
+
+// swagger:parameters deleteExpenseRate
+type DeleteExpenseRateParameters struct {
+	// The ID of the expense rate.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters listEntryExpenses
+type ListEntryExpensesParameters struct {
+	// The ID of the entry.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createEntryExpense
+type CreateEntryExpenseParameters struct {
+	// The ID of the entry.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.CreateExpense
+}
+
+// swagger:parameters getExpense
+type GetExpenseParameters struct {
+	// The ID of the expense.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters updateExpense
+type UpdateExpenseParameters struct {
+	// The ID of the expense.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateExpense
+}
+
+// swagger:parameters deleteExpense
+type DeleteExpenseParameters struct {
+	// The ID of the expense.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters getUserExpenseReport
+type GetUserExpenseReportParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The month of the report (format "YYYY-MM"). (default=previous month)
+	//
+	// in: query
+	// required: false
+	Month string `json:"month"`
+}
+
+// --- Responses ---
+
+// The list of expense rates.
+// swagger:response ListExpenseRatesResponse
+type ListExpenseRatesResponse struct {
+	// in: body
+	Body model.ExpenseRateList
+}
+
+// The created expense rate.
+// swagger:response CreateExpenseRateResponse
+type CreateExpenseRateResponse struct {
+	// in: body
+	Body model.ExpenseRate
+}
+
+// The updated expense rate.
+// swagger:response UpdateExpenseRateResponse
+type UpdateExpenseRateResponse struct {
+	// in: body
+	Body model.ExpenseRate
+}
+
+// The list of expenses.
+// swagger:response ListExpensesResponse
+type ListExpensesResponse struct {
+	// in: body
+	Body model.ExpenseList
+}
+
+// The expense.
+// swagger:response GetExpenseResponse
+type GetExpenseResponse struct {
+	// in: body
+	Body model.Expense
+}
+
+// The created expense.
+// swagger:response CreateExpenseResponse
+type CreateExpenseResponse struct {
+	// in: body
+	Body model.Expense
+}
+
+// The updated expense.
+// swagger:response UpdateExpenseResponse
+type UpdateExpenseResponse struct {
+	// in: body
+	Body model.Expense
+}
+
+// The expense report.
+// swagger:response GetExpenseReportResponse
+type GetExpenseReportResponse struct {
+	// in: body
+	Body model.ExpenseReport
+}
+
+// --- Expense rate endpoints ---
+
+// GetExpenseRatesHandler returns a handler for "GET /expense_rates".
+func (c *ExpenseController) GetExpenseRatesHandler() echo.HandlerFunc {
+	// swagger:operation GET /expense_rates expense_rates listExpenseRates
+	//
+	// Lists all expense rates (ordered by type).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListExpenseRatesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-205]: No right to get expense rates"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		rates, err := c.xServ.GetExpenseRates(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		axrl := mapper.ToExpenseRates(rates)
+		return writeResponse(eCtx, http.StatusOK, axrl)
+	}
+}
+
+// CreateExpenseRateHandler returns a handler for "POST /expense_rates".
+func (c *ExpenseController) CreateExpenseRateHandler() echo.HandlerFunc {
+	// swagger:operation POST /expense_rates expense_rates createExpenseRate
+	//
+	// Create a mileage or per diem expense rate.
+	//
+	// # Input Rules
+	//
+	// __Type:__
+	//
+	// ⦁ Allowed values: `mileage`, `per_diem`
+	//
+	// __Name:__
+	//
+	// ⦁ Minimum length: 1
+	//
+	// ⦁ Maximum length: 50
+	//
+	// __Amount:__
+	//
+	// ⦁ Must be positive
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateExpenseRateResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-333]: Invalid expense type\n
+	//       ⦁ [-334]: Invalid currency"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to create expense rates"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acxr model.CreateExpenseRate
+		if err := readRequestBody(eCtx, &acxr); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateExpenseRate(&acxr); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		rate := mapper.FromCreateExpenseRate(&acxr)
+
+		// Execute action
+		if err := c.xServ.CreateExpenseRate(getContext(eCtx), rate); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		axr := mapper.ToExpenseRate(rate)
+		return writeResponse(eCtx, http.StatusOK, axr)
+	}
+}
+
+// UpdateExpenseRateHandler returns a handler for "PUT /expense_rates/{id}".
+func (c *ExpenseController) UpdateExpenseRateHandler() echo.HandlerFunc {
+	// swagger:operation PUT /expense_rates/{id} expense_rates updateExpenseRate
+	//
+	// Update an expense rate by its ID. The type of a rate can not be changed.
+	// Existing expenses keep the amount which was calculated with the previous rate.
+	//
+	// # Input Rules
+	//
+	// __Name:__
+	//
+	// ⦁ Minimum length: 1
+	//
+	// ⦁ Maximum length: 50
+	//
+	// __Amount:__
+	//
+	// ⦁ Must be positive
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateExpenseRateResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-334]: Invalid currency"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to update expense rates"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-430]: Expense rate not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var auxr model.UpdateExpenseRate
+		if err := readRequestBody(eCtx, &auxr); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateExpenseRate(&auxr); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		rate := mapper.FromUpdateExpenseRate(id, &auxr)
+
+		// Execute action
+		if err := c.xServ.UpdateExpenseRate(getContext(eCtx), rate); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		axr := mapper.ToExpenseRate(rate)
+		return writeResponse(eCtx, http.StatusOK, axr)
+	}
+}
+
+// DeleteExpenseRateHandler returns a handler for "DELETE /expense_rates/{id}".
+func (c *ExpenseController) DeleteExpenseRateHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /expense_rates/{id} expense_rates deleteExpenseRate
+	//
+	// Delete an expense rate by its ID. Rates which are used by expenses can not be
+	// deleted.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-206]: No right to delete expense rates"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-430]: Expense rate not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-431]: Expense rate still used"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.xServ.DeleteExpenseRateById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Expense endpoints ---
+
+// GetEntryExpensesHandler returns a handler for "GET /entries/{id}/expenses".
+func (c *ExpenseController) GetEntryExpensesHandler() echo.HandlerFunc {
+	// swagger:operation GET /entries/{id}/expenses expenses listEntryExpenses
+	//
+	// Lists the expenses of an entry.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListExpensesResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-401]: Entry not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get entry ID from request
+		entryId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		expenses, err := c.xServ.GetExpensesByEntryId(getContext(eCtx), entryId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		axl := mapper.ToExpenses(expenses)
+		return writeResponse(eCtx, http.StatusOK, axl)
+	}
+}
+
+// CreateEntryExpenseHandler returns a handler for "POST /entries/{id}/expenses".
+func (c *ExpenseController) CreateEntryExpenseHandler() echo.HandlerFunc {
+	// swagger:operation POST /entries/{id}/expenses expenses createEntryExpense
+	//
+	// Create an expense for an entry. Only entries whose type allows expenses can have
+	// expenses. Amount and currency of mileage and per diem expenses are calculated with the rate.
+	//
+	// # Input Rules
+	//
+	// __Type:__
+	//
+	// ⦁ Allowed values: `mileage`, `per_diem`, `receipt`
+	//
+	// __Rate ID / Quantity:__
+	//
+	// ⦁ Required for mileage and per diem expenses
+	//
+	// ⦁ The rate must have the type of the expense
+	//
+	// __Amount / Currency:__
+	//
+	// ⦁ Required for receipts
+	//
+	// ⦁ Currency must be an ISO 4217 code (e.g. `EUR`)
+	//
+	// __Description:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateExpenseResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-333]: Invalid expense type\n
+	//       ⦁ [-334]: Invalid currency\n
+	//       ⦁ [-432]: Invalid expense rate\n
+	//       ⦁ [-434]: Entry can not have expenses"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to change entries of other users\n
+	//       ⦁ [-210]: No right to change own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-401]: Entry not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get entry ID from request
+		entryId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var acx model.CreateExpense
+		if err := readRequestBody(eCtx, &acx); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateExpense(&acx); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		expense := mapper.FromCreateExpense(entryId, &acx)
+
+		// Execute action
+		if err := c.xServ.CreateExpense(getContext(eCtx), expense); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ax := mapper.ToExpense(expense)
+		return writeResponse(eCtx, http.StatusOK, ax)
+	}
+}
+
+// GetExpenseHandler returns a handler for "GET /expenses/{id}".
+func (c *ExpenseController) GetExpenseHandler() echo.HandlerFunc {
+	// swagger:operation GET /expenses/{id} expenses getExpense
+	//
+	// Get an expense by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetExpenseResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-433]: Expense not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		expense, err := c.xServ.GetExpenseById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Check if an expense was found
+		if expense == nil {
+			err := e.NewError(e.LogicExpenseNotFound, fmt.Sprintf("Could not find expense %d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		ax := mapper.ToExpense(expense)
+		return writeResponse(eCtx, http.StatusOK, ax)
+	}
+}
+
+// UpdateExpenseHandler returns a handler for "PUT /expenses/{id}".
+func (c *ExpenseController) UpdateExpenseHandler() echo.HandlerFunc {
+	// swagger:operation PUT /expenses/{id} expenses updateExpense
+	//
+	// Update an expense by its ID. The entry of an expense can not be changed.
+	//
+	// # Input Rules
+	//
+	// __Type:__
+	//
+	// ⦁ Allowed values: `mileage`, `per_diem`, `receipt`
+	//
+	// __Rate ID / Quantity:__
+	//
+	// ⦁ Required for mileage and per diem expenses
+	//
+	// ⦁ The rate must have the type of the expense
+	//
+	// __Amount / Currency:__
+	//
+	// ⦁ Required for receipts
+	//
+	// ⦁ Currency must be an ISO 4217 code (e.g. `EUR`)
+	//
+	// __Description:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateExpenseResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-333]: Invalid expense type\n
+	//       ⦁ [-334]: Invalid currency\n
+	//       ⦁ [-432]: Invalid expense rate\n
+	//       ⦁ [-434]: Entry can not have expenses"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to change entries of other users\n
+	//       ⦁ [-210]: No right to change own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-433]: Expense not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var aux model.UpdateExpense
+		if err := readRequestBody(eCtx, &aux); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateExpense(&aux); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		expense := mapper.FromUpdateExpense(id, &aux)
+
+		// Execute action
+		if err := c.xServ.UpdateExpense(getContext(eCtx), expense); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ax := mapper.ToExpense(expense)
+		return writeResponse(eCtx, http.StatusOK, ax)
+	}
+}
+
+// DeleteExpenseHandler returns a handler for "DELETE /expenses/{id}".
+func (c *ExpenseController) DeleteExpenseHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /expenses/{id} expenses deleteExpense
+	//
+	// Delete an expense by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-208]: No right to change entries of other users\n
+	//       ⦁ [-210]: No right to change own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-433]: Expense not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.xServ.DeleteExpenseById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Expense report endpoints ---
+
+// GetUserExpenseReportHandler returns a handler for "GET /users/{id}/expense_report".
+func (c *ExpenseController) GetUserExpenseReportHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/expense_report expenses getUserExpenseReport
+	//
+	// Gets a monthly report of the expenses of a user with the total amounts per
+	// currency. The month is determined in the time zone of the user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetExpenseReportResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-313]: Invalid date"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-408]: User not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Get month from request
+		month, err := getMonthQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		report, err := c.xServ.GetMonthExpenseReportByUserId(getContext(eCtx), userId,
+			month.Year(), int(month.Month()))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		axr := mapper.ToExpenseReport(report)
+		return writeResponse(eCtx, http.StatusOK, axr)
+	}
+}
+
+// --- Permission helper functions ---
+
+func (c *ExpenseController) convertPermissionError(ctx context.Context, id int,
+	err error) error {
+	er, ok := err.(*e.Error)
+	if ok && er.IsPermissionError() && !hasCurrentUserRight(ctx, m.RightGetAllEntries) {
+		return e.WrapError(e.LogicExpenseNotFound, fmt.Sprintf("Could not find expense %d.", id),
+			err)
+	}
+	return err
+}
@@ -6,57 +6,126 @@ import (
 
 	"github.com/labstack/echo/v4"
 
-	"kellnhofer.com/work-log/api/export"
 	"kellnhofer.com/work-log/pkg/constant"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/export"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
 	"kellnhofer.com/work-log/pkg/service"
 )
 
 // ExportController handles requests for export endpoints.
 type ExportController struct {
+	uServ *service.UserService
 	eServ *service.EntryService
 
-	exporter *export.EntriesExporter
+	registry          *export.Registry
+	timesheetExporter *export.TimesheetPdfExporter
 }
 
-// NewExportController creates a new export controller.
-func NewExportController(eServ *service.EntryService) *ExportController {
+// NewExportController creates a new export controller. The timesheet header, logo and signature
+// lines are used for the PDF timesheet export.
+func NewExportController(uServ *service.UserService, eServ *service.EntryService,
+	timesheetHeader string, timesheetLogo string, timesheetSignatureLines bool,
+) *ExportController {
 	return &ExportController{
+		uServ:    uServ,
 		eServ:    eServ,
-		exporter: export.NewEntriesExporter(),
+		registry: export.NewRegistry(),
+		timesheetExporter: export.NewTimesheetPdfExporter(timesheetHeader, timesheetLogo,
+			timesheetSignatureLines),
 	}
 }
 
+// --- Parameters ---
+
+// swagger:parameters getUserTimesheet
+type GetUserTimesheetParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The month of the timesheet (format "YYYY-MM"). (default=previous month)
+	//
+	// in: query
+	// required: false
+	Month string `json:"month"`
+}
+
+// --- Endpoints ---
+
 // GetExportHandler returns a handler for "GET /export".
 func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 	// swagger:operation GET /export export exportEntries
 	//
-	// Export entries as CSV.
+	// Export entries as CSV, Excel, JSON, JSON Lines or OpenDocument spreadsheet.
 	//
-	// Only entries a user can see are exported.
+	// Only entries a user can see are exported. Besides the raw duration, the billed duration and
+	// the rounding policy which applies to an entry are exported. Times are exported in the time
+	// zone of the current user. The entries are read and written one by one, so large result sets
+	// can be exported without a limit.
+	//
+	// # Format
+	//
+	// The format is selected via the `format` parameter or, if it is missing, via the `Accept`
+	// header:
+	// | format | media type                                                          |
+	// | ------ | ------------------------------------------------------------------- |
+	// | csv    | text/csv                                                            |
+	// | xlsx   | application/vnd.openxmlformats-officedocument.spreadsheetml.sheet   |
+	// | json   | application/json                                                    |
+	// | jsonl  | application/x-ndjson                                                |
+	// | ods    | application/vnd.oasis.opendocument.spreadsheet                      |
+	// &#9432; If neither is specified, the entries are exported as XLSX.
+	//
+	// # Export Profiles
+	//
+	// The columns, the delimiter, the time and duration formats, the header language and the label
+	// separator of CSV files can be configured via a export profile of the current user (see
+	// `/user/export_profiles`). The profile is selected via the `profileId` parameter. Without a
+	// profile, all columns are exported with comma delimiter, RFC 3339 times, durations in minutes
+	// and space separated labels.
 	//
 	// # Filtering
 	//
 	// The result can be filtered via following fields:
-	// | field name  | operators             | data type / allowed values |
-	// | ----------- | --------------------- | -------------------------- |
-	// | userId      | eq (equal)            | int                        |
-	// | typeId      | eq (equal)            | int                        |
-	// | startTime   | bt (between)          | datetime strings           |
-	// | activityId  | i (is), eq (equal)    | null, int                  |
-	// | project     | i (is), cn (contains) | null, string               |
-	// | description | i (is), cn (contains) | null, string               |
-	// | labels      | i (is), in (in)       | null, strings              |
-	// &#9432; Filters are connected via logical conjunction (AND).
+	// | field name  | operators                                         | data type / allowed values      |
+	// | ----------- | ------------------------------------------------- | ------------------------------- |
+	// | userId      | eq (equal)                                        | int                             |
+	// | typeId      | eq (equal), in (in)                               | int                             |
+	// | startTime   | bt (between), gt (greater), lt (less)             | datetime strings                |
+	// | endTime     | bt (between), gt (greater), lt (less)             | datetime strings                |
+	// | duration    | eq (equal), bt (between), gt (greater), lt (less) | int (minutes)                   |
+	// | weekday     | eq (equal), in (in)                               | int (1 = Monday ... 7 = Sunday) |
+	// | activityId  | i (is), eq (equal), in (in)                       | null, int                       |
+	// | project     | i (is), cn (contains)                             | null, string                    |
+	// | description | i (is), cn (contains)                             | null, string                    |
+	// | labels      | i (is), in (in)                                   | null, strings                   |
+	// &#9432; Filters are connected via logical conjunction (AND). Filters can be grouped with
+	// `and(...)`, `or(...)` and `not(...)`. The `userId` filter is not supported within groups.
 	//
 	// __Filter Syntax:__
 	// [field name];[operator];[value-1];...;[value-n]
 	//
+	// [filter-1]|...|[filter-n]
+	//
+	// or([filter-1]|...|[filter-n])
+	//
+	// &#9432; The characters `\`, `|`, `;` and `)` can be escaped with a backslash.
+	//
 	// __Examples:__
 	//
-	// Get entries for a specific time interval: startTime;bt;2019-01-01T00:00:00;2019-01-05T00:00:00
+	// Get entries for a specific time interval:
+	// startTime;bt;2019-01-01T00:00:00Z;2019-01-05T00:00:00Z
 	//
 	// Get entries with specific labels (OR logic): labels;in;bug;frontend
 	//
+	// Get work or travel entries on weekends: or(typeId;eq;1|typeId;eq;2)|weekday;in;6;7
+	//
+	// Get entries longer than 8 hours without label "overtime": duration;gt;480|not(labels;in;overtime)
+	//
 	// # Sorting
 	//
 	// The result can be sorted via following fields:
@@ -79,8 +148,22 @@ func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 	//
 	// produces:
 	// - text/csv
+	// - application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+	// - application/json
+	// - application/x-ndjson
+	// - application/vnd.oasis.opendocument.spreadsheet
 	//
 	// parameters:
+	// - name: format
+	//   in: query
+	//   description: Format of the exported file (csv, xlsx, json, jsonl or ods).
+	//   required: false
+	//   type: string
+	// - name: profileId
+	//   in: query
+	//   description: ID of a export profile of the current user (only applied to CSV).
+	//   required: false
+	//   type: integer
 	// - name: filter
 	//   in: query
 	//   description: Filtering applied to the entries result.
@@ -94,14 +177,16 @@ func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 	//
 	// responses:
 	//   '200':
-	//     description: CSV file containing the exported entries.
+	//     description: File containing the exported entries.
 	//     schema:
 	//       type: string
 	//       format: binary
 	//   '400':
 	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
 	//       ⦁ [-304]: Invalid filter\n
-	//       ⦁ [-305]: Invalid sort"
+	//       ⦁ [-305]: Invalid sort\n
+	//       ⦁ [-336]: Invalid export format"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
@@ -116,6 +201,11 @@ func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 	//       ⦁ [-207]: No right to get entries of other users"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-447]: Export profile not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
 	//   '412':
 	//     description: "__Precondition Failed__\n\n
 	//       ⦁ [-103]: User not activated"
@@ -124,8 +214,22 @@ func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 	//   default:
 	//     "$ref": "#/responses/ErrorResponse"
 	return func(eCtx echo.Context) error {
+		// Get exporter for requested format
+		exporter, err := c.registry.Select(eCtx.QueryParam("format"),
+			eCtx.Request().Header.Get("Accept"))
+		if err != nil {
+			return err
+		}
+
+		// Get export profile from request
+		profile, err := c.getExportProfile(eCtx)
+		if err != nil {
+			return err
+		}
+
 		// Get filter from request
-		f, err := getEntryFilter(getFilterQueryParam(eCtx))
+		f, err := getEntryFilter(getFilterQueryParam(eCtx),
+			getCurrentUserLocation(getContext(eCtx)))
 		if err != nil {
 			return err
 		}
@@ -136,29 +240,157 @@ func (c *ExportController) GetExportHandler() echo.HandlerFunc {
 			return err
 		}
 
-		// Get all entries (no pagination for export)
-		entries, _, err := c.eServ.GetEntries(getContext(eCtx), f, s, 0, 0)
+		// Get all entries (no pagination for export, entries are streamed)
+		entries, err := c.eServ.GetEntriesIterator(getContext(eCtx), f, s)
+		if err != nil {
+			return err
+		}
+		defer entries.Close()
+
+		// Create file name
+		timestamp := time.Now().Format(constant.ExportTimestampFormat)
+		fileName := fmt.Sprintf(constant.ExportFileNameTemplate, timestamp, exporter.Format())
+		// Create export
+		data, err := export.CreateEntriesData(getContext(eCtx), c.eServ, f, entries,
+			getCurrentUserLocation(getContext(eCtx)))
+		if err != nil {
+			return err
+		}
+		data.Profile = profile
+		file := exporter.Export(data)
+
+		// Write file response
+		return writeFileResponse(eCtx, exporter.ContentType(), fileName, file)
+	}
+}
+
+func (c *ExportController) getExportProfile(eCtx echo.Context) (*model.ExportProfile, error) {
+	id, pErr := getIntQueryParam(eCtx, "profileId")
+	if pErr != nil || id < 0 {
+		err := e.NewError(e.ValIdInvalid, "Invalid profile ID. (ID must be a positive number.)")
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	if id == 0 {
+		return nil, nil
+	}
+	return c.uServ.GetCurrentUserExportProfileById(getContext(eCtx), id)
+}
+
+// GetUserTimesheetHandler returns a handler for "GET /users/{id}/timesheet".
+func (c *ExportController) GetUserTimesheetHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/timesheet export getUserTimesheet
+	//
+	// Export the monthly timesheet of a user as PDF.
+	//
+	// The timesheet contains the same summary, day and entry tables as the overview page. Header,
+	// logo and signature lines are configured on the server. The month is determined in the time
+	// zone of the user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/pdf
+	//
+	// responses:
+	//   '200':
+	//     description: PDF file containing the timesheet.
+	//     schema:
+	//       type: string
+	//       format: binary
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-313]: Invalid date"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-201]: No right to get user\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-408]: User not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		ctx := getContext(eCtx)
+
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Get month from request
+		month, err := getMonthQueryParam(eCtx)
 		if err != nil {
 			return err
 		}
+		year, mon := month.Year(), int(month.Month())
 
-		// Get entry types and entry activities for lookup
-		entryTypes, err := c.eServ.GetEntryTypes(getContext(eCtx))
+		// Get user and contract
+		user, err := c.uServ.GetUserById(ctx, userId)
 		if err != nil {
 			return err
 		}
-		entryActivities, err := c.eServ.GetEntryActivities(getContext(eCtx))
+		if user == nil {
+			err := e.NewError(e.LogicUserNotFound, fmt.Sprintf("Could not find user %d.", userId))
+			log.Debug(err.StackTrace())
+			return err
+		}
+		contract, err := c.uServ.GetUserContractByUserId(ctx, userId)
 		if err != nil {
 			return err
 		}
 
-		// Create file name
-		timestamp := time.Now().Format(constant.ExportTimestampFormat)
-		fileName := fmt.Sprintf(constant.ExportFileNameTemplate, timestamp, "csv")
-		// Create CSV export
-		file := c.exporter.ExportEntries(entries, entryTypes, entryActivities)
+		// Get entries, entry master data and rounding policies
+		entries, err := c.eServ.GetMonthEntriesByUserId(ctx, userId, year, mon)
+		if err != nil {
+			return err
+		}
+		entryTypesMap, err := c.eServ.GetEntryTypesMap(ctx)
+		if err != nil {
+			return err
+		}
+		entryActivitiesMap, err := c.eServ.GetEntryActivitiesMap(ctx)
+		if err != nil {
+			return err
+		}
+		roundingPolicies, err := c.eServ.GetRoundingPoliciesByUserId(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		// Create PDF timesheet
+		data := export.NewTimesheetData(user.Name, contract, year, mon, entries,
+			user.GetLocation(), entryTypesMap, entryActivitiesMap, roundingPolicies)
+		fileName := fmt.Sprintf(constant.ExportFileNameTemplate, fmt.Sprintf("%d%02d", year, mon),
+			"pdf")
+		file := c.timesheetExporter.ExportTimesheet(data)
 
 		// Write file response
-		return writeFileResponse(eCtx, fileName, file)
+		return writeFileResponse(eCtx, "application/pdf", fileName, file)
 	}
 }
@@ -2,8 +2,8 @@ package controller
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
-	"strings"
 	"time"
 
 	e "kellnhofer.com/work-log/pkg/error"
@@ -23,6 +23,21 @@ const (
 	filterOpContains = "cn"
 	filterOpBetween  = "bt"
 	filterOpIn       = "in"
+	filterOpGreater  = "gt"
+	filterOpLess     = "lt"
+)
+
+const (
+	filterGroupAnd = "and("
+	filterGroupOr  = "or("
+	filterGroupNot = "not("
+)
+
+const (
+	filterCharConjunction = '|'
+	filterCharSeparator   = ';'
+	filterCharGroupEnd    = ')'
+	filterCharEscape      = '\\'
 )
 
 // --- Entry filter functions ---
@@ -31,114 +46,216 @@ const (
 	filterNameUserId      = "userId"
 	filterNameTypeId      = "typeId"
 	filterNameStartTime   = "startTime"
+	filterNameEndTime     = "endTime"
+	filterNameDuration    = "duration"
+	filterNameWeekday     = "weekday"
 	filterNameActivityId  = "activityId"
 	filterNameProject     = "project"
 	filterNameDescription = "description"
 	filterNameLabels      = "labels"
 )
 
-func getEntryFilter(str string) (model.EntryFilter, error) {
-	// Get filter values
-	filters, err := getFilters(str)
+// entryFilterField describes how a filter field is converted to a entry filter condition.
+type entryFilterField struct {
+	field     model.EntryFilterField
+	operators []string
+	nullable  bool
+	value     func(string) (any, bool)
+}
+
+var entryFilterFields = map[string]*entryFilterField{
+	filterNameTypeId: {model.EntryFilterFieldType,
+		[]string{filterOpEqual, filterOpIn}, false, parseIdFilterValue},
+	filterNameStartTime: {model.EntryFilterFieldStartTime,
+		[]string{filterOpBetween, filterOpGreater, filterOpLess}, false, parseTimeFilterValue},
+	filterNameEndTime: {model.EntryFilterFieldEndTime,
+		[]string{filterOpBetween, filterOpGreater, filterOpLess}, false, parseTimeFilterValue},
+	filterNameDuration: {model.EntryFilterFieldDuration,
+		[]string{filterOpEqual, filterOpBetween, filterOpGreater, filterOpLess}, false,
+		parseDurationFilterValue},
+	filterNameWeekday: {model.EntryFilterFieldWeekday,
+		[]string{filterOpEqual, filterOpIn}, false, parseWeekdayFilterValue},
+	filterNameActivityId: {model.EntryFilterFieldActivity,
+		[]string{filterOpEqual, filterOpIn}, true, parseIdFilterValue},
+	filterNameProject: {model.EntryFilterFieldProject,
+		[]string{filterOpContains}, true, parseStringFilterValue},
+	filterNameDescription: {model.EntryFilterFieldDescription,
+		[]string{filterOpContains}, true, parseStringFilterValue},
+	filterNameLabels: {model.EntryFilterFieldLabels,
+		[]string{filterOpIn}, true, parseStringFilterValue},
+}
+
+func getEntryFilter(str string, loc *time.Location) (*model.ExpressionEntryFilter, error) {
+	entryFilter := model.NewExpressionEntryFilter()
+	entryFilter.Location = loc
+
+	// If filter string is empty: Abort
+	if str == "" {
+		return entryFilter, nil
+	}
+
+	// Parse filter string
+	p := &filterParser{str: []rune(str)}
+	expressions, err := p.parseList(0, entryFilter)
 	if err != nil {
 		return nil, err
 	}
+	if !p.isAtEnd() {
+		return nil, createInvalidFilterSyntaxError(fmt.Sprintf("Unexpected character '%c' at "+
+			"position %d.", p.str[p.pos], p.pos+1))
+	}
 
-	// Check for unknown/unsupported filters
-	filterNames := []string{filterNameUserId, filterNameTypeId, filterNameStartTime,
-		filterNameActivityId, filterNameProject, filterNameDescription, filterNameLabels}
-	if err = checkFiltersSupported(filters, filterNames); err != nil {
-		return nil, err
+	// Top level expressions are connected via logical conjunction
+	switch len(expressions) {
+	case 0:
+		// Do nothing
+	case 1:
+		entryFilter.Expression = expressions[0]
+	default:
+		entryFilter.Expression = &model.AndEntryFilterExpression{Expressions: expressions}
 	}
 
-	entryFilter := model.NewFieldEntryFilter()
+	return entryFilter, nil
+}
 
-	// Get user ID filter
-	if filter, ok := filters[filterNameUserId]; ok {
-		entryFilter.ByUser = true
-		entryFilter.UserId, err = getIdFilterValue(filter, false)
-		if err != nil {
-			return nil, err
-		}
+// --- Filter parsing functions ---
+
+// filterParser parses filter strings with following grammar:
+//
+//	list      = term { "|" term }
+//	term      = "and(" list ")" | "or(" list ")" | "not(" list ")" | condition
+//	condition = name ";" operator ";" value { ";" value }
+//
+// Special characters within names and values can be escaped with a backslash.
+type filterParser struct {
+	str []rune
+	pos int
+}
+
+func (p *filterParser) isAtEnd() bool {
+	return p.pos >= len(p.str)
+}
+
+func (p *filterParser) hasPrefix(prefix string) bool {
+	pr := []rune(prefix)
+	if len(p.str)-p.pos < len(pr) {
+		return false
 	}
-	// Get type ID filter
-	if filter, ok := filters[filterNameTypeId]; ok {
-		entryFilter.ByType = true
-		entryFilter.TypeId, err = getIdFilterValue(filter, false)
+	return string(p.str[p.pos:p.pos+len(pr)]) == prefix
+}
+
+func (p *filterParser) parseList(depth int, entryFilter *model.ExpressionEntryFilter) (
+	[]model.EntryFilterExpression, error) {
+	var expressions []model.EntryFilterExpression
+	for {
+		expression, err := p.parseTerm(depth, entryFilter)
 		if err != nil {
 			return nil, err
 		}
-	}
-	// Get start time filter
-	if filter, ok := filters[filterNameStartTime]; ok {
-		entryFilter.ByTime = true
-		entryFilter.StartTime, entryFilter.EndTime, err = getTimeIntervalFilterValue(filter)
-		if err != nil {
-			return nil, err
+		if expression != nil {
+			expressions = append(expressions, expression)
 		}
-	}
-	// Get activity ID filter
-	if filter, ok := filters[filterNameActivityId]; ok {
-		entryFilter.ByActivity = true
-		entryFilter.ActivityId, err = getIdFilterValue(filter, true)
-		if err != nil {
-			return nil, err
+
+		// Continue with next term or stop at end of list
+		if p.isAtEnd() || p.str[p.pos] != filterCharConjunction {
+			return expressions, nil
 		}
+		p.pos++
 	}
-	// Get project filter
-	if filter, ok := filters[filterNameProject]; ok {
-		entryFilter.ByProject = true
-		entryFilter.Project, err = getStringFilterValue(filter, true)
-		if err != nil {
-			return nil, err
+}
+
+func (p *filterParser) parseTerm(depth int, entryFilter *model.ExpressionEntryFilter) (
+	model.EntryFilterExpression, error) {
+	// Parse group
+	for _, group := range []string{filterGroupAnd, filterGroupOr, filterGroupNot} {
+		if p.hasPrefix(group) {
+			return p.parseGroup(group, depth)
 		}
 	}
-	// Get description filter
-	if filter, ok := filters[filterNameDescription]; ok {
-		entryFilter.ByDescription = true
-		entryFilter.Description, err = getStringFilterValue(filter, true)
-		if err != nil {
-			return nil, err
-		}
+
+	// Parse condition
+	f, err := p.parseCondition(depth)
+	if err != nil {
+		return nil, err
 	}
-	// Get labels filter
-	if filter, ok := filters[filterNameLabels]; ok {
-		entryFilter.ByLabel = true
-		entryFilter.Labels, err = getStringArrayFilterValue(filter, true)
+
+	// The user filter is handled separately (since it is used for permission checks), so it is
+	// only supported at top level
+	if f.name == filterNameUserId {
+		if depth > 0 {
+			return nil, createInvalidFilterSyntaxError(fmt.Sprintf("Field name '%s' is not "+
+				"supported within groups.", f.name))
+		}
+		userId, err := getUserIdFilterValue(f)
 		if err != nil {
 			return nil, err
 		}
+		entryFilter.SetUserFilter(userId)
+		return nil, nil
 	}
 
-	return entryFilter, nil
+	return createEntryFilterCondition(f)
 }
 
-// --- Filter parsing functions ---
+func (p *filterParser) parseGroup(group string, depth int) (model.EntryFilterExpression, error) {
+	p.pos += len([]rune(group))
 
-func getFilters(str string) (map[string]*filter, error) {
-	// If filter string is empty: Abort
-	if str == "" {
-		return make(map[string]*filter), nil
+	// Parse group content (user filters are not supported within groups)
+	expressions, err := p.parseList(depth+1, nil)
+	if err != nil {
+		return nil, err
 	}
-
-	// Split fiter string
-	filtersParts := strings.Split(str, "|")
-	fs := make([]*filter, len(filtersParts))
-	for i, filtersPart := range filtersParts {
-		filterParts := strings.Split(filtersPart, ";")
-		if err := checkFilterParts(filterParts); err != nil {
-			return nil, err
+	if p.isAtEnd() || p.str[p.pos] != filterCharGroupEnd {
+		return nil, createInvalidFilterSyntaxError("Missing closing parenthesis.")
+	}
+	p.pos++
+
+	switch group {
+	case filterGroupOr:
+		return &model.OrEntryFilterExpression{Expressions: expressions}, nil
+	case filterGroupNot:
+		var expression model.EntryFilterExpression = &model.AndEntryFilterExpression{
+			Expressions: expressions}
+		if len(expressions) == 1 {
+			expression = expressions[0]
 		}
-		fs[i] = &filter{filterParts[0], filterParts[1], filterParts[2:]}
+		return &model.NotEntryFilterExpression{Expression: expression}, nil
+	default:
+		return &model.AndEntryFilterExpression{Expressions: expressions}, nil
 	}
+}
 
-	// Create filters map
-	fm := make(map[string]*filter)
-	for _, f := range fs {
-		fm[f.name] = f
+func (p *filterParser) parseCondition(depth int) (*filter, error) {
+	// Split condition into its parts (until end of condition, list or group)
+	var parts []string
+	var part []rune
+	for !p.isAtEnd() {
+		c := p.str[p.pos]
+		if c == filterCharConjunction || (c == filterCharGroupEnd && depth > 0) {
+			break
+		}
+		p.pos++
+
+		switch c {
+		case filterCharEscape:
+			if p.isAtEnd() {
+				return nil, createInvalidFilterSyntaxError("Incomplete escape sequence.")
+			}
+			part = append(part, p.str[p.pos])
+			p.pos++
+		case filterCharSeparator:
+			parts = append(parts, string(part))
+			part = nil
+		default:
+			part = append(part, c)
+		}
 	}
+	parts = append(parts, string(part))
 
-	return fm, nil
+	if err := checkFilterParts(parts); err != nil {
+		return nil, err
+	}
+	return &filter{parts[0], parts[1], parts[2:]}, nil
 }
 
 func checkFilterParts(parts []string) error {
@@ -167,113 +284,128 @@ func checkFilterParts(parts []string) error {
 	return nil
 }
 
-func checkFiltersSupported(filters map[string]*filter, names []string) error {
-	for name := range filters {
-		if !isValidFilterName(names, name) {
-			err := e.NewError(e.ValFilterInvalid, fmt.Sprintf("Invalid filter. (Unknown/unsupported "+
-				" field name '%s'.)", name))
-			log.Debug(err.StackTrace())
-			return err
-		}
+func createEntryFilterCondition(f *filter) (*model.ConditionEntryFilterExpression, error) {
+	// Check for unknown/unsupported field name
+	ff, ok := entryFilterFields[f.name]
+	if !ok {
+		err := e.NewError(e.ValFilterInvalid, fmt.Sprintf("Invalid filter. (Unknown/unsupported "+
+			"field name '%s'.)", f.name))
+		log.Debug(err.StackTrace())
+		return nil, err
 	}
-	return nil
-}
 
-func isValidFilterName(names []string, n string) bool {
-	for _, name := range names {
-		if name == n {
-			return true
-		}
-	}
-	return false
-}
+	condition := &model.ConditionEntryFilterExpression{Field: ff.field}
 
-func getIdFilterValue(f *filter, nullable bool) (int, error) {
-	// Get "is null" value
-	if nullable && f.operator == filterOpIs {
+	// Get "is null" condition
+	if ff.nullable && f.operator == filterOpIs {
 		if len(f.values) == 1 && f.values[0] == "null" {
-			return 0, nil
+			condition.Operator = model.EntryFilterOpIsNull
+			return condition, nil
 		}
-		return 0, createInvalidFilterValueError(f.name)
+		return nil, createInvalidFilterValueError(f.name)
 	}
 
-	// Get "equals" value
-	if f.operator == filterOpEqual {
-		if len(f.values) == 1 && f.values[0] != "" {
-			if id, err := strconv.Atoi(f.values[0]); err == nil && id > 0 {
-				return id, nil
-			}
-		}
-		return 0, createInvalidFilterValueError(f.name)
+	// Check for unsupported operator
+	if !slices.Contains(ff.operators, f.operator) {
+		return nil, createInvalidFilterOperatorError(f.name, f.operator)
 	}
 
-	return 0, createInvalidFilterOperatorError(f.name, f.operator)
-}
-
-func getStringFilterValue(f *filter, nullable bool) (string, error) {
-	// Get "is null" value
-	if nullable && f.operator == filterOpIs {
-		if len(f.values) == 1 && f.values[0] == "null" {
-			return "", nil
-		}
-		return "", createInvalidFilterValueError(f.name)
+	// Check number of values
+	var validCount bool
+	switch f.operator {
+	case filterOpBetween:
+		condition.Operator = model.EntryFilterOpBetween
+		validCount = len(f.values) == 2
+	case filterOpIn:
+		condition.Operator = model.EntryFilterOpIn
+		validCount = len(f.values) >= 1
+	case filterOpEqual:
+		condition.Operator = model.EntryFilterOpEqual
+		validCount = len(f.values) == 1
+	case filterOpContains:
+		condition.Operator = model.EntryFilterOpContains
+		validCount = len(f.values) == 1
+	case filterOpGreater:
+		condition.Operator = model.EntryFilterOpGreater
+		validCount = len(f.values) == 1
+	case filterOpLess:
+		condition.Operator = model.EntryFilterOpLess
+		validCount = len(f.values) == 1
+	}
+	if !validCount {
+		return nil, createInvalidFilterValueError(f.name)
 	}
 
-	// Get "contains" value
-	if f.operator == filterOpContains {
-		if len(f.values) == 1 && f.values[0] != "" {
-			return f.values[0], nil
+	// Convert values
+	condition.Values = make([]any, 0, len(f.values))
+	for _, v := range f.values {
+		value, ok := ff.value(v)
+		if !ok {
+			return nil, createInvalidFilterValueError(f.name)
 		}
-		return "", createInvalidFilterValueError(f.name)
+		condition.Values = append(condition.Values, value)
 	}
 
-	return "", createInvalidFilterOperatorError(f.name, f.operator)
+	return condition, nil
 }
 
-func getStringArrayFilterValue(f *filter, nullable bool) ([]string, error) {
-	// Get "is null" value
-	if nullable && f.operator == filterOpIs {
-		if len(f.values) == 1 && f.values[0] == "null" {
-			return []string{}, nil
-		}
-		return nil, createInvalidFilterValueError(f.name)
+func getUserIdFilterValue(f *filter) (int, error) {
+	// Check if wrong operator
+	if f.operator != filterOpEqual {
+		return 0, createInvalidFilterOperatorError(f.name, f.operator)
+	}
+	// Check if wrong number of values
+	if len(f.values) != 1 {
+		return 0, createInvalidFilterValueError(f.name)
 	}
 
-	// Get "in" values
-	if f.operator == filterOpIn {
-		if len(f.values) >= 1 {
-			// Check all values are non-empty
-			for _, v := range f.values {
-				if v == "" {
-					return nil, createInvalidFilterValueError(f.name)
-				}
-			}
-			return f.values, nil
-		}
-		return nil, createInvalidFilterValueError(f.name)
+	// Parse value
+	id, ok := parseIdFilterValue(f.values[0])
+	if !ok {
+		return 0, createInvalidFilterValueError(f.name)
 	}
 
-	return nil, createInvalidFilterOperatorError(f.name, f.operator)
+	return id.(int), nil
 }
 
-func getTimeIntervalFilterValue(f *filter) (time.Time, time.Time, error) {
-	// Check if wrong operator
-	if f.operator != filterOpBetween {
-		return time.Now(), time.Now(), createInvalidFilterOperatorError(f.name, f.operator)
+func parseIdFilterValue(v string) (any, bool) {
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		return nil, false
 	}
-	// Check if wrong number of values
-	if len(f.values) != 2 {
-		return time.Now(), time.Now(), createInvalidFilterValueError(f.name)
+	return id, true
+}
+
+func parseTimeFilterValue(v string) (any, bool) {
+	t, err := parseTimestamp(v)
+	if err != nil {
+		return nil, false
 	}
+	return t, true
+}
 
-	// Parse values
-	start, sErr := parseTimestamp(f.values[0])
-	end, eErr := parseTimestamp(f.values[1])
-	if sErr != nil || eErr != nil {
-		return time.Now(), time.Now(), createInvalidFilterValueError(f.name)
+func parseDurationFilterValue(v string) (any, bool) {
+	min, err := strconv.Atoi(v)
+	if err != nil || min < 0 {
+		return nil, false
 	}
+	return time.Duration(min) * time.Minute, true
+}
 
-	return start, end, nil
+func parseWeekdayFilterValue(v string) (any, bool) {
+	// Weekdays are numbered according to ISO 8601 (1 = Monday ... 7 = Sunday)
+	day, err := strconv.Atoi(v)
+	if err != nil || day < 1 || day > 7 {
+		return nil, false
+	}
+	return time.Weekday(day % 7), true
+}
+
+func parseStringFilterValue(v string) (any, bool) {
+	if v == "" {
+		return nil, false
+	}
+	return v, true
 }
 
 func createInvalidFilterOperatorError(name string, operator string) error {
@@ -283,6 +415,12 @@ func createInvalidFilterOperatorError(name string, operator string) error {
 	return err
 }
 
+func createInvalidFilterSyntaxError(reason string) error {
+	err := e.NewError(e.ValFilterInvalid, fmt.Sprintf("Invalid filter. (%s)", reason))
+	log.Debug(err.StackTrace())
+	return err
+}
+
 func createInvalidFilterValueError(name string) error {
 	err := e.NewError(e.ValFilterInvalid, fmt.Sprintf("Invalid filter '%s'. (Invalid value(s).)",
 		name))
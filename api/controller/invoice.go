@@ -0,0 +1,1397 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/export"
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// InvoiceController handles requests for client, hourly rate and invoice endpoints.
+type InvoiceController struct {
+	iServ     *service.InvoiceService
+	iExporter *export.InvoiceExporter
+}
+
+// NewInvoiceController create a new invoice controller. The issuer and the payment days are
+// printed on exported invoices.
+func NewInvoiceController(is *service.InvoiceService, issuerName string, issuerAddress []string,
+	paymentDays int) *InvoiceController {
+	return &InvoiceController{
+		iServ:     is,
+		iExporter: export.NewInvoiceExporter(issuerName, issuerAddress, paymentDays),
+	}
+}
+
+// --- Parameters ---
+
+// swagger:parameters getClient
+type GetClientParameters struct {
+	// The ID of the client.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createClient
+type CreateClientParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateClient
+}
+
+// swagger:parameters updateClient
+type UpdateClientParameters struct {
+	// The ID of the client.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateClient
+}
+
+// swagger:parameters deleteClient
+type DeleteClientParameters struct {
+	// The ID of the client.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createHourlyRate
+type CreateHourlyRateParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateHourlyRate
+}
+
+// swagger:parameters updateHourlyRate
+type UpdateHourlyRateParameters struct {
+	// The ID of the hourly rate.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateHourlyRate
+}
+
+// swagger:parameters deleteHourlyRate
+type DeleteHourlyRateParameters struct {
+	// The ID of the hourly rate.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters getInvoice getInvoicePdf getInvoiceXlsx deleteInvoice
+type GetInvoiceParameters struct {
+	// The ID of the invoice.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createInvoice
+type CreateInvoiceParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateInvoice
+}
+
+// --- Responses ---
+
+// The list of clients.
+// swagger:response ListClientsResponse
+type ListClientsResponse struct {
+	// in: body
+	Body model.ClientList
+}
+
+// The client.
+// swagger:response GetClientResponse
+type GetClientResponse struct {
+	// in: body
+	Body model.Client
+}
+
+// The created client.
+// swagger:response CreateClientResponse
+type CreateClientResponse struct {
+	// in: body
+	Body model.Client
+}
+
+// The updated client.
+// swagger:response UpdateClientResponse
+type UpdateClientResponse struct {
+	// in: body
+	Body model.Client
+}
+
+// The list of hourly rates.
+// swagger:response ListHourlyRatesResponse
+type ListHourlyRatesResponse struct {
+	// in: body
+	Body model.HourlyRateList
+}
+
+// The created hourly rate.
+// swagger:response CreateHourlyRateResponse
+type CreateHourlyRateResponse struct {
+	// in: body
+	Body model.HourlyRate
+}
+
+// The updated hourly rate.
+// swagger:response UpdateHourlyRateResponse
+type UpdateHourlyRateResponse struct {
+	// in: body
+	Body model.HourlyRate
+}
+
+// The list of invoices.
+// swagger:response ListInvoicesResponse
+type ListInvoicesResponse struct {
+	// in: body
+	Body model.InvoiceList
+}
+
+// The invoice.
+// swagger:response GetInvoiceResponse
+type GetInvoiceResponse struct {
+	// in: body
+	Body model.Invoice
+}
+
+// The created invoice.
+// swagger:response CreateInvoiceResponse
+type CreateInvoiceResponse struct {
+	// in: body
+	Body model.Invoice
+}
+
+// The invoice file.
+// swagger:response InvoiceFileResponse
+type InvoiceFileResponse struct {
+	// in: body
+	Body []byte
+}
+
+// --- Client endpoints ---
+
+// GetClientsHandler returns a handler for "GET /clients".
+func (c *InvoiceController) GetClientsHandler() echo.HandlerFunc {
+	// swagger:operation GET /clients clients listClients
+	//
+	// Lists all clients (ordered by name).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListClientsResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		clients, err := c.iServ.GetClients(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		acl := mapper.ToClients(clients)
+		return writeResponse(eCtx, http.StatusOK, acl)
+	}
+}
+
+// GetClientHandler returns a handler for "GET /clients/{id}".
+func (c *InvoiceController) GetClientHandler() echo.HandlerFunc {
+	// swagger:operation GET /clients/{id} clients getClient
+	//
+	// Get a client by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetClientResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		client, err := c.iServ.GetClientById(getContext(eCtx), id)
+		if err != nil {
+			return err
+		}
+
+		// Check if a client was found
+		if client == nil {
+			err := e.NewError(e.LogicClientNotFound, fmt.Sprintf("Could not find client %d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		ac := mapper.ToClient(client)
+		return writeResponse(eCtx, http.StatusOK, ac)
+	}
+}
+
+// CreateClientHandler returns a handler for "POST /clients".
+func (c *InvoiceController) CreateClientHandler() echo.HandlerFunc {
+	// swagger:operation POST /clients clients createClient
+	//
+	// Create a client. A project can only be assigned to one client.
+	//
+	// # Input Rules
+	//
+	// __Name:__
+	//
+	// ⦁ Minimum length: 1
+	//
+	// ⦁ Maximum length: 100
+	//
+	// __Address:__
+	//
+	// ⦁ Maximum length: 500
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// __Projects:__
+	//
+	// ⦁ Element minimum length: 1
+	//
+	// ⦁ Element maximum length: 30
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateClientResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-334]: Invalid currency"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-440]: Client already exists\n
+	//       ⦁ [-442]: Project already assigned to another client"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acc model.CreateClient
+		if err := readRequestBody(eCtx, &acc); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateClient(&acc); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		client := mapper.FromCreateClient(&acc)
+
+		// Execute action
+		if err := c.iServ.CreateClient(getContext(eCtx), client); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ac := mapper.ToClient(client)
+		return writeResponse(eCtx, http.StatusOK, ac)
+	}
+}
+
+// UpdateClientHandler returns a handler for "PUT /clients/{id}".
+func (c *InvoiceController) UpdateClientHandler() echo.HandlerFunc {
+	// swagger:operation PUT /clients/{id} clients updateClient
+	//
+	// Update a client by its ID. Existing invoices are not changed.
+	//
+	// # Input Rules
+	//
+	// __Name:__
+	//
+	// ⦁ Minimum length: 1
+	//
+	// ⦁ Maximum length: 100
+	//
+	// __Address:__
+	//
+	// ⦁ Maximum length: 500
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// __Projects:__
+	//
+	// ⦁ Element minimum length: 1
+	//
+	// ⦁ Element maximum length: 30
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateClientResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-334]: Invalid currency"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-440]: Client already exists\n
+	//       ⦁ [-442]: Project already assigned to another client"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var auc model.UpdateClient
+		if err := readRequestBody(eCtx, &auc); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateClient(&auc); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		client := mapper.FromUpdateClient(id, &auc)
+
+		// Execute action
+		if err := c.iServ.UpdateClient(getContext(eCtx), client); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ac := mapper.ToClient(client)
+		return writeResponse(eCtx, http.StatusOK, ac)
+	}
+}
+
+// DeleteClientHandler returns a handler for "DELETE /clients/{id}".
+func (c *InvoiceController) DeleteClientHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /clients/{id} clients deleteClient
+	//
+	// Delete a client by its ID. Clients which were already invoiced can not be deleted.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-441]: Client has invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.iServ.DeleteClientById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Hourly rate endpoints ---
+
+// GetHourlyRatesHandler returns a handler for "GET /hourly_rates".
+func (c *InvoiceController) GetHourlyRatesHandler() echo.HandlerFunc {
+	// swagger:operation GET /hourly_rates hourly_rates listHourlyRates
+	//
+	// Lists all hourly rates (ordered by valid from).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListHourlyRatesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		rates, err := c.iServ.GetHourlyRates(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ahrl := mapper.ToHourlyRates(rates)
+		return writeResponse(eCtx, http.StatusOK, ahrl)
+	}
+}
+
+// CreateHourlyRateHandler returns a handler for "POST /hourly_rates".
+func (c *InvoiceController) CreateHourlyRateHandler() echo.HandlerFunc {
+	// swagger:operation POST /hourly_rates hourly_rates createHourlyRate
+	//
+	// Create an hourly rate. If several rates match an entry, the most specific rate is used
+	// (activity before project before user). If these are equal, the rate which became valid last
+	// is used.
+	//
+	// # Input Rules
+	//
+	// __User ID:__
+	//
+	// ⦁ Must be zero or positive
+	//
+	// __Project:__
+	//
+	// ⦁ Maximum length: 30
+	//
+	// __Activity ID:__
+	//
+	// ⦁ Must be zero or positive
+	//
+	// __Amount:__
+	//
+	// ⦁ Must be positive
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// __Valid From:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// __Valid To:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// ⦁ Must not be before valid from (empty if valid indefinitely)
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateHourlyRateResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-334]: Invalid currency\n
+	//       ⦁ [-406]: Invalid date interval"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-403]: Activity not found\n
+	//       ⦁ [-408]: User not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var achr model.CreateHourlyRate
+		if err := readRequestBody(eCtx, &achr); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateHourlyRate(&achr); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		rate := mapper.FromCreateHourlyRate(&achr)
+
+		// Execute action
+		if err := c.iServ.CreateHourlyRate(getContext(eCtx), rate); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ahr := mapper.ToHourlyRate(rate)
+		return writeResponse(eCtx, http.StatusOK, ahr)
+	}
+}
+
+// UpdateHourlyRateHandler returns a handler for "PUT /hourly_rates/{id}".
+func (c *InvoiceController) UpdateHourlyRateHandler() echo.HandlerFunc {
+	// swagger:operation PUT /hourly_rates/{id} hourly_rates updateHourlyRate
+	//
+	// Update an hourly rate by its ID. Existing invoices keep the rate which was used when they were
+	// created.
+	//
+	// # Input Rules
+	//
+	// __User ID:__
+	//
+	// ⦁ Must be zero or positive
+	//
+	// __Project:__
+	//
+	// ⦁ Maximum length: 30
+	//
+	// __Activity ID:__
+	//
+	// ⦁ Must be zero or positive
+	//
+	// __Amount:__
+	//
+	// ⦁ Must be positive
+	//
+	// __Currency:__
+	//
+	// ⦁ ISO 4217 code (e.g. `EUR`)
+	//
+	// __Valid From:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// __Valid To:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// ⦁ Must not be before valid from (empty if valid indefinitely)
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateHourlyRateResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-310]: Number not positive\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-334]: Invalid currency\n
+	//       ⦁ [-406]: Invalid date interval"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-403]: Activity not found\n
+	//       ⦁ [-408]: User not found\n
+	//       ⦁ [-443]: Hourly rate not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var auhr model.UpdateHourlyRate
+		if err := readRequestBody(eCtx, &auhr); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateHourlyRate(&auhr); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		rate := mapper.FromUpdateHourlyRate(id, &auhr)
+
+		// Execute action
+		if err := c.iServ.UpdateHourlyRate(getContext(eCtx), rate); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ahr := mapper.ToHourlyRate(rate)
+		return writeResponse(eCtx, http.StatusOK, ahr)
+	}
+}
+
+// DeleteHourlyRateHandler returns a handler for "DELETE /hourly_rates/{id}".
+func (c *InvoiceController) DeleteHourlyRateHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /hourly_rates/{id} hourly_rates deleteHourlyRate
+	//
+	// Delete an hourly rate by its ID. Existing invoices keep the rate which was used when they were
+	// created.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-443]: Hourly rate not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.iServ.DeleteHourlyRateById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// --- Invoice endpoints ---
+
+// GetInvoicesHandler returns a handler for "GET /invoices".
+func (c *InvoiceController) GetInvoicesHandler() echo.HandlerFunc {
+	// swagger:operation GET /invoices invoices listInvoices
+	//
+	// Lists all invoices without their items (ordered by number, newest first).
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListInvoicesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		invoices, err := c.iServ.GetInvoices(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ail := mapper.ToInvoices(invoices)
+		return writeResponse(eCtx, http.StatusOK, ail)
+	}
+}
+
+// GetInvoiceHandler returns a handler for "GET /invoices/{id}".
+func (c *InvoiceController) GetInvoiceHandler() echo.HandlerFunc {
+	// swagger:operation GET /invoices/{id} invoices getInvoice
+	//
+	// Get an invoice including its items by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetInvoiceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-445]: Invoice not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get invoice
+		invoice, err := c.getInvoice(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ai := mapper.ToInvoice(invoice)
+		return writeResponse(eCtx, http.StatusOK, ai)
+	}
+}
+
+// CreateInvoiceHandler returns a handler for "POST /invoices".
+func (c *InvoiceController) CreateInvoiceHandler() echo.HandlerFunc {
+	// swagger:operation POST /invoices invoices createInvoice
+	//
+	// Create an invoice for a client. All billable entries of the projects of the client, which start
+	// in the period (in the time zone of the current user) and were not invoiced yet, are billed with
+	// the hourly rates in the currency of the client. Invoiced entries can no longer be changed or
+	// deleted until the invoice is deleted.
+	//
+	// # Input Rules
+	//
+	// __Client ID:__
+	//
+	// ⦁ Must be positive
+	//
+	// __Start Date:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// __End Date:__
+	//
+	// ⦁ Format: `YYYY-MM-DD`
+	//
+	// ⦁ Must not be before start date
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateInvoiceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-406]: Invalid date interval"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-438]: Entry already invoiced\n
+	//       ⦁ [-444]: Hourly rate missing\n
+	//       ⦁ [-446]: No entries to invoice"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var aci model.CreateInvoice
+		if err := readRequestBody(eCtx, &aci); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateInvoice(&aci); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		invoice := mapper.FromCreateInvoice(&aci)
+
+		// Execute action
+		if err := c.iServ.CreateInvoice(getContext(eCtx), invoice); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ai := mapper.ToInvoice(invoice)
+		return writeResponse(eCtx, http.StatusOK, ai)
+	}
+}
+
+// DeleteInvoiceHandler returns a handler for "DELETE /invoices/{id}".
+func (c *InvoiceController) DeleteInvoiceHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /invoices/{id} invoices deleteInvoice
+	//
+	// Delete an invoice by its ID. The entries of the invoice are released, so that they can be
+	// changed and invoiced again.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// responses:
+	//   '204':
+	//     description: "__No Content__"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-215]: No right to change invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-445]: Invoice not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.iServ.DeleteInvoiceById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+// GetInvoicePdfHandler returns a handler for "GET /invoices/{id}/pdf".
+func (c *InvoiceController) GetInvoicePdfHandler() echo.HandlerFunc {
+	// swagger:operation GET /invoices/{id}/pdf invoices getInvoicePdf
+	//
+	// Get an invoice as PDF file.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/pdf
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/InvoiceFileResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found\n
+	//       ⦁ [-445]: Invoice not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get invoice and client
+		invoice, client, err := c.getInvoiceAndClient(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Create file and write response
+		file := c.iExporter.ExportInvoicePdf(invoice, client)
+		fileName := fmt.Sprintf("invoice_%s.pdf", invoice.Number)
+		return writeFileResponse(eCtx, "application/pdf", fileName, file)
+	}
+}
+
+// GetInvoiceXlsxHandler returns a handler for "GET /invoices/{id}/xlsx".
+func (c *InvoiceController) GetInvoiceXlsxHandler() echo.HandlerFunc {
+	// swagger:operation GET /invoices/{id}/xlsx invoices getInvoiceXlsx
+	//
+	// Get an invoice as Excel file.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/InvoiceFileResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-214]: No right to get invoices"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-439]: Client not found\n
+	//       ⦁ [-445]: Invoice not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get invoice and client
+		invoice, client, err := c.getInvoiceAndClient(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Create file and write response
+		file := c.iExporter.ExportInvoiceXlsx(invoice, client)
+		fileName := fmt.Sprintf("invoice_%s.xlsx", invoice.Number)
+		return writeFileResponse(eCtx, xlsxContentType, fileName, file)
+	}
+}
+
+// --- Helper functions ---
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+func (c *InvoiceController) getInvoice(eCtx echo.Context) (*m.Invoice, error) {
+	// Get ID from request
+	id, err := getIdPathVar(eCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get invoice
+	invoice, err := c.iServ.GetInvoiceById(getContext(eCtx), id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		err := e.NewError(e.LogicInvoiceNotFound, fmt.Sprintf("Could not find invoice %d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (c *InvoiceController) getInvoiceAndClient(eCtx echo.Context) (*m.Invoice, *m.Client,
+	error) {
+	// Get invoice
+	invoice, err := c.getInvoice(eCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get client
+	client, err := c.iServ.GetClientById(getContext(eCtx), invoice.ClientId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if client == nil {
+		err := e.NewError(e.LogicClientNotFound, fmt.Sprintf("Could not find client %d.",
+			invoice.ClientId))
+		log.Debug(err.StackTrace())
+		return nil, nil, err
+	}
+	return invoice, client, nil
+}
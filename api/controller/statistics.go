@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// StatisticsController handles requests for statistics endpoints.
+type StatisticsController struct {
+	sServ *service.StatisticsService
+}
+
+// NewStatisticsController create a new statistics controller.
+func NewStatisticsController(ss *service.StatisticsService) *StatisticsController {
+	return &StatisticsController{ss}
+}
+
+// --- Parameters ---
+
+// swagger:parameters getStatistics
+type GetStatisticsParameters struct {
+	// The first day of the period. The period must not contain more than 1000 buckets of the
+	// interval. (default=first day of the current month)
+	//
+	// in: query
+	// required: false
+	Start string `json:"start"`
+
+	// The last day of the period. (default=last day of the current month)
+	//
+	// in: query
+	// required: false
+	End string `json:"end"`
+
+	// The entry characteristic the durations are grouped by (type, activity, project, label or
+	// user). (default=type)
+	//
+	// in: query
+	// required: false
+	GroupBy string `json:"groupBy"`
+
+	// The length of the time buckets (day, week or month). (default=day)
+	//
+	// in: query
+	// required: false
+	Interval string `json:"interval"`
+
+	// Filtering applied to the entries. (same syntax as for "GET /entries")
+	//
+	// in: query
+	// required: false
+	Filter string `json:"filter"`
+}
+
+// --- Responses ---
+
+// The statistics.
+// swagger:response GetStatisticsResponse
+type GetStatisticsResponse struct {
+	// in: body
+	Body model.Statistics
+}
+
+// --- Endpoints ---
+
+// GetStatisticsHandler returns a handler for "GET /statistics".
+func (c *StatisticsController) GetStatisticsHandler() echo.HandlerFunc {
+	// swagger:operation GET /statistics statistics getStatistics
+	//
+	// Gets the durations of the entries in a period grouped by entry type, entry activity, project,
+	// label or user and split into day, week or month buckets.
+	//
+	// Only entries a user can see are included. An entry is assigned to the bucket its start time
+	// lies in. Days, weeks (starting on Monday) and months are determined in the time zone of the
+	// current user. Entries without activity, project or label are summed up in a group with ID 0
+	// and an empty name. If the durations are grouped by label, an entry is counted for each of its
+	// labels, so the hours of the groups can add up to more than the total hours.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetStatisticsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-304]: Invalid filter\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-343]: Invalid grouping\n
+	//       ⦁ [-344]: Invalid interval\n
+	//       ⦁ [-345]: Period too long"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get period from request
+		now := time.Now().In(getCurrentUserLocation(getContext(eCtx)))
+		defStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		start, err := getDateQueryParam(eCtx, "start", defStart)
+		if err != nil {
+			return err
+		}
+		end, err := getDateQueryParam(eCtx, "end", defStart.AddDate(0, 1, -1))
+		if err != nil {
+			return err
+		}
+		if end.Before(start) {
+			err := e.NewError(e.ValDateInvalid, "Invalid end date. (End must not be before start.)")
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Get grouping and interval from request
+		grouping, err := getStatisticsGroupingQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+		interval, err := getStatisticsIntervalQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+		if !m.IsValidStatisticsPeriod(start, end, interval) {
+			err := e.NewError(e.ValPeriodTooLong, fmt.Sprintf("Invalid period. (Period must not "+
+				"contain more than %d buckets.)", m.StatisticsMaxBuckets))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Get filter from request
+		f, err := getEntryFilter(getFilterQueryParam(eCtx),
+			getCurrentUserLocation(getContext(eCtx)))
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		stats, err := c.sServ.GetStatistics(getContext(eCtx), f, start, end, grouping, interval)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		as := mapper.ToStatistics(stats)
+		return writeResponse(eCtx, http.StatusOK, as)
+	}
+}
+
+// --- Helper functions ---
+
+func getStatisticsGroupingQueryParam(eCtx echo.Context) (m.StatisticsGrouping, error) {
+	qv := eCtx.QueryParam("groupBy")
+	if qv == "" {
+		return m.StatisticsGroupingType, nil
+	}
+	grouping := m.StatisticsGrouping(qv)
+	if !slices.Contains(m.StatisticsGroupings, grouping) {
+		err := e.NewError(e.ValGroupingInvalid, "Invalid 'groupBy'. (Must be 'type', 'activity', "+
+			"'project', 'label' or 'user'.)")
+		log.Debug(err.StackTrace())
+		return "", err
+	}
+	return grouping, nil
+}
+
+func getStatisticsIntervalQueryParam(eCtx echo.Context) (m.StatisticsInterval, error) {
+	qv := eCtx.QueryParam("interval")
+	if qv == "" {
+		return m.StatisticsIntervalDay, nil
+	}
+	interval := m.StatisticsInterval(qv)
+	if !slices.Contains(m.StatisticsIntervals, interval) {
+		err := e.NewError(e.ValIntervalInvalid, "Invalid 'interval'. (Must be 'day', 'week' or "+
+			"'month'.)")
+		log.Debug(err.StackTrace())
+		return "", err
+	}
+	return interval, nil
+}
@@ -33,6 +33,42 @@ type UpdateCurrentUserPasswordParameters struct {
 	Body model.UpdateUserPassword
 }
 
+// swagger:parameters updateCurrentUserNotificationSettings
+type UpdateCurrentUserNotificationSettingsParameters struct {
+	// in: body
+	// required: true
+	Body model.NotificationSettings
+}
+
+// swagger:parameters createCurrentUserExportProfile
+type CreateCurrentUserExportProfileParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateExportProfile
+}
+
+// swagger:parameters getCurrentUserExportProfile deleteCurrentUserExportProfile
+type GetCurrentUserExportProfileParameters struct {
+	// The ID of the export profile.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters updateCurrentUserExportProfile
+type UpdateCurrentUserExportProfileParameters struct {
+	// The ID of the export profile.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateExportProfile
+}
+
 // swagger:parameters getUser
 type GetUserParameters struct {
 	// The ID of the user.
@@ -105,6 +141,48 @@ type UpdateUserRolesParameters struct {
 
 // --- Responses ---
 
+// The notification settings.
+// swagger:response GetNotificationSettingsResponse
+type GetNotificationSettingsResponse struct {
+	// in: body
+	Body model.NotificationSettings
+}
+
+// The updated notification settings.
+// swagger:response UpdateNotificationSettingsResponse
+type UpdateNotificationSettingsResponse struct {
+	// in: body
+	Body model.NotificationSettings
+}
+
+// The list of export profiles.
+// swagger:response GetExportProfilesResponse
+type GetExportProfilesResponse struct {
+	// in: body
+	Body model.ExportProfileList
+}
+
+// The export profile.
+// swagger:response GetExportProfileResponse
+type GetExportProfileResponse struct {
+	// in: body
+	Body model.ExportProfile
+}
+
+// The created export profile.
+// swagger:response CreateExportProfileResponse
+type CreateExportProfileResponse struct {
+	// in: body
+	Body model.ExportProfile
+}
+
+// The updated export profile.
+// swagger:response UpdateExportProfileResponse
+type UpdateExportProfileResponse struct {
+	// in: body
+	Body model.ExportProfile
+}
+
 // The list of users.
 // swagger:response GetUsersResponse
 type GetUsersResponse struct {
@@ -307,6 +385,550 @@ func (c *UserController) GetCurrentUserRolesHandler() echo.HandlerFunc {
 	}
 }
 
+// GetCurrentUserNotificationSettingsHandler returns a handler for
+// "GET /user/notification_settings".
+func (c *UserController) GetCurrentUserNotificationSettingsHandler() echo.HandlerFunc {
+	// swagger:operation GET /user/notification_settings user getCurrentUserNotificationSettings
+	//
+	// Get the notification settings of the current user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetNotificationSettingsResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		settings, err := c.uServ.GetCurrentUserNotificationSettings(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ans := mapper.ToNotificationSettings(settings)
+		return writeResponse(eCtx, http.StatusOK, ans)
+	}
+}
+
+// UpdateCurrentUserNotificationSettingsHandler returns a handler for
+// "PUT /user/notification_settings".
+func (c *UserController) UpdateCurrentUserNotificationSettingsHandler() echo.HandlerFunc {
+	// swagger:operation PUT /user/notification_settings user updateCurrentUserNotificationSettings
+	//
+	// Update the notification settings of the current user.
+	//
+	// If a email address is set and the reminder is enabled, the user receives a email when
+	// working days have no or too few logged hours. Evaluators can additionally enable a weekly
+	// digest of the missing entries of all users.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateNotificationSettingsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-321]: Invalid email"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var ans model.NotificationSettings
+		if err := readRequestBody(eCtx, &ans); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateNotificationSettings(&ans); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		settings := mapper.FromNotificationSettings(&ans)
+
+		// Execute action
+		if err := c.uServ.UpdateCurrentUserNotificationSettings(getContext(eCtx),
+			settings); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ans = *mapper.ToNotificationSettings(settings)
+		return writeResponse(eCtx, http.StatusOK, ans)
+	}
+}
+
+// GetCurrentUserExportProfilesHandler returns a handler for "GET /user/export_profiles".
+func (c *UserController) GetCurrentUserExportProfilesHandler() echo.HandlerFunc {
+	// swagger:operation GET /user/export_profiles user listCurrentUserExportProfiles
+	//
+	// Lists the export profiles of the current user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetExportProfilesResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		profiles, err := c.uServ.GetCurrentUserExportProfiles(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aps := mapper.ToExportProfiles(profiles)
+		return writeResponse(eCtx, http.StatusOK, aps)
+	}
+}
+
+// CreateCurrentUserExportProfileHandler returns a handler for "POST /user/export_profiles".
+func (c *UserController) CreateCurrentUserExportProfileHandler() echo.HandlerFunc {
+	// swagger:operation POST /user/export_profiles user createCurrentUserExportProfile
+	//
+	// Create a export profile for the current user.
+	//
+	// A export profile defines the columns and formats of a CSV export. It is applied by passing
+	// its ID as `profileId` parameter to `/export`.
+	//
+	// # Input Rules
+	//
+	// __Name:__
+	//
+	// ⦁ Not empty
+	// ⦁ Maximum length: 50
+	// ⦁ Unique per user
+	//
+	// __Columns:__
+	//
+	// ⦁ Not empty
+	// ⦁ Allowed values: `start_time`, `end_time`, `type`, `activity`, `project`, `description`,
+	// `labels`, `duration`, `billed_duration`, `rounding`
+	// ⦁ Each column at most once
+	//
+	// __Delimiter:__
+	//
+	// ⦁ A single character (except quotation marks and line breaks)
+	//
+	// __Date Format:__
+	//
+	// ⦁ Tokens: `yyyy` (year), `yy` (two digit year), `MM` (month), `dd` (day), `HH` (hour), `mm`
+	// (minute), `ss` (second)
+	// ⦁ Separator characters: space, `.`, `,`, `:`, `;`, `-`, `/`, `_`, `T`
+	// ⦁ Maximum length: 30
+	// ⦁ Empty for RFC 3339
+	//
+	// __Duration Unit:__
+	//
+	// ⦁ Allowed values: `minutes`, `hours` (with two decimal places)
+	//
+	// __Decimal Separator:__
+	//
+	// ⦁ Allowed values: `.`, `,`
+	//
+	// __Language:__
+	//
+	// ⦁ Allowed values: `en`, `de` or empty (for the default language)
+	//
+	// __Label Separator:__
+	//
+	// ⦁ Not empty
+	// ⦁ Maximum length: 5
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '201':
+	//     "$ref": "#/responses/CreateExportProfileResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-301]: Invalid JSON\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-315]: Empty array\n
+	//       ⦁ [-337]: Invalid export column\n
+	//       ⦁ [-338]: Invalid date format\n
+	//       ⦁ [-339]: Invalid delimiter\n
+	//       ⦁ [-340]: Invalid duration unit\n
+	//       ⦁ [-341]: Invalid decimal separator\n
+	//       ⦁ [-342]: Invalid language"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-448]: Export profile already exists"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acp model.CreateExportProfile
+		if err := readRequestBody(eCtx, &acp); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateExportProfile(&acp); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		profile := mapper.FromCreateExportProfile(&acp)
+
+		// Execute action
+		if err := c.uServ.CreateCurrentUserExportProfile(getContext(eCtx), profile); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ap := mapper.ToExportProfile(profile)
+		return writeResponse(eCtx, http.StatusCreated, ap)
+	}
+}
+
+// GetCurrentUserExportProfileHandler returns a handler for "GET /user/export_profiles/{id}".
+func (c *UserController) GetCurrentUserExportProfileHandler() echo.HandlerFunc {
+	// swagger:operation GET /user/export_profiles/{id} user getCurrentUserExportProfile
+	//
+	// Get a export profile of the current user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetExportProfileResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-447]: Export profile not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get export profile ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		profile, err := c.uServ.GetCurrentUserExportProfileById(getContext(eCtx), id)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ap := mapper.ToExportProfile(profile)
+		return writeResponse(eCtx, http.StatusOK, ap)
+	}
+}
+
+// UpdateCurrentUserExportProfileHandler returns a handler for "PUT /user/export_profiles/{id}".
+func (c *UserController) UpdateCurrentUserExportProfileHandler() echo.HandlerFunc {
+	// swagger:operation PUT /user/export_profiles/{id} user updateCurrentUserExportProfile
+	//
+	// Update a export profile of the current user.
+	//
+	// # Input Rules
+	//
+	// __Name:__
+	//
+	// ⦁ Not empty
+	// ⦁ Maximum length: 50
+	// ⦁ Unique per user
+	//
+	// __Columns:__
+	//
+	// ⦁ Not empty
+	// ⦁ Allowed values: `start_time`, `end_time`, `type`, `activity`, `project`, `description`,
+	// `labels`, `duration`, `billed_duration`, `rounding`
+	// ⦁ Each column at most once
+	//
+	// __Delimiter:__
+	//
+	// ⦁ A single character (except quotation marks and line breaks)
+	//
+	// __Date Format:__
+	//
+	// ⦁ Tokens: `yyyy` (year), `yy` (two digit year), `MM` (month), `dd` (day), `HH` (hour), `mm`
+	// (minute), `ss` (second)
+	// ⦁ Separator characters: space, `.`, `,`, `:`, `;`, `-`, `/`, `_`, `T`
+	// ⦁ Maximum length: 30
+	// ⦁ Empty for RFC 3339
+	//
+	// __Duration Unit:__
+	//
+	// ⦁ Allowed values: `minutes`, `hours` (with two decimal places)
+	//
+	// __Decimal Separator:__
+	//
+	// ⦁ Allowed values: `.`, `,`
+	//
+	// __Language:__
+	//
+	// ⦁ Allowed values: `en`, `de` or empty (for the default language)
+	//
+	// __Label Separator:__
+	//
+	// ⦁ Not empty
+	// ⦁ Maximum length: 5
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateExportProfileResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-301]: Invalid JSON\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-315]: Empty array\n
+	//       ⦁ [-337]: Invalid export column\n
+	//       ⦁ [-338]: Invalid date format\n
+	//       ⦁ [-339]: Invalid delimiter\n
+	//       ⦁ [-340]: Invalid duration unit\n
+	//       ⦁ [-341]: Invalid decimal separator\n
+	//       ⦁ [-342]: Invalid language"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-447]: Export profile not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-448]: Export profile already exists"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get export profile ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var aup model.UpdateExportProfile
+		if err := readRequestBody(eCtx, &aup); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateExportProfile(&aup); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		profile := mapper.FromUpdateExportProfile(id, &aup)
+
+		// Execute action
+		if err := c.uServ.UpdateCurrentUserExportProfile(getContext(eCtx), profile); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ap := mapper.ToExportProfile(profile)
+		return writeResponse(eCtx, http.StatusOK, ap)
+	}
+}
+
+// DeleteCurrentUserExportProfileHandler returns a handler for
+// "DELETE /user/export_profiles/{id}".
+func (c *UserController) DeleteCurrentUserExportProfileHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /user/export_profiles/{id} user deleteCurrentUserExportProfile
+	//
+	// Delete a export profile of the current user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-447]: Export profile not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get export profile ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.uServ.DeleteCurrentUserExportProfileById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return eCtx.NoContent(http.StatusNoContent)
+	}
+}
+
 // GetUsersHandler returns a handler for "GET /users".
 func (c *UserController) GetUsersHandler() echo.HandlerFunc {
 	// swagger:operation GET /users users listUsers
@@ -401,8 +1023,11 @@ func (c *UserController) CreateUserHandler() echo.HandlerFunc {
 	//       ⦁ [-313]: Invalid date\n
 	//       ⦁ [-317]: Invalid username\n
 	//       ⦁ [-318]: Invalid password\n
+	//       ⦁ [-331]: Invalid rounding policy\n
 	//       ⦁ [-410]: Invalid contract working hours\n
-	//       ⦁ [-411]: Invalid contract vacation days"
+	//       ⦁ [-411]: Invalid contract vacation days\n
+	//       ⦁ [-416]: Invalid contract break rules\n
+	//       ⦁ [-422]: Invalid contract vacation rules"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
@@ -558,8 +1183,11 @@ func (c *UserController) UpdateUserHandler() echo.HandlerFunc {
 	//       ⦁ [-313]: Invalid date\n
 	//       ⦁ [-317]: Invalid username\n
 	//       ⦁ [-318]: Invalid password\n
+	//       ⦁ [-331]: Invalid rounding policy\n
 	//       ⦁ [-410]: Invalid contract working hours\n
-	//       ⦁ [-411]: Invalid contract vacation days"
+	//       ⦁ [-411]: Invalid contract vacation days\n
+	//       ⦁ [-416]: Invalid contract break rules\n
+	//       ⦁ [-422]: Invalid contract vacation rules"
 	//     schema:
 	//       "$ref": "#/definitions/Error"
 	//   '401':
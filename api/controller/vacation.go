@@ -0,0 +1,842 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// VacationController handles requests for vacation endpoints.
+type VacationController struct {
+	vServ *service.VacationService
+}
+
+// NewVacationController create a new vacation controller.
+func NewVacationController(vs *service.VacationService) *VacationController {
+	return &VacationController{vs}
+}
+
+// --- Parameters ---
+
+// swagger:parameters getUserVacationBalance
+type GetUserVacationBalanceParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters getUserVacationLedger
+type GetUserVacationLedgerParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters listUserVacationRequests
+type ListUserVacationRequestsParameters struct {
+	// The ID of the user.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// The status of the requests ("pending", "approved" or "denied"). (default=all)
+	//
+	// in: query
+	// required: false
+	Status string `json:"status"`
+}
+
+// swagger:parameters listVacationRequests
+type ListVacationRequestsParameters struct {
+	// The status of the requests ("pending", "approved" or "denied"). (default=all)
+	//
+	// in: query
+	// required: false
+	Status string `json:"status"`
+}
+
+// swagger:parameters getVacationRequest
+type GetVacationRequestParameters struct {
+	// The ID of the vacation request.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters createVacationRequest
+type CreateVacationRequestParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateVacationRequest
+}
+
+// swagger:parameters approveVacationRequest
+type ApproveVacationRequestParameters struct {
+	// The ID of the vacation request.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+	// in: body
+	// required: false
+	Body model.VacationRequestDecision
+}
+
+// swagger:parameters denyVacationRequest
+type DenyVacationRequestParameters struct {
+	// The ID of the vacation request.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+	// in: body
+	// required: false
+	Body model.VacationRequestDecision
+}
+
+// swagger:parameters deleteVacationRequest
+type DeleteVacationRequestParameters struct {
+	// The ID of the vacation request.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// --- Responses ---
+
+// The vacation balance.
+// swagger:response GetUserVacationBalanceResponse
+type GetUserVacationBalanceResponse struct {
+	// in: body
+	Body model.VacationBalance
+}
+
+// The vacation ledger.
+// swagger:response GetUserVacationLedgerResponse
+type GetUserVacationLedgerResponse struct {
+	// in: body
+	Body model.VacationLedger
+}
+
+// The list of vacation requests.
+// swagger:response ListVacationRequestsResponse
+type ListVacationRequestsResponse struct {
+	// in: body
+	Body model.VacationRequestList
+}
+
+// The vacation request.
+// swagger:response GetVacationRequestResponse
+type GetVacationRequestResponse struct {
+	// in: body
+	Body model.VacationRequest
+}
+
+// The created vacation request.
+// swagger:response CreateVacationRequestResponse
+type CreateVacationRequestResponse struct {
+	// in: body
+	Body model.VacationRequest
+}
+
+// The approved or denied vacation request.
+// swagger:response DecideVacationRequestResponse
+type DecideVacationRequestResponse struct {
+	// in: body
+	Body model.VacationRequest
+}
+
+// --- Endpoints ---
+
+// GetUserVacationBalanceHandler returns a handler for "GET /users/{id}/vacation_balance".
+func (c *VacationController) GetUserVacationBalanceHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/vacation_balance vacations getUserVacationBalance
+	//
+	// Gets the vacation balance of a user in the current year. The balance is computed from the
+	// vacation days of the user's contract and the vacation entries of the user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetUserVacationBalanceResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		balance, err := c.vServ.GetVacationBalanceByUserId(getContext(eCtx), userId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		avb := mapper.ToVacationBalance(balance)
+		return writeResponse(eCtx, http.StatusOK, avb)
+	}
+}
+
+// GetUserVacationLedgerHandler returns a handler for "GET /users/{id}/vacation_ledger".
+func (c *VacationController) GetUserVacationLedgerHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/vacation_ledger vacations getUserVacationLedger
+	//
+	// Gets the vacation ledger of a user. The ledger contains the carried over, accrued, adjusted,
+	// taken and expired vacation days of every year since the first work day of the user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetUserVacationLedgerResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		ledger, err := c.vServ.GetVacationLedgerByUserId(getContext(eCtx), userId)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		avl := mapper.ToVacationLedger(userId, ledger)
+		return writeResponse(eCtx, http.StatusOK, avl)
+	}
+}
+
+// GetUserVacationRequestsHandler returns a handler for "GET /users/{id}/vacation_requests".
+func (c *VacationController) GetUserVacationRequestsHandler() echo.HandlerFunc {
+	// swagger:operation GET /users/{id}/vacation_requests vacations listUserVacationRequests
+	//
+	// Lists the vacation requests of a user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListVacationRequestsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-327]: Invalid status"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users\n
+	//       ⦁ [-209]: No right to get own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get user ID from request
+		userId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Get status from request
+		status, err := getVacationRequestStatusQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		requests, err := c.vServ.GetVacationRequestsByUserId(getContext(eCtx), userId, status)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		avrs := mapper.ToVacationRequests(requests)
+		return writeResponse(eCtx, http.StatusOK, avrs)
+	}
+}
+
+// GetVacationRequestsHandler returns a handler for "GET /vacation_requests".
+func (c *VacationController) GetVacationRequestsHandler() echo.HandlerFunc {
+	// swagger:operation GET /vacation_requests vacations listVacationRequests
+	//
+	// Lists the vacation requests of all users. Pending requests which exceed the remaining
+	// vacation days of the user contain the warning `balance_exceeded`.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/ListVacationRequestsResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-327]: Invalid status"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-207]: No right to get entries of other users"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get status from request
+		status, err := getVacationRequestStatusQueryParam(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		requests, err := c.vServ.GetVacationRequests(getContext(eCtx), status)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		avrs := mapper.ToVacationRequests(requests)
+		return writeResponse(eCtx, http.StatusOK, avrs)
+	}
+}
+
+// CreateVacationRequestHandler returns a handler for "POST /vacation_requests".
+func (c *VacationController) CreateVacationRequestHandler() echo.HandlerFunc {
+	// swagger:operation POST /vacation_requests vacations createVacationRequest
+	//
+	// Request leave for the current user. Only working days are counted as requested vacation
+	// days. If the requested days exceed the remaining vacation days, the request is created
+	// anyway but contains the warning `balance_exceeded`.
+	//
+	// # Input Rules
+	//
+	// __Day fraction:__
+	//
+	// ⦁ Allowed values: `full`, `half`
+	//
+	// __Description:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/CreateVacationRequestResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-313]: Invalid date\n
+	//       ⦁ [-326]: Invalid day fraction\n
+	//       ⦁ [-406]: Invalid date interval\n
+	//       ⦁ [-419]: Vacation without working days"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-210]: No right to create own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acvr model.CreateVacationRequest
+		if err := readRequestBody(eCtx, &acvr); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateVacationRequest(&acvr); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		request := mapper.FromCreateVacationRequest(&acvr)
+
+		// Execute action
+		if err := c.vServ.CreateVacationRequest(getContext(eCtx), request); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		avr := mapper.ToVacationRequest(request)
+		return writeResponse(eCtx, http.StatusOK, avr)
+	}
+}
+
+// GetVacationRequestHandler returns a handler for "GET /vacation_requests/{id}".
+func (c *VacationController) GetVacationRequestHandler() echo.HandlerFunc {
+	// swagger:operation GET /vacation_requests/{id} vacations getVacationRequest
+	//
+	// Get a vacation request by its ID.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetVacationRequestResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-420]: Vacation request not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		request, err := c.vServ.GetVacationRequestById(getContext(eCtx), id)
+		if err != nil {
+			return c.convertPermissionError(getContext(eCtx), id, err)
+		}
+
+		// Check if a request was found
+		if request == nil {
+			err := e.NewError(e.LogicVacationRequestNotFound, fmt.Sprintf("Could not find "+
+				"vacation request %d.", id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		// Convert to API model and write response
+		avr := mapper.ToVacationRequest(request)
+		return writeResponse(eCtx, http.StatusOK, avr)
+	}
+}
+
+// ApproveVacationRequestHandler returns a handler for "POST /vacation_requests/{id}/approve".
+func (c *VacationController) ApproveVacationRequestHandler() echo.HandlerFunc {
+	// swagger:operation POST /vacation_requests/{id}/approve vacations approveVacationRequest
+	//
+	// Approve a pending vacation request. A vacation absence is created for the requested period
+	// (with one vacation entry per working day).
+	//
+	// # Input Rules
+	//
+	// __Comment:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/DecideVacationRequestResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-419]: Vacation without working days"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-213]: No right to approve vacations\n
+	//       ⦁ [-216]: No right to approve/deny own vacation requests"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-420]: Vacation request not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-421]: Vacation request already approved/denied\n
+	//       ⦁ [-425]: Year closed"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		return c.handleDecision(eCtx, c.vServ.ApproveVacationRequest)
+	}
+}
+
+// DenyVacationRequestHandler returns a handler for "POST /vacation_requests/{id}/deny".
+func (c *VacationController) DenyVacationRequestHandler() echo.HandlerFunc {
+	// swagger:operation POST /vacation_requests/{id}/deny vacations denyVacationRequest
+	//
+	// Deny a pending vacation request.
+	//
+	// # Input Rules
+	//
+	// __Comment:__
+	//
+	// ⦁ Maximum length: 200
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/DecideVacationRequestResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-312]: Too long string"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-213]: No right to approve vacations\n
+	//       ⦁ [-216]: No right to approve/deny own vacation requests"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-420]: Vacation request not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-421]: Vacation request already approved/denied"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		return c.handleDecision(eCtx, c.vServ.DenyVacationRequest)
+	}
+}
+
+// DeleteVacationRequestHandler returns a handler for "DELETE /vacation_requests/{id}".
+func (c *VacationController) DeleteVacationRequestHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /vacation_requests/{id} vacations deleteVacationRequest
+	//
+	// Withdraw a pending vacation request of the current user.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-210]: No right to delete own entries"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-420]: Vacation request not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '409':
+	//     description: "__Conflict__\n\n
+	//       ⦁ [-421]: Vacation request already approved/denied"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.vServ.DeleteVacationRequestById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return writeResponse(eCtx, http.StatusNoContent, nil)
+	}
+}
+
+func (c *VacationController) handleDecision(eCtx echo.Context, decide func(ctx context.Context,
+	id int, comment string) (*m.VacationRequest, error)) error {
+	// Get ID from request
+	id, err := getIdPathVar(eCtx)
+	if err != nil {
+		return err
+	}
+
+	// Read API model from request (the body is optional)
+	var avrd model.VacationRequestDecision
+	if eCtx.Request().ContentLength != 0 {
+		if err := readRequestBody(eCtx, &avrd); err != nil {
+			return err
+		}
+	}
+
+	// Validate model
+	if err := validator.ValidateVacationRequestDecision(&avrd); err != nil {
+		return err
+	}
+
+	// Execute action
+	request, err := decide(getContext(eCtx), id, avrd.Comment)
+	if err != nil {
+		return err
+	}
+
+	// Convert to API model and write response
+	avr := mapper.ToVacationRequest(request)
+	return writeResponse(eCtx, http.StatusOK, avr)
+}
+
+func getVacationRequestStatusQueryParam(eCtx echo.Context) (string, error) {
+	status := eCtx.QueryParam("status")
+	if status != "" && !m.IsValidVacationRequestStatus(status) {
+		err := e.NewError(e.ValStatusInvalid, fmt.Sprintf("Invalid status '%s'.", status))
+		log.Debug(err.StackTrace())
+		return "", err
+	}
+	return status, nil
+}
+
+// --- Permission helper functions ---
+
+func (c *VacationController) convertPermissionError(ctx context.Context, id int, err error) error {
+	er, ok := err.(*e.Error)
+	if ok && er.IsPermissionError() && !hasCurrentUserRight(ctx, m.RightGetAllEntries) {
+		return e.WrapError(e.LogicVacationRequestNotFound, fmt.Sprintf("Could not find vacation "+
+			"request %d.", id), err)
+	}
+	return err
+}
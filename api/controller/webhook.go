@@ -0,0 +1,665 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"kellnhofer.com/work-log/api/mapper"
+	"kellnhofer.com/work-log/api/model"
+	"kellnhofer.com/work-log/api/validator"
+	"kellnhofer.com/work-log/pkg/service"
+)
+
+// WebhookController handles requests for webhook endpoints.
+type WebhookController struct {
+	wServ *service.WebhookService
+}
+
+// NewWebhookController create a new webhook controller.
+func NewWebhookController(ws *service.WebhookService) *WebhookController {
+	return &WebhookController{ws}
+}
+
+// --- Parameters ---
+
+// swagger:parameters createWebhook
+type CreateWebhookParameters struct {
+	// in: body
+	// required: true
+	Body model.CreateWebhook
+}
+
+// swagger:parameters getWebhook deleteWebhook testWebhook
+type GetWebhookParameters struct {
+	// The ID of the webhook.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// swagger:parameters updateWebhook
+type UpdateWebhookParameters struct {
+	// The ID of the webhook.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// in: body
+	// required: true
+	Body model.UpdateWebhook
+}
+
+// swagger:parameters listWebhookDeliveries
+type GetWebhookDeliveriesParameters struct {
+	// The ID of the webhook.
+	//
+	// in: path
+	// required: true
+	Id int `json:"id"`
+
+	// Start of the deliveries result page.
+	//
+	// in: query
+	// required: false
+	Offset int `json:"offset"`
+
+	// Size of the deliveries result page. (default=50)
+	//
+	// in: query
+	// required: false
+	Limit int `json:"limit"`
+}
+
+// --- Responses ---
+
+// The list of webhooks.
+// swagger:response GetWebhooksResponse
+type GetWebhooksResponse struct {
+	// in: body
+	Body model.WebhookList
+}
+
+// The webhook.
+// swagger:response GetWebhookResponse
+type GetWebhookResponse struct {
+	// in: body
+	Body model.Webhook
+}
+
+// The created webhook.
+// swagger:response CreateWebhookResponse
+type CreateWebhookResponse struct {
+	// in: body
+	Body model.Webhook
+}
+
+// The updated webhook.
+// swagger:response UpdateWebhookResponse
+type UpdateWebhookResponse struct {
+	// in: body
+	Body model.Webhook
+}
+
+// The list of webhook deliveries.
+// swagger:response GetWebhookDeliveriesResponse
+type GetWebhookDeliveriesResponse struct {
+	// in: body
+	Body model.WebhookDeliveryList
+}
+
+// The test delivery.
+// swagger:response TestWebhookResponse
+type TestWebhookResponse struct {
+	// in: body
+	Body model.WebhookDelivery
+}
+
+// --- Endpoints ---
+
+// GetWebhooksHandler returns a handler for "GET /webhooks".
+func (c *WebhookController) GetWebhooksHandler() echo.HandlerFunc {
+	// swagger:operation GET /webhooks webhooks listWebhooks
+	//
+	// Lists all webhooks.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetWebhooksResponse"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-211]: No right to get webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Execute action
+		webhooks, err := c.wServ.GetWebhooks(getContext(eCtx))
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aws := mapper.ToWebhooks(webhooks)
+		return writeResponse(eCtx, http.StatusOK, aws)
+	}
+}
+
+// CreateWebhookHandler returns a handler for "POST /webhooks".
+func (c *WebhookController) CreateWebhookHandler() echo.HandlerFunc {
+	// swagger:operation POST /webhooks webhooks createWebhook
+	//
+	// Create a webhook.
+	//
+	// Creates a new webhook subscription. The secret is only returned in the response of this
+	// request.
+	//
+	// # Deliveries
+	//
+	// Events are sent as HTTP POST requests with a JSON body containing the fields `event`,
+	// `timestamp` and `data`. Each request contains the following headers:
+	//
+	// ⦁ `X-Work-Log-Event`: The event type
+	// ⦁ `X-Work-Log-Delivery`: The ID of the delivery
+	// ⦁ `X-Work-Log-Signature`: `sha256=` followed by the hex encoded HMAC-SHA256 of the body
+	// (keyed with the webhook secret)
+	//
+	// A delivery is successful if the receiver responds with a 2xx status code. Failed
+	// deliveries are retried with an exponential backoff.
+	//
+	// # Input Rules
+	//
+	// __URL:__
+	//
+	// ⦁ Absolute HTTP or HTTPS URL
+	// ⦁ Maximum length: 500
+	//
+	// __Secret:__
+	//
+	// ⦁ Maximum length: 100
+	//
+	// __Event Types:__
+	//
+	// ⦁ Allowed values: `entry.created`, `entry.updated`, `entry.deleted`, `user.created`
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '201':
+	//     "$ref": "#/responses/CreateWebhookResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-301]: Invalid JSON\n
+	//       ⦁ [-308]: Null field\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-315]: Empty array\n
+	//       ⦁ [-322]: Invalid URL\n
+	//       ⦁ [-323]: Invalid event type"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-212]: No right to change webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Read API model from request
+		var acw model.CreateWebhook
+		if err := readRequestBody(eCtx, &acw); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateCreateWebhook(&acw); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		webhook := mapper.FromCreateWebhook(&acw)
+
+		// Execute action
+		if err := c.wServ.CreateWebhook(getContext(eCtx), webhook); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aw := mapper.ToWebhookFull(webhook)
+		return writeResponse(eCtx, http.StatusCreated, aw)
+	}
+}
+
+// GetWebhookHandler returns a handler for "GET /webhooks/{id}".
+func (c *WebhookController) GetWebhookHandler() echo.HandlerFunc {
+	// swagger:operation GET /webhooks/{id} webhooks getWebhook
+	//
+	// Get a webhook.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetWebhookResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-211]: No right to get webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-414]: Webhook not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get webhook ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		webhook, err := c.wServ.GetWebhookById(getContext(eCtx), id)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aw := mapper.ToWebhook(webhook)
+		return writeResponse(eCtx, http.StatusOK, aw)
+	}
+}
+
+// UpdateWebhookHandler returns a handler for "PUT /webhooks/{id}".
+func (c *WebhookController) UpdateWebhookHandler() echo.HandlerFunc {
+	// swagger:operation PUT /webhooks/{id} webhooks updateWebhook
+	//
+	// Update a webhook.
+	//
+	// If no secret is provided, the existing secret is kept.
+	//
+	// # Input Rules
+	//
+	// __URL:__
+	//
+	// ⦁ Absolute HTTP or HTTPS URL
+	// ⦁ Maximum length: 500
+	//
+	// __Secret:__
+	//
+	// ⦁ Maximum length: 100
+	//
+	// __Event Types:__
+	//
+	// ⦁ Allowed values: `entry.created`, `entry.updated`, `entry.deleted`, `user.created`
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/UpdateWebhookResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-301]: Invalid JSON\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-308]: Null field\n
+	//       ⦁ [-311]: Empty string\n
+	//       ⦁ [-312]: Too long string\n
+	//       ⦁ [-315]: Empty array\n
+	//       ⦁ [-322]: Invalid URL\n
+	//       ⦁ [-323]: Invalid event type"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-212]: No right to change webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-414]: Webhook not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get webhook ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Read API model from request
+		var auw model.UpdateWebhook
+		if err := readRequestBody(eCtx, &auw); err != nil {
+			return err
+		}
+
+		// Validate model
+		if err := validator.ValidateUpdateWebhook(&auw); err != nil {
+			return err
+		}
+
+		// Convert to logic model
+		webhook := mapper.FromUpdateWebhook(id, &auw)
+
+		// Execute action
+		if err := c.wServ.UpdateWebhook(getContext(eCtx), webhook); err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		aw := mapper.ToWebhook(webhook)
+		return writeResponse(eCtx, http.StatusOK, aw)
+	}
+}
+
+// DeleteWebhookHandler returns a handler for "DELETE /webhooks/{id}".
+func (c *WebhookController) DeleteWebhookHandler() echo.HandlerFunc {
+	// swagger:operation DELETE /webhooks/{id} webhooks deleteWebhook
+	//
+	// Delete a webhook.
+	//
+	// The delivery log of the webhook is deleted as well.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '204':
+	//     description: No content.
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-212]: No right to change webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-414]: Webhook not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get webhook ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		if err := c.wServ.DeleteWebhookById(getContext(eCtx), id); err != nil {
+			return err
+		}
+
+		// Write response
+		return eCtx.NoContent(http.StatusNoContent)
+	}
+}
+
+// GetWebhookDeliveriesHandler returns a handler for "GET /webhooks/{id}/deliveries".
+func (c *WebhookController) GetWebhookDeliveriesHandler() echo.HandlerFunc {
+	// swagger:operation GET /webhooks/{id}/deliveries webhooks listWebhookDeliveries
+	//
+	// Lists the deliveries of a webhook.
+	//
+	// The newest deliveries are returned first.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/GetWebhookDeliveriesResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID\n
+	//       ⦁ [-306]: Invalid offset\n
+	//       ⦁ [-307]: Invalid limit"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-211]: No right to get webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-414]: Webhook not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get webhook ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Get offset and limit from request
+		var o, l int
+		if o, err = getOffsetQueryParam(eCtx); err != nil {
+			return err
+		}
+		if l, err = getLimitQueryParam(eCtx); err != nil {
+			return err
+		}
+		if l == 0 {
+			l = defaultPageSize
+		}
+
+		// Execute action
+		deliveries, cnt, err := c.wServ.GetWebhookDeliveries(getContext(eCtx), id, o, l)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ads := mapper.ToWebhookDeliveries(deliveries, o, l, cnt)
+		return writeResponse(eCtx, http.StatusOK, ads)
+	}
+}
+
+// TestWebhookHandler returns a handler for "POST /webhooks/{id}/test".
+func (c *WebhookController) TestWebhookHandler() echo.HandlerFunc {
+	// swagger:operation POST /webhooks/{id}/test webhooks testWebhook
+	//
+	// Test a webhook.
+	//
+	// Immediately sends a `ping` event to the webhook and returns the resulting delivery. A failed
+	// test delivery is not retried.
+	//
+	// ---
+	//
+	// security:
+	// - Basic: []
+	// - Bearer Token: []
+	//
+	// produces:
+	// - application/json
+	//
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/TestWebhookResponse"
+	//   '400':
+	//     description: "__Bad Request__\n\n
+	//       ⦁ [-303]: Invalid ID"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '401':
+	//     description: "__Unauthorized__\n\n
+	//       ⦁ [-101]: Invalid authentication data\n
+	//       ⦁ [-102]: Invalid credentials\n
+	//       ⦁ [-104]: Invalid token"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '403':
+	//     description: "__Forbidden__\n\n
+	//       ⦁ [-212]: No right to change webhooks"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '404':
+	//     description: "__Not Found__\n\n
+	//       ⦁ [-414]: Webhook not found"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   '412':
+	//     description: "__Precondition Failed__\n\n
+	//       ⦁ [-103]: User not activated"
+	//     schema:
+	//       "$ref": "#/definitions/Error"
+	//   default:
+	//     "$ref": "#/responses/ErrorResponse"
+	return func(eCtx echo.Context) error {
+		// Get webhook ID from request
+		id, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		// Execute action
+		delivery, err := c.wServ.TestWebhook(getContext(eCtx), id)
+		if err != nil {
+			return err
+		}
+
+		// Convert to API model and write response
+		ad := mapper.ToWebhookDelivery(delivery)
+		return writeResponse(eCtx, http.StatusOK, ad)
+	}
+}
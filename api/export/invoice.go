@@ -0,0 +1,264 @@
+package export
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/message"
+
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/pdf"
+)
+
+const invoiceDateFormat = "02.01.2006"
+
+type xlsxWriterToAdapter struct {
+	f *excelize.File
+}
+
+func (xta *xlsxWriterToAdapter) WriteTo(w io.Writer) (n int64, err error) {
+	return xta.f.WriteTo(w)
+}
+
+// InvoiceExporter exports invoices to PDF and Excel files.
+type InvoiceExporter struct {
+	issuerName    string
+	issuerAddress []string
+	paymentDays   int
+}
+
+// NewInvoiceExporter creates a new invoice exporter. The issuer is printed in the letterhead of
+// the invoices and the payment days are used to calculate the due date.
+func NewInvoiceExporter(issuerName string, issuerAddress []string, paymentDays int,
+) *InvoiceExporter {
+	return &InvoiceExporter{
+		issuerName:    issuerName,
+		issuerAddress: issuerAddress,
+		paymentDays:   paymentDays,
+	}
+}
+
+// ExportInvoicePdf creates the PDF file for the supplied invoice and returns it as an io.WriterTo
+// that can be used to write the file to a writer.
+func (e *InvoiceExporter) ExportInvoicePdf(invoice *model.Invoice, client *model.Client,
+) io.WriterTo {
+	title := createString("invoiceExportTitle", invoice.Number)
+	doc := pdf.NewDocument(title, createString("appName"))
+
+	// Write letterhead
+	doc.SetFont(true, 12)
+	doc.WriteLineRight(e.issuerName)
+	doc.SetFont(false, 9)
+	for _, line := range e.issuerAddress {
+		doc.WriteLineRight(line)
+	}
+	doc.Space(10)
+
+	// Write recipient
+	doc.SetFont(false, 10)
+	doc.WriteLine(client.Name)
+	doc.WriteLine(client.Address)
+	doc.Space(10)
+
+	// Write title and details
+	doc.SetFont(true, 14)
+	doc.WriteLine(title)
+	doc.SetFont(false, 10)
+	doc.Space(2)
+	doc.WriteLine(createString("invoiceExportLabelDate") + " " + e.formatDate(invoice.CreatedAt))
+	doc.WriteLine(createString("invoiceExportLabelPeriod") + " " + e.formatPeriod(invoice))
+	doc.Space(6)
+
+	// Write items
+	cols := []*pdf.Column{
+		{Title: createString("tableColDate"), Width: 22},
+		{Title: createString("tableColProject"), Width: 28},
+		{Title: createString("tableColDescription"), Width: 60},
+		{Title: createString("invoiceExportColHours"), Width: 18, AlignRight: true},
+		{Title: createString("tableColRate"), Width: 20, AlignRight: true},
+		{Title: createString("tableColAmount"), Width: 22, AlignRight: true},
+	}
+	var rows [][]string
+	for _, item := range invoice.Items {
+		rows = append(rows, []string{
+			e.formatDate(item.Date),
+			item.Project,
+			item.Description,
+			e.formatNumber(item.Hours),
+			e.formatNumber(item.Rate),
+			e.formatNumber(item.Amount),
+		})
+	}
+	doc.Table(cols, rows, nil)
+	doc.Space(4)
+
+	// Write totals
+	totalCols := []*pdf.Column{
+		{Width: 148},
+		{Width: 22, AlignRight: true},
+	}
+	totalRows := [][]string{
+		{createString("invoiceExportLabelNet"),
+			e.formatAmount(invoice.NetAmount, invoice.Currency)},
+		{createString("invoiceExportLabelTax", e.formatNumber(invoice.TaxRate)),
+			e.formatAmount(invoice.TaxAmount, invoice.Currency)},
+		{createString("invoiceExportLabelTotal"),
+			e.formatAmount(invoice.TotalAmount, invoice.Currency)},
+	}
+	doc.Table(totalCols, totalRows, map[int]bool{2: true})
+	doc.Space(10)
+
+	// Write payment terms
+	doc.WriteLine(createString("invoiceExportPaymentTerms", e.formatDate(e.getDueDate(invoice))))
+
+	return doc
+}
+
+// ExportInvoiceXlsx creates the Excel file for the supplied invoice and returns it as an
+// io.WriterTo that can be used to write the file to a writer.
+func (e *InvoiceExporter) ExportInvoiceXlsx(invoice *model.Invoice, client *model.Client,
+) io.WriterTo {
+	f := excelize.NewFile()
+	sheet := createString("invoiceExportSheetName")
+	f.SetSheetName("Sheet1", sheet)
+
+	// Configure document properties
+	now := time.Now()
+	f.SetDocProps(&excelize.DocProperties{
+		Created:        now.Format(time.RFC3339),
+		Creator:        createString("appName"),
+		Modified:       now.Format(time.RFC3339),
+		LastModifiedBy: createString("appName"),
+		Description:    createString("exportPropDescription", createString("appName")),
+		Language:       loc.LngTag.String(),
+	})
+
+	// Create styles
+	styleTitle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 14, Bold: true}})
+	styleBold, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 10, Bold: true}})
+	styleAmount, _ := f.NewStyle(&excelize.Style{NumFmt: 4, Font: &excelize.Font{Size: 10}})
+	styleAmountBold, _ := f.NewStyle(&excelize.Style{NumFmt: 4,
+		Font: &excelize.Font{Size: 10, Bold: true}})
+
+	// Configure work sheet
+	f.SetColWidth(sheet, "A", "A", 12)
+	f.SetColWidth(sheet, "B", "B", 16.5)
+	f.SetColWidth(sheet, "C", "C", 42)
+	f.SetColWidth(sheet, "D", "F", 10.5)
+
+	// Write issuer, recipient and details
+	row := 1
+	f.SetCellValue(sheet, getCellName("A", row), e.issuerName)
+	f.SetCellStyle(sheet, getCellName("A", row), getCellName("A", row), styleBold)
+	row++
+	for _, line := range e.issuerAddress {
+		f.SetCellValue(sheet, getCellName("A", row), line)
+		row++
+	}
+	row++
+	f.SetCellValue(sheet, getCellName("A", row), client.Name)
+	row++
+	for _, line := range strings.Split(client.Address, "\n") {
+		f.SetCellValue(sheet, getCellName("A", row), line)
+		row++
+	}
+	row++
+	f.SetCellValue(sheet, getCellName("A", row), createString("invoiceExportTitle", invoice.Number))
+	f.SetCellStyle(sheet, getCellName("A", row), getCellName("A", row), styleTitle)
+	row++
+	f.SetCellValue(sheet, getCellName("A", row), createString("invoiceExportLabelDate"))
+	f.SetCellValue(sheet, getCellName("C", row), e.formatDate(invoice.CreatedAt))
+	row++
+	f.SetCellValue(sheet, getCellName("A", row), createString("invoiceExportLabelPeriod"))
+	f.SetCellValue(sheet, getCellName("C", row), e.formatPeriod(invoice))
+	row += 2
+
+	// Write items
+	header := []string{
+		createString("tableColDate"),
+		createString("tableColProject"),
+		createString("tableColDescription"),
+		createString("invoiceExportColHours"),
+		createString("tableColRate"),
+		createString("tableColAmount"),
+	}
+	for i, title := range header {
+		f.SetCellValue(sheet, getCellName(string(rune('A'+i)), row), title)
+	}
+	f.SetCellStyle(sheet, getCellName("A", row), getCellName("F", row), styleBold)
+	row++
+	startRow := row
+	for _, item := range invoice.Items {
+		f.SetCellValue(sheet, getCellName("A", row), e.formatDate(item.Date))
+		f.SetCellValue(sheet, getCellName("B", row), item.Project)
+		f.SetCellValue(sheet, getCellName("C", row), item.Description)
+		f.SetCellValue(sheet, getCellName("D", row), item.Hours)
+		f.SetCellValue(sheet, getCellName("E", row), item.Rate)
+		f.SetCellValue(sheet, getCellName("F", row), item.Amount)
+		row++
+	}
+	if row > startRow {
+		f.SetCellStyle(sheet, getCellName("D", startRow), getCellName("F", row-1), styleAmount)
+	}
+	row++
+
+	// Write totals
+	f.SetCellValue(sheet, getCellName("C", row), createString("invoiceExportLabelNet"))
+	f.SetCellValue(sheet, getCellName("F", row), invoice.NetAmount)
+	f.SetCellStyle(sheet, getCellName("F", row), getCellName("F", row), styleAmount)
+	row++
+	f.SetCellValue(sheet, getCellName("C", row), createString("invoiceExportLabelTax",
+		e.formatNumber(invoice.TaxRate)))
+	f.SetCellValue(sheet, getCellName("F", row), invoice.TaxAmount)
+	f.SetCellStyle(sheet, getCellName("F", row), getCellName("F", row), styleAmount)
+	row++
+	f.SetCellValue(sheet, getCellName("C", row), createString("invoiceExportLabelTotal"))
+	f.SetCellValue(sheet, getCellName("D", row), invoice.Currency)
+	f.SetCellValue(sheet, getCellName("F", row), invoice.TotalAmount)
+	f.SetCellStyle(sheet, getCellName("C", row), getCellName("C", row), styleBold)
+	f.SetCellStyle(sheet, getCellName("F", row), getCellName("F", row), styleAmountBold)
+	row += 2
+
+	// Write payment terms
+	f.SetCellValue(sheet, getCellName("A", row), createString("invoiceExportPaymentTerms",
+		e.formatDate(e.getDueDate(invoice))))
+
+	return &xlsxWriterToAdapter{
+		f: f,
+	}
+}
+
+func (e *InvoiceExporter) getDueDate(invoice *model.Invoice) time.Time {
+	return invoice.CreatedAt.AddDate(0, 0, e.paymentDays)
+}
+
+func (e *InvoiceExporter) formatPeriod(invoice *model.Invoice) string {
+	return e.formatDate(invoice.StartDate) + " - " + e.formatDate(invoice.EndDate)
+}
+
+func (e *InvoiceExporter) formatDate(t time.Time) string {
+	return t.Format(invoiceDateFormat)
+}
+
+func (e *InvoiceExporter) formatNumber(n float64) string {
+	printer := message.NewPrinter(loc.LngTag)
+	return printer.Sprintf("%.2f", n)
+}
+
+func (e *InvoiceExporter) formatAmount(n float64, currency string) string {
+	return e.formatNumber(n) + " " + currency
+}
+
+// --- Helper functions ---
+
+func createString(key string, args ...any) string {
+	return loc.CreateString(key, args...)
+}
+
+func getCellName(col string, row int) string {
+	return col + strconv.Itoa(row)
+}
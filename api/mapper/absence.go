@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Absence functions ---
+
+// ToAbsence converts a logic absence model to an API absence model.
+func ToAbsence(a *m.Absence) *am.Absence {
+	if a == nil {
+		return nil
+	}
+
+	var out am.Absence
+	out.Id = a.Id
+	out.UserId = a.UserId
+	out.TypeId = a.TypeId
+	out.StartDate = formatDate(a.StartDate)
+	out.EndDate = formatDate(a.EndDate)
+	out.DayFraction = a.DayFraction
+	out.Description = a.Description
+	out.EntryIds = a.EntryIds
+	return &out
+}
+
+// FromCreateAbsence converts an API absence creation model to a logic absence model.
+func FromCreateAbsence(ca *am.CreateAbsence) *m.Absence {
+	if ca == nil {
+		return nil
+	}
+
+	out := m.NewAbsence()
+	out.UserId = ca.UserId
+	out.TypeId = ca.TypeId
+	out.StartDate = parseDate(ca.StartDate)
+	out.EndDate = parseDate(ca.EndDate)
+	out.DayFraction = ca.DayFraction
+	out.Description = trimString(ca.Description)
+	return out
+}
+
+// FromUpdateAbsence converts an API absence update model to a logic absence model.
+func FromUpdateAbsence(id int, ua *am.UpdateAbsence) *m.Absence {
+	if ua == nil {
+		return nil
+	}
+
+	out := m.NewAbsence()
+	out.Id = id
+	out.UserId = ua.UserId
+	out.TypeId = ua.TypeId
+	out.StartDate = parseDate(ua.StartDate)
+	out.EndDate = parseDate(ua.EndDate)
+	out.DayFraction = ua.DayFraction
+	out.Description = trimString(ua.Description)
+	return out
+}
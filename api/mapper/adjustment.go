@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ToBalanceAdjustments converts a list of logic balance adjustment models to an API balance
+// adjustment list model.
+func ToBalanceAdjustments(as []*m.BalanceAdjustment) *am.BalanceAdjustmentList {
+	if as == nil {
+		return nil
+	}
+
+	out := make([]*am.BalanceAdjustment, 0, len(as))
+	for _, a := range as {
+		out = append(out, ToBalanceAdjustment(a))
+	}
+	return am.NewBalanceAdjustmentList(out)
+}
+
+// ToBalanceAdjustment converts a logic balance adjustment model to an API balance adjustment model.
+func ToBalanceAdjustment(a *m.BalanceAdjustment) *am.BalanceAdjustment {
+	if a == nil {
+		return nil
+	}
+
+	var out am.BalanceAdjustment
+	out.Id = a.Id
+	out.UserId = a.UserId
+	out.Type = a.Type
+	out.Date = formatDate(a.Date)
+	out.Value = a.Value
+	out.Reason = a.Reason
+	out.CreatedByUserId = a.CreatedByUserId
+	out.CreatedAt = formatTimestamp(a.CreatedAt)
+	return &out
+}
+
+// FromCreateBalanceAdjustment converts an API balance adjustment creation model to a logic balance
+// adjustment model.
+func FromCreateBalanceAdjustment(userId int, ca *am.CreateBalanceAdjustment,
+) *m.BalanceAdjustment {
+	if ca == nil {
+		return nil
+	}
+
+	out := m.NewBalanceAdjustment()
+	out.UserId = userId
+	out.Type = ca.Type
+	out.Date = parseDate(ca.Date)
+	out.Value = ca.Value
+	out.Reason = trimString(ca.Reason)
+	return out
+}
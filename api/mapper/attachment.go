@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ToAttachments converts a list of logic attachment models to an API attachment list model.
+func ToAttachments(as []*m.Attachment) *am.AttachmentList {
+	if as == nil {
+		return nil
+	}
+
+	out := make([]*am.Attachment, 0, len(as))
+	for _, a := range as {
+		out = append(out, ToAttachment(a))
+	}
+	return am.NewAttachmentList(out)
+}
+
+// ToAttachment converts a logic attachment model to an API attachment model.
+func ToAttachment(a *m.Attachment) *am.Attachment {
+	if a == nil {
+		return nil
+	}
+
+	var out am.Attachment
+	out.Id = a.Id
+	out.EntryId = a.EntryId
+	out.FileName = a.FileName
+	out.MimeType = a.MimeType
+	out.Size = a.Size
+	out.CreatedAt = formatTimestamp(a.CreatedAt)
+	return &out
+}
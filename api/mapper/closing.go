@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ToYearClosings converts a list of logic year closing models to an API year closing list model.
+func ToYearClosings(cs []*m.YearClosing) *am.YearClosingList {
+	if cs == nil {
+		return nil
+	}
+
+	out := make([]*am.YearClosing, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, ToYearClosing(c))
+	}
+	return am.NewYearClosingList(out)
+}
+
+// ToYearClosing converts a logic year closing model to an API year closing model.
+func ToYearClosing(c *m.YearClosing) *am.YearClosing {
+	if c == nil {
+		return nil
+	}
+
+	var out am.YearClosing
+	out.UserId = c.UserId
+	out.Year = c.Year
+	out.OvertimeHours = c.OvertimeHours
+	out.Vacation = toVacationLedgerYear(c.Vacation)
+	out.ClosedByUserId = c.ClosedByUserId
+	out.ClosedAt = formatTimestamp(c.ClosedAt)
+	return &out
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Compliance functions ---
+
+// ToComplianceViolations converts a list of logic compliance violation models to an API compliance
+// violation list.
+func ToComplianceViolations(cvs []*m.ComplianceViolation) *am.ComplianceViolationList {
+	if cvs == nil {
+		return nil
+	}
+
+	return am.NewComplianceViolationList(toComplianceViolations(cvs))
+}
+
+// ToComplianceReport converts a logic compliance report model to an API compliance report model.
+func ToComplianceReport(cr *m.ComplianceReport) *am.ComplianceReport {
+	if cr == nil {
+		return nil
+	}
+
+	var out am.ComplianceReport
+	out.StartDate = formatDate(cr.StartDate)
+	out.EndDate = formatDate(cr.EndDate)
+	out.Users = make([]*am.UserComplianceViolations, len(cr.Users))
+	for i, ucv := range cr.Users {
+		out.Users[i] = &am.UserComplianceViolations{}
+		out.Users[i].UserId = ucv.User.Id
+		out.Users[i].Name = ucv.User.Name
+		out.Users[i].Violations = toComplianceViolations(ucv.Violations)
+	}
+	return &out
+}
+
+func toComplianceViolations(cvs []*m.ComplianceViolation) []*am.ComplianceViolation {
+	outs := make([]*am.ComplianceViolation, len(cvs))
+	for i, cv := range cvs {
+		outs[i] = &am.ComplianceViolation{}
+		outs[i].Rule = string(cv.Rule)
+		outs[i].Date = formatDate(cv.Date)
+		outs[i].ActualHours = float32(cv.Actual.Hours())
+		outs[i].LimitHours = float32(cv.Limit.Hours())
+	}
+	return outs
+}
@@ -1,24 +1,27 @@
 package mapper
 
 import (
+	"strconv"
+
 	am "kellnhofer.com/work-log/api/model"
 	m "kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
 )
 
 // --- Entry functions ---
 
-// ToEntries converts a list of logic entry models to a list of API entry models.
-func ToEntries(es []*m.Entry, o int, l int, t int) *am.EntryList {
-	if es == nil {
+// ToEntries converts a page of logic entry models to a list of API entry models.
+func ToEntries(ep *m.EntryPage, o int, l int) *am.EntryList {
+	if ep == nil {
 		return nil
 	}
 
-	items := make([]*am.Entry, len(es))
-	for i, e := range es {
+	items := make([]*am.Entry, len(ep.Entries))
+	for i, e := range ep.Entries {
 		items[i] = ToEntry(e)
 	}
 
-	return am.NewEntryList(o, l, t, items)
+	return am.NewEntryList(o, l, ep.Total, ToEntryCursor(ep.Cursor), items)
 }
 
 // ToEntry converts a logic entry model to an API entry model.
@@ -37,6 +40,12 @@ func ToEntry(e *m.Entry) *am.Entry {
 	out.Project = e.Project
 	out.Description = e.Description
 	out.Labels = e.Labels
+	out.Billable = e.Billable
+	out.InvoiceId = e.InvoiceId
+	out.AbsenceId = e.AbsenceId
+	out.CreatedAt = formatTimestamp(e.CreatedAt)
+	out.UpdatedAt = formatTimestamp(e.UpdatedAt)
+	out.Version = e.Version
 	return &out
 }
 
@@ -55,6 +64,7 @@ func FromCreateEntry(ce *am.CreateEntry) *m.Entry {
 	out.Project = trimString(ce.Project)
 	out.Description = trimString(ce.Description)
 	out.Labels = trimStrings(ce.Labels)
+	out.Billable = ce.Billable
 	return &out
 }
 
@@ -74,9 +84,61 @@ func FromUpdateEntry(id int, ue *am.UpdateEntry) *m.Entry {
 	out.Project = trimString(ue.Project)
 	out.Description = trimString(ue.Description)
 	out.Labels = trimStrings(ue.Labels)
+	out.Billable = ue.Billable
 	return &out
 }
 
+// --- Entry change functions ---
+
+// ToEntryChanges converts logic entry changes to an API entry change list.
+func ToEntryChanges(ecs *m.EntryChanges) *am.EntryChangeList {
+	if ecs == nil {
+		return nil
+	}
+
+	items := make([]*am.EntryChange, len(ecs.Changes))
+	for i, ec := range ecs.Changes {
+		items[i] = ToEntryChange(ec)
+	}
+
+	return am.NewEntryChangeList(ToEntryChangeCursor(ecs.Cursor), ecs.HasMore, items)
+}
+
+// ToEntryChange converts a logic entry change model to an API entry change model.
+func ToEntryChange(ec *m.EntryChange) *am.EntryChange {
+	if ec == nil {
+		return nil
+	}
+
+	var out am.EntryChange
+	out.Id = ec.Entry.Id
+	out.Deleted = ec.Deleted
+	out.UpdatedAt = formatTimestamp(ec.Entry.UpdatedAt)
+	if !ec.Deleted {
+		out.Entry = ToEntry(ec.Entry)
+	}
+	return &out
+}
+
+// ToEntryChangeCursor converts a logic entry change cursor to an (opaque) API cursor string.
+func ToEntryChangeCursor(c *m.EntryChangeCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	return util.EncodeBase64(formatTimestamp(c.UpdatedAt) + ";" + strconv.Itoa(c.Id))
+}
+
+// ToEntryCursor converts a logic entry cursor to an (opaque) API cursor string.
+func ToEntryCursor(c *m.EntryCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	return util.EncodeBase64(formatTimestamp(c.StartTime) + ";" + formatTimestamp(c.EndTime) + ";" +
+		strconv.Itoa(c.Id))
+}
+
 // --- Entry type functions ---
 
 // ToEntryTypes converts a list of logic entry type models to a list of API entry type models.
@@ -100,10 +162,52 @@ func ToEntryType(et *m.EntryType) *am.EntryType {
 
 	var out am.EntryType
 	out.Id = et.Id
+	out.Name = et.Name
 	out.Description = et.Description
+	out.CountsAsWork = et.CountsAsWork
+	out.ReducesTarget = et.ReducesTarget
+	out.ConsumesVacation = et.ConsumesVacation
+	out.AllowsActivities = et.AllowsActivities
+	out.AllowsExpenses = et.AllowsExpenses
+	out.Color = et.Color
 	return &out
 }
 
+// FromCreateEntryType converts an API entry type creation model to a logic entry type model.
+func FromCreateEntryType(cet *am.CreateEntryType) *m.EntryType {
+	if cet == nil {
+		return nil
+	}
+
+	out := m.NewEntryType()
+	out.Description = cet.Description
+	out.CountsAsWork = *cet.CountsAsWork
+	out.ReducesTarget = *cet.ReducesTarget
+	out.ConsumesVacation = *cet.ConsumesVacation
+	out.AllowsActivities = *cet.AllowsActivities
+	out.AllowsExpenses = cet.AllowsExpenses
+	out.Color = cet.Color
+	return out
+}
+
+// FromUpdateEntryType converts an API entry type update model to a logic entry type model.
+func FromUpdateEntryType(id int, uet *am.UpdateEntryType) *m.EntryType {
+	if uet == nil {
+		return nil
+	}
+
+	out := m.NewEntryType()
+	out.Id = id
+	out.Description = uet.Description
+	out.CountsAsWork = *uet.CountsAsWork
+	out.ReducesTarget = *uet.ReducesTarget
+	out.ConsumesVacation = *uet.ConsumesVacation
+	out.AllowsActivities = *uet.AllowsActivities
+	out.AllowsExpenses = uet.AllowsExpenses
+	out.Color = uet.Color
+	return out
+}
+
 // --- Entry activity functions ---
 
 // ToEntryActivities converts a list of logic entry activity models to a list of API entry activity
@@ -129,6 +233,8 @@ func ToEntryActivity(ea *m.EntryActivity) *am.EntryActivity {
 	var out am.EntryActivity
 	out.Id = ea.Id
 	out.Description = ea.Description
+	out.Archived = ea.Archived
+	out.TypeIds = ea.TypeIds
 	return &out
 }
 
@@ -139,9 +245,12 @@ func FromCreateEntryActivity(cea *am.CreateEntryActivity) *m.EntryActivity {
 		return nil
 	}
 
-	var out m.EntryActivity
+	out := m.NewEntryActivity()
 	out.Description = cea.Description
-	return &out
+	if cea.TypeIds != nil {
+		out.TypeIds = cea.TypeIds
+	}
+	return out
 }
 
 // FromUpdateEntryActivity converts an API entry activity update model to a logic entry activity
@@ -151,8 +260,80 @@ func FromUpdateEntryActivity(id int, uea *am.UpdateEntryActivity) *m.EntryActivi
 		return nil
 	}
 
-	var out m.EntryActivity
+	out := m.NewEntryActivity()
 	out.Id = id
 	out.Description = uea.Description
+	out.Archived = *uea.Archived
+	if uea.TypeIds != nil {
+		out.TypeIds = uea.TypeIds
+	}
+	return out
+}
+
+// --- Rounding policy functions ---
+
+// ToProjectRoundingPolicies converts a list of logic project rounding policy models to a list of
+// API project rounding policy models.
+func ToProjectRoundingPolicies(prps []*m.ProjectRoundingPolicy) []*am.ProjectRoundingPolicy {
+	if prps == nil {
+		return nil
+	}
+
+	outs := make([]*am.ProjectRoundingPolicy, len(prps))
+	for i, prp := range prps {
+		outs[i] = ToProjectRoundingPolicy(prp)
+	}
+	return outs
+}
+
+// ToProjectRoundingPolicy converts a logic project rounding policy model to an API project rounding
+// policy model.
+func ToProjectRoundingPolicy(prp *m.ProjectRoundingPolicy) *am.ProjectRoundingPolicy {
+	if prp == nil {
+		return nil
+	}
+
+	var out am.ProjectRoundingPolicy
+	out.Project = prp.Project
+	out.Mode = prp.Mode
+	out.IntervalMinutes = prp.IntervalMinutes
+	out.Scope = prp.Scope
 	return &out
 }
+
+// FromProjectRoundingPolicy converts an API project rounding policy model to a logic project
+// rounding policy model.
+func FromProjectRoundingPolicy(prp *am.ProjectRoundingPolicy) *m.ProjectRoundingPolicy {
+	if prp == nil {
+		return nil
+	}
+
+	out := m.NewProjectRoundingPolicy()
+	out.Project = trimString(prp.Project)
+	out.Mode = prp.Mode
+	out.IntervalMinutes = prp.IntervalMinutes
+	out.Scope = prp.Scope
+	return out
+}
+
+func toRoundingPolicy(rp *m.RoundingPolicy) *am.RoundingPolicy {
+	if rp == nil {
+		return nil
+	}
+	return &am.RoundingPolicy{
+		Mode:            rp.Mode,
+		IntervalMinutes: rp.IntervalMinutes,
+		Scope:           rp.Scope,
+	}
+}
+
+func fromRoundingPolicy(rp *am.RoundingPolicy) *m.RoundingPolicy {
+	if rp == nil {
+		return nil
+	}
+	return &m.RoundingPolicy{
+		Mode:            rp.Mode,
+		IntervalMinutes: rp.IntervalMinutes,
+		Scope:           rp.Scope,
+	}
+}
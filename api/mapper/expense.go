@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Expense rate functions ---
+
+// ToExpenseRates converts a list of logic expense rate models to an API expense rate list model.
+func ToExpenseRates(rs []*m.ExpenseRate) *am.ExpenseRateList {
+	if rs == nil {
+		return nil
+	}
+
+	out := make([]*am.ExpenseRate, 0, len(rs))
+	for _, r := range rs {
+		out = append(out, ToExpenseRate(r))
+	}
+	return am.NewExpenseRateList(out)
+}
+
+// ToExpenseRate converts a logic expense rate model to an API expense rate model.
+func ToExpenseRate(r *m.ExpenseRate) *am.ExpenseRate {
+	if r == nil {
+		return nil
+	}
+
+	var out am.ExpenseRate
+	out.Id = r.Id
+	out.Type = r.Type
+	out.Name = r.Name
+	out.Amount = r.Amount
+	out.Currency = r.Currency
+	return &out
+}
+
+// FromCreateExpenseRate converts an API expense rate creation model to a logic expense rate model.
+func FromCreateExpenseRate(cr *am.CreateExpenseRate) *m.ExpenseRate {
+	if cr == nil {
+		return nil
+	}
+
+	out := m.NewExpenseRate()
+	out.Type = cr.Type
+	out.Name = trimString(cr.Name)
+	out.Amount = cr.Amount
+	out.Currency = cr.Currency
+	return out
+}
+
+// FromUpdateExpenseRate converts an API expense rate update model to a logic expense rate model.
+func FromUpdateExpenseRate(id int, ur *am.UpdateExpenseRate) *m.ExpenseRate {
+	if ur == nil {
+		return nil
+	}
+
+	out := m.NewExpenseRate()
+	out.Id = id
+	out.Name = trimString(ur.Name)
+	out.Amount = ur.Amount
+	out.Currency = ur.Currency
+	return out
+}
+
+// --- Expense functions ---
+
+// ToExpenses converts a list of logic expense models to an API expense list model.
+func ToExpenses(xs []*m.Expense) *am.ExpenseList {
+	if xs == nil {
+		return nil
+	}
+
+	out := make([]*am.Expense, 0, len(xs))
+	for _, x := range xs {
+		out = append(out, ToExpense(x))
+	}
+	return am.NewExpenseList(out)
+}
+
+// ToExpense converts a logic expense model to an API expense model.
+func ToExpense(x *m.Expense) *am.Expense {
+	if x == nil {
+		return nil
+	}
+
+	var out am.Expense
+	out.Id = x.Id
+	out.EntryId = x.EntryId
+	out.Type = x.Type
+	out.RateId = x.RateId
+	out.Quantity = x.Quantity
+	out.Amount = x.Amount
+	out.Currency = x.Currency
+	out.Description = x.Description
+	return &out
+}
+
+// FromCreateExpense converts an API expense creation model to a logic expense model.
+func FromCreateExpense(entryId int, cx *am.CreateExpense) *m.Expense {
+	if cx == nil {
+		return nil
+	}
+
+	out := m.NewExpense()
+	out.EntryId = entryId
+	out.Type = cx.Type
+	out.RateId = cx.RateId
+	out.Quantity = cx.Quantity
+	out.Amount = cx.Amount
+	out.Currency = cx.Currency
+	out.Description = trimString(cx.Description)
+	return out
+}
+
+// FromUpdateExpense converts an API expense update model to a logic expense model.
+func FromUpdateExpense(id int, ux *am.UpdateExpense) *m.Expense {
+	if ux == nil {
+		return nil
+	}
+
+	out := m.NewExpense()
+	out.Id = id
+	out.Type = ux.Type
+	out.RateId = ux.RateId
+	out.Quantity = ux.Quantity
+	out.Amount = ux.Amount
+	out.Currency = ux.Currency
+	out.Description = trimString(ux.Description)
+	return out
+}
+
+// --- Expense report functions ---
+
+// ToExpenseReport converts a logic expense report model to an API expense report model.
+func ToExpenseReport(xr *m.ExpenseReport) *am.ExpenseReport {
+	if xr == nil {
+		return nil
+	}
+
+	var out am.ExpenseReport
+	out.UserId = xr.UserId
+	out.StartDate = formatDate(xr.StartDate)
+	out.EndDate = formatDate(xr.EndDate)
+	out.Items = make([]*am.ExpenseReportItem, len(xr.Items))
+	for i, item := range xr.Items {
+		out.Items[i] = &am.ExpenseReportItem{}
+		out.Items[i].Date = formatDate(item.Date)
+		out.Items[i].Project = item.Entry.Project
+		if item.Rate != nil {
+			out.Items[i].RateName = item.Rate.Name
+		}
+		out.Items[i].Expense = ToExpense(item.Expense)
+	}
+	out.Totals = make([]*am.ExpenseTotal, len(xr.Totals))
+	for i, total := range xr.Totals {
+		out.Totals[i] = &am.ExpenseTotal{}
+		out.Totals[i].Currency = total.Currency
+		out.Totals[i].Amount = total.Amount
+	}
+	return &out
+}
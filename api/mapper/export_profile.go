@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ToExportProfiles converts a list of logic export profile models to an API export profile list
+// model.
+func ToExportProfiles(ps []*m.ExportProfile) *am.ExportProfileList {
+	if ps == nil {
+		return nil
+	}
+
+	out := make([]*am.ExportProfile, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, ToExportProfile(p))
+	}
+	return am.NewExportProfileList(out)
+}
+
+// ToExportProfile converts a logic export profile model to an API export profile model.
+func ToExportProfile(p *m.ExportProfile) *am.ExportProfile {
+	if p == nil {
+		return nil
+	}
+
+	var out am.ExportProfile
+	out.Id = p.Id
+	out.Name = p.Name
+	out.Columns = p.Columns
+	out.Delimiter = p.Delimiter
+	out.DateFormat = p.DateFormat
+	out.DurationUnit = p.DurationUnit
+	out.DecimalSeparator = p.DecimalSeparator
+	out.Language = p.Language
+	out.LabelSeparator = p.LabelSeparator
+	return &out
+}
+
+// FromCreateExportProfile converts an API export profile creation model to a logic export profile
+// model.
+func FromCreateExportProfile(cp *am.CreateExportProfile) *m.ExportProfile {
+	if cp == nil {
+		return nil
+	}
+
+	out := m.NewExportProfile()
+	out.Name = trimString(cp.Name)
+	out.Columns = cp.Columns
+	out.Delimiter = cp.Delimiter
+	out.DateFormat = cp.DateFormat
+	out.DurationUnit = cp.DurationUnit
+	out.DecimalSeparator = cp.DecimalSeparator
+	out.Language = cp.Language
+	out.LabelSeparator = cp.LabelSeparator
+	return out
+}
+
+// FromUpdateExportProfile converts an API export profile update model to a logic export profile
+// model.
+func FromUpdateExportProfile(id int, up *am.UpdateExportProfile) *m.ExportProfile {
+	if up == nil {
+		return nil
+	}
+
+	out := m.NewExportProfile()
+	out.Id = id
+	out.Name = trimString(up.Name)
+	out.Columns = up.Columns
+	out.Delimiter = up.Delimiter
+	out.DateFormat = up.DateFormat
+	out.DurationUnit = up.DurationUnit
+	out.DecimalSeparator = up.DecimalSeparator
+	out.Language = up.Language
+	out.LabelSeparator = up.LabelSeparator
+	return out
+}
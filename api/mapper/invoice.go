@@ -0,0 +1,204 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Client functions ---
+
+// ToClients converts a list of logic client models to an API client list model.
+func ToClients(cs []*m.Client) *am.ClientList {
+	if cs == nil {
+		return nil
+	}
+
+	out := make([]*am.Client, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, ToClient(c))
+	}
+	return am.NewClientList(out)
+}
+
+// ToClient converts a logic client model to an API client model.
+func ToClient(c *m.Client) *am.Client {
+	if c == nil {
+		return nil
+	}
+
+	var out am.Client
+	out.Id = c.Id
+	out.Name = c.Name
+	out.Address = c.Address
+	out.Currency = c.Currency
+	out.Projects = c.Projects
+	return &out
+}
+
+// FromCreateClient converts an API client creation model to a logic client model.
+func FromCreateClient(cc *am.CreateClient) *m.Client {
+	if cc == nil {
+		return nil
+	}
+
+	out := m.NewClient()
+	out.Name = trimString(cc.Name)
+	out.Address = trimString(cc.Address)
+	out.Currency = cc.Currency
+	out.Projects = trimStrings(cc.Projects)
+	return out
+}
+
+// FromUpdateClient converts an API client update model to a logic client model.
+func FromUpdateClient(id int, uc *am.UpdateClient) *m.Client {
+	if uc == nil {
+		return nil
+	}
+
+	out := m.NewClient()
+	out.Id = id
+	out.Name = trimString(uc.Name)
+	out.Address = trimString(uc.Address)
+	out.Currency = uc.Currency
+	out.Projects = trimStrings(uc.Projects)
+	return out
+}
+
+// --- Hourly rate functions ---
+
+// ToHourlyRates converts a list of logic hourly rate models to an API hourly rate list model.
+func ToHourlyRates(rs []*m.HourlyRate) *am.HourlyRateList {
+	if rs == nil {
+		return nil
+	}
+
+	out := make([]*am.HourlyRate, 0, len(rs))
+	for _, r := range rs {
+		out = append(out, ToHourlyRate(r))
+	}
+	return am.NewHourlyRateList(out)
+}
+
+// ToHourlyRate converts a logic hourly rate model to an API hourly rate model.
+func ToHourlyRate(r *m.HourlyRate) *am.HourlyRate {
+	if r == nil {
+		return nil
+	}
+
+	var out am.HourlyRate
+	out.Id = r.Id
+	out.UserId = r.UserId
+	out.Project = r.Project
+	out.ActivityId = r.ActivityId
+	out.Amount = r.Amount
+	out.Currency = r.Currency
+	out.ValidFrom = formatDate(r.ValidFrom)
+	if !r.ValidTo.IsZero() {
+		out.ValidTo = formatDate(r.ValidTo)
+	}
+	return &out
+}
+
+// FromCreateHourlyRate converts an API hourly rate creation model to a logic hourly rate model.
+func FromCreateHourlyRate(cr *am.CreateHourlyRate) *m.HourlyRate {
+	if cr == nil {
+		return nil
+	}
+
+	out := m.NewHourlyRate()
+	out.UserId = cr.UserId
+	out.Project = trimString(cr.Project)
+	out.ActivityId = cr.ActivityId
+	out.Amount = cr.Amount
+	out.Currency = cr.Currency
+	out.ValidFrom = parseDate(cr.ValidFrom)
+	if cr.ValidTo != "" {
+		out.ValidTo = parseDate(cr.ValidTo)
+	}
+	return out
+}
+
+// FromUpdateHourlyRate converts an API hourly rate update model to a logic hourly rate model.
+func FromUpdateHourlyRate(id int, ur *am.UpdateHourlyRate) *m.HourlyRate {
+	if ur == nil {
+		return nil
+	}
+
+	out := m.NewHourlyRate()
+	out.Id = id
+	out.UserId = ur.UserId
+	out.Project = trimString(ur.Project)
+	out.ActivityId = ur.ActivityId
+	out.Amount = ur.Amount
+	out.Currency = ur.Currency
+	out.ValidFrom = parseDate(ur.ValidFrom)
+	if ur.ValidTo != "" {
+		out.ValidTo = parseDate(ur.ValidTo)
+	}
+	return out
+}
+
+// --- Invoice functions ---
+
+// ToInvoices converts a list of logic invoice models to an API invoice list model.
+func ToInvoices(is []*m.Invoice) *am.InvoiceList {
+	if is == nil {
+		return nil
+	}
+
+	out := make([]*am.Invoice, 0, len(is))
+	for _, i := range is {
+		out = append(out, ToInvoice(i))
+	}
+	return am.NewInvoiceList(out)
+}
+
+// ToInvoice converts a logic invoice model to an API invoice model.
+func ToInvoice(i *m.Invoice) *am.Invoice {
+	if i == nil {
+		return nil
+	}
+
+	var out am.Invoice
+	out.Id = i.Id
+	out.Number = i.Number
+	out.ClientId = i.ClientId
+	out.StartDate = formatDate(i.StartDate)
+	out.EndDate = formatDate(i.EndDate)
+	out.Currency = i.Currency
+	out.NetAmount = i.NetAmount
+	out.TaxRate = i.TaxRate
+	out.TaxAmount = i.TaxAmount
+	out.TotalAmount = i.TotalAmount
+	out.CreatedAt = formatTimestamp(i.CreatedAt)
+	for _, item := range i.Items {
+		out.Items = append(out.Items, toInvoiceItem(item))
+	}
+	return &out
+}
+
+func toInvoiceItem(i *m.InvoiceItem) *am.InvoiceItem {
+	var out am.InvoiceItem
+	out.EntryId = i.EntryId
+	out.UserId = i.UserId
+	out.Date = formatDate(i.Date)
+	out.Project = i.Project
+	out.Description = i.Description
+	out.Hours = i.Hours
+	out.Rate = i.Rate
+	out.Amount = i.Amount
+	return &out
+}
+
+// FromCreateInvoice converts an API invoice creation model to a logic invoice model.
+func FromCreateInvoice(ci *am.CreateInvoice) *m.Invoice {
+	if ci == nil {
+		return nil
+	}
+
+	out := m.NewInvoice()
+	out.ClientId = ci.ClientId
+	out.StartDate = parseDate(ci.StartDate)
+	out.EndDate = parseDate(ci.EndDate)
+	return out
+}
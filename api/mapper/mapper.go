@@ -9,8 +9,9 @@ import (
 	"kellnhofer.com/work-log/pkg/log"
 )
 
+// parseDate parses a date as civil date (midnight UTC).
 func parseDate(d string) time.Time {
-	t, pErr := time.ParseInLocation(constant.ApiDateFormat, d, time.Local)
+	t, pErr := time.ParseInLocation(constant.ApiDateFormat, d, time.UTC)
 	if pErr != nil {
 		err := e.WrapError(e.SysUnknown, "Could not parse date.", pErr)
 		log.Error(err.StackTrace())
@@ -24,7 +25,7 @@ func formatDate(t time.Time) string {
 }
 
 func parseTimestamp(ts string) time.Time {
-	t, pErr := time.ParseInLocation(constant.ApiTimestampFormat, ts, time.Local)
+	t, pErr := time.Parse(constant.ApiTimestampFormat, ts)
 	if pErr != nil {
 		err := e.WrapError(e.SysUnknown, "Could not parse timestamp.", pErr)
 		log.Error(err.StackTrace())
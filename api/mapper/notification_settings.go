@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"strings"
+
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ToNotificationSettings converts a logic notification settings model to an API notification
+// settings model.
+func ToNotificationSettings(ns *m.NotificationSettings) *am.NotificationSettings {
+	if ns == nil {
+		return nil
+	}
+
+	var out am.NotificationSettings
+	out.Email = ns.Email
+	out.MissingEntriesReminder = ns.MissingEntriesReminder
+	out.WeeklyDigest = ns.WeeklyDigest
+	return &out
+}
+
+// FromNotificationSettings converts an API notification settings model to a logic notification
+// settings model.
+func FromNotificationSettings(ns *am.NotificationSettings) *m.NotificationSettings {
+	if ns == nil {
+		return nil
+	}
+
+	var out m.NotificationSettings
+	out.Email = strings.TrimSpace(ns.Email)
+	out.MissingEntriesReminder = ns.MissingEntriesReminder
+	out.WeeklyDigest = ns.WeeklyDigest
+	return &out
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"time"
+
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Statistics functions ---
+
+// ToStatistics converts a logic statistics model to an API statistics model.
+func ToStatistics(s *m.Statistics) *am.Statistics {
+	if s == nil {
+		return nil
+	}
+
+	var out am.Statistics
+	out.Grouping = string(s.Grouping)
+	out.Interval = string(s.Interval)
+	out.StartDate = formatDate(s.StartDate)
+	out.EndDate = formatDate(s.EndDate)
+	out.Buckets = make([]string, len(s.Buckets))
+	for i, b := range s.Buckets {
+		out.Buckets[i] = formatDate(b)
+	}
+	out.Hours = toHours(s.Durations)
+	out.TotalHours = float32(s.TotalDuration.Hours())
+	out.Groups = make([]*am.StatisticsGroup, len(s.Groups))
+	for i, g := range s.Groups {
+		out.Groups[i] = &am.StatisticsGroup{}
+		out.Groups[i].Id = g.Id
+		out.Groups[i].Name = g.Name
+		out.Groups[i].Hours = toHours(g.Durations)
+		out.Groups[i].TotalHours = float32(g.TotalDuration.Hours())
+	}
+	return &out
+}
+
+func toHours(ds []time.Duration) []float32 {
+	outs := make([]float32, len(ds))
+	for i, d := range ds {
+		outs[i] = float32(d.Hours())
+	}
+	return outs
+}
@@ -33,6 +33,7 @@ func ToUserData(ud *m.UserData) *am.UserData {
 	out.Id = ud.Id
 	out.Name = ud.User.Name
 	out.Username = ud.User.Username
+	out.TimeZone = ud.User.TimeZone
 	out.Contract = toContract(ud.Contract)
 	return &out
 }
@@ -71,6 +72,7 @@ func fromCreateUser(cud *am.CreateUserData) *m.User {
 	out.Name = strings.TrimSpace(cud.Name)
 	out.Username = strings.TrimSpace(cud.Username)
 	out.Password = strings.TrimSpace(cud.Password)
+	out.TimeZone = strings.TrimSpace(cud.TimeZone)
 	return &out
 }
 
@@ -83,6 +85,7 @@ func fromUpdateUser(id int, uud *am.UpdateUserData) *m.User {
 	out.Id = id
 	out.Name = strings.TrimSpace(uud.Name)
 	out.Username = strings.TrimSpace(uud.Username)
+	out.TimeZone = strings.TrimSpace(uud.TimeZone)
 	return &out
 }
 
@@ -97,6 +100,10 @@ func toContract(uc *m.Contract) *am.Contract {
 	out.InitVacationDays = uc.InitVacationDays
 	out.WorkingHours = toContractWorkingHours(uc.WorkingHours)
 	out.VacationDays = toContractVacationDays(uc.VacationDays)
+	out.BreakRules = toContractBreakRules(uc.BreakRules)
+	out.DeductMissingBreaks = uc.DeductMissingBreaks
+	out.VacationRules = toContractVacationRules(uc.VacationRules)
+	out.RoundingPolicy = toRoundingPolicy(uc.RoundingPolicy)
 	return &out
 }
 
@@ -111,6 +118,10 @@ func fromCreateContract(cuc *am.CreateContract) *m.Contract {
 	out.InitVacationDays = cuc.InitVacationDays
 	out.WorkingHours = fromContractWorkingHours(cuc.WorkingHours)
 	out.VacationDays = fromContractVacationDays(cuc.VacationDays)
+	out.BreakRules = fromContractBreakRules(cuc.BreakRules)
+	out.DeductMissingBreaks = cuc.DeductMissingBreaks
+	out.VacationRules = fromContractVacationRules(cuc.VacationRules)
+	out.RoundingPolicy = fromRoundingPolicy(cuc.RoundingPolicy)
 	return &out
 }
 
@@ -125,6 +136,10 @@ func fromUpdateContract(uuc *am.UpdateContract) *m.Contract {
 	out.InitVacationDays = uuc.InitVacationDays
 	out.WorkingHours = fromContractWorkingHours(uuc.WorkingHours)
 	out.VacationDays = fromContractVacationDays(uuc.VacationDays)
+	out.BreakRules = fromContractBreakRules(uuc.BreakRules)
+	out.DeductMissingBreaks = uuc.DeductMissingBreaks
+	out.VacationRules = fromContractVacationRules(uuc.VacationRules)
+	out.RoundingPolicy = fromRoundingPolicy(uuc.RoundingPolicy)
 	return &out
 }
 
@@ -170,6 +185,27 @@ func fromContractVacationDays(vds []*am.ContractVacationDays) []m.ContractVacati
 	return outs
 }
 
+func toContractBreakRules(brs []m.ContractBreakRule) []*am.ContractBreakRule {
+	outs := make([]*am.ContractBreakRule, len(brs))
+	for i, br := range brs {
+		outs[i] = &am.ContractBreakRule{}
+		outs[i].WorkHours = br.WorkHours
+		outs[i].BreakMinutes = br.BreakMinutes
+	}
+	return outs
+}
+
+func fromContractBreakRules(brs []*am.ContractBreakRule) []m.ContractBreakRule {
+	outs := make([]m.ContractBreakRule, len(brs))
+	for i, br := range brs {
+		if br != nil {
+			outs[i].WorkHours = br.WorkHours
+			outs[i].BreakMinutes = br.BreakMinutes
+		}
+	}
+	return outs
+}
+
 // --- Role functions ---
 
 // ToRoles converts a list of logic role models to an API roles model.
@@ -199,3 +235,23 @@ func FromRoles(uurs *am.UpdateUserRoles) []m.Role {
 	}
 	return roles
 }
+
+func toContractVacationRules(vr *m.ContractVacationRules) *am.ContractVacationRules {
+	if vr == nil {
+		return nil
+	}
+	return &am.ContractVacationRules{
+		CarryOverMaxDays: vr.CarryOverMaxDays,
+		ExpiryMonths:     vr.ExpiryMonths,
+	}
+}
+
+func fromContractVacationRules(vr *am.ContractVacationRules) *m.ContractVacationRules {
+	if vr == nil {
+		return nil
+	}
+	return &m.ContractVacationRules{
+		CarryOverMaxDays: vr.CarryOverMaxDays,
+		ExpiryMonths:     vr.ExpiryMonths,
+	}
+}
@@ -0,0 +1,104 @@
+package mapper
+
+import (
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Vacation functions ---
+
+// ToVacationBalance converts a logic vacation balance model to an API vacation balance model.
+func ToVacationBalance(b *m.VacationBalance) *am.VacationBalance {
+	if b == nil {
+		return nil
+	}
+
+	var out am.VacationBalance
+	out.UserId = b.UserId
+	out.Year = b.Year
+	out.EntitledDays = b.EntitledDays
+	out.TakenDays = b.TakenDays
+	out.PlannedDays = b.PlannedDays
+	out.PendingDays = b.PendingDays
+	out.RemainingDays = b.RemainingDays
+	return &out
+}
+
+// ToVacationLedger converts a list of logic vacation ledger year models to an API vacation ledger
+// model.
+func ToVacationLedger(userId int, ys []*m.VacationLedgerYear) *am.VacationLedger {
+	if ys == nil {
+		return nil
+	}
+
+	out := make([]*am.VacationLedgerYear, 0, len(ys))
+	for _, y := range ys {
+		out = append(out, toVacationLedgerYear(y))
+	}
+	return &am.VacationLedger{UserId: userId, Years: out}
+}
+
+func toVacationLedgerYear(y *m.VacationLedgerYear) *am.VacationLedgerYear {
+	var out am.VacationLedgerYear
+	out.Year = y.Year
+	out.CarriedOverDays = y.CarriedOverDays
+	out.AccruedDays = y.AccruedDays
+	out.AdjustedDays = y.AdjustedDays
+	out.TakenDays = y.TakenDays
+	out.ExpiredDays = y.ExpiredDays
+	out.RemainingDays = y.RemainingDays
+	return &out
+}
+
+// ToVacationRequests converts a list of logic vacation request models to an API vacation request
+// list model.
+func ToVacationRequests(rs []*m.VacationRequest) *am.VacationRequestList {
+	if rs == nil {
+		return nil
+	}
+
+	out := make([]*am.VacationRequest, 0, len(rs))
+	for _, r := range rs {
+		out = append(out, ToVacationRequest(r))
+	}
+	return am.NewVacationRequestList(out)
+}
+
+// ToVacationRequest converts a logic vacation request model to an API vacation request model.
+func ToVacationRequest(r *m.VacationRequest) *am.VacationRequest {
+	if r == nil {
+		return nil
+	}
+
+	var out am.VacationRequest
+	out.Id = r.Id
+	out.UserId = r.UserId
+	out.StartDate = formatDate(r.StartDate)
+	out.EndDate = formatDate(r.EndDate)
+	out.DayFraction = r.DayFraction
+	out.Description = r.Description
+	out.Days = r.Days
+	out.Status = r.Status
+	out.RequestedAt = formatTimestamp(r.RequestedAt)
+	out.DecidedByUserId = r.DecidedByUserId
+	out.DecidedAt = formatOptionalTimestamp(r.DecidedAt)
+	out.DecisionComment = r.DecisionComment
+	out.AbsenceId = r.AbsenceId
+	out.Warnings = r.Warnings
+	return &out
+}
+
+// FromCreateVacationRequest converts an API vacation request creation model to a logic vacation
+// request model.
+func FromCreateVacationRequest(cr *am.CreateVacationRequest) *m.VacationRequest {
+	if cr == nil {
+		return nil
+	}
+
+	out := m.NewVacationRequest()
+	out.StartDate = parseDate(cr.StartDate)
+	out.EndDate = parseDate(cr.EndDate)
+	out.DayFraction = cr.DayFraction
+	out.Description = trimString(cr.Description)
+	return out
+}
@@ -0,0 +1,125 @@
+package mapper
+
+import (
+	"time"
+
+	am "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Webhook functions ---
+
+// ToWebhooks converts a list of logic webhook models to an API webhook list (without secrets).
+func ToWebhooks(ws []*m.Webhook) *am.WebhookList {
+	if ws == nil {
+		return nil
+	}
+
+	items := make([]*am.Webhook, len(ws))
+	for i, w := range ws {
+		items[i] = ToWebhook(w)
+	}
+
+	return am.NewWebhookList(items)
+}
+
+// ToWebhook converts a logic webhook model to an API webhook model (without secret).
+func ToWebhook(w *m.Webhook) *am.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	var out am.Webhook
+	out.Id = w.Id
+	out.Url = w.Url
+	out.EventTypes = w.EventTypes
+	out.Active = w.Active
+	return &out
+}
+
+// ToWebhookFull converts a logic webhook model to an API webhook model (with secret).
+func ToWebhookFull(w *m.Webhook) *am.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	out := ToWebhook(w)
+	out.Secret = w.Secret
+	return out
+}
+
+// FromCreateWebhook converts an API CreateWebhook model to a logic webhook model.
+func FromCreateWebhook(cw *am.CreateWebhook) *m.Webhook {
+	if cw == nil {
+		return nil
+	}
+
+	out := m.NewWebhook()
+	out.Url = trimString(cw.Url)
+	if cw.Secret != "" {
+		out.Secret = cw.Secret
+	}
+	out.EventTypes = trimStrings(cw.EventTypes)
+	out.Active = *cw.Active
+	return out
+}
+
+// FromUpdateWebhook converts an API UpdateWebhook model to a logic webhook model.
+func FromUpdateWebhook(id int, uw *am.UpdateWebhook) *m.Webhook {
+	if uw == nil {
+		return nil
+	}
+
+	var out m.Webhook
+	out.Id = id
+	out.Url = trimString(uw.Url)
+	out.Secret = uw.Secret
+	out.EventTypes = trimStrings(uw.EventTypes)
+	out.Active = *uw.Active
+	return &out
+}
+
+// --- Webhook delivery functions ---
+
+// ToWebhookDeliveries converts a list of logic webhook delivery models to an API webhook delivery
+// list.
+func ToWebhookDeliveries(ds []*m.WebhookDelivery, o int, l int, t int) *am.WebhookDeliveryList {
+	if ds == nil {
+		return nil
+	}
+
+	items := make([]*am.WebhookDelivery, len(ds))
+	for i, d := range ds {
+		items[i] = ToWebhookDelivery(d)
+	}
+
+	return am.NewWebhookDeliveryList(o, l, t, items)
+}
+
+// ToWebhookDelivery converts a logic webhook delivery model to an API webhook delivery model.
+func ToWebhookDelivery(d *m.WebhookDelivery) *am.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	var out am.WebhookDelivery
+	out.Id = d.Id
+	out.WebhookId = d.WebhookId
+	out.EventType = d.EventType
+	out.Payload = d.Payload
+	out.Status = d.Status
+	out.Attempts = d.Attempts
+	out.CreatedAt = formatTimestamp(d.CreatedAt)
+	out.NextAttemptAt = formatOptionalTimestamp(d.NextAttemptAt)
+	out.LastAttemptAt = formatOptionalTimestamp(d.LastAttemptAt)
+	out.LastStatusCode = d.LastStatusCode
+	out.LastError = d.LastError
+	return &out
+}
+
+func formatOptionalTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return formatTimestamp(t)
+}
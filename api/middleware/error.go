@@ -30,6 +30,12 @@ var httpStatusCodeMapping = map[int]int{
 	e.PermChangeAllEntries:    http.StatusForbidden,
 	e.PermGetOwnEntries:       http.StatusForbidden,
 	e.PermChangeOwnEntries:    http.StatusForbidden,
+	e.PermGetWebhooks:         http.StatusForbidden,
+	e.PermChangeWebhooks:      http.StatusForbidden,
+	e.PermApproveVacations:    http.StatusForbidden,
+	e.PermGetInvoices:         http.StatusForbidden,
+	e.PermChangeInvoices:      http.StatusForbidden,
+	e.PermDecideOwnVacations:  http.StatusForbidden,
 
 	e.ValUnknown:                 http.StatusBadRequest,
 	e.ValJsonInvalid:             http.StatusBadRequest,
@@ -52,6 +58,31 @@ var httpStatusCodeMapping = map[int]int{
 	e.ValPasswordInvalid:         http.StatusBadRequest,
 	e.ValLabelInvalid:            http.StatusBadRequest,
 	e.ValContentTypeNotSupported: http.StatusBadRequest,
+	e.ValEmailInvalid:            http.StatusBadRequest,
+	e.ValUrlInvalid:              http.StatusBadRequest,
+	e.ValEventTypeInvalid:        http.StatusBadRequest,
+	e.ValCursorInvalid:           http.StatusBadRequest,
+	e.ValVersionInvalid:          http.StatusBadRequest,
+	e.ValDayFractionInvalid:      http.StatusBadRequest,
+	e.ValStatusInvalid:           http.StatusBadRequest,
+	e.ValAdjustmentTypeInvalid:   http.StatusBadRequest,
+	e.ValYearInvalid:             http.StatusBadRequest,
+	e.ValColorInvalid:            http.StatusBadRequest,
+	e.ValRoundingPolicyInvalid:   http.StatusBadRequest,
+	e.ValTimeZoneInvalid:         http.StatusBadRequest,
+	e.ValExpenseTypeInvalid:      http.StatusBadRequest,
+	e.ValCurrencyInvalid:         http.StatusBadRequest,
+	e.ValFileMissing:             http.StatusBadRequest,
+	e.ValExportFormatInvalid:     http.StatusBadRequest,
+	e.ValExportColumnInvalid:     http.StatusBadRequest,
+	e.ValDateFormatInvalid:       http.StatusBadRequest,
+	e.ValDelimiterInvalid:        http.StatusBadRequest,
+	e.ValDurationUnitInvalid:     http.StatusBadRequest,
+	e.ValDecimalSeparatorInvalid: http.StatusBadRequest,
+	e.ValLanguageInvalid:         http.StatusBadRequest,
+	e.ValGroupingInvalid:         http.StatusBadRequest,
+	e.ValIntervalInvalid:         http.StatusBadRequest,
+	e.ValPeriodTooLong:           http.StatusBadRequest,
 
 	e.LogicEntryNotFound:                 http.StatusNotFound,
 	e.LogicEntryTypeNotFound:             http.StatusNotFound,
@@ -64,8 +95,44 @@ var httpStatusCodeMapping = map[int]int{
 	e.LogicUserAlreadyExists:             http.StatusConflict,
 	e.LogicContractWorkingHoursInvalid:   http.StatusBadRequest,
 	e.LogicContractVacationDaysInvalid:   http.StatusBadRequest,
+	e.LogicContractBreakRulesInvalid:     http.StatusBadRequest,
+	e.LogicContractVacationRulesInvalid:  http.StatusBadRequest,
 	e.LogicEntryActivityNotAllowed:       http.StatusBadRequest,
 	e.LogicTokenNotFound:                 http.StatusNotFound,
+	e.LogicWebhookNotFound:               http.StatusNotFound,
+	e.LogicEntryVersionConflict:          http.StatusPreconditionFailed,
+	e.LogicAbsenceNotFound:               http.StatusNotFound,
+	e.LogicAbsenceTypeInvalid:            http.StatusBadRequest,
+	e.LogicAbsenceWithoutWorkDays:        http.StatusBadRequest,
+	e.LogicVacationRequestNotFound:       http.StatusNotFound,
+	e.LogicVacationRequestNotPending:     http.StatusConflict,
+	e.LogicBalanceAdjustmentNotFound:     http.StatusNotFound,
+	e.LogicYearClosingInvalid:            http.StatusBadRequest,
+	e.LogicYearClosed:                    http.StatusConflict,
+	e.LogicYearClosingNotFound:           http.StatusNotFound,
+	e.LogicEntryTypeDeleteNotAllowed:     http.StatusConflict,
+	e.LogicEntryActivityMergeInvalid:     http.StatusBadRequest,
+	e.LogicProjectRoundingPolicyNotFound: http.StatusNotFound,
+	e.LogicExpenseRateNotFound:           http.StatusNotFound,
+	e.LogicExpenseRateDeleteNotAllowed:   http.StatusConflict,
+	e.LogicExpenseRateInvalid:            http.StatusBadRequest,
+	e.LogicExpenseNotFound:               http.StatusNotFound,
+	e.LogicExpensesNotAllowed:            http.StatusBadRequest,
+	e.LogicAttachmentNotFound:            http.StatusNotFound,
+	e.LogicAttachmentTooLarge:            http.StatusRequestEntityTooLarge,
+	e.LogicAttachmentTypeNotAllowed:      http.StatusUnsupportedMediaType,
+	e.LogicEntryInvoiced:                 http.StatusConflict,
+	e.LogicClientNotFound:                http.StatusNotFound,
+	e.LogicClientAlreadyExists:           http.StatusConflict,
+	e.LogicClientDeleteNotAllowed:        http.StatusConflict,
+	e.LogicProjectAlreadyAssigned:        http.StatusConflict,
+	e.LogicHourlyRateNotFound:            http.StatusNotFound,
+	e.LogicHourlyRateMissing:             http.StatusConflict,
+	e.LogicInvoiceNotFound:               http.StatusNotFound,
+	e.LogicInvoiceWithoutEntries:         http.StatusConflict,
+	e.LogicExportProfileNotFound:         http.StatusNotFound,
+	e.LogicExportProfileAlreadyExists:    http.StatusConflict,
+	e.LogicEntryOfAbsence:                http.StatusConflict,
 }
 
 func getHttpStatusCode(errorCode int) int {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
@@ -256,7 +257,16 @@ func (m *SecurityMiddleware) createSecurityContext(ctx context.Context, userId i
 		return nil, err
 	}
 
-	return model.NewSecurityContext(userId, userRoles), nil
+	userLocation := time.Local
+	user, err := m.uServ.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		userLocation = user.GetLocation()
+	}
+
+	return model.NewSecurityContext(userId, userRoles, userLocation), nil
 }
 
 func (m *SecurityMiddleware) getAuthenticationData(r *http.Request) string {
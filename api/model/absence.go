@@ -0,0 +1,42 @@
+package model
+
+// Absence
+//
+// Contains information about a multi-day absence (vacation or illness). An absence is expanded into
+// one entry per working day.
+//
+// swagger:model Absence
+type Absence struct {
+	// The ID of the absence.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The ID of the entry type (only vacation and illness are allowed).
+	// example: 3
+	TypeId int `json:"typeId"`
+
+	// The first day of the absence.
+	// example: 2019-01-07
+	StartDate string `json:"startDate"`
+
+	// The last day of the absence.
+	// example: 2019-01-18
+	EndDate string `json:"endDate"`
+
+	// The fraction of the daily working hours which is recorded per day ("full" or "half").
+	// example: full
+	DayFraction string `json:"dayFraction"`
+
+	// The description with additional information about the absence.
+	// min length: 0
+	// max length: 200
+	Description string `json:"description"`
+
+	// The IDs of the entries created for the absence.
+	// example: [1, 2, 3]
+	EntryIds []int `json:"entryIds"`
+}
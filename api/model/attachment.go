@@ -0,0 +1,33 @@
+package model
+
+// Attachment
+//
+// Contains information about a file which is attached to an entry (e.g. a doctor's note or a
+// receipt).
+//
+// swagger:model Attachment
+type Attachment struct {
+	// The ID of the attachment.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the entry the attachment belongs to.
+	// example: 1
+	EntryId int `json:"entryId"`
+
+	// The name of the file.
+	// example: receipt.pdf
+	FileName string `json:"fileName"`
+
+	// The MIME type of the file.
+	// example: application/pdf
+	MimeType string `json:"mimeType"`
+
+	// The size of the file in bytes.
+	// example: 48213
+	Size int64 `json:"size"`
+
+	// The time when the attachment was uploaded.
+	// example: 2019-01-01T09:00:00Z
+	CreatedAt string `json:"createdAt"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// AttachmentList
+//
+// A list of attachments.
+//
+// swagger:model AttachmentList
+type AttachmentList struct {
+	// The list of attachments.
+	Items []*Attachment `json:"items"`
+}
+
+// NewAttachmentList creates a new AttachmentList model.
+func NewAttachmentList(items []*Attachment) *AttachmentList {
+	return &AttachmentList{items}
+}
@@ -0,0 +1,41 @@
+package model
+
+// BalanceAdjustment
+//
+// Contains information about a manual adjustment of the overtime or vacation balance of a user.
+//
+// swagger:model BalanceAdjustment
+type BalanceAdjustment struct {
+	// The ID of the adjustment.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the user whose balance is adjusted.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The type of the adjustment ("overtime" or "vacation").
+	// example: overtime
+	Type string `json:"type"`
+
+	// The date from which the adjustment is effective.
+	// example: 2019-01-31
+	Date string `json:"date"`
+
+	// The hours (overtime) or days (vacation) which are added to the balance. Negative values are
+	// subtracted.
+	// example: -20
+	Value float32 `json:"value"`
+
+	// The reason for the adjustment.
+	// example: Overtime payout
+	Reason string `json:"reason"`
+
+	// The ID of the user who created the adjustment. (0 if the user was deleted)
+	// example: 2
+	CreatedByUserId int `json:"createdByUserId"`
+
+	// The time of creation.
+	// example: 2019-01-31T12:00:00Z
+	CreatedAt string `json:"createdAt"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// BalanceAdjustmentList
+//
+// A list of balance adjustments.
+//
+// swagger:model BalanceAdjustmentList
+type BalanceAdjustmentList struct {
+	// The list of balance adjustments.
+	Items []*BalanceAdjustment `json:"items"`
+}
+
+// NewBalanceAdjustmentList creates a new BalanceAdjustmentList model.
+func NewBalanceAdjustmentList(items []*BalanceAdjustment) *BalanceAdjustmentList {
+	return &BalanceAdjustmentList{items}
+}
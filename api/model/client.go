@@ -0,0 +1,28 @@
+package model
+
+// Client
+//
+// Contains information about a client which is billed for the work on its projects.
+//
+// swagger:model Client
+type Client struct {
+	// The ID of the client.
+	// example: 1
+	Id int `json:"id"`
+
+	// The name of the client.
+	// example: Example Corp.
+	Name string `json:"name"`
+
+	// The postal address of the client (lines separated by line breaks).
+	// example: Example Street 1\n12345 Example City
+	Address string `json:"address"`
+
+	// The currency the client is billed in. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The names of the projects which are billed to the client.
+	// example: ["project-a", "project-b"]
+	Projects []string `json:"projects"`
+}
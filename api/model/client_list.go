@@ -0,0 +1,16 @@
+package model
+
+// ClientList
+//
+// A list of clients.
+//
+// swagger:model ClientList
+type ClientList struct {
+	// The list of clients.
+	Items []*Client `json:"items"`
+}
+
+// NewClientList creates a new ClientList model.
+func NewClientList(items []*Client) *ClientList {
+	return &ClientList{items}
+}
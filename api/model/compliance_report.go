@@ -0,0 +1,37 @@
+package model
+
+// ComplianceReport
+//
+// Contains the compliance violations of all users in a month.
+//
+// swagger:model ComplianceReport
+type ComplianceReport struct {
+	// The first day of the month.
+	// example: 2019-01-01
+	StartDate string `json:"startDate"`
+
+	// The last day of the month.
+	// example: 2019-01-31
+	EndDate string `json:"endDate"`
+
+	// The users with compliance violations.
+	Users []*UserComplianceViolations `json:"users"`
+}
+
+// UserComplianceViolations
+//
+// Contains the compliance violations of a user.
+//
+// swagger:model UserComplianceViolations
+type UserComplianceViolations struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The name of the user.
+	// example: John Doe
+	Name string `json:"name"`
+
+	// The compliance violations.
+	Violations []*ComplianceViolation `json:"violations"`
+}
@@ -0,0 +1,25 @@
+package model
+
+// ComplianceViolation
+//
+// Contains information about a violation of a working time compliance rule.
+//
+// swagger:model ComplianceViolation
+type ComplianceViolation struct {
+	// The violated rule.
+	// enum: max_daily_hours,max_weekly_hours,min_rest_period,sunday_work,night_work
+	// example: max_daily_hours
+	Rule string `json:"rule"`
+
+	// The day of the violation.
+	// example: 2019-01-01
+	Date string `json:"date"`
+
+	// The actual hours (work hours, rest hours, Sunday work hours or night work hours).
+	// example: 10.5
+	ActualHours float32 `json:"actualHours"`
+
+	// The limit of the rule in hours (0 for rules without limit).
+	// example: 10.0
+	LimitHours float32 `json:"limitHours"`
+}
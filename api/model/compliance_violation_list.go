@@ -0,0 +1,16 @@
+package model
+
+// ComplianceViolationList
+//
+// A list of compliance violations.
+//
+// swagger:model ComplianceViolationList
+type ComplianceViolationList struct {
+	// The list of compliance violations.
+	Items []*ComplianceViolation `json:"items"`
+}
+
+// NewComplianceViolationList creates a new ComplianceViolationList model.
+func NewComplianceViolationList(items []*ComplianceViolation) *ComplianceViolationList {
+	return &ComplianceViolationList{items}
+}
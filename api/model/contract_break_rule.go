@@ -0,0 +1,17 @@
+package model
+
+// ContractBreakRule
+//
+// Contains information about a break rule of a work contract. The break is required if the daily
+// work time exceeds the work hours.
+//
+// swagger:model ContractBreakRule
+type ContractBreakRule struct {
+	// The number of work hours after which the break is required.
+	// example: 6.0
+	WorkHours float32 `json:"workHours"`
+
+	// The number of required break minutes.
+	// example: 30
+	BreakMinutes int `json:"breakMinutes"`
+}
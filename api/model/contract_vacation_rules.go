@@ -0,0 +1,17 @@
+package model
+
+// ContractVacationRules
+//
+// Contains information about the carry-over and expiry of unused vacation days of a work contract.
+//
+// swagger:model ContractVacationRules
+type ContractVacationRules struct {
+	// The maximum number of unused vacation days which are carried over to the next year.
+	// example: 10
+	CarryOverMaxDays float32 `json:"carryOverMaxDays"`
+
+	// The number of months of the next year in which carried over days can be taken. Afterwards
+	// the days expire. (0 if they never expire)
+	// example: 3
+	ExpiryMonths int `json:"expiryMonths"`
+}
@@ -0,0 +1,27 @@
+package model
+
+// CreateBalanceAdjustment
+//
+// Holds information about a new balance adjustment.
+//
+// swagger:model CreateBalanceAdjustment
+type CreateBalanceAdjustment struct {
+	// The type of the adjustment ("overtime" or "vacation").
+	// example: overtime
+	Type string `json:"type"`
+
+	// The date from which the adjustment is effective.
+	// example: 2019-01-31
+	Date string `json:"date"`
+
+	// The hours (overtime) or days (vacation) which are added to the balance. Negative values are
+	// subtracted.
+	// example: -20
+	Value float32 `json:"value"`
+
+	// The reason for the adjustment.
+	// min length: 1
+	// max length: 200
+	// example: Overtime payout
+	Reason string `json:"reason"`
+}
@@ -23,4 +23,18 @@ type CreateContract struct {
 
 	// The monthly vacation days.
 	VacationDays []*ContractVacationDays `json:"vacationDays"`
+
+	// The break rules.
+	BreakRules []*ContractBreakRule `json:"breakRules"`
+
+	// Determines if missing breaks are deducted from the work time.
+	// example: true
+	DeductMissingBreaks bool `json:"deductMissingBreaks"`
+
+	// The carry-over and expiry rules of unused vacation days. (null if unused days are carried
+	// over without limit and never expire)
+	VacationRules *ContractVacationRules `json:"vacationRules"`
+
+	// The rounding policy for the durations of work entries. (null if durations are not rounded)
+	RoundingPolicy *RoundingPolicy `json:"roundingPolicy"`
 }
@@ -11,11 +11,11 @@ type CreateEntry struct {
 	UserId int `json:"userId"`
 
 	// The start time of the entry.
-	// example: 2019-01-01T15:00:00
+	// example: 2019-01-01T15:00:00Z
 	StartTime string `json:"startTime"`
 
 	// The end time of the entry.
-	// example: 2019-01-01T16:00:00
+	// example: 2019-01-01T16:00:00Z
 	EndTime string `json:"endTime"`
 
 	// The ID of the entry type.
@@ -42,4 +42,8 @@ type CreateEntry struct {
 	// max length: 20
 	// example: ["bug", "frontend"]
 	Labels []string `json:"labels"`
+
+	// Whether the entry is billed to the client of its project.
+	// example: true
+	Billable bool `json:"billable"`
 }
@@ -11,4 +11,8 @@ type CreateEntryActivity struct {
 	// max length: 50
 	// example: Development
 	Description string `json:"description"`
+
+	// The IDs of the entry types the entry activity is allowed for.
+	// example: [1]
+	TypeIds []int `json:"typeIds"`
 }
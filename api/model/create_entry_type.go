@@ -0,0 +1,39 @@
+package model
+
+// CreateEntryType
+//
+// Holds information about a new entry type.
+//
+// swagger:model CreateEntryType
+type CreateEntryType struct {
+	// The description of the entry type.
+	// min length: 1
+	// max length: 50
+	// example: Training
+	Description string `json:"description"`
+
+	// Determines if entries of the type are counted as work time.
+	// example: true
+	CountsAsWork *bool `json:"countsAsWork"`
+
+	// Determines if entries of the type are credited against the target hours.
+	// example: false
+	ReducesTarget *bool `json:"reducesTarget"`
+
+	// Determines if entries of the type consume vacation days.
+	// example: false
+	ConsumesVacation *bool `json:"consumesVacation"`
+
+	// Determines if entries of the type can have an activity.
+	// example: false
+	AllowsActivities *bool `json:"allowsActivities"`
+
+	// Determines if entries of the type can have expenses (e.g. mileage or per diem). (Default:
+	// false)
+	// example: true
+	AllowsExpenses bool `json:"allowsExpenses"`
+
+	// The color of the entry type. (Format: #RRGGBB)
+	// example: #9b59b6
+	Color string `json:"color"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// CreateExpenseRate
+//
+// Holds information about a new expense rate.
+//
+// swagger:model CreateExpenseRate
+type CreateExpenseRate struct {
+	// The type of the expenses the rate is used for ("mileage" or "per_diem").
+	// example: mileage
+	Type string `json:"type"`
+
+	// The name of the rate (e.g. the vehicle type).
+	// min length: 1
+	// max length: 50
+	// example: Car
+	Name string `json:"name"`
+
+	// The amount per kilometer (mileage) or per day (per diem).
+	// example: 0.3
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+}
@@ -0,0 +1,48 @@
+package model
+
+// CreateExportProfile
+//
+// Holds information about a new export profile.
+//
+// swagger:model CreateExportProfile
+type CreateExportProfile struct {
+	// The name of the export profile.
+	// min length: 1
+	// max length: 50
+	// example: Payroll
+	Name string `json:"name"`
+
+	// The exported columns in the order they are written. (Allowed values: `start_time`,
+	// `end_time`, `type`, `activity`, `project`, `description`, `labels`, `duration`,
+	// `billed_duration`, `rounding`)
+	// example: ["start_time", "end_time", "project", "description", "duration"]
+	Columns []string `json:"columns"`
+
+	// The field delimiter. (A single character.)
+	// example: ;
+	Delimiter string `json:"delimiter"`
+
+	// The format of the start and end times. Supported tokens are `yyyy`, `yy`, `MM`, `dd`,
+	// `HH`, `mm` and `ss`. (Empty for RFC 3339.)
+	// max length: 30
+	// example: dd.MM.yyyy HH:mm
+	DateFormat string `json:"dateFormat"`
+
+	// The unit of the durations. (`minutes` or `hours`)
+	// example: hours
+	DurationUnit string `json:"durationUnit"`
+
+	// The decimal separator of durations in hours. (`.` or `,`)
+	// example: ,
+	DecimalSeparator string `json:"decimalSeparator"`
+
+	// The language of the header. (Empty for the default language.)
+	// example: de
+	Language string `json:"language"`
+
+	// The separator between the labels of a entry.
+	// min length: 1
+	// max length: 5
+	// example: ,
+	LabelSeparator string `json:"labelSeparator"`
+}
@@ -0,0 +1,37 @@
+package model
+
+// CreateHourlyRate
+//
+// Holds information about a new hourly rate.
+//
+// swagger:model CreateHourlyRate
+type CreateHourlyRate struct {
+	// The ID of the user the rate applies to. (0 for all users)
+	// example: 0
+	UserId int `json:"userId"`
+
+	// The name of the project the rate applies to. (empty for all projects)
+	// max length: 30
+	// example: project-a
+	Project string `json:"project"`
+
+	// The ID of the activity the rate applies to. (0 for all activities)
+	// example: 0
+	ActivityId int `json:"activityId"`
+
+	// The amount per hour.
+	// example: 95
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The first day the rate is valid. (format "YYYY-MM-DD")
+	// example: 2024-01-01
+	ValidFrom string `json:"validFrom"`
+
+	// The last day the rate is valid. (format "YYYY-MM-DD", empty if valid indefinitely)
+	// example: 2024-12-31
+	ValidTo string `json:"validTo"`
+}
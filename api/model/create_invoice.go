@@ -0,0 +1,21 @@
+package model
+
+// CreateInvoice
+//
+// Holds information about a new invoice. All uninvoiced billable entries of the projects of the
+// client in the period are billed.
+//
+// swagger:model CreateInvoice
+type CreateInvoice struct {
+	// The ID of the client.
+	// example: 1
+	ClientId int `json:"clientId"`
+
+	// The first day of the billed period. (format "YYYY-MM-DD")
+	// example: 2024-01-01
+	StartDate string `json:"startDate"`
+
+	// The last day of the billed period. (format "YYYY-MM-DD")
+	// example: 2024-01-31
+	EndDate string `json:"endDate"`
+}
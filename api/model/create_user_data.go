@@ -24,6 +24,12 @@ type CreateUserData struct {
 	// example: secret
 	Password string `json:"password"`
 
+	// The time zone of the user as IANA time zone name. If empty, the time zone of the server is
+	// used.
+	// max length: 64
+	// example: Europe/Berlin
+	TimeZone string `json:"timeZone"`
+
 	// The work contract of the user.
 	Contract *CreateContract `json:"contract"`
 }
@@ -0,0 +1,25 @@
+package model
+
+// CreateVacationRequest
+//
+// Holds information about a new vacation request.
+//
+// swagger:model CreateVacationRequest
+type CreateVacationRequest struct {
+	// The first day of the vacation.
+	// example: 2019-01-07
+	StartDate string `json:"startDate"`
+
+	// The last day of the vacation.
+	// example: 2019-01-18
+	EndDate string `json:"endDate"`
+
+	// The fraction of the daily working hours which is recorded per day ("full" or "half").
+	// example: full
+	DayFraction string `json:"dayFraction"`
+
+	// The description with additional information about the vacation.
+	// min length: 0
+	// max length: 200
+	Description string `json:"description"`
+}
@@ -15,11 +15,11 @@ type Entry struct {
 	UserId int `json:"userId"`
 
 	// The start time of the entry.
-	// example: 2019-01-01T15:00:00
+	// example: 2019-01-01T15:00:00Z
 	StartTime string `json:"startTime"`
 
 	// The end time of the entry.
-	// example: 2019-01-01T16:00:00
+	// example: 2019-01-01T16:00:00Z
 	EndTime string `json:"endTime"`
 
 	// The ID of the entry type.
@@ -46,4 +46,29 @@ type Entry struct {
 	// max length: 20
 	// example: ["bug", "frontend"]
 	Labels []string `json:"labels"`
+
+	// Whether the entry is billed to the client of its project.
+	// example: true
+	Billable bool `json:"billable"`
+
+	// The ID of the invoice the entry was billed with (0 if the entry was not invoiced yet).
+	// example: 0
+	InvoiceId int `json:"invoiceId"`
+
+	// The ID of the absence the entry belongs to (0 if the entry does not belong to an absence).
+	// example: 0
+	AbsenceId int `json:"absenceId"`
+
+	// The time the entry was created.
+	// example: 2019-01-01T16:05:00Z
+	CreatedAt string `json:"createdAt"`
+
+	// The time the entry was last changed.
+	// example: 2019-01-01T16:05:00Z
+	UpdatedAt string `json:"updatedAt"`
+
+	// The version of the entry. It is also returned as entity tag (`ETag` header) and can be
+	// supplied via the `If-Match` header when updating the entry.
+	// example: 1
+	Version int `json:"version"`
 }
@@ -15,4 +15,13 @@ type EntryActivity struct {
 	// max length: 50
 	// example: Development
 	Description string `json:"description"`
+
+	// Determines if the entry activity is archived. (Archived activities can not be used for new
+	// entries.)
+	// example: false
+	Archived bool `json:"archived"`
+
+	// The IDs of the entry types the entry activity is allowed for.
+	// example: [1]
+	TypeIds []int `json:"typeIds"`
 }
@@ -0,0 +1,23 @@
+package model
+
+// EntryChange
+//
+// Contains information about a created, updated or deleted entry.
+//
+// swagger:model EntryChange
+type EntryChange struct {
+	// The ID of the entry.
+	// example: 1
+	Id int `json:"id"`
+
+	// Determines if the entry was deleted.
+	// example: false
+	Deleted bool `json:"deleted"`
+
+	// The time the entry was last changed (or deleted).
+	// example: 2019-01-01T16:05:00Z
+	UpdatedAt string `json:"updatedAt"`
+
+	// The entry. (Null if the entry was deleted.)
+	Entry *Entry `json:"entry"`
+}
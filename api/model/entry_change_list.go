@@ -0,0 +1,24 @@
+package model
+
+// EntryChangeList
+//
+// A list of entry changes.
+//
+// swagger:model EntryChangeList
+type EntryChangeList struct {
+	// The cursor to request the next changes with. (Empty if there were no changes at all.)
+	// example: MjAxOS0wMS0wMVQxNjowNTowMDsx
+	Cursor string `json:"cursor"`
+
+	// Determines if more changes are available.
+	// example: false
+	HasMore bool `json:"hasMore"`
+
+	// The changes ordered by their change time.
+	Items []*EntryChange `json:"items"`
+}
+
+// NewEntryChangeList creates a new entry change list.
+func NewEntryChangeList(cursor string, hasMore bool, items []*EntryChange) *EntryChangeList {
+	return &EntryChangeList{cursor, hasMore, items}
+}
@@ -21,11 +21,15 @@ type EntryList struct {
 	// example: 0
 	Total int `json:"total"`
 
+	// The cursor to request the next page with. (Empty if there are no more entries.)
+	// example: MjAxOS0wMS0wMVQwODowMDowMFo7MjAxOS0wMS0wMVQxMjowMDowMFo7MQ==
+	Cursor string `json:"cursor"`
+
 	// The entries.
 	Items []*Entry `json:"items"`
 }
 
 // NewEntryList creates a new entry list.
-func NewEntryList(offset int, limit int, total int, items []*Entry) *EntryList {
-	return &EntryList{offset, limit, total, items}
+func NewEntryList(offset int, limit int, total int, cursor string, items []*Entry) *EntryList {
+	return &EntryList{offset, limit, total, cursor, items}
 }
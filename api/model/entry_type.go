@@ -10,9 +10,37 @@ type EntryType struct {
 	// example: 1
 	Id int `json:"id"`
 
+	// The name of a built-in entry type. (Empty for user-defined entry types.)
+	// example: work
+	Name string `json:"name,omitempty"`
+
 	// The description of the entry type.
 	// min length: 1
 	// max length: 50
 	// example: Work
 	Description string `json:"description"`
+
+	// Determines if entries of the type are counted as work time.
+	// example: true
+	CountsAsWork bool `json:"countsAsWork"`
+
+	// Determines if entries of the type are credited against the target hours.
+	// example: false
+	ReducesTarget bool `json:"reducesTarget"`
+
+	// Determines if entries of the type consume vacation days.
+	// example: false
+	ConsumesVacation bool `json:"consumesVacation"`
+
+	// Determines if entries of the type can have an activity.
+	// example: true
+	AllowsActivities bool `json:"allowsActivities"`
+
+	// Determines if entries of the type can have expenses (e.g. mileage or per diem).
+	// example: false
+	AllowsExpenses bool `json:"allowsExpenses"`
+
+	// The color of the entry type. (Format: #RRGGBB)
+	// example: #0c63e4
+	Color string `json:"color"`
 }
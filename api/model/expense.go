@@ -0,0 +1,40 @@
+package model
+
+// Expense
+//
+// Contains information about an expense of an entry (e.g. of a travel).
+//
+// swagger:model Expense
+type Expense struct {
+	// The ID of the expense.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the entry the expense belongs to.
+	// example: 1
+	EntryId int `json:"entryId"`
+
+	// The type of the expense ("mileage", "per_diem" or "receipt").
+	// example: mileage
+	Type string `json:"type"`
+
+	// The ID of the rate which was used to calculate the amount. (0 for receipts)
+	// example: 1
+	RateId int `json:"rateId"`
+
+	// The distance in kilometers (mileage) or the number of days (per diem). (0 for receipts)
+	// example: 120
+	Quantity float64 `json:"quantity"`
+
+	// The amount of the expense.
+	// example: 36
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The description of the expense.
+	// example: Drive to customer
+	Description string `json:"description"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// ExpenseList
+//
+// A list of expenses.
+//
+// swagger:model ExpenseList
+type ExpenseList struct {
+	// The list of expenses.
+	Items []*Expense `json:"items"`
+}
+
+// NewExpenseList creates a new ExpenseList model.
+func NewExpenseList(items []*Expense) *ExpenseList {
+	return &ExpenseList{items}
+}
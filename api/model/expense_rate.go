@@ -0,0 +1,28 @@
+package model
+
+// ExpenseRate
+//
+// Contains information about a rate which is used to calculate mileage or per diem expenses.
+//
+// swagger:model ExpenseRate
+type ExpenseRate struct {
+	// The ID of the rate.
+	// example: 1
+	Id int `json:"id"`
+
+	// The type of the expenses the rate is used for ("mileage" or "per_diem").
+	// example: mileage
+	Type string `json:"type"`
+
+	// The name of the rate (e.g. the vehicle type).
+	// example: Car
+	Name string `json:"name"`
+
+	// The amount per kilometer (mileage) or per day (per diem).
+	// example: 0.3
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+}
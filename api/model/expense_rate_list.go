@@ -0,0 +1,16 @@
+package model
+
+// ExpenseRateList
+//
+// A list of expense rates.
+//
+// swagger:model ExpenseRateList
+type ExpenseRateList struct {
+	// The list of expense rates.
+	Items []*ExpenseRate `json:"items"`
+}
+
+// NewExpenseRateList creates a new ExpenseRateList model.
+func NewExpenseRateList(items []*ExpenseRate) *ExpenseRateList {
+	return &ExpenseRateList{items}
+}
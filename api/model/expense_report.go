@@ -0,0 +1,63 @@
+package model
+
+// ExpenseReport
+//
+// Contains the expenses of a user in a month.
+//
+// swagger:model ExpenseReport
+type ExpenseReport struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The first day of the month.
+	// example: 2019-01-01
+	StartDate string `json:"startDate"`
+
+	// The last day of the month.
+	// example: 2019-01-31
+	EndDate string `json:"endDate"`
+
+	// The expenses (ordered by date).
+	Items []*ExpenseReportItem `json:"items"`
+
+	// The total amounts per currency (ordered by currency).
+	Totals []*ExpenseTotal `json:"totals"`
+}
+
+// ExpenseReportItem
+//
+// Contains an expense of an expense report.
+//
+// swagger:model ExpenseReportItem
+type ExpenseReportItem struct {
+	// The day of the entry the expense belongs to.
+	// example: 2019-01-15
+	Date string `json:"date"`
+
+	// The project of the entry the expense belongs to.
+	// example: Project A
+	Project string `json:"project"`
+
+	// The name of the rate which was used to calculate the amount. (Empty for receipts)
+	// example: Car
+	RateName string `json:"rateName"`
+
+	// The expense.
+	Expense *Expense `json:"expense"`
+}
+
+// ExpenseTotal
+//
+// Contains the total amount of expenses in a currency.
+//
+// swagger:model ExpenseTotal
+type ExpenseTotal struct {
+	// The currency. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The total amount.
+	// example: 64
+	Amount float64 `json:"amount"`
+}
@@ -0,0 +1,44 @@
+package model
+
+// ExportProfile
+//
+// Contains the settings of a CSV export.
+//
+// swagger:model ExportProfile
+type ExportProfile struct {
+	// The ID of the export profile.
+	// example: 1
+	Id int `json:"id"`
+
+	// The name of the export profile.
+	// example: Payroll
+	Name string `json:"name"`
+
+	// The exported columns in the order they are written.
+	// example: ["start_time", "end_time", "project", "description", "duration"]
+	Columns []string `json:"columns"`
+
+	// The field delimiter.
+	// example: ;
+	Delimiter string `json:"delimiter"`
+
+	// The format of the start and end times. (Empty for RFC 3339.)
+	// example: dd.MM.yyyy HH:mm
+	DateFormat string `json:"dateFormat"`
+
+	// The unit of the durations. (`minutes` or `hours`)
+	// example: hours
+	DurationUnit string `json:"durationUnit"`
+
+	// The decimal separator of durations in hours. (`.` or `,`)
+	// example: ,
+	DecimalSeparator string `json:"decimalSeparator"`
+
+	// The language of the header. (Empty for the default language.)
+	// example: de
+	Language string `json:"language"`
+
+	// The separator between the labels of a entry.
+	// example: ,
+	LabelSeparator string `json:"labelSeparator"`
+}
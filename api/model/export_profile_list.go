@@ -0,0 +1,16 @@
+package model
+
+// ExportProfileList
+//
+// A list of export profiles.
+//
+// swagger:model ExportProfileList
+type ExportProfileList struct {
+	// The list of export profiles.
+	Items []*ExportProfile `json:"items"`
+}
+
+// NewExportProfileList creates a new ExportProfileList model.
+func NewExportProfileList(items []*ExportProfile) *ExportProfileList {
+	return &ExportProfileList{items}
+}
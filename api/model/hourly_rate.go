@@ -0,0 +1,41 @@
+package model
+
+// HourlyRate
+//
+// Contains information about an amount which is billed per hour of work. A rate can be
+// restricted to a user, a project and/or an activity.
+//
+// swagger:model HourlyRate
+type HourlyRate struct {
+	// The ID of the rate.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the user the rate applies to. (0 for all users)
+	// example: 0
+	UserId int `json:"userId"`
+
+	// The name of the project the rate applies to. (empty for all projects)
+	// example: project-a
+	Project string `json:"project"`
+
+	// The ID of the activity the rate applies to. (0 for all activities)
+	// example: 0
+	ActivityId int `json:"activityId"`
+
+	// The amount per hour.
+	// example: 95
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The first day the rate is valid. (format "YYYY-MM-DD")
+	// example: 2024-01-01
+	ValidFrom string `json:"validFrom"`
+
+	// The last day the rate is valid. (format "YYYY-MM-DD", empty if valid indefinitely)
+	// example: 2024-12-31
+	ValidTo string `json:"validTo"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// HourlyRateList
+//
+// A list of hourly rates.
+//
+// swagger:model HourlyRateList
+type HourlyRateList struct {
+	// The list of hourly rates.
+	Items []*HourlyRate `json:"items"`
+}
+
+// NewHourlyRateList creates a new HourlyRateList model.
+func NewHourlyRateList(items []*HourlyRate) *HourlyRateList {
+	return &HourlyRateList{items}
+}
@@ -0,0 +1,55 @@
+package model
+
+// Invoice
+//
+// Contains information about an invoice.
+//
+// swagger:model Invoice
+type Invoice struct {
+	// The ID of the invoice.
+	// example: 1
+	Id int `json:"id"`
+
+	// The number of the invoice.
+	// example: 2024-0001
+	Number string `json:"number"`
+
+	// The ID of the billed client.
+	// example: 1
+	ClientId int `json:"clientId"`
+
+	// The first day of the billed period. (format "YYYY-MM-DD")
+	// example: 2024-01-01
+	StartDate string `json:"startDate"`
+
+	// The last day of the billed period. (format "YYYY-MM-DD")
+	// example: 2024-01-31
+	EndDate string `json:"endDate"`
+
+	// The currency of the amounts. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The sum of the amounts of the items.
+	// example: 1000
+	NetAmount float64 `json:"netAmount"`
+
+	// The tax rate in percent.
+	// example: 19
+	TaxRate float64 `json:"taxRate"`
+
+	// The tax amount.
+	// example: 190
+	TaxAmount float64 `json:"taxAmount"`
+
+	// The net amount plus the tax amount.
+	// example: 1190
+	TotalAmount float64 `json:"totalAmount"`
+
+	// The creation timestamp of the invoice. (format "YYYY-MM-DDTHH:mm:ss±hh:mm")
+	// example: 2024-02-01T09:00:00+01:00
+	CreatedAt string `json:"createdAt"`
+
+	// The items of the invoice (ordered by date). Lists of invoices do not contain items.
+	Items []*InvoiceItem `json:"items,omitempty"`
+}
@@ -0,0 +1,40 @@
+package model
+
+// InvoiceItem
+//
+// Contains information about a billed entry of an invoice.
+//
+// swagger:model InvoiceItem
+type InvoiceItem struct {
+	// The ID of the billed entry.
+	// example: 1
+	EntryId int `json:"entryId"`
+
+	// The ID of the user of the entry.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The date of the entry. (format "YYYY-MM-DD")
+	// example: 2024-01-02
+	Date string `json:"date"`
+
+	// The project of the entry.
+	// example: project-a
+	Project string `json:"project"`
+
+	// The description of the work.
+	// example: Development - Implemented feature
+	Description string `json:"description"`
+
+	// The billed hours.
+	// example: 7.5
+	Hours float64 `json:"hours"`
+
+	// The hourly rate.
+	// example: 95
+	Rate float64 `json:"rate"`
+
+	// The billed hours multiplied with the hourly rate.
+	// example: 712.5
+	Amount float64 `json:"amount"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// InvoiceList
+//
+// A list of invoices.
+//
+// swagger:model InvoiceList
+type InvoiceList struct {
+	// The list of invoices.
+	Items []*Invoice `json:"items"`
+}
+
+// NewInvoiceList creates a new InvoiceList model.
+func NewInvoiceList(items []*Invoice) *InvoiceList {
+	return &InvoiceList{items}
+}
@@ -0,0 +1,12 @@
+package model
+
+// MergeEntryActivity
+//
+// Holds information about merging a entry activity into another entry activity.
+//
+// swagger:model MergeEntryActivity
+type MergeEntryActivity struct {
+	// The ID of the entry activity the entries are reassigned to.
+	// example: 1
+	TargetActivityId int `json:"targetActivityId"`
+}
@@ -0,0 +1,21 @@
+package model
+
+// NotificationSettings
+//
+// Contains the notification settings of a user.
+//
+// swagger:model NotificationSettings
+type NotificationSettings struct {
+	// The email address notifications are sent to. (Empty if no notifications should be sent.)
+	// max length: 100
+	// example: john@example.com
+	Email string `json:"email"`
+
+	// Determines if reminders for working days with no or too few logged hours are sent.
+	// example: true
+	MissingEntriesReminder bool `json:"missingEntriesReminder"`
+
+	// Determines if a weekly digest of missing entries is sent. (Only for evaluators.)
+	// example: false
+	WeeklyDigest bool `json:"weeklyDigest"`
+}
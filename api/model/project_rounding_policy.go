@@ -0,0 +1,30 @@
+package model
+
+// ProjectRoundingPolicy
+//
+// Contains information about how the durations of the entries of a project are rounded. (A project
+// rounding policy overrides the rounding policy of the user.)
+//
+// swagger:model ProjectRoundingPolicy
+type ProjectRoundingPolicy struct {
+	// The name of the project.
+	// min length: 1
+	// max length: 30
+	// example: Project A
+	Project string `json:"project"`
+
+	// The rounding mode. (nearest, up or down)
+	// example: up
+	Mode string `json:"mode"`
+
+	// The interval in minutes the durations are rounded to.
+	// minimum: 1
+	// maximum: 60
+	// example: 15
+	IntervalMinutes int `json:"intervalMinutes"`
+
+	// The rounding scope. (save: the rounded duration is stored when an entry is saved, report:
+	// only reports and exports show the rounded duration)
+	// example: report
+	Scope string `json:"scope"`
+}
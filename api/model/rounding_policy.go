@@ -0,0 +1,23 @@
+package model
+
+// RoundingPolicy
+//
+// Contains information about how the durations of work entries are rounded.
+//
+// swagger:model RoundingPolicy
+type RoundingPolicy struct {
+	// The rounding mode. (nearest, up or down)
+	// example: nearest
+	Mode string `json:"mode"`
+
+	// The interval in minutes the durations are rounded to.
+	// minimum: 1
+	// maximum: 60
+	// example: 15
+	IntervalMinutes int `json:"intervalMinutes"`
+
+	// The rounding scope. (save: the rounded duration is stored when an entry is saved, report:
+	// only reports and exports show the rounded duration)
+	// example: report
+	Scope string `json:"scope"`
+}
@@ -0,0 +1,67 @@
+package model
+
+// Statistics
+//
+// Contains the durations of entries grouped by an entry characteristic and split into time
+// buckets.
+//
+// swagger:model Statistics
+type Statistics struct {
+	// The entry characteristic the durations are grouped by.
+	// enum: type,activity,project,label,user
+	// example: project
+	Grouping string `json:"grouping"`
+
+	// The length of the time buckets.
+	// enum: day,week,month
+	// example: month
+	Interval string `json:"interval"`
+
+	// The first day of the period.
+	// example: 2019-01-01
+	StartDate string `json:"startDate"`
+
+	// The last day of the period.
+	// example: 2019-03-31
+	EndDate string `json:"endDate"`
+
+	// The first days of the buckets.
+	// example: ["2019-01-01","2019-02-01","2019-03-01"]
+	Buckets []string `json:"buckets"`
+
+	// The hours of all entries per bucket.
+	// example: [152.5,160,148.25]
+	Hours []float32 `json:"hours"`
+
+	// The hours of all entries.
+	// example: 460.75
+	TotalHours float32 `json:"totalHours"`
+
+	// The groups (sorted descending by their total hours).
+	Groups []*StatisticsGroup `json:"groups"`
+}
+
+// StatisticsGroup
+//
+// Contains the durations of the entries of a group. Entries without activity, project or label
+// are contained in a group with ID 0 and an empty name.
+//
+// swagger:model StatisticsGroup
+type StatisticsGroup struct {
+	// The ID of the entry type, entry activity or user. (0 for projects and labels)
+	// example: 0
+	Id int `json:"id"`
+
+	// The description of the entry type or entry activity, the project name, the label or the
+	// name of the user.
+	// example: Website relaunch
+	Name string `json:"name"`
+
+	// The hours of the entries of the group per bucket.
+	// example: [40,62.5,12]
+	Hours []float32 `json:"hours"`
+
+	// The hours of all entries of the group.
+	// example: 114.5
+	TotalHours float32 `json:"totalHours"`
+}
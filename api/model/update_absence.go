@@ -0,0 +1,33 @@
+package model
+
+// UpdateAbsence
+//
+// Holds the new information about an absence.
+//
+// swagger:model UpdateAbsence
+type UpdateAbsence struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The ID of the entry type (only vacation and illness are allowed).
+	// example: 3
+	TypeId int `json:"typeId"`
+
+	// The first day of the absence.
+	// example: 2019-01-07
+	StartDate string `json:"startDate"`
+
+	// The last day of the absence.
+	// example: 2019-01-18
+	EndDate string `json:"endDate"`
+
+	// The fraction of the daily working hours which is recorded per day ("full" or "half").
+	// example: full
+	DayFraction string `json:"dayFraction"`
+
+	// The description with additional information about the absence.
+	// min length: 0
+	// max length: 200
+	Description string `json:"description"`
+}
@@ -0,0 +1,28 @@
+package model
+
+// UpdateClient
+//
+// Holds information about an updated client.
+//
+// swagger:model UpdateClient
+type UpdateClient struct {
+	// The name of the client.
+	// min length: 1
+	// max length: 100
+	// example: Example Corp.
+	Name string `json:"name"`
+
+	// The postal address of the client (lines separated by line breaks).
+	// max length: 500
+	// example: Example Street 1\n12345 Example City
+	Address string `json:"address"`
+
+	// The currency the client is billed in. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The names of the projects which are billed to the client. A project can only be assigned
+	// to one client.
+	// example: ["project-a", "project-b"]
+	Projects []string `json:"projects"`
+}
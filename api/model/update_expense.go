@@ -0,0 +1,35 @@
+package model
+
+// UpdateExpense
+//
+// Holds the new information about an expense.
+//
+// swagger:model UpdateExpense
+type UpdateExpense struct {
+	// The type of the expense ("mileage", "per_diem" or "receipt").
+	// example: mileage
+	Type string `json:"type"`
+
+	// The ID of the rate which is used to calculate the amount. (Ignored for receipts)
+	// example: 1
+	RateId int `json:"rateId"`
+
+	// The distance in kilometers (mileage) or the number of days (per diem). (Ignored for
+	// receipts)
+	// example: 120
+	Quantity float64 `json:"quantity"`
+
+	// The amount of the receipt. (Ignored for mileage and per diem, the amount is calculated with
+	// the rate)
+	// example: 12.5
+	Amount float64 `json:"amount"`
+
+	// The currency of the receipt. (ISO 4217 code, ignored for mileage and per diem)
+	// example: EUR
+	Currency string `json:"currency"`
+
+	// The description of the expense.
+	// max length: 200
+	// example: Drive to customer
+	Description string `json:"description"`
+}
@@ -0,0 +1,22 @@
+package model
+
+// UpdateExpenseRate
+//
+// Holds the new information about an expense rate. Existing expenses keep their amounts.
+//
+// swagger:model UpdateExpenseRate
+type UpdateExpenseRate struct {
+	// The name of the rate (e.g. the vehicle type).
+	// min length: 1
+	// max length: 50
+	// example: Car
+	Name string `json:"name"`
+
+	// The amount per kilometer (mileage) or per day (per diem).
+	// example: 0.3
+	Amount float64 `json:"amount"`
+
+	// The currency of the amount. (ISO 4217 code)
+	// example: EUR
+	Currency string `json:"currency"`
+}
@@ -18,6 +18,12 @@ type UpdateUserData struct {
 	// example: john
 	Username string `json:"username"`
 
+	// The time zone of the user as IANA time zone name. If empty, the time zone of the server is
+	// used.
+	// max length: 64
+	// example: Europe/Berlin
+	TimeZone string `json:"timeZone"`
+
 	// The work contract of the user.
 	Contract *UpdateContract `json:"contract"`
 }
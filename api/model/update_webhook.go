@@ -0,0 +1,26 @@
+package model
+
+// UpdateWebhook
+//
+// Holds the new information about a webhook.
+//
+// swagger:model UpdateWebhook
+type UpdateWebhook struct {
+	// The URL events are sent to. (Must be an absolute HTTP or HTTPS URL.)
+	// max length: 500
+	// example: https://example.com/work-log/events
+	Url string `json:"url"`
+
+	// The secret used to sign deliveries. (The existing secret is kept if none is provided.)
+	// max length: 100
+	// example: my-secret
+	Secret string `json:"secret"`
+
+	// The subscribed event types.
+	// example: ["entry.created","entry.updated"]
+	EventTypes []string `json:"eventTypes"`
+
+	// Determines if events are delivered to the webhook.
+	// example: true
+	Active *bool `json:"active"`
+}
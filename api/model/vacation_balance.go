@@ -0,0 +1,36 @@
+package model
+
+// VacationBalance
+//
+// Contains information about the vacation balance of a user in the current year.
+//
+// swagger:model VacationBalance
+type VacationBalance struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The year of the balance.
+	// example: 2019
+	Year int `json:"year"`
+
+	// The vacation days available until the end of the year (including the initial days).
+	// example: 30
+	EntitledDays float32 `json:"entitledDays"`
+
+	// The vacation days taken until today.
+	// example: 12
+	TakenDays float32 `json:"takenDays"`
+
+	// The vacation days booked after today.
+	// example: 5
+	PlannedDays float32 `json:"plannedDays"`
+
+	// The vacation days of pending requests.
+	// example: 3
+	PendingDays float32 `json:"pendingDays"`
+
+	// The vacation days which are neither taken nor booked.
+	// example: 13
+	RemainingDays float32 `json:"remainingDays"`
+}
@@ -0,0 +1,50 @@
+package model
+
+// VacationLedger
+//
+// Contains the vacation account of a user for every year since the first work day.
+//
+// swagger:model VacationLedger
+type VacationLedger struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The years of the ledger (oldest first).
+	Years []*VacationLedgerYear `json:"years"`
+}
+
+// VacationLedgerYear
+//
+// Contains the vacation account of a user for a single year.
+//
+// swagger:model VacationLedgerYear
+type VacationLedgerYear struct {
+	// The year.
+	// example: 2019
+	Year int `json:"year"`
+
+	// The vacation days carried over from the previous year.
+	// example: 5
+	CarriedOverDays float32 `json:"carriedOverDays"`
+
+	// The vacation days accrued in the year (including the initial days in the first year).
+	// example: 30
+	AccruedDays float32 `json:"accruedDays"`
+
+	// The vacation days added (or subtracted) by manual balance adjustments.
+	// example: 0
+	AdjustedDays float32 `json:"adjustedDays"`
+
+	// The vacation days taken or booked in the year.
+	// example: 25
+	TakenDays float32 `json:"takenDays"`
+
+	// The vacation days expired in the year.
+	// example: 2
+	ExpiredDays float32 `json:"expiredDays"`
+
+	// The vacation days remaining at the end of the year.
+	// example: 8
+	RemainingDays float32 `json:"remainingDays"`
+}
@@ -0,0 +1,67 @@
+package model
+
+// VacationRequest
+//
+// Contains information about a request for leave.
+//
+// swagger:model VacationRequest
+type VacationRequest struct {
+	// The ID of the request.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the requesting user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The first day of the vacation.
+	// example: 2019-01-07
+	StartDate string `json:"startDate"`
+
+	// The last day of the vacation.
+	// example: 2019-01-18
+	EndDate string `json:"endDate"`
+
+	// The fraction of the daily working hours which is recorded per day ("full" or "half").
+	// example: full
+	DayFraction string `json:"dayFraction"`
+
+	// The description with additional information about the vacation.
+	// min length: 0
+	// max length: 200
+	Description string `json:"description"`
+
+	// The number of requested vacation days (only working days are counted).
+	// example: 10
+	Days float32 `json:"days"`
+
+	// The status of the request ("pending", "approved" or "denied").
+	// example: pending
+	Status string `json:"status"`
+
+	// The time of the request.
+	// example: 2018-12-03T09:15:00Z
+	RequestedAt string `json:"requestedAt"`
+
+	// The ID of the user who approved or denied the request. (0 if not decided yet)
+	// example: 2
+	DecidedByUserId int `json:"decidedByUserId"`
+
+	// The time of the decision. (empty if not decided yet)
+	// example: 2018-12-04T14:30:00Z
+	DecidedAt string `json:"decidedAt"`
+
+	// The comment of the decision.
+	// min length: 0
+	// max length: 200
+	DecisionComment string `json:"decisionComment"`
+
+	// The ID of the absence created on approval. (0 if not approved)
+	// example: 1
+	AbsenceId int `json:"absenceId"`
+
+	// The warnings of a pending request. ("balance_exceeded": The requested days exceed the
+	// remaining vacation days.)
+	// example: ["balance_exceeded"]
+	Warnings []string `json:"warnings"`
+}
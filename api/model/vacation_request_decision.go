@@ -0,0 +1,13 @@
+package model
+
+// VacationRequestDecision
+//
+// Holds information about the approval or denial of a vacation request.
+//
+// swagger:model VacationRequestDecision
+type VacationRequestDecision struct {
+	// The comment of the decision.
+	// min length: 0
+	// max length: 200
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// VacationRequestList
+//
+// A list of vacation requests.
+//
+// swagger:model VacationRequestList
+type VacationRequestList struct {
+	// The list of vacation requests.
+	Items []*VacationRequest `json:"items"`
+}
+
+// NewVacationRequestList creates a new VacationRequestList model.
+func NewVacationRequestList(items []*VacationRequest) *VacationRequestList {
+	return &VacationRequestList{items}
+}
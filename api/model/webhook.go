@@ -0,0 +1,29 @@
+package model
+
+// Webhook
+//
+// Contains information about a webhook.
+//
+// swagger:model Webhook
+type Webhook struct {
+	// The ID of the webhook.
+	// example: 1
+	Id int `json:"id"`
+
+	// The URL events are sent to.
+	// max length: 500
+	// example: https://example.com/work-log/events
+	Url string `json:"url"`
+
+	// The secret used to sign deliveries. (Only returned when the webhook is created.)
+	// example: a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5p6
+	Secret string `json:"secret,omitempty"`
+
+	// The subscribed event types.
+	// example: ["entry.created","entry.updated"]
+	EventTypes []string `json:"eventTypes"`
+
+	// Determines if events are delivered to the webhook.
+	// example: true
+	Active bool `json:"active"`
+}
@@ -0,0 +1,53 @@
+package model
+
+// WebhookDelivery
+//
+// Contains information about the delivery of an event to a webhook.
+//
+// swagger:model WebhookDelivery
+type WebhookDelivery struct {
+	// The ID of the delivery.
+	// example: 1
+	Id int `json:"id"`
+
+	// The ID of the webhook.
+	// example: 1
+	WebhookId int `json:"webhookId"`
+
+	// The type of the delivered event.
+	// example: entry.created
+	EventType string `json:"eventType"`
+
+	// The JSON payload of the delivered event.
+	// example: {"event":"entry.created","timestamp":"2019-01-01T08:00:00Z","data":{"id":1}}
+	Payload string `json:"payload"`
+
+	// The status of the delivery.
+	// enum: pending,succeeded,failed
+	// example: succeeded
+	Status string `json:"status"`
+
+	// The number of delivery attempts.
+	// example: 1
+	Attempts int `json:"attempts"`
+
+	// The time the delivery was created.
+	// example: 2019-01-01T08:00:00Z
+	CreatedAt string `json:"createdAt"`
+
+	// The time of the next delivery attempt. (Empty if there is none.)
+	// example: 2019-01-01T08:01:00Z
+	NextAttemptAt string `json:"nextAttemptAt"`
+
+	// The time of the last delivery attempt. (Empty if there was none.)
+	// example: 2019-01-01T08:00:00Z
+	LastAttemptAt string `json:"lastAttemptAt"`
+
+	// The HTTP status code of the last delivery attempt. (0 if no response was received.)
+	// example: 200
+	LastStatusCode int `json:"lastStatusCode"`
+
+	// The error of the last delivery attempt.
+	// example: receiver responded with status code 500
+	LastError string `json:"lastError"`
+}
@@ -0,0 +1,32 @@
+package model
+
+// WebhookDeliveryList
+//
+// A list of webhook deliveries.
+//
+// swagger:model WebhookDeliveryList
+type WebhookDeliveryList struct {
+	// The deliveries page offset.
+	// min: 0
+	// example: 0
+	Offset int `json:"offset"`
+
+	// The deliveries page limit.
+	// min: 0
+	// example: 0
+	Limit int `json:"limit"`
+
+	// The total count of deliveries available.
+	// min: 0
+	// example: 0
+	Total int `json:"total"`
+
+	// The deliveries.
+	Items []*WebhookDelivery `json:"items"`
+}
+
+// NewWebhookDeliveryList creates a new webhook delivery list.
+func NewWebhookDeliveryList(offset int, limit int, total int,
+	items []*WebhookDelivery) *WebhookDeliveryList {
+	return &WebhookDeliveryList{offset, limit, total, items}
+}
@@ -0,0 +1,16 @@
+package model
+
+// WebhookList
+//
+// A list of webhooks.
+//
+// swagger:model WebhookList
+type WebhookList struct {
+	// The list of webhooks.
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// NewWebhookList creates a new WebhookList model.
+func NewWebhookList(webhooks []*Webhook) *WebhookList {
+	return &WebhookList{webhooks}
+}
@@ -0,0 +1,31 @@
+package model
+
+// YearClosing
+//
+// Contains the closing balances of a user at the end of a year.
+//
+// swagger:model YearClosing
+type YearClosing struct {
+	// The ID of the user.
+	// example: 1
+	UserId int `json:"userId"`
+
+	// The closed year.
+	// example: 2019
+	Year int `json:"year"`
+
+	// The overtime balance (in hours) at the end of the year.
+	// example: 12.5
+	OvertimeHours float32 `json:"overtimeHours"`
+
+	// The vacation account of the year.
+	Vacation *VacationLedgerYear `json:"vacation"`
+
+	// The ID of the user who closed the year (0 if the user was deleted).
+	// example: 1
+	ClosedByUserId int `json:"closedByUserId"`
+
+	// The time of the closing.
+	// example: 2020-01-10T09:00:00Z
+	ClosedAt string `json:"closedAt"`
+}
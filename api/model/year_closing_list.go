@@ -0,0 +1,16 @@
+package model
+
+// YearClosingList
+//
+// A list of year closings.
+//
+// swagger:model YearClosingList
+type YearClosingList struct {
+	// The list of year closings.
+	Items []*YearClosing `json:"items"`
+}
+
+// NewYearClosingList creates a new YearClosingList model.
+func NewYearClosingList(items []*YearClosing) *YearClosingList {
+	return &YearClosingList{items}
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+
+	vm "kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ValidateCreateAbsence validates information of a CreateAbsence API model.
+func ValidateCreateAbsence(data *vm.CreateAbsence) error {
+	return checkAbsence(data.UserId, data.TypeId, data.StartDate, data.EndDate, data.DayFraction,
+		data.Description)
+}
+
+// ValidateUpdateAbsence validates information of a UpdateAbsence API model.
+func ValidateUpdateAbsence(data *vm.UpdateAbsence) error {
+	return checkAbsence(data.UserId, data.TypeId, data.StartDate, data.EndDate, data.DayFraction,
+		data.Description)
+}
+
+func checkAbsence(userId int, typeId int, startDate string, endDate string, dayFraction string,
+	description string) error {
+	if err := checkIdPositive("userId", userId); err != nil {
+		return err
+	}
+	if err := checkIdPositive("typeId", typeId); err != nil {
+		return err
+	}
+	if err := checkDateValid("startDate", startDate); err != nil {
+		return err
+	}
+	if err := checkDateValid("endDate", endDate); err != nil {
+		return err
+	}
+	if err := checkAbsenceDayFraction(dayFraction); err != nil {
+		return err
+	}
+	return checkStringNotTooLong("description", description, m.MaxLengthEntryDescription)
+}
+
+func checkAbsenceDayFraction(dayFraction string) error {
+	if !m.IsValidAbsenceDayFraction(dayFraction) {
+		err := e.NewError(e.ValDayFractionInvalid, fmt.Sprintf("Day fraction '%s' is not valid.",
+			dayFraction))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"fmt"
+
+	vm "kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ValidateCreateBalanceAdjustment validates information of a CreateBalanceAdjustment API model.
+func ValidateCreateBalanceAdjustment(data *vm.CreateBalanceAdjustment) error {
+	if !m.IsValidBalanceAdjustmentType(data.Type) {
+		err := e.NewError(e.ValAdjustmentTypeInvalid, fmt.Sprintf("Adjustment type '%s' is not "+
+			"valid.", data.Type))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if err := checkDateValid("date", data.Date); err != nil {
+		return err
+	}
+	if err := checkStringNotEmpty("reason", data.Reason); err != nil {
+		return err
+	}
+	return checkStringNotTooLong("reason", data.Reason, m.MaxLengthEntryDescription)
+}
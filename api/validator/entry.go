@@ -3,6 +3,7 @@ package validator
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	vm "kellnhofer.com/work-log/api/model"
@@ -11,16 +12,87 @@ import (
 	m "kellnhofer.com/work-log/pkg/model"
 )
 
+// --- Entry type API model valdidation functions ---
+
+// ValidateCreateEntryType validates information of a CreateEntryType API model.
+func ValidateCreateEntryType(data *vm.CreateEntryType) error {
+	return checkEntryType(data.Description, data.CountsAsWork, data.ReducesTarget,
+		data.ConsumesVacation, data.AllowsActivities, data.Color)
+}
+
+// ValidateUpdateEntryType validates information of a UpdateEntryType API model.
+func ValidateUpdateEntryType(data *vm.UpdateEntryType) error {
+	return checkEntryType(data.Description, data.CountsAsWork, data.ReducesTarget,
+		data.ConsumesVacation, data.AllowsActivities, data.Color)
+}
+
+// --- Basic entry type validation functions ---
+
+func checkEntryType(desc string, countsAsWork *bool, reducesTarget *bool, consumesVacation *bool,
+	allowsActivities *bool, color string) error {
+	if err := checkEntryTypeDescription(desc); err != nil {
+		return err
+	}
+	if err := checkBoolNotNil("countsAsWork", countsAsWork); err != nil {
+		return err
+	}
+	if err := checkBoolNotNil("reducesTarget", reducesTarget); err != nil {
+		return err
+	}
+	if err := checkBoolNotNil("consumesVacation", consumesVacation); err != nil {
+		return err
+	}
+	if err := checkBoolNotNil("allowsActivities", allowsActivities); err != nil {
+		return err
+	}
+	return checkEntryTypeColor(color)
+}
+
+func checkEntryTypeDescription(desc string) error {
+	if err := checkStringNotEmpty("description", desc); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("description", desc, m.MaxLengthEntryTypeDescription); err !=
+		nil {
+		return err
+	}
+	return nil
+}
+
+func checkEntryTypeColor(color string) error {
+	r := regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+	if !r.MatchString(color) {
+		err := e.NewError(e.ValColorInvalid, "'color' must be a color in format '#RRGGBB'.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
 // --- Entry activity API model valdidation functions ---
 
 // ValidateCreateEntryActivity validates information of a CreateEntryActivity API model.
 func ValidateCreateEntryActivity(data *vm.CreateEntryActivity) error {
-	return checkEntryActivityDescription(data.Description)
+	if err := checkEntryActivityDescription(data.Description); err != nil {
+		return err
+	}
+	return checkEntryActivityTypeIds(data.TypeIds)
 }
 
 // ValidateUpdateEntryActivity validates information of a UpdateEntryActivity API model.
 func ValidateUpdateEntryActivity(data *vm.UpdateEntryActivity) error {
-	return checkEntryActivityDescription(data.Description)
+	if err := checkEntryActivityDescription(data.Description); err != nil {
+		return err
+	}
+	if err := checkBoolNotNil("archived", data.Archived); err != nil {
+		return err
+	}
+	return checkEntryActivityTypeIds(data.TypeIds)
+}
+
+// ValidateMergeEntryActivity validates information of a MergeEntryActivity API model.
+func ValidateMergeEntryActivity(data *vm.MergeEntryActivity) error {
+	return checkIdPositive("targetActivityId", data.TargetActivityId)
 }
 
 // --- Basic entry activity validation functions ---
@@ -36,6 +108,15 @@ func checkEntryActivityDescription(desc string) error {
 	return nil
 }
 
+func checkEntryActivityTypeIds(typeIds []int) error {
+	for _, typeId := range typeIds {
+		if err := checkIdPositive("typeIds", typeId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // --- Entry API model valdidation functions ---
 
 // ValidateCreateEntry validates information of a CreateEntryA API model.
@@ -162,3 +243,48 @@ func checkEntryLabel(label string) error {
 	}
 	return nil
 }
+
+// --- Rounding policy API model valdidation functions ---
+
+// ValidateProjectRoundingPolicy validates information of a ProjectRoundingPolicy API model.
+func ValidateProjectRoundingPolicy(data *vm.ProjectRoundingPolicy) error {
+	if err := checkStringNotEmpty("project", data.Project); err != nil {
+		return err
+	}
+	if err := checkEntryProject(data.Project); err != nil {
+		return err
+	}
+	return checkRoundingPolicyValues(data.Mode, data.IntervalMinutes, data.Scope)
+}
+
+// --- Basic rounding policy validation functions ---
+
+func checkRoundingPolicy(data *vm.RoundingPolicy) error {
+	// Rounding policy is optional
+	if data == nil {
+		return nil
+	}
+	return checkRoundingPolicyValues(data.Mode, data.IntervalMinutes, data.Scope)
+}
+
+func checkRoundingPolicyValues(mode string, intervalMinutes int, scope string) error {
+	if !slices.Contains(m.RoundingModes, mode) {
+		err := e.NewError(e.ValRoundingPolicyInvalid, fmt.Sprintf("'mode' must be one of: %s.",
+			strings.Join(m.RoundingModes, ", ")))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if intervalMinutes < 1 || intervalMinutes > 60 {
+		err := e.NewError(e.ValRoundingPolicyInvalid, "'intervalMinutes' must be between 1 and "+
+			"60.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if !slices.Contains(m.RoundingScopes, scope) {
+		err := e.NewError(e.ValRoundingPolicyInvalid, fmt.Sprintf("'scope' must be one of: %s.",
+			strings.Join(m.RoundingScopes, ", ")))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
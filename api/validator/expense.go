@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+
+	vm "kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Expense rate API model valdidation functions ---
+
+// ValidateCreateExpenseRate validates information of a CreateExpenseRate API model.
+func ValidateCreateExpenseRate(data *vm.CreateExpenseRate) error {
+	if !m.IsExpenseRateType(data.Type) {
+		err := e.NewError(e.ValExpenseTypeInvalid, fmt.Sprintf("Expense rate type '%s' is not "+
+			"valid.", data.Type))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return checkExpenseRate(data.Name, data.Amount, data.Currency)
+}
+
+// ValidateUpdateExpenseRate validates information of a UpdateExpenseRate API model.
+func ValidateUpdateExpenseRate(data *vm.UpdateExpenseRate) error {
+	return checkExpenseRate(data.Name, data.Amount, data.Currency)
+}
+
+// --- Basic expense rate validation functions ---
+
+func checkExpenseRate(name string, amount float64, currency string) error {
+	if err := checkStringNotEmpty("name", name); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("name", name, m.MaxLengthExpenseRateName); err != nil {
+		return err
+	}
+	if err := checkFloatNotNegativeOrZero("amount", amount); err != nil {
+		return err
+	}
+	return checkCurrency(currency)
+}
+
+// --- Expense API model valdidation functions ---
+
+// ValidateCreateExpense validates information of a CreateExpense API model.
+func ValidateCreateExpense(data *vm.CreateExpense) error {
+	return checkExpense(data.Type, data.RateId, data.Quantity, data.Amount, data.Currency,
+		data.Description)
+}
+
+// ValidateUpdateExpense validates information of a UpdateExpense API model.
+func ValidateUpdateExpense(data *vm.UpdateExpense) error {
+	return checkExpense(data.Type, data.RateId, data.Quantity, data.Amount, data.Currency,
+		data.Description)
+}
+
+// --- Basic expense validation functions ---
+
+func checkExpense(expType string, rateId int, quantity float64, amount float64, currency string,
+	desc string) error {
+	if !m.IsValidExpenseType(expType) {
+		err := e.NewError(e.ValExpenseTypeInvalid, fmt.Sprintf("Expense type '%s' is not valid.",
+			expType))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if m.IsExpenseRateType(expType) {
+		if err := checkIdPositive("rateId", rateId); err != nil {
+			return err
+		}
+		if err := checkFloatNotNegativeOrZero("quantity", quantity); err != nil {
+			return err
+		}
+	} else {
+		if err := checkFloatNotNegativeOrZero("amount", amount); err != nil {
+			return err
+		}
+		if err := checkCurrency(currency); err != nil {
+			return err
+		}
+	}
+	return checkStringNotTooLong("description", desc, m.MaxLengthExpenseDescription)
+}
+
+func checkCurrency(currency string) error {
+	r := regexp.MustCompile("^[A-Z]{3}$")
+	if !r.MatchString(currency) {
+		err := e.NewError(e.ValCurrencyInvalid, "'currency' must be an ISO 4217 currency code "+
+			"(e.g. 'EUR').")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
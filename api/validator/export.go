@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	vm "kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ValidateCreateExportProfile validates information of a CreateExportProfile API model.
+func ValidateCreateExportProfile(data *vm.CreateExportProfile) error {
+	return checkExportProfile(data.Name, data.Columns, data.Delimiter, data.DateFormat,
+		data.DurationUnit, data.DecimalSeparator, data.Language, data.LabelSeparator)
+}
+
+// ValidateUpdateExportProfile validates information of a UpdateExportProfile API model.
+func ValidateUpdateExportProfile(data *vm.UpdateExportProfile) error {
+	return checkExportProfile(data.Name, data.Columns, data.Delimiter, data.DateFormat,
+		data.DurationUnit, data.DecimalSeparator, data.Language, data.LabelSeparator)
+}
+
+func checkExportProfile(name string, columns []string, delimiter string, dateFormat string,
+	durationUnit string, decimalSeparator string, language string, labelSeparator string) error {
+	if err := checkStringNotEmpty("name", name); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("name", name, m.MaxLengthExportProfileName); err != nil {
+		return err
+	}
+	if err := checkExportColumns(columns); err != nil {
+		return err
+	}
+	if err := checkExportDelimiter(delimiter); err != nil {
+		return err
+	}
+	if err := checkExportDateFormat(dateFormat); err != nil {
+		return err
+	}
+	if err := checkExportDurationUnit(durationUnit); err != nil {
+		return err
+	}
+	if err := checkExportDecimalSeparator(decimalSeparator); err != nil {
+		return err
+	}
+	if err := checkExportLanguage(language); err != nil {
+		return err
+	}
+	return checkExportLabelSeparator(labelSeparator)
+}
+
+func checkExportColumns(columns []string) error {
+	if err := checkArrayLengthNotZero("columns", len(columns)); err != nil {
+		return err
+	}
+	found := make(map[string]bool)
+	for _, column := range columns {
+		if !m.IsValidExportColumn(column) {
+			err := e.NewError(e.ValExportColumnInvalid, fmt.Sprintf("Column '%s' is not valid.",
+				column))
+			log.Debug(err.StackTrace())
+			return err
+		}
+		if found[column] {
+			err := e.NewError(e.ValExportColumnInvalid, fmt.Sprintf("Column '%s' must not be "+
+				"used more than once.", column))
+			log.Debug(err.StackTrace())
+			return err
+		}
+		found[column] = true
+	}
+	return nil
+}
+
+func checkExportDelimiter(delimiter string) error {
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size == 0 || size != len(delimiter) || r == utf8.RuneError || r == '"' || r == '\r' ||
+		r == '\n' {
+		err := e.NewError(e.ValDelimiterInvalid, "'delimiter' must be a single character "+
+			"(except quotation marks and line breaks).")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkExportDateFormat(dateFormat string) error {
+	if err := checkStringNotTooLong("dateFormat", dateFormat,
+		m.MaxLengthExportDateFormat); err != nil {
+		return err
+	}
+	if !m.IsValidExportDateFormat(dateFormat) {
+		err := e.NewError(e.ValDateFormatInvalid, "'dateFormat' must only contain the tokens "+
+			"'yyyy', 'yy', 'MM', 'dd', 'HH', 'mm' and 'ss' and separator characters.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkExportDurationUnit(durationUnit string) error {
+	if durationUnit != m.ExportDurationUnitMinutes && durationUnit != m.ExportDurationUnitHours {
+		err := e.NewError(e.ValDurationUnitInvalid, fmt.Sprintf("'durationUnit' must be '%s' or "+
+			"'%s'.", m.ExportDurationUnitMinutes, m.ExportDurationUnitHours))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkExportDecimalSeparator(decimalSeparator string) error {
+	if decimalSeparator != m.ExportDecimalSeparatorPoint &&
+		decimalSeparator != m.ExportDecimalSeparatorComma {
+		err := e.NewError(e.ValDecimalSeparatorInvalid, fmt.Sprintf("'decimalSeparator' must be "+
+			"'%s' or '%s'.", m.ExportDecimalSeparatorPoint, m.ExportDecimalSeparatorComma))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkExportLanguage(language string) error {
+	if language != "" && !loc.IsLanguageAvailable(language) {
+		err := e.NewError(e.ValLanguageInvalid, fmt.Sprintf("Language '%s' is not available.",
+			language))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkExportLabelSeparator(labelSeparator string) error {
+	// Spaces are valid separators, so the separator is not trimmed
+	if len(labelSeparator) == 0 {
+		err := e.NewError(e.ValStringEmpty, "'labelSeparator' must not be empty.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return checkStringNotTooLong("labelSeparator", labelSeparator,
+		m.MaxLengthExportLabelSeparator)
+}
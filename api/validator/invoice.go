@@ -0,0 +1,92 @@
+package validator
+
+import (
+	vm "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// --- Client API model valdidation functions ---
+
+// ValidateCreateClient validates information of a CreateClient API model.
+func ValidateCreateClient(data *vm.CreateClient) error {
+	return checkClient(data.Name, data.Address, data.Currency, data.Projects)
+}
+
+// ValidateUpdateClient validates information of a UpdateClient API model.
+func ValidateUpdateClient(data *vm.UpdateClient) error {
+	return checkClient(data.Name, data.Address, data.Currency, data.Projects)
+}
+
+// --- Hourly rate API model valdidation functions ---
+
+// ValidateCreateHourlyRate validates information of a CreateHourlyRate API model.
+func ValidateCreateHourlyRate(data *vm.CreateHourlyRate) error {
+	return checkHourlyRate(data.UserId, data.Project, data.ActivityId, data.Amount, data.Currency,
+		data.ValidFrom, data.ValidTo)
+}
+
+// ValidateUpdateHourlyRate validates information of a UpdateHourlyRate API model.
+func ValidateUpdateHourlyRate(data *vm.UpdateHourlyRate) error {
+	return checkHourlyRate(data.UserId, data.Project, data.ActivityId, data.Amount, data.Currency,
+		data.ValidFrom, data.ValidTo)
+}
+
+// --- Invoice API model valdidation functions ---
+
+// ValidateCreateInvoice validates information of a CreateInvoice API model.
+func ValidateCreateInvoice(data *vm.CreateInvoice) error {
+	if err := checkIdPositive("clientId", data.ClientId); err != nil {
+		return err
+	}
+	if err := checkDateValid("startDate", data.StartDate); err != nil {
+		return err
+	}
+	return checkDateValid("endDate", data.EndDate)
+}
+
+// --- Basic validation functions ---
+
+func checkClient(name string, address string, currency string, projects []string) error {
+	if err := checkStringNotEmpty("name", name); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("name", name, m.MaxLengthClientName); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("address", address, m.MaxLengthClientAddress); err != nil {
+		return err
+	}
+	if err := checkCurrency(currency); err != nil {
+		return err
+	}
+	if err := checkStringArrayNotEmpty("projects", projects); err != nil {
+		return err
+	}
+	return checkStringArrayNotTooLong("projects", projects, m.MaxLengthEntryProjectName)
+}
+
+func checkHourlyRate(userId int, project string, activityId int, amount float64,
+	currency string, validFrom string, validTo string) error {
+	if err := checkIdZeroPositive("userId", userId); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("project", project, m.MaxLengthEntryProjectName); err != nil {
+		return err
+	}
+	if err := checkIdZeroPositive("activityId", activityId); err != nil {
+		return err
+	}
+	if err := checkFloatNotNegativeOrZero("amount", amount); err != nil {
+		return err
+	}
+	if err := checkCurrency(currency); err != nil {
+		return err
+	}
+	if err := checkDateValid("validFrom", validFrom); err != nil {
+		return err
+	}
+	if validTo != "" {
+		return checkDateValid("validTo", validTo)
+	}
+	return nil
+}
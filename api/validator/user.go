@@ -2,7 +2,9 @@ package validator
 
 import (
 	"fmt"
+	"net/mail"
 	"regexp"
+	"time"
 
 	vm "kellnhofer.com/work-log/api/model"
 	e "kellnhofer.com/work-log/pkg/error"
@@ -23,6 +25,9 @@ func ValidateCreateUser(data *vm.CreateUserData) error {
 	if err := checkUserPassword(data.Password); err != nil {
 		return err
 	}
+	if err := checkUserTimeZone(data.TimeZone); err != nil {
+		return err
+	}
 	return ValidateCreateContract(data.Contract)
 }
 
@@ -43,7 +48,16 @@ func ValidateCreateContract(data *vm.CreateContract) error {
 	if err := checkContractWorkingHours(data.WorkingHours); err != nil {
 		return err
 	}
-	return checkContractVacationDays(data.VacationDays)
+	if err := checkContractVacationDays(data.VacationDays); err != nil {
+		return err
+	}
+	if err := checkContractBreakRules(data.BreakRules); err != nil {
+		return err
+	}
+	if err := checkContractVacationRules(data.VacationRules); err != nil {
+		return err
+	}
+	return checkRoundingPolicy(data.RoundingPolicy)
 }
 
 // ValidateUpdateUser validates information of a UpdateUserData API model.
@@ -54,6 +68,9 @@ func ValidateUpdateUser(data *vm.UpdateUserData) error {
 	if err := checkUserUsername(data.Username); err != nil {
 		return err
 	}
+	if err := checkUserTimeZone(data.TimeZone); err != nil {
+		return err
+	}
 	return ValidateUpdateContract(data.Contract)
 }
 
@@ -74,7 +91,16 @@ func ValidateUpdateContract(data *vm.UpdateContract) error {
 	if err := checkContractWorkingHours(data.WorkingHours); err != nil {
 		return err
 	}
-	return checkContractVacationDays(data.VacationDays)
+	if err := checkContractVacationDays(data.VacationDays); err != nil {
+		return err
+	}
+	if err := checkContractBreakRules(data.BreakRules); err != nil {
+		return err
+	}
+	if err := checkContractVacationRules(data.VacationRules); err != nil {
+		return err
+	}
+	return checkRoundingPolicy(data.RoundingPolicy)
 }
 
 // ValidateUpdateUserPassword validates information of a UpdateUserPassword API model.
@@ -101,6 +127,11 @@ func ValidateUpdateUserRoles(data *vm.UpdateUserRoles) error {
 	return nil
 }
 
+// ValidateNotificationSettings validates information of a NotificationSettings API model.
+func ValidateNotificationSettings(data *vm.NotificationSettings) error {
+	return checkUserEmail(data.Email)
+}
+
 // --- Basic user validation functions ---
 
 func checkRole(role string) error {
@@ -151,6 +182,25 @@ func checkUserUsername(username string) error {
 	return nil
 }
 
+func checkUserTimeZone(timeZone string) error {
+	if len(timeZone) == 0 {
+		return nil
+	}
+	if len(timeZone) > m.MaxLengthUserTimeZone {
+		err := e.NewError(e.ValTimeZoneInvalid, fmt.Sprintf("'timeZone' must not be longer than "+
+			"%d.", m.MaxLengthUserTimeZone))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if _, lErr := time.LoadLocation(timeZone); lErr != nil {
+		err := e.WrapError(e.ValTimeZoneInvalid, "'timeZone' must be a valid IANA time zone name.",
+			lErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
 func checkUserPassword(password string) error {
 	if len(password) == 0 {
 		err := e.NewError(e.ValPasswordInvalid, "'password' must not be empty.")
@@ -178,6 +228,31 @@ func checkUserPassword(password string) error {
 	return nil
 }
 
+func checkUserEmail(email string) error {
+	// Empty email is allowed (no notifications will be sent)
+	if email == "" {
+		return nil
+	}
+	if len(email) > m.MaxLengthUserEmail {
+		err := e.NewError(e.ValEmailInvalid, fmt.Sprintf("'email' must not be longer than %d.",
+			m.MaxLengthUserEmail))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	addr, pErr := mail.ParseAddress(email)
+	if pErr != nil {
+		err := e.WrapError(e.ValEmailInvalid, "'email' is not a valid email address.", pErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if addr.Address != email {
+		err := e.NewError(e.ValEmailInvalid, "'email' must only contain the email address.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
 func checkContractFirstDay(date string) error {
 	return checkDateValid("firstDay", date)
 }
@@ -213,7 +288,7 @@ func checkContractDailyWorkingHours(data *vm.ContractWorkingHours) error {
 	if err := checkDateValid("firstDay", data.FirstDay); err != nil {
 		return err
 	}
-	return checkFloatNotNegativeOrZero("hours", data.Hours)
+	return checkFloatNotNegativeOrZero("hours", float64(data.Hours))
 }
 
 func checkContractVacationDays(data []*vm.ContractVacationDays) error {
@@ -239,3 +314,36 @@ func checkContractMonthlyVacationDays(data *vm.ContractVacationDays) error {
 	}
 	return checkFloatNotNegative("days", data.Days)
 }
+
+func checkContractBreakRules(data []*vm.ContractBreakRule) error {
+	// Break rules are optional
+	for _, br := range data {
+		if br == nil {
+			err := e.NewError(e.ValFieldNil, "Elements of 'breakRules' must not be null.")
+			log.Debug(err.StackTrace())
+			return err
+		}
+		if err := checkContractBreakRule(br); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkContractBreakRule(data *vm.ContractBreakRule) error {
+	if err := checkFloatNotNegativeOrZero("workHours", float64(data.WorkHours)); err != nil {
+		return err
+	}
+	return checkIntNotNegative("breakMinutes", data.BreakMinutes)
+}
+
+func checkContractVacationRules(data *vm.ContractVacationRules) error {
+	// Vacation rules are optional
+	if data == nil {
+		return nil
+	}
+	if err := checkFloatNotNegative("carryOverMaxDays", data.CarryOverMaxDays); err != nil {
+		return err
+	}
+	return checkIntNotNegative("expiryMonths", data.ExpiryMonths)
+}
@@ -0,0 +1,25 @@
+package validator
+
+import (
+	vm "kellnhofer.com/work-log/api/model"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ValidateCreateVacationRequest validates information of a CreateVacationRequest API model.
+func ValidateCreateVacationRequest(data *vm.CreateVacationRequest) error {
+	if err := checkDateValid("startDate", data.StartDate); err != nil {
+		return err
+	}
+	if err := checkDateValid("endDate", data.EndDate); err != nil {
+		return err
+	}
+	if err := checkAbsenceDayFraction(data.DayFraction); err != nil {
+		return err
+	}
+	return checkStringNotTooLong("description", data.Description, m.MaxLengthEntryDescription)
+}
+
+// ValidateVacationRequestDecision validates information of a VacationRequestDecision API model.
+func ValidateVacationRequestDecision(data *vm.VacationRequestDecision) error {
+	return checkStringNotTooLong("comment", data.Comment, m.MaxLengthEntryDescription)
+}
@@ -55,7 +55,7 @@ func checkIntNotNegativeOrZero(name string, num float32) error {
 	return nil
 }
 
-func checkFloatNotNegativeOrZero(name string, num float32) error {
+func checkFloatNotNegativeOrZero(name string, num float64) error {
 	if num <= 0 {
 		err := e.NewError(e.ValNumberNegativeOrZero, fmt.Sprintf("'%s' must be positive.", name))
 		log.Debug(err.StackTrace())
@@ -93,6 +93,15 @@ func checkNotNil(name string, obj any) error {
 	return nil
 }
 
+func checkBoolNotNil(name string, b *bool) error {
+	if b == nil {
+		err := e.NewError(e.ValFieldNil, fmt.Sprintf("'%s' must not be null.", name))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
 func checkArrayLengthNotZero(name string, length int) error {
 	if length == 0 {
 		err := e.NewError(e.ValArrayEmpty, fmt.Sprintf("'%s' must not be empty.", name))
@@ -128,7 +137,7 @@ func checkStringArrayNotTooLong(name string, strs []string, length int) error {
 }
 
 func checkDateValid(name string, date string) error {
-	_, pErr := time.ParseInLocation(constant.ApiDateFormat, date, time.Local)
+	_, pErr := time.ParseInLocation(constant.ApiDateFormat, date, time.UTC)
 	if pErr != nil {
 		err := e.WrapError(e.ValDateInvalid, fmt.Sprintf("'%s' must have format 'YYYY-MM-DD'.",
 			name), pErr)
@@ -139,10 +148,10 @@ func checkDateValid(name string, date string) error {
 }
 
 func checkTimestampValid(name string, timestamp string) error {
-	_, pErr := time.ParseInLocation(constant.ApiTimestampFormat, timestamp, time.Local)
+	_, pErr := time.Parse(constant.ApiTimestampFormat, timestamp)
 	if pErr != nil {
 		err := e.WrapError(e.ValTimestampInvalid, fmt.Sprintf("'%s' must have format "+
-			"'YYYY-MM-DDTHH:mm:ss'.", name), pErr)
+			"'YYYY-MM-DDTHH:mm:ss±hh:mm'.", name), pErr)
 		log.Error(err.StackTrace())
 		return err
 	}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"fmt"
+	"net/url"
+
+	vm "kellnhofer.com/work-log/api/model"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	m "kellnhofer.com/work-log/pkg/model"
+)
+
+// ValidateCreateWebhook validates information of a CreateWebhook API model.
+func ValidateCreateWebhook(data *vm.CreateWebhook) error {
+	return checkWebhook(data.Url, data.Secret, data.EventTypes, data.Active)
+}
+
+// ValidateUpdateWebhook validates information of a UpdateWebhook API model.
+func ValidateUpdateWebhook(data *vm.UpdateWebhook) error {
+	return checkWebhook(data.Url, data.Secret, data.EventTypes, data.Active)
+}
+
+func checkWebhook(url string, secret string, eventTypes []string, active *bool) error {
+	if err := checkWebhookUrl(url); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("secret", secret, m.MaxLengthWebhookSecret); err != nil {
+		return err
+	}
+	if err := checkWebhookEventTypes(eventTypes); err != nil {
+		return err
+	}
+	return checkBoolNotNil("active", active)
+}
+
+func checkWebhookUrl(u string) error {
+	if err := checkStringNotEmpty("url", u); err != nil {
+		return err
+	}
+	if err := checkStringNotTooLong("url", u, m.MaxLengthWebhookUrl); err != nil {
+		return err
+	}
+	pu, pErr := url.Parse(u)
+	if pErr != nil {
+		err := e.WrapError(e.ValUrlInvalid, "'url' is not a valid URL.", pErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if (pu.Scheme != "http" && pu.Scheme != "https") || pu.Host == "" {
+		err := e.NewError(e.ValUrlInvalid, "'url' must be an absolute HTTP or HTTPS URL.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func checkWebhookEventTypes(eventTypes []string) error {
+	if err := checkArrayLengthNotZero("eventTypes", len(eventTypes)); err != nil {
+		return err
+	}
+	for _, et := range eventTypes {
+		if !m.IsValidWebhookEventType(et) {
+			err := e.NewError(e.ValEventTypeInvalid, fmt.Sprintf("Event type '%s' is not valid.",
+				et))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+	return nil
+}
@@ -6,7 +6,11 @@ import (
 	"kellnhofer.com/work-log/pkg/config"
 	"kellnhofer.com/work-log/pkg/db"
 	"kellnhofer.com/work-log/pkg/db/tx"
+	"kellnhofer.com/work-log/pkg/mail"
+	"kellnhofer.com/work-log/pkg/model"
 	"kellnhofer.com/work-log/pkg/service"
+	"kellnhofer.com/work-log/pkg/storage"
+	"kellnhofer.com/work-log/pkg/webhook"
 	vc "kellnhofer.com/work-log/web/controller"
 	vm "kellnhofer.com/work-log/web/middleware"
 )
@@ -17,10 +21,25 @@ type Initializer struct {
 
 	db *db.Db
 
+	mailer        *mail.Mailer
+	webhookSender *webhook.Sender
+	storage       storage.Storage
+
 	entryServ *service.EntryService
 	tokenServ *service.TokenService
 	sessServ  *service.SessionService
 	userServ  *service.UserService
+	notiServ  *service.NotificationService
+	hookServ  *service.WebhookService
+	compServ  *service.ComplianceService
+	statServ  *service.StatisticsService
+	absServ   *service.AbsenceService
+	vacServ   *service.VacationService
+	adjServ   *service.AdjustmentService
+	closServ  *service.ClosingService
+	expServ   *service.ExpenseService
+	attServ   *service.AttachmentService
+	invServ   *service.InvoiceService
 	jobServ   *service.JobService
 
 	errVCtrl      *vc.ErrorController
@@ -30,11 +49,22 @@ type Initializer struct {
 	logVCtrl      *vc.LogController
 	overviewVCtrl *vc.OverviewController
 	searchVCtrl   *vc.SearchController
+	statVCtrl     *vc.StatisticsController
 	userVCtrl     *vc.UserController
 	entryACtrl    *ac.EntryController
 	exportACtrl   *ac.ExportController
 	tokenACtrl    *ac.TokenController
 	userACtrl     *ac.UserController
+	webhookACtrl  *ac.WebhookController
+	compACtrl     *ac.ComplianceController
+	statACtrl     *ac.StatisticsController
+	absACtrl      *ac.AbsenceController
+	vacACtrl      *ac.VacationController
+	adjACtrl      *ac.AdjustmentController
+	closACtrl     *ac.ClosingController
+	expACtrl      *ac.ExpenseController
+	attACtrl      *ac.AttachmentController
+	invACtrl      *ac.InvoiceController
 
 	txMidw    *tx.TransactionMiddleware
 	errVMidw  *vm.ErrorMiddleware
@@ -61,13 +91,44 @@ func (i *Initializer) GetDb() *db.Db {
 	return i.db
 }
 
+// --- Mail functions ---
+
+// GetMailer returns a initialized mailer object.
+func (i *Initializer) GetMailer() *mail.Mailer {
+	if i.mailer == nil {
+		i.mailer = mail.NewMailer(i.conf)
+	}
+	return i.mailer
+}
+
+// --- Webhook functions ---
+
+// GetWebhookSender returns a initialized webhook sender object.
+func (i *Initializer) GetWebhookSender() *webhook.Sender {
+	if i.webhookSender == nil {
+		i.webhookSender = webhook.NewSender(i.conf)
+	}
+	return i.webhookSender
+}
+
+// --- Storage functions ---
+
+// GetStorage returns a initialized storage object.
+func (i *Initializer) GetStorage() storage.Storage {
+	if i.storage == nil {
+		i.storage = storage.NewLocalStorage(i.conf)
+	}
+	return i.storage
+}
+
 // --- Service functions ---
 
 // GetEntryService returns a initialized entry service object.
 func (i *Initializer) GetEntryService() *service.EntryService {
 	if i.entryServ == nil {
 		i.entryServ = service.NewEntryService(i.GetDb().GetTransactionManager(),
-			i.GetDb().GetEntryRepo())
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo(), i.GetDb().GetContractRepo(),
+			i.GetDb().GetClosingRepo(), i.GetWebhookService())
 	}
 	return i.entryServ
 }
@@ -94,15 +155,131 @@ func (i *Initializer) GetTokenService() *service.TokenService {
 func (i *Initializer) GetUserService() *service.UserService {
 	if i.userServ == nil {
 		i.userServ = service.NewUserService(i.GetDb().GetTransactionManager(),
-			i.GetDb().GetUserRepo(), i.GetDb().GetContractRepo())
+			i.GetDb().GetUserRepo(), i.GetDb().GetContractRepo(), i.GetWebhookService())
 	}
 	return i.userServ
 }
 
+// GetNotificationService returns a initialized notification service object.
+func (i *Initializer) GetNotificationService() *service.NotificationService {
+	if i.notiServ == nil {
+		i.notiServ = service.NewNotificationService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetContractRepo(), i.GetDb().GetEntryRepo(),
+			i.GetMailer(), i.conf.ReminderMinHoursPercent, i.conf.ReminderCheckDays)
+	}
+	return i.notiServ
+}
+
+// GetWebhookService returns a initialized webhook service object.
+func (i *Initializer) GetWebhookService() *service.WebhookService {
+	if i.hookServ == nil {
+		i.hookServ = service.NewWebhookService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetWebhookRepo(), i.GetWebhookSender(), i.conf.WebhookMaxAttempts)
+	}
+	return i.hookServ
+}
+
+// GetComplianceService returns a initialized compliance service object.
+func (i *Initializer) GetComplianceService() *service.ComplianceService {
+	if i.compServ == nil {
+		i.compServ = service.NewComplianceService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo(), &model.ComplianceSettings{
+				MaxDailyHours:   i.conf.ComplianceMaxDailyHours,
+				MaxWeeklyHours:  i.conf.ComplianceMaxWeeklyHours,
+				MinRestHours:    i.conf.ComplianceMinRestHours,
+				CheckSundayWork: i.conf.ComplianceCheckSundayWork,
+				NightStartHour:  i.conf.ComplianceNightStartHour,
+				NightEndHour:    i.conf.ComplianceNightEndHour,
+			})
+	}
+	return i.compServ
+}
+
+// GetStatisticsService returns a initialized statistics service object.
+func (i *Initializer) GetStatisticsService() *service.StatisticsService {
+	if i.statServ == nil {
+		i.statServ = service.NewStatisticsService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo())
+	}
+	return i.statServ
+}
+
+// GetAbsenceService returns a initialized absence service object.
+func (i *Initializer) GetAbsenceService() *service.AbsenceService {
+	if i.absServ == nil {
+		i.absServ = service.NewAbsenceService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetAbsenceRepo(), i.GetDb().GetEntryRepo(),
+			i.GetDb().GetContractRepo(), i.GetDb().GetClosingRepo(), i.GetWebhookService())
+	}
+	return i.absServ
+}
+
+// GetVacationService returns a initialized vacation service object.
+func (i *Initializer) GetVacationService() *service.VacationService {
+	if i.vacServ == nil {
+		i.vacServ = service.NewVacationService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetVacationRepo(), i.GetDb().GetEntryRepo(),
+			i.GetDb().GetContractRepo(), i.GetDb().GetAdjustmentRepo(), i.GetDb().GetClosingRepo(),
+			i.GetAbsenceService())
+	}
+	return i.vacServ
+}
+
+// GetAdjustmentService returns a initialized adjustment service object.
+func (i *Initializer) GetAdjustmentService() *service.AdjustmentService {
+	if i.adjServ == nil {
+		i.adjServ = service.NewAdjustmentService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetAdjustmentRepo(), i.GetDb().GetUserRepo(), i.GetDb().GetClosingRepo())
+	}
+	return i.adjServ
+}
+
+// GetClosingService returns a initialized closing service object.
+func (i *Initializer) GetClosingService() *service.ClosingService {
+	if i.closServ == nil {
+		i.closServ = service.NewClosingService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetClosingRepo(), i.GetDb().GetContractRepo(), i.GetDb().GetAdjustmentRepo(),
+			i.GetEntryService(), i.GetVacationService())
+	}
+	return i.closServ
+}
+
+// GetExpenseService returns a initialized expense service object.
+func (i *Initializer) GetExpenseService() *service.ExpenseService {
+	if i.expServ == nil {
+		i.expServ = service.NewExpenseService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo(), i.GetDb().GetExpenseRepo(),
+			i.GetDb().GetClosingRepo())
+	}
+	return i.expServ
+}
+
+// GetAttachmentService returns a initialized attachment service object.
+func (i *Initializer) GetAttachmentService() *service.AttachmentService {
+	if i.attServ == nil {
+		i.attServ = service.NewAttachmentService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo(), i.GetDb().GetClosingRepo(),
+			i.GetDb().GetAttachmentRepo(), i.GetStorage(), i.conf.AttachmentMaxSize,
+			i.conf.AttachmentMimeTypes)
+	}
+	return i.attServ
+}
+
+// GetInvoiceService returns a initialized invoice service object.
+func (i *Initializer) GetInvoiceService() *service.InvoiceService {
+	if i.invServ == nil {
+		i.invServ = service.NewInvoiceService(i.GetDb().GetTransactionManager(),
+			i.GetDb().GetUserRepo(), i.GetDb().GetEntryRepo(), i.GetDb().GetContractRepo(),
+			i.GetDb().GetInvoiceRepo(), i.conf.InvoiceTaxRate)
+	}
+	return i.invServ
+}
+
 // GetJobService returns a initialized job service object.
 func (i *Initializer) GetJobService() *service.JobService {
 	if i.jobServ == nil {
-		i.jobServ = service.NewJobService(i.GetSessionService())
+		i.jobServ = service.NewJobService(i.GetSessionService(), i.GetNotificationService(),
+			i.GetWebhookService(), i.GetAttachmentService())
 	}
 	return i.jobServ
 }
@@ -128,7 +305,8 @@ func (i *Initializer) GetAuthViewController() *vc.AuthController {
 // GetEntryViewController returns a initialized entry view controller object.
 func (i *Initializer) GetEntryViewController() *vc.EntryController {
 	if i.entryVCtrl == nil {
-		i.entryVCtrl = vc.NewEntryController(i.GetUserService(), i.GetEntryService())
+		i.entryVCtrl = vc.NewEntryController(i.GetUserService(), i.GetEntryService(),
+			i.GetAbsenceService(), i.GetAttachmentService())
 	}
 	return i.entryVCtrl
 }
@@ -144,7 +322,9 @@ func (i *Initializer) GetExportViewController() *vc.ExportController {
 // GetLogViewController returns a initialized log view controller object.
 func (i *Initializer) GetLogViewController() *vc.LogController {
 	if i.logVCtrl == nil {
-		i.logVCtrl = vc.NewLogController(i.GetUserService(), i.GetEntryService())
+		i.logVCtrl = vc.NewLogController(i.GetUserService(), i.GetEntryService(),
+			i.GetComplianceService(), i.GetVacationService(), i.GetClosingService(),
+			i.GetAttachmentService())
 	}
 	return i.logVCtrl
 }
@@ -152,7 +332,9 @@ func (i *Initializer) GetLogViewController() *vc.LogController {
 // GetOverviewViewController returns a initialized overview view controller object.
 func (i *Initializer) GetOverviewViewController() *vc.OverviewController {
 	if i.overviewVCtrl == nil {
-		i.overviewVCtrl = vc.NewOverviewController(i.GetUserService(), i.GetEntryService())
+		i.overviewVCtrl = vc.NewOverviewController(i.GetUserService(), i.GetEntryService(),
+			i.GetExpenseService(), i.GetClosingService(), i.conf.TimesheetHeader,
+			i.conf.TimesheetLogo, i.conf.TimesheetSignatureLines)
 	}
 	return i.overviewVCtrl
 }
@@ -160,15 +342,26 @@ func (i *Initializer) GetOverviewViewController() *vc.OverviewController {
 // GetSearchViewController returns a initialized search view controller object.
 func (i *Initializer) GetSearchViewController() *vc.SearchController {
 	if i.searchVCtrl == nil {
-		i.searchVCtrl = vc.NewSearchController(i.GetUserService(), i.GetEntryService())
+		i.searchVCtrl = vc.NewSearchController(i.GetUserService(), i.GetEntryService(),
+			i.GetAttachmentService())
 	}
 	return i.searchVCtrl
 }
 
+// GetStatisticsViewController returns a initialized statistics view controller object.
+func (i *Initializer) GetStatisticsViewController() *vc.StatisticsController {
+	if i.statVCtrl == nil {
+		i.statVCtrl = vc.NewStatisticsController(i.GetUserService(), i.GetEntryService(),
+			i.GetStatisticsService())
+	}
+	return i.statVCtrl
+}
+
 // GetUserViewController returns a initialized user view controller object.
 func (i *Initializer) GetUserViewController() *vc.UserController {
 	if i.userVCtrl == nil {
-		i.userVCtrl = vc.NewUserController(i.GetUserService())
+		i.userVCtrl = vc.NewUserController(i.GetUserService(), i.GetVacationService(),
+			i.GetAdjustmentService())
 	}
 	return i.userVCtrl
 }
@@ -186,7 +379,8 @@ func (i *Initializer) GetEntryApiController() *ac.EntryController {
 // GetExportApiController returns a initialized export API controller object.
 func (i *Initializer) GetExportApiController() *ac.ExportController {
 	if i.exportACtrl == nil {
-		i.exportACtrl = ac.NewExportController(i.GetEntryService())
+		i.exportACtrl = ac.NewExportController(i.GetUserService(), i.GetEntryService(),
+			i.conf.TimesheetHeader, i.conf.TimesheetLogo, i.conf.TimesheetSignatureLines)
 	}
 	return i.exportACtrl
 }
@@ -207,6 +401,87 @@ func (i *Initializer) GetUserApiController() *ac.UserController {
 	return i.userACtrl
 }
 
+// GetWebhookApiController returns a initialized webhook API controller object.
+func (i *Initializer) GetWebhookApiController() *ac.WebhookController {
+	if i.webhookACtrl == nil {
+		i.webhookACtrl = ac.NewWebhookController(i.GetWebhookService())
+	}
+	return i.webhookACtrl
+}
+
+// GetComplianceApiController returns a initialized compliance API controller object.
+func (i *Initializer) GetComplianceApiController() *ac.ComplianceController {
+	if i.compACtrl == nil {
+		i.compACtrl = ac.NewComplianceController(i.GetComplianceService())
+	}
+	return i.compACtrl
+}
+
+// GetStatisticsApiController returns a initialized statistics API controller object.
+func (i *Initializer) GetStatisticsApiController() *ac.StatisticsController {
+	if i.statACtrl == nil {
+		i.statACtrl = ac.NewStatisticsController(i.GetStatisticsService())
+	}
+	return i.statACtrl
+}
+
+// GetAbsenceApiController returns a initialized absence API controller object.
+func (i *Initializer) GetAbsenceApiController() *ac.AbsenceController {
+	if i.absACtrl == nil {
+		i.absACtrl = ac.NewAbsenceController(i.GetAbsenceService())
+	}
+	return i.absACtrl
+}
+
+// GetVacationApiController returns a initialized vacation API controller object.
+func (i *Initializer) GetVacationApiController() *ac.VacationController {
+	if i.vacACtrl == nil {
+		i.vacACtrl = ac.NewVacationController(i.GetVacationService())
+	}
+	return i.vacACtrl
+}
+
+// GetAdjustmentApiController returns a initialized adjustment API controller object.
+func (i *Initializer) GetAdjustmentApiController() *ac.AdjustmentController {
+	if i.adjACtrl == nil {
+		i.adjACtrl = ac.NewAdjustmentController(i.GetAdjustmentService())
+	}
+	return i.adjACtrl
+}
+
+// GetClosingApiController returns a initialized closing API controller object.
+func (i *Initializer) GetClosingApiController() *ac.ClosingController {
+	if i.closACtrl == nil {
+		i.closACtrl = ac.NewClosingController(i.GetClosingService())
+	}
+	return i.closACtrl
+}
+
+// GetExpenseApiController returns a initialized expense API controller object.
+func (i *Initializer) GetExpenseApiController() *ac.ExpenseController {
+	if i.expACtrl == nil {
+		i.expACtrl = ac.NewExpenseController(i.GetExpenseService())
+	}
+	return i.expACtrl
+}
+
+// GetAttachmentApiController returns a initialized attachment API controller object.
+func (i *Initializer) GetAttachmentApiController() *ac.AttachmentController {
+	if i.attACtrl == nil {
+		i.attACtrl = ac.NewAttachmentController(i.GetAttachmentService())
+	}
+	return i.attACtrl
+}
+
+// GetInvoiceApiController returns a initialized invoice API controller object.
+func (i *Initializer) GetInvoiceApiController() *ac.InvoiceController {
+	if i.invACtrl == nil {
+		i.invACtrl = ac.NewInvoiceController(i.GetInvoiceService(), i.conf.InvoiceIssuerName,
+			i.conf.InvoiceIssuerAddress, i.conf.InvoicePaymentDays)
+	}
+	return i.invACtrl
+}
+
 // --- General middleware functions ---
 
 // GetTransactionMiddleware returns a initialized transaction middleware object.
@@ -80,6 +80,7 @@ func addViewHandlers(init *Initializer, e *echo.Echo) {
 	logCtrl := init.GetLogViewController()
 	overviewCtrl := init.GetOverviewViewController()
 	searchCtrl := init.GetSearchViewController()
+	statisticsCtrl := init.GetStatisticsViewController()
 	userVCtrl := init.GetUserViewController()
 
 	// General handlers
@@ -112,6 +113,15 @@ func addViewHandlers(init *Initializer, e *echo.Echo) {
 	e.GET("/overview/export", overviewCtrl.GetOverviewExportHandler(), proRoute...)
 	e.GET("/hx/overview", overviewCtrl.GetHxNavHandler(), proRoute...)
 	e.GET("/hx/overview/content", overviewCtrl.GetHxContentHandler(), proRoute...)
+	e.GET("/overview/year", overviewCtrl.GetOverviewYearHandler(), proRoute...)
+	e.GET("/overview/year/export", overviewCtrl.GetOverviewYearExportHandler(), proRoute...)
+	e.GET("/hx/overview/year", overviewCtrl.GetHxYearNavHandler(), proRoute...)
+	e.GET("/hx/overview/year/content", overviewCtrl.GetHxYearContentHandler(), proRoute...)
+
+	// Statistics related handlers
+	e.GET("/statistics", statisticsCtrl.GetStatisticsHandler(), proRoute...)
+	e.GET("/hx/statistics", statisticsCtrl.GetHxNavHandler(), proRoute...)
+	e.GET("/hx/statistics/content", statisticsCtrl.GetHxContentHandler(), proRoute...)
 
 	// Entry modal related handlers
 	e.GET("/hx/entry-modal/activities", entryCtrl.GetHxActivitiesHandler(), proRoute...)
@@ -122,8 +132,22 @@ func addViewHandlers(init *Initializer, e *echo.Echo) {
 	e.POST("/hx/entry-modal/edit/:id", entryCtrl.PostHxEditHandler(), proRoute...)
 	e.GET("/hx/entry-modal/delete/:id", entryCtrl.GetHxDeleteHandler(), proRoute...)
 	e.POST("/hx/entry-modal/delete/:id", entryCtrl.PostHxDeleteHandler(), proRoute...)
+	e.GET("/hx/entry-modal/create-absence", entryCtrl.GetHxCreateAbsenceHandler(), proRoute...)
+	e.POST("/hx/entry-modal/create-absence", entryCtrl.PostHxCreateAbsenceHandler(), proRoute...)
+	e.GET("/hx/entry-modal/edit-absence/:id", entryCtrl.GetHxEditAbsenceHandler(), proRoute...)
+	e.POST("/hx/entry-modal/edit-absence/:id", entryCtrl.PostHxEditAbsenceHandler(), proRoute...)
+	e.GET("/hx/entry-modal/delete-absence/:id", entryCtrl.GetHxDeleteAbsenceHandler(), proRoute...)
+	e.POST("/hx/entry-modal/delete-absence/:id", entryCtrl.PostHxDeleteAbsenceHandler(),
+		proRoute...)
+	e.POST("/hx/entry-modal/attachments/:id", entryCtrl.PostHxCreateAttachmentHandler(),
+		proRoute...)
+	e.POST("/hx/entry-modal/delete-attachment/:id", entryCtrl.PostHxDeleteAttachmentHandler(),
+		proRoute...)
 	e.POST("/hx/entry-modal/cancel", entryCtrl.PostHxCancelHandler(), proRoute...)
 
+	// Attachment related handlers
+	e.GET("/attachment/:id", entryCtrl.GetAttachmentHandler(), proRoute...)
+
 	// User profile related handlers
 	e.GET("/hx/user-profile-modal", userVCtrl.GetHxUserProfileModalHandler(), proRoute...)
 	e.POST("/hx/user-profile-modal/close", userVCtrl.PostHxUserProfileModalCloseHandler(), proRoute...)
@@ -160,22 +184,89 @@ func addApiHandlers(init *Initializer, e *echo.Echo) {
 	exportCtrl := init.GetExportApiController()
 	tokenCtrl := init.GetTokenApiController()
 	userCtrl := init.GetUserApiController()
+	webhookCtrl := init.GetWebhookApiController()
+	complianceCtrl := init.GetComplianceApiController()
+	statisticsCtrl := init.GetStatisticsApiController()
+	absenceCtrl := init.GetAbsenceApiController()
+	vacationCtrl := init.GetVacationApiController()
+	adjustmentCtrl := init.GetAdjustmentApiController()
+	closingCtrl := init.GetClosingApiController()
+	expenseCtrl := init.GetExpenseApiController()
+	attachmentCtrl := init.GetAttachmentApiController()
+	invoiceCtrl := init.GetInvoiceApiController()
 
 	// Register protected handlers
 	g.GET("/entries", entryCtrl.GetEntriesHandler())
+	g.GET("/entries/changes", entryCtrl.GetEntryChangesHandler())
 	g.POST("/entries", entryCtrl.CreateEntryHandler())
 	g.GET("/entries/:id", entryCtrl.GetEntryHandler())
 	g.PUT("/entries/:id", entryCtrl.UpdateEntryHandler())
 	g.DELETE("/entries/:id", entryCtrl.DeleteEntryHandler())
+	g.GET("/entries/:id/expenses", expenseCtrl.GetEntryExpensesHandler())
+	g.POST("/entries/:id/expenses", expenseCtrl.CreateEntryExpenseHandler())
+	g.GET("/entries/:id/attachments", attachmentCtrl.GetEntryAttachmentsHandler())
+	g.POST("/entries/:id/attachments", attachmentCtrl.CreateEntryAttachmentHandler())
+	g.GET("/attachments/:id", attachmentCtrl.GetAttachmentHandler())
+	g.GET("/attachments/:id/content", attachmentCtrl.GetAttachmentContentHandler())
+	g.DELETE("/attachments/:id", attachmentCtrl.DeleteAttachmentHandler())
+	g.GET("/expenses/:id", expenseCtrl.GetExpenseHandler())
+	g.PUT("/expenses/:id", expenseCtrl.UpdateExpenseHandler())
+	g.DELETE("/expenses/:id", expenseCtrl.DeleteExpenseHandler())
+	g.POST("/absences", absenceCtrl.CreateAbsenceHandler())
+	g.GET("/absences/:id", absenceCtrl.GetAbsenceHandler())
+	g.PUT("/absences/:id", absenceCtrl.UpdateAbsenceHandler())
+	g.DELETE("/absences/:id", absenceCtrl.DeleteAbsenceHandler())
+	g.GET("/vacation_requests", vacationCtrl.GetVacationRequestsHandler())
+	g.POST("/vacation_requests", vacationCtrl.CreateVacationRequestHandler())
+	g.GET("/vacation_requests/:id", vacationCtrl.GetVacationRequestHandler())
+	g.DELETE("/vacation_requests/:id", vacationCtrl.DeleteVacationRequestHandler())
+	g.POST("/vacation_requests/:id/approve", vacationCtrl.ApproveVacationRequestHandler())
+	g.POST("/vacation_requests/:id/deny", vacationCtrl.DenyVacationRequestHandler())
+	g.GET("/balance_adjustments/:id", adjustmentCtrl.GetBalanceAdjustmentHandler())
+	g.DELETE("/balance_adjustments/:id", adjustmentCtrl.DeleteBalanceAdjustmentHandler())
 	g.GET("/entry_types", entryCtrl.GetEntryTypesHandler())
+	g.POST("/entry_types", entryCtrl.CreateEntryTypeHandler())
+	g.PUT("/entry_types/:id", entryCtrl.UpdateEntryTypeHandler())
+	g.DELETE("/entry_types/:id", entryCtrl.DeleteEntryTypeHandler())
 	g.GET("/entry_activities", entryCtrl.GetEntryActivitiesHandler())
 	g.POST("/entry_activities", entryCtrl.CreateEntryActivityHandler())
 	g.PUT("/entry_activities/:id", entryCtrl.UpdateEntryActivityHandler())
 	g.DELETE("/entry_activities/:id", entryCtrl.DeleteEntryActivityHandler())
+	g.POST("/entry_activities/:id/merge", entryCtrl.MergeEntryActivityHandler())
+	g.GET("/expense_rates", expenseCtrl.GetExpenseRatesHandler())
+	g.POST("/expense_rates", expenseCtrl.CreateExpenseRateHandler())
+	g.PUT("/expense_rates/:id", expenseCtrl.UpdateExpenseRateHandler())
+	g.DELETE("/expense_rates/:id", expenseCtrl.DeleteExpenseRateHandler())
+	g.GET("/clients", invoiceCtrl.GetClientsHandler())
+	g.POST("/clients", invoiceCtrl.CreateClientHandler())
+	g.GET("/clients/:id", invoiceCtrl.GetClientHandler())
+	g.PUT("/clients/:id", invoiceCtrl.UpdateClientHandler())
+	g.DELETE("/clients/:id", invoiceCtrl.DeleteClientHandler())
+	g.GET("/hourly_rates", invoiceCtrl.GetHourlyRatesHandler())
+	g.POST("/hourly_rates", invoiceCtrl.CreateHourlyRateHandler())
+	g.PUT("/hourly_rates/:id", invoiceCtrl.UpdateHourlyRateHandler())
+	g.DELETE("/hourly_rates/:id", invoiceCtrl.DeleteHourlyRateHandler())
+	g.GET("/invoices", invoiceCtrl.GetInvoicesHandler())
+	g.POST("/invoices", invoiceCtrl.CreateInvoiceHandler())
+	g.GET("/invoices/:id", invoiceCtrl.GetInvoiceHandler())
+	g.DELETE("/invoices/:id", invoiceCtrl.DeleteInvoiceHandler())
+	g.GET("/invoices/:id/pdf", invoiceCtrl.GetInvoicePdfHandler())
+	g.GET("/invoices/:id/xlsx", invoiceCtrl.GetInvoiceXlsxHandler())
+	g.GET("/project_rounding_policies", entryCtrl.GetProjectRoundingPoliciesHandler())
+	g.PUT("/project_rounding_policies", entryCtrl.SetProjectRoundingPolicyHandler())
+	g.DELETE("/project_rounding_policies", entryCtrl.DeleteProjectRoundingPolicyHandler())
 	g.GET("/export", exportCtrl.GetExportHandler())
+	g.GET("/users/:id/timesheet", exportCtrl.GetUserTimesheetHandler())
 	g.GET("/user", userCtrl.GetCurrentUserHandler())
 	g.PUT("/user/password", userCtrl.UpdateCurrentUserPasswordHandler())
 	g.GET("/user/roles", userCtrl.GetCurrentUserRolesHandler())
+	g.GET("/user/notification_settings", userCtrl.GetCurrentUserNotificationSettingsHandler())
+	g.PUT("/user/notification_settings", userCtrl.UpdateCurrentUserNotificationSettingsHandler())
+	g.GET("/user/export_profiles", userCtrl.GetCurrentUserExportProfilesHandler())
+	g.POST("/user/export_profiles", userCtrl.CreateCurrentUserExportProfileHandler())
+	g.GET("/user/export_profiles/:id", userCtrl.GetCurrentUserExportProfileHandler())
+	g.PUT("/user/export_profiles/:id", userCtrl.UpdateCurrentUserExportProfileHandler())
+	g.DELETE("/user/export_profiles/:id", userCtrl.DeleteCurrentUserExportProfileHandler())
 	g.GET("/users", userCtrl.GetUsersHandler())
 	g.POST("/users", userCtrl.CreateUserHandler())
 	g.GET("/users/:id", userCtrl.GetUserHandler())
@@ -184,10 +275,29 @@ func addApiHandlers(init *Initializer, e *echo.Echo) {
 	g.PUT("/users/:id/password", userCtrl.UpdateUserPasswordHandler())
 	g.GET("/users/:id/roles", userCtrl.GetUserRolesHandler())
 	g.PUT("/users/:id/roles", userCtrl.UpdateUserRolesHandler())
+	g.GET("/users/:id/compliance", complianceCtrl.GetUserComplianceHandler())
+	g.GET("/users/:id/vacation_balance", vacationCtrl.GetUserVacationBalanceHandler())
+	g.GET("/users/:id/vacation_ledger", vacationCtrl.GetUserVacationLedgerHandler())
+	g.GET("/users/:id/vacation_requests", vacationCtrl.GetUserVacationRequestsHandler())
+	g.GET("/users/:id/balance_adjustments", adjustmentCtrl.GetUserBalanceAdjustmentsHandler())
+	g.POST("/users/:id/balance_adjustments", adjustmentCtrl.CreateUserBalanceAdjustmentHandler())
+	g.GET("/users/:id/expense_report", expenseCtrl.GetUserExpenseReportHandler())
+	g.GET("/users/:id/year_closings", closingCtrl.GetUserYearClosingsHandler())
+	g.POST("/users/:id/year_closings/:year", closingCtrl.CloseUserYearHandler())
+	g.DELETE("/users/:id/year_closings/:year", closingCtrl.ReopenUserYearHandler())
+	g.GET("/compliance/report", complianceCtrl.GetComplianceReportHandler())
+	g.GET("/statistics", statisticsCtrl.GetStatisticsHandler())
 	g.GET("/user/tokens", tokenCtrl.GetTokensHandler())
 	g.POST("/user/tokens", tokenCtrl.CreateTokenHandler())
 	g.GET("/user/tokens/:id", tokenCtrl.GetTokenHandler())
 	g.DELETE("/user/tokens/:id", tokenCtrl.DeleteTokenHandler())
+	g.GET("/webhooks", webhookCtrl.GetWebhooksHandler())
+	g.POST("/webhooks", webhookCtrl.CreateWebhookHandler())
+	g.GET("/webhooks/:id", webhookCtrl.GetWebhookHandler())
+	g.PUT("/webhooks/:id", webhookCtrl.UpdateWebhookHandler())
+	g.DELETE("/webhooks/:id", webhookCtrl.DeleteWebhookHandler())
+	g.GET("/webhooks/:id/deliveries", webhookCtrl.GetWebhookDeliveriesHandler())
+	g.POST("/webhooks/:id/test", webhookCtrl.TestWebhookHandler())
 }
 
 func addSwaggerUiHandlers(e *echo.Echo) {
@@ -16,6 +16,39 @@ type Config struct {
 	DbUsername  string
 	DbPassword  string
 	LocLanguage string
+
+	MailEnabled  bool
+	MailHost     string
+	MailPort     int
+	MailUsername string
+	MailPassword string
+	MailFrom     string
+
+	ReminderMinHoursPercent int
+	ReminderCheckDays       int
+
+	WebhookTimeout     int
+	WebhookMaxAttempts int
+
+	ComplianceMaxDailyHours   int
+	ComplianceMaxWeeklyHours  int
+	ComplianceMinRestHours    int
+	ComplianceCheckSundayWork bool
+	ComplianceNightStartHour  int
+	ComplianceNightEndHour    int
+
+	AttachmentDir       string
+	AttachmentMaxSize   int
+	AttachmentMimeTypes []string
+
+	InvoiceIssuerName    string
+	InvoiceIssuerAddress []string
+	InvoiceTaxRate       int
+	InvoicePaymentDays   int
+
+	TimesheetHeader         string
+	TimesheetLogo           string
+	TimesheetSignatureLines bool
 }
 
 // LoadConfig loads the configuration from "/config/config.ini".
@@ -46,14 +79,57 @@ func loadConfig(name string) *Config {
 
 	locLanguage := getStringValue(cfg, "localization", "language")
 
+	mailEnabled := getBoolValue(cfg, "mail", "enabled")
+	mailHost := getStringValue(cfg, "mail", "host")
+	mailPort := getIntValue(cfg, "mail", "port")
+	mailUsername := getStringValue(cfg, "mail", "username")
+	mailPassword := getStringValue(cfg, "mail", "password")
+	mailFrom := getStringValue(cfg, "mail", "from")
+
+	reminderMinHoursPercent := getIntValue(cfg, "reminder", "min_hours_percent")
+	reminderCheckDays := getIntValue(cfg, "reminder", "check_days")
+
+	webhookTimeout := getIntValue(cfg, "webhook", "timeout")
+	webhookMaxAttempts := getIntValue(cfg, "webhook", "max_attempts")
+
+	complianceMaxDailyHours := getIntValue(cfg, "compliance", "max_daily_hours")
+	complianceMaxWeeklyHours := getIntValue(cfg, "compliance", "max_weekly_hours")
+	complianceMinRestHours := getIntValue(cfg, "compliance", "min_rest_hours")
+	complianceCheckSundayWork := getBoolValue(cfg, "compliance", "check_sunday_work")
+	complianceNightStartHour := getIntValue(cfg, "compliance", "night_start_hour")
+	complianceNightEndHour := getIntValue(cfg, "compliance", "night_end_hour")
+
+	attachmentDir := getStringValue(cfg, "attachment", "dir")
+	attachmentMaxSize := getIntValue(cfg, "attachment", "max_size")
+	attachmentMimeTypes := getStringsValue(cfg, "attachment", "mime_types")
+
+	invoiceIssuerName := getStringValue(cfg, "invoice", "issuer_name")
+	invoiceIssuerAddress := getStringsValue(cfg, "invoice", "issuer_address")
+	invoiceTaxRate := getIntValue(cfg, "invoice", "tax_rate")
+	invoicePaymentDays := getIntValue(cfg, "invoice", "payment_days")
+
+	timesheetHeader := getStringValue(cfg, "timesheet", "header")
+	timesheetLogo := getStringValue(cfg, "timesheet", "logo")
+	timesheetSignatureLines := getBoolValue(cfg, "timesheet", "signature_lines")
+
 	return &Config{serverPort, logLevel, dbHost, dbPort, dbScheme, dbUsername, dbPassword,
-		locLanguage}
+		locLanguage, mailEnabled, mailHost, mailPort, mailUsername, mailPassword, mailFrom,
+		reminderMinHoursPercent, reminderCheckDays, webhookTimeout, webhookMaxAttempts,
+		complianceMaxDailyHours, complianceMaxWeeklyHours, complianceMinRestHours,
+		complianceCheckSundayWork, complianceNightStartHour, complianceNightEndHour,
+		attachmentDir, attachmentMaxSize, attachmentMimeTypes, invoiceIssuerName,
+		invoiceIssuerAddress, invoiceTaxRate, invoicePaymentDays, timesheetHeader, timesheetLogo,
+		timesheetSignatureLines}
 }
 
 func getStringValue(file *ini.File, secName string, keyName string) string {
 	return getKey(file, secName, keyName).String()
 }
 
+func getStringsValue(file *ini.File, secName string, keyName string) []string {
+	return getKey(file, secName, keyName).Strings(",")
+}
+
 func getIntValue(file *ini.File, secName string, keyName string) int {
 	val, err := getKey(file, secName, keyName).Int()
 	if err != nil {
@@ -62,6 +138,14 @@ func getIntValue(file *ini.File, secName string, keyName string) int {
 	return val
 }
 
+func getBoolValue(file *ini.File, secName string, keyName string) bool {
+	val, err := getKey(file, secName, keyName).Bool()
+	if err != nil {
+		log.Fatalf("Config file has invalid value for key '%s'!", keyName)
+	}
+	return val
+}
+
 func getKey(file *ini.File, secName string, keyName string) *ini.Key {
 	sec, err := file.GetSection(secName)
 	if err != nil {
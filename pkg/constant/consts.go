@@ -11,7 +11,7 @@ const (
 	DbTimestampFormat string = "2006-01-02 15:04:05"
 
 	ApiDateFormat      string = "2006-01-02"
-	ApiTimestampFormat string = "2006-01-02T15:04:05"
+	ApiTimestampFormat string = time.RFC3339
 
 	ExportTimestampFormat  string = "20060102-150405"
 	ExportFileNameTemplate string = "work-log-export-%s.%s"
@@ -17,7 +17,7 @@ import (
 	"kellnhofer.com/work-log/pkg/log"
 )
 
-const curDbVers = 7
+const curDbVers = 25
 
 // Db abstracts the database access and provides repositories execute CRUD operations.
 type Db struct {
@@ -30,20 +30,30 @@ type Db struct {
 	sRepo *repo.SessionRepo
 	tRepo *repo.TokenRepo
 	eRepo *repo.EntryRepo
+	wRepo *repo.WebhookRepo
+	aRepo *repo.AbsenceRepo
+	vRepo *repo.VacationRepo
+	bRepo *repo.AdjustmentRepo
+	yRepo *repo.ClosingRepo
+	xRepo *repo.ExpenseRepo
+	fRepo *repo.AttachmentRepo
+	iRepo *repo.InvoiceRepo
 }
 
 // NewDb creates a new Db for the supplied configuration.
 func NewDb(config *config.Config) *Db {
-	return &Db{config, nil, nil, nil, nil, nil, nil, nil}
+	return &Db{config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}
 }
 
 // --- Public functions ---
 
 // OpenDb opens the underlying database.
 func (db *Db) OpenDb() {
+	// The session time zone is set to UTC so that timestamps are stored and read as UTC instants
+	// (independent of the time zone of the database server and of the application server)
 	con := db.config.DbUsername + ":" + db.config.DbPassword +
 		"@tcp(" + db.config.DbHost + ":" + strconv.Itoa(db.config.DbPort) + ")" +
-		"/" + db.config.DbScheme
+		"/" + db.config.DbScheme + "?time_zone=%27%2B00%3A00%27"
 
 	var err error
 
@@ -144,6 +154,78 @@ func (db *Db) GetEntryRepo() *repo.EntryRepo {
 	return db.eRepo
 }
 
+// GetWebhookRepo provides the WebhookRepo.
+func (db *Db) GetWebhookRepo() *repo.WebhookRepo {
+	if db.wRepo == nil {
+		db.wRepo = repo.NewWebhookRepo(db.db)
+	}
+
+	return db.wRepo
+}
+
+// GetAbsenceRepo provides the AbsenceRepo.
+func (db *Db) GetAbsenceRepo() *repo.AbsenceRepo {
+	if db.aRepo == nil {
+		db.aRepo = repo.NewAbsenceRepo(db.db)
+	}
+
+	return db.aRepo
+}
+
+// GetVacationRepo provides the VacationRepo.
+func (db *Db) GetVacationRepo() *repo.VacationRepo {
+	if db.vRepo == nil {
+		db.vRepo = repo.NewVacationRepo(db.db)
+	}
+
+	return db.vRepo
+}
+
+// GetAdjustmentRepo provides the AdjustmentRepo.
+func (db *Db) GetAdjustmentRepo() *repo.AdjustmentRepo {
+	if db.bRepo == nil {
+		db.bRepo = repo.NewAdjustmentRepo(db.db)
+	}
+
+	return db.bRepo
+}
+
+// GetClosingRepo provides the ClosingRepo.
+func (db *Db) GetClosingRepo() *repo.ClosingRepo {
+	if db.yRepo == nil {
+		db.yRepo = repo.NewClosingRepo(db.db)
+	}
+
+	return db.yRepo
+}
+
+// GetExpenseRepo provides the ExpenseRepo.
+func (db *Db) GetExpenseRepo() *repo.ExpenseRepo {
+	if db.xRepo == nil {
+		db.xRepo = repo.NewExpenseRepo(db.db)
+	}
+
+	return db.xRepo
+}
+
+// GetAttachmentRepo provides the AttachmentRepo.
+func (db *Db) GetAttachmentRepo() *repo.AttachmentRepo {
+	if db.fRepo == nil {
+		db.fRepo = repo.NewAttachmentRepo(db.db)
+	}
+
+	return db.fRepo
+}
+
+// GetInvoiceRepo provides the InvoiceRepo.
+func (db *Db) GetInvoiceRepo() *repo.InvoiceRepo {
+	if db.iRepo == nil {
+		db.iRepo = repo.NewInvoiceRepo(db.db)
+	}
+
+	return db.iRepo
+}
+
 // --- Private functions ---
 
 func getDbVersion(db *sql.DB) int {
@@ -178,6 +260,7 @@ func updateDb(db *sql.DB, dbVers int) {
 		dbStmts := readDbFile(fileName)
 		log.Infof("Executing database update v%d ...", i)
 		executeDbStmts(db, dbStmts)
+		migrateDb(db, i)
 		updateDbVersion(db, i)
 	}
 }
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/constant"
+	"kellnhofer.com/work-log/pkg/log"
+)
+
+// dbMigrations contains data migrations which can not be expressed in SQL. A migration is executed
+// after the statements of the database update with the same version.
+var dbMigrations = map[int]func(tx *sql.Tx) error{
+	20: migrateTimestampsToUtc,
+}
+
+// dbTimestampColumns contains the TIMESTAMP columns (per table) that existed before database
+// version 20.
+var dbTimestampColumns = []struct {
+	table   string
+	columns []string
+}{
+	{"session", []string{"expire_at"}},
+	{"entry", []string{"start_time", "end_time", "created_at", "updated_at", "deleted_at"}},
+	{"webhook_delivery", []string{"created_at", "next_attempt_at", "last_attempt_at"}},
+	{"vacation_request", []string{"requested_at", "decided_at"}},
+	{"balance_adjustment", []string{"created_at"}},
+	{"year_closing", []string{"closed_at"}},
+}
+
+// dbZeroTimestamp is the value of TIMESTAMP columns that were never set.
+const dbZeroTimestamp = "0000-00-00 00:00:00"
+
+func migrateDb(db *sql.DB, dbVers int) {
+	migration, ok := dbMigrations[dbVers]
+	if !ok {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Could not begin database migration v%d! (Error: %s)", dbVers, err)
+	}
+	if err := migration(tx); err != nil {
+		_ = tx.Rollback()
+		log.Fatalf("Could not execute database migration v%d! (Error: %s)", dbVers, err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("Could not commit database migration v%d! (Error: %s)", dbVers, err)
+	}
+}
+
+// migrateTimestampsToUtc converts the timestamps written before version 20 to UTC.
+//
+// Until version 20 timestamps were written as local time of the application server, while the
+// database interpreted them in its own session time zone. Since version 20 the session time zone
+// is UTC. To convert the timestamps, they are read in the former session time zone (which yields
+// the originally written local times) and interpreted in the local time zone of the application
+// server. (The migration must therefore run with the same time zone as the former application.)
+func migrateTimestampsToUtc(tx *sql.Tx) error {
+	for _, tc := range dbTimestampColumns {
+		for _, column := range tc.columns {
+			if err := migrateTimestampColumnToUtc(tx, tc.table, column); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func migrateTimestampColumnToUtc(tx *sql.Tx, table string, column string) error {
+	// Read timestamps in the former session time zone
+	if _, err := tx.Exec("SET time_zone = @@global.time_zone"); err != nil {
+		return err
+	}
+	rows, err := tx.Query("SELECT id, " + column + " FROM " + table + " WHERE " + column +
+		" IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0)
+	timestamps := make([]string, 0)
+	for rows.Next() {
+		var id, ts string
+		if err := rows.Scan(&id, &ts); err != nil {
+			rows.Close()
+			return err
+		}
+		if ts == dbZeroTimestamp {
+			continue
+		}
+		ids = append(ids, id)
+		timestamps = append(timestamps, ts)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Write timestamps in UTC
+	if _, err := tx.Exec("SET time_zone = '+00:00'"); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		t, err := time.ParseInLocation(constant.DbTimestampFormat, timestamps[i], time.Local)
+		if err != nil {
+			return err
+		}
+		ut := t.UTC().Format(constant.DbTimestampFormat)
+		if _, err := tx.Exec("UPDATE "+table+" SET "+column+" = ? WHERE id = ?", ut, id); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Converted %d timestamps of %s.%s to UTC.", len(ids), table, column)
+	return nil
+}
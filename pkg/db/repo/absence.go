@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbAbsence struct {
+	id          int
+	userId      int
+	typeId      int
+	startDate   string
+	endDate     string
+	dayFraction string
+	description sql.NullString
+}
+
+// AbsenceRepo retrieves and stores absence related entities.
+type AbsenceRepo struct {
+	repo
+}
+
+// NewAbsenceRepo creates a new absence repository.
+func NewAbsenceRepo(db *sql.DB) *AbsenceRepo {
+	return &AbsenceRepo{repo{db}}
+}
+
+// --- Absence functions ---
+
+// GetAbsenceById retrieves an absence by its ID.
+func (r *AbsenceRepo) GetAbsenceById(ctx context.Context, id int) (*model.Absence, error) {
+	q := "SELECT id, user_id, type_id, start_date, end_date, day_fraction, description " +
+		"FROM absence WHERE id = ?"
+
+	sh := newAbsenceScanHelper()
+	absence, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read absence %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return absence, nil
+}
+
+// CreateAbsence creates a new absence.
+func (r *AbsenceRepo) CreateAbsence(ctx context.Context, absence *model.Absence) error {
+	dbA := toDbAbsence(absence)
+
+	q := "INSERT INTO absence (user_id, type_id, start_date, end_date, day_fraction, " +
+		"description) VALUES (?, ?, ?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbA.userId, dbA.typeId, dbA.startDate, dbA.endDate,
+		dbA.dayFraction, dbA.description)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create absence in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	absence.Id = id
+	return nil
+}
+
+// UpdateAbsence updates an absence.
+func (r *AbsenceRepo) UpdateAbsence(ctx context.Context, absence *model.Absence) error {
+	dbA := toDbAbsence(absence)
+
+	q := "UPDATE absence SET user_id = ?, type_id = ?, start_date = ?, end_date = ?, " +
+		"day_fraction = ?, description = ? WHERE id = ?"
+
+	uErr := r.exec(ctx, q, dbA.userId, dbA.typeId, dbA.startDate, dbA.endDate, dbA.dayFraction,
+		dbA.description, dbA.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update absence %d in "+
+			"database.", absence.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteAbsenceById deletes an absence by its ID.
+func (r *AbsenceRepo) DeleteAbsenceById(ctx context.Context, id int) error {
+	q := "DELETE FROM absence WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete absence %d from "+
+			"database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newAbsenceScanHelper() *scanHelper[*model.Absence] {
+	return newScanHelper(10, scanAbsenceFunc)
+}
+
+func scanAbsenceFunc(s scanner) (*model.Absence, error) {
+	var dbA dbAbsence
+	err := s.Scan(&dbA.id, &dbA.userId, &dbA.typeId, &dbA.startDate, &dbA.endDate,
+		&dbA.dayFraction, &dbA.description)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbAbsence(&dbA), nil
+}
+
+func toDbAbsence(in *model.Absence) *dbAbsence {
+	var out dbAbsence
+	out.id = in.Id
+	out.userId = in.UserId
+	out.typeId = in.TypeId
+	out.startDate = *formatDate(&in.StartDate)
+	out.endDate = *formatDate(&in.EndDate)
+	out.dayFraction = in.DayFraction
+	if strings.TrimSpace(in.Description) != "" {
+		out.description = sql.NullString{String: in.Description, Valid: true}
+	} else {
+		out.description = sql.NullString{String: "", Valid: false}
+	}
+	return &out
+}
+
+func fromDbAbsence(in *dbAbsence) *model.Absence {
+	var out model.Absence
+	out.Id = in.id
+	out.UserId = in.userId
+	out.TypeId = in.typeId
+	out.StartDate = *parseDate(&in.startDate)
+	out.EndDate = *parseDate(&in.endDate)
+	out.DayFraction = in.dayFraction
+	if in.description.Valid {
+		out.Description = in.description.String
+	} else {
+		out.Description = ""
+	}
+	out.EntryIds = []int{}
+	return &out
+}
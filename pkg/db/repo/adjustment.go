@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbBalanceAdjustment struct {
+	id              int
+	userId          int
+	adjType         string
+	date            string
+	value           float32
+	reason          string
+	createdByUserId sql.NullInt64
+	createdAt       string
+}
+
+// AdjustmentRepo retrieves and stores balance adjustment records.
+type AdjustmentRepo struct {
+	repo
+}
+
+// NewAdjustmentRepo creates a new adjustment repository.
+func NewAdjustmentRepo(db *sql.DB) *AdjustmentRepo {
+	return &AdjustmentRepo{repo{db}}
+}
+
+// GetBalanceAdjustmentsByUserId retrieves all balance adjustments of a user (ordered by date).
+func (r *AdjustmentRepo) GetBalanceAdjustmentsByUserId(ctx context.Context, userId int) (
+	[]*model.BalanceAdjustment, error) {
+	q := "SELECT id, user_id, type, date, value, reason, created_by_user_id, created_at " +
+		"FROM balance_adjustment WHERE user_id = ? ORDER BY date ASC, id ASC"
+
+	sh := newBalanceAdjustmentScanHelper()
+	adjustments, qErr := sh.scanRows(r.query(ctx, q, userId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query balance adjustments "+
+			"of user %d from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+// GetBalanceAdjustmentById retrieves a balance adjustment by its ID.
+func (r *AdjustmentRepo) GetBalanceAdjustmentById(ctx context.Context, id int) (
+	*model.BalanceAdjustment, error) {
+	q := "SELECT id, user_id, type, date, value, reason, created_by_user_id, created_at " +
+		"FROM balance_adjustment WHERE id = ?"
+
+	sh := newBalanceAdjustmentScanHelper()
+	adjustment, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read balance adjustment %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return adjustment, nil
+}
+
+// CreateBalanceAdjustment creates a new balance adjustment.
+func (r *AdjustmentRepo) CreateBalanceAdjustment(ctx context.Context,
+	adjustment *model.BalanceAdjustment) error {
+	dbA := toDbBalanceAdjustment(adjustment)
+
+	q := "INSERT INTO balance_adjustment (user_id, type, date, value, reason, " +
+		"created_by_user_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbA.userId, dbA.adjType, dbA.date, dbA.value, dbA.reason,
+		dbA.createdByUserId, dbA.createdAt)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create balance adjustment in database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	adjustment.Id = id
+	return nil
+}
+
+// DeleteBalanceAdjustmentById deletes a balance adjustment by its ID.
+func (r *AdjustmentRepo) DeleteBalanceAdjustmentById(ctx context.Context, id int) error {
+	q := "DELETE FROM balance_adjustment WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete balance adjustment "+
+			"%d from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newBalanceAdjustmentScanHelper() *scanHelper[*model.BalanceAdjustment] {
+	return newScanHelper(10, scanBalanceAdjustmentFunc)
+}
+
+func scanBalanceAdjustmentFunc(s scanner) (*model.BalanceAdjustment, error) {
+	var dbA dbBalanceAdjustment
+	err := s.Scan(&dbA.id, &dbA.userId, &dbA.adjType, &dbA.date, &dbA.value, &dbA.reason,
+		&dbA.createdByUserId, &dbA.createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbBalanceAdjustment(&dbA), nil
+}
+
+func toDbBalanceAdjustment(in *model.BalanceAdjustment) *dbBalanceAdjustment {
+	var out dbBalanceAdjustment
+	out.id = in.Id
+	out.userId = in.UserId
+	out.adjType = in.Type
+	out.date = *formatDate(&in.Date)
+	out.value = in.Value
+	out.reason = in.Reason
+	out.createdByUserId = toDbNullId(in.CreatedByUserId)
+	out.createdAt = *formatTimestamp(&in.CreatedAt)
+	return &out
+}
+
+func fromDbBalanceAdjustment(in *dbBalanceAdjustment) *model.BalanceAdjustment {
+	var out model.BalanceAdjustment
+	out.Id = in.id
+	out.UserId = in.userId
+	out.Type = in.adjType
+	out.Date = *parseDate(&in.date)
+	out.Value = in.value
+	out.Reason = in.reason
+	out.CreatedByUserId = int(in.createdByUserId.Int64)
+	out.CreatedAt = *parseTimestamp(&in.createdAt)
+	return &out
+}
@@ -0,0 +1,205 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbAttachment struct {
+	id         int
+	entryId    int
+	fileName   string
+	mimeType   string
+	size       int64
+	storageKey string
+	createdAt  string
+}
+
+type dbAttachmentCount struct {
+	entryId int
+	count   int
+}
+
+// AttachmentRepo retrieves and stores attachment records.
+type AttachmentRepo struct {
+	repo
+}
+
+// NewAttachmentRepo creates a new attachment repository.
+func NewAttachmentRepo(db *sql.DB) *AttachmentRepo {
+	return &AttachmentRepo{repo{db}}
+}
+
+// GetAttachmentsByEntryId retrieves all attachments of an entry.
+func (r *AttachmentRepo) GetAttachmentsByEntryId(ctx context.Context, entryId int) (
+	[]*model.Attachment, error) {
+	q := "SELECT id, entry_id, file_name, mime_type, size, storage_key, created_at " +
+		"FROM attachment WHERE entry_id = ? ORDER BY id ASC"
+
+	sh := newAttachmentScanHelper()
+	attachments, qErr := sh.scanRows(r.query(ctx, q, entryId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query attachments of entry "+
+			"%d from database.", entryId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAttachmentCountsByEntryIds retrieves the number of attachments of entries (by entry ID).
+// Entries without attachments are not contained in the result.
+func (r *AttachmentRepo) GetAttachmentCountsByEntryIds(ctx context.Context, entryIds []int) (
+	map[int]int, error) {
+	counts := make(map[int]int)
+	if len(entryIds) == 0 {
+		return counts, nil
+	}
+
+	q := "SELECT entry_id, COUNT(*) FROM attachment WHERE entry_id IN (" +
+		createPlaceholderString(len(entryIds)) + ") GROUP BY entry_id"
+	args := make([]any, len(entryIds))
+	for i, entryId := range entryIds {
+		args[i] = entryId
+	}
+
+	sh := newAttachmentCountScanHelper()
+	dbCounts, qErr := sh.scanRows(r.query(ctx, q, args...))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query attachment counts from database.",
+			qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	for _, dbC := range dbCounts {
+		counts[dbC.entryId] = dbC.count
+	}
+	return counts, nil
+}
+
+// GetOrphanedAttachments retrieves all attachments of deleted entries.
+func (r *AttachmentRepo) GetOrphanedAttachments(ctx context.Context) ([]*model.Attachment, error) {
+	q := "SELECT a.id, a.entry_id, a.file_name, a.mime_type, a.size, a.storage_key, a.created_at " +
+		"FROM attachment a " +
+		"INNER JOIN entry e ON e.id = a.entry_id " +
+		"WHERE e.deleted_at IS NOT NULL"
+
+	sh := newAttachmentScanHelper()
+	attachments, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query orphaned attachments from database.",
+			qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAttachmentById retrieves an attachment by its ID.
+func (r *AttachmentRepo) GetAttachmentById(ctx context.Context, id int) (*model.Attachment,
+	error) {
+	q := "SELECT id, entry_id, file_name, mime_type, size, storage_key, created_at " +
+		"FROM attachment WHERE id = ?"
+
+	sh := newAttachmentScanHelper()
+	attachment, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query attachment %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return attachment, nil
+}
+
+// CreateAttachment creates a new attachment.
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	q := "INSERT INTO attachment (entry_id, file_name, mime_type, size, storage_key, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?)"
+
+	dbA := toDbAttachment(attachment)
+	id, cErr := r.insert(ctx, q, dbA.entryId, dbA.fileName, dbA.mimeType, dbA.size, dbA.storageKey,
+		dbA.createdAt)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create attachment in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	attachment.Id = id
+	return nil
+}
+
+// DeleteAttachmentById deletes an attachment by its ID.
+func (r *AttachmentRepo) DeleteAttachmentById(ctx context.Context, id int) error {
+	q := "DELETE FROM attachment WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete attachment %d from "+
+			"database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newAttachmentScanHelper() *scanHelper[*model.Attachment] {
+	return newScanHelper(10, scanAttachmentFunc)
+}
+
+func scanAttachmentFunc(s scanner) (*model.Attachment, error) {
+	var dbA dbAttachment
+	err := s.Scan(&dbA.id, &dbA.entryId, &dbA.fileName, &dbA.mimeType, &dbA.size, &dbA.storageKey,
+		&dbA.createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbAttachment(&dbA), nil
+}
+
+func newAttachmentCountScanHelper() *scanHelper[*dbAttachmentCount] {
+	return newScanHelper(10, scanAttachmentCountFunc)
+}
+
+func scanAttachmentCountFunc(s scanner) (*dbAttachmentCount, error) {
+	var dbC dbAttachmentCount
+	err := s.Scan(&dbC.entryId, &dbC.count)
+	if err != nil {
+		return nil, err
+	}
+	return &dbC, nil
+}
+
+func toDbAttachment(in *model.Attachment) *dbAttachment {
+	var out dbAttachment
+	out.id = in.Id
+	out.entryId = in.EntryId
+	out.fileName = in.FileName
+	out.mimeType = in.MimeType
+	out.size = in.Size
+	out.storageKey = in.StorageKey
+	out.createdAt = *formatTimestamp(&in.CreatedAt)
+	return &out
+}
+
+func fromDbAttachment(in *dbAttachment) *model.Attachment {
+	var out model.Attachment
+	out.Id = in.id
+	out.EntryId = in.entryId
+	out.FileName = in.fileName
+	out.MimeType = in.mimeType
+	out.Size = in.size
+	out.StorageKey = in.storageKey
+	out.CreatedAt = *parseTimestamp(&in.createdAt)
+	return &out
+}
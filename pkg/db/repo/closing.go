@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbYearClosing struct {
+	userId                  int
+	year                    int
+	overtimeHours           float32
+	vacationCarriedOverDays float32
+	vacationAccruedDays     float32
+	vacationAdjustedDays    float32
+	vacationTakenDays       float32
+	vacationExpiredDays     float32
+	vacationRemainingDays   float32
+	closedByUserId          sql.NullInt64
+	closedAt                string
+}
+
+// ClosingRepo retrieves and stores year closing records.
+type ClosingRepo struct {
+	repo
+}
+
+// NewClosingRepo creates a new closing repository.
+func NewClosingRepo(db *sql.DB) *ClosingRepo {
+	return &ClosingRepo{repo{db}}
+}
+
+// GetYearClosingsByUserId retrieves all year closings of a user (ordered by year).
+func (r *ClosingRepo) GetYearClosingsByUserId(ctx context.Context, userId int) (
+	[]*model.YearClosing, error) {
+	q := "SELECT user_id, year, overtime_hours, vacation_carried_over_days, " +
+		"vacation_accrued_days, vacation_adjusted_days, vacation_taken_days, " +
+		"vacation_expired_days, vacation_remaining_days, closed_by_user_id, closed_at " +
+		"FROM year_closing WHERE user_id = ? ORDER BY year ASC"
+
+	sh := newYearClosingScanHelper()
+	closings, qErr := sh.scanRows(r.query(ctx, q, userId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query year closings of "+
+			"user %d from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return closings, nil
+}
+
+// GetLatestYearClosingByUserId retrieves the year closing of the latest closed year of a user.
+func (r *ClosingRepo) GetLatestYearClosingByUserId(ctx context.Context, userId int) (
+	*model.YearClosing, error) {
+	q := "SELECT user_id, year, overtime_hours, vacation_carried_over_days, " +
+		"vacation_accrued_days, vacation_adjusted_days, vacation_taken_days, " +
+		"vacation_expired_days, vacation_remaining_days, closed_by_user_id, closed_at " +
+		"FROM year_closing WHERE user_id = ? ORDER BY year DESC LIMIT 1"
+
+	sh := newYearClosingScanHelper()
+	closing, found, qErr := sh.scanRow(r.queryRow(ctx, q, userId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read latest year closing "+
+			"of user %d from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return closing, nil
+}
+
+// CreateYearClosing creates a new year closing.
+func (r *ClosingRepo) CreateYearClosing(ctx context.Context, closing *model.YearClosing) error {
+	dbC := toDbYearClosing(closing)
+
+	q := "INSERT INTO year_closing (user_id, year, overtime_hours, vacation_carried_over_days, " +
+		"vacation_accrued_days, vacation_adjusted_days, vacation_taken_days, " +
+		"vacation_expired_days, vacation_remaining_days, closed_by_user_id, closed_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	_, cErr := r.insert(ctx, q, dbC.userId, dbC.year, dbC.overtimeHours,
+		dbC.vacationCarriedOverDays, dbC.vacationAccruedDays, dbC.vacationAdjustedDays,
+		dbC.vacationTakenDays, dbC.vacationExpiredDays, dbC.vacationRemainingDays,
+		dbC.closedByUserId, dbC.closedAt)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, fmt.Sprintf("Could not create year closing %d of "+
+			"user %d in database.", closing.Year, closing.UserId), cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteYearClosing deletes the year closing of a user.
+func (r *ClosingRepo) DeleteYearClosing(ctx context.Context, userId int, year int) error {
+	q := "DELETE FROM year_closing WHERE user_id = ? AND year = ?"
+
+	dErr := r.exec(ctx, q, userId, year)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete year closing %d of "+
+			"user %d from database.", year, userId), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newYearClosingScanHelper() *scanHelper[*model.YearClosing] {
+	return newScanHelper(10, scanYearClosingFunc)
+}
+
+func scanYearClosingFunc(s scanner) (*model.YearClosing, error) {
+	var dbC dbYearClosing
+	err := s.Scan(&dbC.userId, &dbC.year, &dbC.overtimeHours, &dbC.vacationCarriedOverDays,
+		&dbC.vacationAccruedDays, &dbC.vacationAdjustedDays, &dbC.vacationTakenDays,
+		&dbC.vacationExpiredDays, &dbC.vacationRemainingDays, &dbC.closedByUserId, &dbC.closedAt)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbYearClosing(&dbC), nil
+}
+
+func toDbYearClosing(in *model.YearClosing) *dbYearClosing {
+	var out dbYearClosing
+	out.userId = in.UserId
+	out.year = in.Year
+	out.overtimeHours = in.OvertimeHours
+	out.vacationCarriedOverDays = in.Vacation.CarriedOverDays
+	out.vacationAccruedDays = in.Vacation.AccruedDays
+	out.vacationAdjustedDays = in.Vacation.AdjustedDays
+	out.vacationTakenDays = in.Vacation.TakenDays
+	out.vacationExpiredDays = in.Vacation.ExpiredDays
+	out.vacationRemainingDays = in.Vacation.RemainingDays
+	out.closedByUserId = toDbNullId(in.ClosedByUserId)
+	out.closedAt = *formatTimestamp(&in.ClosedAt)
+	return &out
+}
+
+func fromDbYearClosing(in *dbYearClosing) *model.YearClosing {
+	out := model.NewYearClosing()
+	out.UserId = in.userId
+	out.Year = in.year
+	out.OvertimeHours = in.overtimeHours
+	out.Vacation.Year = in.year
+	out.Vacation.CarriedOverDays = in.vacationCarriedOverDays
+	out.Vacation.AccruedDays = in.vacationAccruedDays
+	out.Vacation.AdjustedDays = in.vacationAdjustedDays
+	out.Vacation.TakenDays = in.vacationTakenDays
+	out.Vacation.ExpiredDays = in.vacationExpiredDays
+	out.Vacation.RemainingDays = in.vacationRemainingDays
+	out.ClosedByUserId = int(in.closedByUserId.Int64)
+	out.ClosedAt = *parseTimestamp(&in.closedAt)
+	return out
+}
@@ -11,9 +11,15 @@ import (
 )
 
 type dbContract struct {
-	initOvertimeHours float32
-	initVacationDays  float32
-	firstDay          string
+	initOvertimeHours        float32
+	initVacationDays         float32
+	firstDay                 string
+	deductMissingBreaks      bool
+	vacationCarryOverMaxDays sql.NullFloat64
+	vacationExpiryMonths     sql.NullInt64
+	roundingMode             sql.NullString
+	roundingIntervalMinutes  sql.NullInt64
+	roundingScope            sql.NullString
 }
 
 type dbContractWorkingHours struct {
@@ -26,6 +32,11 @@ type dbContractVacationDays struct {
 	monthlyDays float32
 }
 
+type dbContractBreakRule struct {
+	workHours    float32
+	breakMinutes int
+}
+
 // ContractRepo retrieves and stores contract related entities.
 type ContractRepo struct {
 	repo
@@ -58,6 +69,12 @@ func (r *ContractRepo) GetContractByUserId(ctx context.Context, userId int) (*mo
 	}
 	c.WorkingHours = cwh
 
+	cbr, qErr := r.getContractBreakRules(ctx, userId)
+	if qErr != nil {
+		return nil, qErr
+	}
+	c.BreakRules = cbr
+
 	return c, nil
 }
 
@@ -74,6 +91,9 @@ func (r *ContractRepo) CreateContract(ctx context.Context, userId int, contract
 		if err := r.setContractWorkingHours(tx, userId, contract.WorkingHours); err != nil {
 			return err
 		}
+		if err := r.setContractBreakRules(tx, userId, contract.BreakRules); err != nil {
+			return err
+		}
 		return nil
 	})
 }
@@ -91,13 +111,17 @@ func (r *ContractRepo) UpdateContract(ctx context.Context, userId int, contract
 		if err := r.setContractWorkingHours(tx, userId, contract.WorkingHours); err != nil {
 			return err
 		}
+		if err := r.setContractBreakRules(tx, userId, contract.BreakRules); err != nil {
+			return err
+		}
 		return nil
 	})
 }
 
 func (r *ContractRepo) getContract(ctx context.Context, userId int) (*model.Contract, error) {
-	q := "SELECT init_overtime_hours, init_vacation_days, first_day FROM contract " +
-		"WHERE user_id = ?"
+	q := "SELECT init_overtime_hours, init_vacation_days, first_day, deduct_missing_breaks, " +
+		"vacation_carry_over_max_days, vacation_expiry_months, rounding_mode, " +
+		"rounding_interval_minutes, rounding_scope FROM contract WHERE user_id = ?"
 
 	sh := newContractScanHelper()
 	contract, found, qErr := sh.scanRow(r.queryRow(ctx, q, userId))
@@ -117,10 +141,14 @@ func (r *ContractRepo) createContract(tx *sql.Tx, userId int, contract *model.Co
 ) error {
 	c := toDbContract(contract)
 
-	q := "INSERT INTO contract (user_id, init_overtime_hours, init_vacation_days, first_day) " +
-		"VALUES (?, ?, ?, ?)"
+	q := "INSERT INTO contract (user_id, init_overtime_hours, init_vacation_days, first_day, " +
+		"deduct_missing_breaks, vacation_carry_over_max_days, vacation_expiry_months, " +
+		"rounding_mode, rounding_interval_minutes, rounding_scope) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 
-	_, cErr := r.insertWithTx(tx, q, userId, c.initOvertimeHours, c.initVacationDays, c.firstDay)
+	_, cErr := r.insertWithTx(tx, q, userId, c.initOvertimeHours, c.initVacationDays, c.firstDay,
+		c.deductMissingBreaks, c.vacationCarryOverMaxDays, c.vacationExpiryMonths, c.roundingMode,
+		c.roundingIntervalMinutes, c.roundingScope)
 	if cErr != nil {
 		err := e.WrapError(e.SysDbInsertFailed, fmt.Sprintf("Could not create contract for user %d "+
 			"in database.", userId), cErr)
@@ -135,10 +163,14 @@ func (r *ContractRepo) updateContract(tx *sql.Tx, userId int, contract *model.Co
 ) error {
 	c := toDbContract(contract)
 
-	q := "UPDATE contract SET init_overtime_hours = ?, init_vacation_days = ?, first_day = ? " +
-		"WHERE user_id = ?"
+	q := "UPDATE contract SET init_overtime_hours = ?, init_vacation_days = ?, first_day = ?, " +
+		"deduct_missing_breaks = ?, vacation_carry_over_max_days = ?, " +
+		"vacation_expiry_months = ?, rounding_mode = ?, rounding_interval_minutes = ?, " +
+		"rounding_scope = ? WHERE user_id = ?"
 
-	uErr := r.execWithTx(tx, q, c.initOvertimeHours, c.initVacationDays, c.firstDay, userId)
+	uErr := r.execWithTx(tx, q, c.initOvertimeHours, c.initVacationDays, c.firstDay,
+		c.deductMissingBreaks, c.vacationCarryOverMaxDays, c.vacationExpiryMonths, c.roundingMode,
+		c.roundingIntervalMinutes, c.roundingScope, userId)
 	if uErr != nil {
 		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update contract for user %d "+
 			"in database.", userId), uErr)
@@ -231,6 +263,48 @@ func (r *ContractRepo) setContractWorkingHours(tx *sql.Tx, userId int,
 	return nil
 }
 
+func (r *ContractRepo) getContractBreakRules(ctx context.Context, userId int,
+) ([]model.ContractBreakRule, error) {
+	q := "SELECT work_hours, break_minutes FROM contract_break_rules WHERE user_id = ? " +
+		"ORDER BY work_hours"
+
+	sh := newContractBreakRuleScanHelper()
+	breakRules, qErr := sh.scanRows(r.query(ctx, q, userId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read contract for user %d "+
+			"from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return breakRules, nil
+}
+
+func (r *ContractRepo) setContractBreakRules(tx *sql.Tx, userId int,
+	breakRules []model.ContractBreakRule) error {
+	dErr := r.execWithTx(tx, "DELETE FROM contract_break_rules WHERE user_id = ?", userId)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not update contract for user "+
+			"%d in database.", userId), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	for _, br := range breakRules {
+		cbr := toDbContractBreakRule(br)
+
+		cErr := r.execWithTx(tx, "INSERT INTO contract_break_rules (user_id, work_hours, "+
+			"break_minutes) VALUES (?, ?, ?)", userId, cbr.workHours, cbr.breakMinutes)
+		if cErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, fmt.Sprintf("Could not update contract for "+
+				"user %d in database.", userId), cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+	}
+
+	return nil
+}
+
 // --- Helper functions ---
 
 func newContractScanHelper() *scanHelper[*model.Contract] {
@@ -240,7 +314,9 @@ func newContractScanHelper() *scanHelper[*model.Contract] {
 func scanContractFunc(s scanner) (*model.Contract, error) {
 	var dbC dbContract
 
-	err := s.Scan(&dbC.initOvertimeHours, &dbC.initVacationDays, &dbC.firstDay)
+	err := s.Scan(&dbC.initOvertimeHours, &dbC.initVacationDays, &dbC.firstDay,
+		&dbC.deductMissingBreaks, &dbC.vacationCarryOverMaxDays, &dbC.vacationExpiryMonths,
+		&dbC.roundingMode, &dbC.roundingIntervalMinutes, &dbC.roundingScope)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +331,26 @@ func toDbContract(in *model.Contract) *dbContract {
 	out.firstDay = *formatDate(&in.FirstDay)
 	out.initOvertimeHours = in.InitOvertimeHours
 	out.initVacationDays = in.InitVacationDays
+	out.deductMissingBreaks = in.DeductMissingBreaks
+	if in.VacationRules != nil {
+		out.vacationCarryOverMaxDays = sql.NullFloat64{
+			Float64: float64(in.VacationRules.CarryOverMaxDays), Valid: true}
+		out.vacationExpiryMonths = sql.NullInt64{Int64: int64(in.VacationRules.ExpiryMonths),
+			Valid: true}
+	} else {
+		out.vacationCarryOverMaxDays = sql.NullFloat64{Float64: 0, Valid: false}
+		out.vacationExpiryMonths = sql.NullInt64{Int64: 0, Valid: false}
+	}
+	if in.RoundingPolicy != nil {
+		out.roundingMode = sql.NullString{String: in.RoundingPolicy.Mode, Valid: true}
+		out.roundingIntervalMinutes = sql.NullInt64{
+			Int64: int64(in.RoundingPolicy.IntervalMinutes), Valid: true}
+		out.roundingScope = sql.NullString{String: in.RoundingPolicy.Scope, Valid: true}
+	} else {
+		out.roundingMode = sql.NullString{String: "", Valid: false}
+		out.roundingIntervalMinutes = sql.NullInt64{Int64: 0, Valid: false}
+		out.roundingScope = sql.NullString{String: "", Valid: false}
+	}
 	return &out
 }
 
@@ -263,6 +359,20 @@ func fromDbContract(in *dbContract) *model.Contract {
 	out.FirstDay = *parseDate(&in.firstDay)
 	out.InitOvertimeHours = in.initOvertimeHours
 	out.InitVacationDays = in.initVacationDays
+	out.DeductMissingBreaks = in.deductMissingBreaks
+	if in.vacationCarryOverMaxDays.Valid {
+		out.VacationRules = &model.ContractVacationRules{
+			CarryOverMaxDays: float32(in.vacationCarryOverMaxDays.Float64),
+			ExpiryMonths:     int(in.vacationExpiryMonths.Int64),
+		}
+	}
+	if in.roundingMode.Valid {
+		out.RoundingPolicy = &model.RoundingPolicy{
+			Mode:            in.roundingMode.String,
+			IntervalMinutes: int(in.roundingIntervalMinutes.Int64),
+			Scope:           in.roundingScope.String,
+		}
+	}
 	return &out
 }
 
@@ -327,3 +437,34 @@ func fromDbContractWorkingHours(in dbContractWorkingHours) model.ContractWorking
 	out.Hours = in.dailyHours
 	return out
 }
+
+func newContractBreakRuleScanHelper() *scanHelper[model.ContractBreakRule] {
+	return newScanHelper(10, scanContractBreakRuleFunc)
+}
+
+func scanContractBreakRuleFunc(s scanner) (model.ContractBreakRule, error) {
+	var dbC dbContractBreakRule
+
+	err := s.Scan(&dbC.workHours, &dbC.breakMinutes)
+	if err != nil {
+		return model.ContractBreakRule{}, err
+	}
+
+	c := fromDbContractBreakRule(dbC)
+
+	return c, nil
+}
+
+func toDbContractBreakRule(in model.ContractBreakRule) dbContractBreakRule {
+	var out dbContractBreakRule
+	out.workHours = in.WorkHours
+	out.breakMinutes = in.BreakMinutes
+	return out
+}
+
+func fromDbContractBreakRule(in dbContractBreakRule) model.ContractBreakRule {
+	var out model.ContractBreakRule
+	out.WorkHours = in.workHours
+	out.BreakMinutes = in.breakMinutes
+	return out
+}
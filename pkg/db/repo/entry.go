@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +14,25 @@ import (
 	"kellnhofer.com/work-log/pkg/model"
 )
 
+// The UTC offset expression covers the offset changes from its start until a number of years from
+// now at most. (Earlier or later timestamps use the first or last offset.)
+var utcOffsetExpressionStart = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const utcOffsetExpressionYears = 10
+
+// entryFilterZone stores the location in which weekdays and dates are determined and the period of
+// the filtered start times. Only the UTC offset changes inside this period are resolved.
+type entryFilterZone struct {
+	loc   *time.Location
+	start time.Time
+	end   time.Time
+}
+
+func newEntryFilterZone(loc *time.Location) *entryFilterZone {
+	return &entryFilterZone{loc, utcOffsetExpressionStart,
+		time.Now().AddDate(utcOffsetExpressionYears, 0, 0)}
+}
+
 type dbReadEntry struct {
 	id          int
 	userId      int
@@ -21,9 +42,19 @@ type dbReadEntry struct {
 	activityId  sql.NullInt64
 	project     sql.NullString
 	description sql.NullString
+	billable    bool
+	absenceId   sql.NullInt64
+	invoiceId   sql.NullInt64
+	createdAt   string
+	updatedAt   string
+	version     int
 	labels      sql.NullString
 }
 
+type dbEntryChange struct {
+	deletedAt sql.NullString
+}
+
 type dbWriteEntry struct {
 	id          int
 	userId      int
@@ -33,6 +64,27 @@ type dbWriteEntry struct {
 	activityId  sql.NullInt64
 	projectId   sql.NullInt64
 	description sql.NullString
+	billable    bool
+	absenceId   sql.NullInt64
+}
+
+type dbEntryType struct {
+	id               int
+	name             sql.NullString
+	description      sql.NullString
+	countsAsWork     bool
+	reducesTarget    bool
+	consumesVacation bool
+	allowsActivities bool
+	allowsExpenses   bool
+	color            string
+}
+
+type dbEntryActivity struct {
+	id          int
+	description string
+	archived    bool
+	typeIds     sql.NullString
 }
 
 type dbWorkDuration struct {
@@ -40,6 +92,43 @@ type dbWorkDuration struct {
 	workDuration int
 }
 
+type dbWorkPeriod struct {
+	startTime string
+	endTime   string
+}
+
+// EntryIterator iterates over the entries of a query result. The entries are read from the
+// database one by one, so the iterator must be closed after use.
+type EntryIterator struct {
+	ri *rowIterator[*model.Entry]
+}
+
+// Next advances the iterator to the next entry. It returns false if there are no more entries or
+// an error occurred.
+func (it *EntryIterator) Next() bool {
+	return it.ri.next()
+}
+
+// Entry returns the current entry.
+func (it *EntryIterator) Entry() *model.Entry {
+	return it.ri.item
+}
+
+// Err returns the error which occurred during the iteration.
+func (it *EntryIterator) Err() error {
+	if rErr := it.ri.error(); rErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not read entries from database.", rErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// Close closes the iterator and releases the database connection.
+func (it *EntryIterator) Close() error {
+	return it.ri.close()
+}
+
 // EntryRepo retrieves and stores entry related entities.
 type EntryRepo struct {
 	repo
@@ -52,10 +141,11 @@ func NewEntryRepo(db *sql.DB) *EntryRepo {
 
 // --- Entry functions ---
 
-// CountDateEntries counts entries (over date).
-func (r *EntryRepo) CountDateEntries(ctx context.Context, filter model.EntryFilter) (int,
-	error) {
-	q, qa := r.buildCountDateEntriesQuery(filter)
+// CountDateEntries counts all entries (over date). The dates are determined in the supplied
+// location.
+func (r *EntryRepo) CountDateEntries(ctx context.Context, filter model.EntryFilter,
+	loc *time.Location) (int, error) {
+	q, qa := r.buildCountDateEntriesQuery(filter, loc)
 
 	sh := newIntScanHelper()
 	count, _, qErr := sh.scanRow(r.queryRow(ctx, q, qa...))
@@ -68,23 +158,27 @@ func (r *EntryRepo) CountDateEntries(ctx context.Context, filter model.EntryFilt
 	return count, nil
 }
 
-func (r *EntryRepo) buildCountDateEntriesQuery(filter model.EntryFilter) (string, []any) {
+func (r *EntryRepo) buildCountDateEntriesQuery(filter model.EntryFilter,
+	loc *time.Location) (string, []any) {
+	dq, dqa := buildEntryDateExpression(getEntryFilterZone(filter, loc))
 	qr, qra := r.buildEntryFilterQueryRestriction(filter)
 
-	q := "SELECT COUNT(DISTINCT(DATE(e.start_time))) " +
+	q := "SELECT COUNT(DISTINCT(" + dq + ")) " +
 		"FROM entry e " +
 		"LEFT JOIN project p ON e.project_id = p.id " +
 		qr
 
-	return q, qra
+	qa := append(dqa, qra...)
+
+	return q, qa
 }
 
-// GetDateEntries retrieves entries (over date).
+// GetDateEntries retrieves entries (over date). The dates are determined in the supplied location.
 func (r *EntryRepo) GetDateEntries(ctx context.Context, filter model.EntryFilter,
-	sort *model.EntrySort, offset int, limit int) ([]*model.Entry, error) {
-	qr, qra := r.buildGetDateEntriesRangeQuery(filter, sort, offset, limit)
+	sort *model.EntrySort, offset int, limit int, loc *time.Location) ([]*model.Entry, error) {
+	qr, qra := r.buildGetDateEntriesRangeQuery(filter, sort, offset, limit, loc)
 
-	start, end, qrErr := r.getDateRange(ctx, qr, qra...)
+	start, end, qrErr := r.getDateRange(ctx, loc, qr, qra...)
 	if qrErr != nil {
 		err := e.WrapError(e.SysDbQueryFailed, "Could not query range for entries (over date) from "+
 			"database.", qrErr)
@@ -109,7 +203,8 @@ func (r *EntryRepo) GetDateEntries(ctx context.Context, filter model.EntryFilter
 }
 
 func (r *EntryRepo) buildGetDateEntriesRangeQuery(filter model.EntryFilter,
-	sort *model.EntrySort, offset int, limit int) (string, []any) {
+	sort *model.EntrySort, offset int, limit int, loc *time.Location) (string, []any) {
+	dq, dqa := buildEntryDateExpression(getEntryFilterZone(filter, loc))
 	qr, qra := r.buildEntryFilterQueryRestriction(filter)
 	var qo string
 	if sort != nil && (sort.ByTime == model.NoSorting || sort.ByTime == model.AscSorting) {
@@ -118,14 +213,16 @@ func (r *EntryRepo) buildGetDateEntriesRangeQuery(filter model.EntryFilter,
 		qo = "ORDER BY date DESC"
 	}
 
-	q := "SELECT DISTINCT(DATE(e.start_time)) AS date " +
+	q := "SELECT DISTINCT(" + dq + ") AS date " +
 		"FROM entry e " +
 		"LEFT JOIN project p ON e.project_id = p.id " +
 		qr + " " +
 		qo + " " +
 		createQueryLimitString(offset, limit)
 
-	return q, qra
+	qa := append(dqa, qra...)
+
+	return q, qa
 }
 
 func (r *EntryRepo) buildGetDateEntriesQuery(filter model.EntryFilter, sort *model.EntrySort,
@@ -136,7 +233,7 @@ func (r *EntryRepo) buildGetDateEntriesQuery(filter model.EntryFilter, sort *mod
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
 		"FROM " + r.getEntrySelectTables() + " " +
 		qr + " " +
-		"AND e.start_time BETWEEN ? AND ? " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
 		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
 		qo
 
@@ -145,9 +242,11 @@ func (r *EntryRepo) buildGetDateEntriesQuery(filter model.EntryFilter, sort *mod
 	return q, qa
 }
 
-// CountDateEntriesByUserId counts all entries (over date) of an user.
-func (r *EntryRepo) CountDateEntriesByUserId(ctx context.Context, userId int) (int, error) {
-	q, qa := r.buildCountDateEntriesByUserIdQuery(userId)
+// CountDateEntriesByUserId counts all entries (over date) of an user. The dates are determined in
+// the supplied location.
+func (r *EntryRepo) CountDateEntriesByUserId(ctx context.Context, userId int,
+	loc *time.Location) (int, error) {
+	q, qa := r.buildCountDateEntriesByUserIdQuery(userId, loc)
 
 	sh := newIntScanHelper()
 	count, _, qErr := sh.scanRow(r.queryRow(ctx, q, qa...))
@@ -160,22 +259,26 @@ func (r *EntryRepo) CountDateEntriesByUserId(ctx context.Context, userId int) (i
 	return count, nil
 }
 
-func (r *EntryRepo) buildCountDateEntriesByUserIdQuery(userId int) (string, []any) {
-	q := "SELECT COUNT(DISTINCT(DATE(e.start_time))) " +
+func (r *EntryRepo) buildCountDateEntriesByUserIdQuery(userId int, loc *time.Location) (string,
+	[]any) {
+	dq, dqa := buildEntryDateExpression(newEntryFilterZone(loc))
+
+	q := "SELECT COUNT(DISTINCT(" + dq + ")) " +
 		"FROM entry e " +
-		"WHERE e.user_id = ?"
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL"
 
-	qa := []any{userId}
+	qa := append(dqa, userId)
 
 	return q, qa
 }
 
-// GetDateEntriesByUserId retrieves all entries (over date) of an user.
-func (r *EntryRepo) GetDateEntriesByUserId(ctx context.Context, userId int, offset int, limit int) (
-	[]*model.Entry, error) {
-	qr, qra := r.buildGetDateEntriesByUserIdRangeQuery(userId, offset, limit)
+// GetDateEntriesByUserId retrieves all entries (over date) of an user. The dates are determined in
+// the supplied location.
+func (r *EntryRepo) GetDateEntriesByUserId(ctx context.Context, userId int, offset int, limit int,
+	loc *time.Location) ([]*model.Entry, error) {
+	qr, qra := r.buildGetDateEntriesByUserIdRangeQuery(userId, offset, limit, loc)
 
-	start, end, qrErr := r.getDateRange(ctx, qr, qra...)
+	start, end, qrErr := r.getDateRange(ctx, loc, qr, qra...)
 	if qrErr != nil {
 		err := e.WrapError(e.SysDbQueryFailed, "Could not query range for entries (over date) "+
 			"from database.", qrErr)
@@ -199,15 +302,17 @@ func (r *EntryRepo) GetDateEntriesByUserId(ctx context.Context, userId int, offs
 	return entries, nil
 }
 
-func (r *EntryRepo) buildGetDateEntriesByUserIdRangeQuery(userId int, offset int, limit int) (string,
-	[]any) {
-	q := "SELECT DISTINCT(DATE(e.start_time)) AS date " +
+func (r *EntryRepo) buildGetDateEntriesByUserIdRangeQuery(userId int, offset int, limit int,
+	loc *time.Location) (string, []any) {
+	dq, dqa := buildEntryDateExpression(newEntryFilterZone(loc))
+
+	q := "SELECT DISTINCT(" + dq + ") AS date " +
 		"FROM entry e " +
-		"WHERE e.user_id = ? " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
 		"ORDER BY date DESC " +
 		createQueryLimitString(offset, limit)
 
-	qa := []any{userId}
+	qa := append(dqa, userId)
 
 	return q, qa
 }
@@ -216,8 +321,8 @@ func (r *EntryRepo) buildGetDateEntriesByUserIdQuery(userId int, start string, e
 	[]any) {
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
 		"FROM " + r.getEntrySelectTables() + " " +
-		"WHERE e.user_id = ? " +
-		"AND e.start_time BETWEEN ? AND ? " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
 		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
 		"ORDER BY e.start_time DESC, e.end_time DESC"
 
@@ -226,10 +331,11 @@ func (r *EntryRepo) buildGetDateEntriesByUserIdQuery(userId int, start string, e
 	return q, qa
 }
 
-// GetMonthEntries retrieves all entries of a month.
-func (r *EntryRepo) GetMonthEntries(ctx context.Context, userId int, year int, month int) (
-	[]*model.Entry, error) {
-	q, qa := r.buildGetMonthEntriesQuery(userId, year, month)
+// GetMonthEntries retrieves all entries of a month. The month is determined in the supplied
+// location.
+func (r *EntryRepo) GetMonthEntries(ctx context.Context, userId int, year int, month int,
+	loc *time.Location) ([]*model.Entry, error) {
+	q, qa := r.buildGetMonthEntriesQuery(userId, year, month, loc)
 
 	sh := newEntryScanHelper()
 	entries, qErr := sh.scanRows(r.query(ctx, q, qa...))
@@ -241,15 +347,18 @@ func (r *EntryRepo) GetMonthEntries(ctx context.Context, userId int, year int, m
 	return entries, nil
 }
 
-func (r *EntryRepo) buildGetMonthEntriesQuery(userId int, year int, month int) (string, []any) {
+func (r *EntryRepo) buildGetMonthEntriesQuery(userId int, year int, month int,
+	loc *time.Location) (string, []any) {
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
 		"FROM " + r.getEntrySelectTables() + " " +
-		"WHERE e.user_id = ? " +
-		"AND YEAR(e.start_time) = ? AND MONTH(e.start_time) = ? " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
 		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
 		"ORDER BY e.start_time ASC, e.end_time ASC"
 
-	qa := []any{userId, year, month}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 1, 0)
+	qa := []any{userId, *formatTimestamp(&start), *formatTimestamp(&end)}
 
 	return q, qa
 }
@@ -275,8 +384,14 @@ func (r *EntryRepo) CountEntries(ctx context.Context, filter model.EntryFilter)
 
 // GetEntries retrieves all entries.
 func (r *EntryRepo) GetEntries(ctx context.Context, filter model.EntryFilter,
-	sort *model.EntrySort, offset int, limit int) ([]*model.Entry, error) {
+	sort *model.EntrySort, cursor *model.EntryCursor, offset int, limit int) ([]*model.Entry,
+	error) {
 	qr, qra := r.buildEntryFilterQueryRestriction(filter)
+	if cursor != nil {
+		cqr, cqra := r.buildEntryCursorQueryRestriction(sort, cursor)
+		qr = qr + " AND " + cqr
+		qra = append(qra, cqra...)
+	}
 	qo := r.buildEntrySortQueryClause(sort)
 
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
@@ -296,11 +411,33 @@ func (r *EntryRepo) GetEntries(ctx context.Context, filter model.EntryFilter,
 	return entries, nil
 }
 
+// GetEntriesIterator retrieves all entries as an iterator. In contrast to GetEntries, the entries
+// are not loaded at once, so even large result sets need constant memory.
+func (r *EntryRepo) GetEntriesIterator(ctx context.Context, filter model.EntryFilter,
+	sort *model.EntrySort) (*EntryIterator, error) {
+	qr, qra := r.buildEntryFilterQueryRestriction(filter)
+	qo := r.buildEntrySortQueryClause(sort)
+
+	q := "SELECT " + r.getEntrySelectColumns() + " " +
+		"FROM " + r.getEntrySelectTables() + " " +
+		qr + " " +
+		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
+		qo
+
+	rows, qErr := r.query(ctx, q, qra...)
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query entries from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return &EntryIterator{newRowIterator(rows, scanEntryFunc)}, nil
+}
+
 // GetEntryById retrieves an entry.
 func (r *EntryRepo) GetEntryById(ctx context.Context, id int) (*model.Entry, error) {
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
 		"FROM " + r.getEntrySelectTables() + " " +
-		"WHERE e.id = ? " +
+		"WHERE e.id = ? AND e.deleted_at IS NULL " +
 		"GROUP BY " + r.getEntrySelectGroupByColumns()
 
 	sh := newEntryScanHelper()
@@ -322,7 +459,7 @@ func (r *EntryRepo) GetEntryByIdAndUserId(ctx context.Context, id int, userId in
 	error) {
 	q := "SELECT " + r.getEntrySelectColumns() + " " +
 		"FROM " + r.getEntrySelectTables() + " " +
-		"WHERE e.id = ? AND e.user_id = ? " +
+		"WHERE e.id = ? AND e.user_id = ? AND e.deleted_at IS NULL " +
 		"GROUP BY " + r.getEntrySelectGroupByColumns()
 
 	sh := newEntryScanHelper()
@@ -339,6 +476,113 @@ func (r *EntryRepo) GetEntryByIdAndUserId(ctx context.Context, id int, userId in
 	return entry, nil
 }
 
+// GetEntriesByAbsenceId retrieves all entries of an absence.
+func (r *EntryRepo) GetEntriesByAbsenceId(ctx context.Context, absenceId int) ([]*model.Entry,
+	error) {
+	q := "SELECT " + r.getEntrySelectColumns() + " " +
+		"FROM " + r.getEntrySelectTables() + " " +
+		"WHERE e.absence_id = ? AND e.deleted_at IS NULL " +
+		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
+		"ORDER BY e.start_time ASC, e.end_time ASC"
+
+	sh := newEntryScanHelper()
+	entries, qErr := sh.scanRows(r.query(ctx, q, absenceId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query entries of absence %d "+
+			"from database.", absenceId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEntriesByActivityId retrieves all entries of an entry activity.
+func (r *EntryRepo) GetEntriesByActivityId(ctx context.Context, activityId int) ([]*model.Entry,
+	error) {
+	q := "SELECT " + r.getEntrySelectColumns() + " " +
+		"FROM " + r.getEntrySelectTables() + " " +
+		"WHERE e.activity_id = ? AND e.deleted_at IS NULL " +
+		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
+		"ORDER BY e.start_time ASC, e.end_time ASC"
+
+	sh := newEntryScanHelper()
+	entries, qErr := sh.scanRows(r.query(ctx, q, activityId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query entries of entry "+
+			"activity %d from database.", activityId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetUninvoicedBillableEntries retrieves all billable entries of the supplied projects which start
+// in a specific time interval and were not invoiced yet (ordered by start time).
+func (r *EntryRepo) GetUninvoicedBillableEntries(ctx context.Context, projects []string,
+	start time.Time, end time.Time) ([]*model.Entry, error) {
+	if len(projects) == 0 {
+		return []*model.Entry{}, nil
+	}
+
+	q := "SELECT " + r.getEntrySelectColumns() + " " +
+		"FROM " + r.getEntrySelectTables() + " " +
+		"WHERE e.billable = 1 AND e.invoice_id IS NULL AND e.deleted_at IS NULL " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
+		"AND p.name IN (" + createPlaceholderString(len(projects)) + ") " +
+		"GROUP BY " + r.getEntrySelectGroupByColumns() + " " +
+		"ORDER BY e.start_time ASC, e.end_time ASC"
+	qas := []any{*formatTimestamp(&start), *formatTimestamp(&end)}
+	for _, project := range projects {
+		qas = append(qas, project)
+	}
+
+	sh := newEntryScanHelper()
+	entries, qErr := sh.scanRows(r.query(ctx, q, qas...))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query uninvoiced billable entries from "+
+			"database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEntryChanges retrieves entries (including deleted entries) that were changed after the
+// supplied cursor and not after the supplied time, ordered by their change time. If the user ID is
+// 0, changes of all users are retrieved.
+func (r *EntryRepo) GetEntryChanges(ctx context.Context, userId int,
+	cursor *model.EntryChangeCursor, until time.Time, limit int) ([]*model.EntryChange, error) {
+	var qrs []string
+	var qas []any
+	if userId != 0 {
+		qrs = append(qrs, "e.user_id = ?")
+		qas = append(qas, userId)
+	}
+	if cursor != nil {
+		ca := *formatTimestamp(&cursor.UpdatedAt)
+		qrs = append(qrs, "(e.updated_at > ? OR (e.updated_at = ? AND e.id > ?))")
+		qas = append(qas, ca, ca, cursor.Id)
+	}
+	qrs = append(qrs, "e.updated_at <= ?")
+	qas = append(qas, *formatTimestamp(&until))
+
+	q := "SELECT " + r.getEntrySelectColumns() + ", e.deleted_at " +
+		"FROM " + r.getEntrySelectTables() + " " +
+		"WHERE " + joinQueryRestrictions(qrs, "AND") + " " +
+		"GROUP BY " + r.getEntrySelectGroupByColumns() + ", e.deleted_at " +
+		"ORDER BY e.updated_at ASC, e.id ASC " +
+		createQueryLimitString(0, limit)
+
+	sh := newEntryChangeScanHelper()
+	changes, qErr := sh.scanRows(r.query(ctx, q, qas...))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query entry changes from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return changes, nil
+}
+
 func (r *EntryRepo) getEntrySelectColumns() string {
 	return r.getEntrySelectBaseColumns() + ", " +
 		r.getEntrySelectProjectColumn() + ", " +
@@ -358,7 +602,8 @@ func (r *EntryRepo) getEntrySelectGroupByColumns() string {
 }
 
 func (r *EntryRepo) getEntrySelectBaseColumns() string {
-	return "e.id, e.user_id, e.type_id, e.start_time, e.end_time, e.activity_id, e.description"
+	return "e.id, e.user_id, e.type_id, e.start_time, e.end_time, e.activity_id, e.description, " +
+		"e.billable, e.absence_id, e.invoice_id, e.created_at, e.updated_at, e.version"
 }
 
 func (r *EntryRepo) getEntrySelectProjectColumn() string {
@@ -371,7 +616,7 @@ func (r *EntryRepo) getEntrySelectLabelsColumn() string {
 
 // ExistsEntryById checks if a entry exists.
 func (r *EntryRepo) ExistsEntryById(ctx context.Context, id int) (bool, error) {
-	cnt, cErr := r.count(ctx, "entry", "id = ?", id)
+	cnt, cErr := r.count(ctx, "entry", "id = ? AND deleted_at IS NULL", id)
 	if cErr != nil {
 		err := e.WrapError(e.SysDbQueryFailed, "Could not count entries from database.", cErr)
 		log.Error(err.StackTrace())
@@ -384,7 +629,8 @@ func (r *EntryRepo) ExistsEntryById(ctx context.Context, id int) (bool, error) {
 // ExistsEntryByIdAndUserId checks if a entry exists for an user.
 func (r *EntryRepo) ExistsEntryByIdAndUserId(ctx context.Context, id int, userId int) (bool,
 	error) {
-	cnt, cErr := r.count(ctx, "entry", "id = ? AND user_id = ?", id, userId)
+	cnt, cErr := r.count(ctx, "entry", "id = ? AND user_id = ? AND deleted_at IS NULL", id,
+		userId)
 	if cErr != nil {
 		err := e.WrapError(e.SysDbQueryFailed, "Could not count entries from database.", cErr)
 		log.Error(err.StackTrace())
@@ -396,7 +642,7 @@ func (r *EntryRepo) ExistsEntryByIdAndUserId(ctx context.Context, id int, userId
 
 // ExistsEntryByActivityId checks if a entry exists for an activity.
 func (r *EntryRepo) ExistsEntryByActivityId(ctx context.Context, activityId int) (bool, error) {
-	cnt, cErr := r.count(ctx, "entry", "activity_id = ?", activityId)
+	cnt, cErr := r.count(ctx, "entry", "activity_id = ? AND deleted_at IS NULL", activityId)
 	if cErr != nil {
 		err := e.WrapError(e.SysDbQueryFailed, "Could not count entries from database.", cErr)
 		log.Error(err.StackTrace())
@@ -415,13 +661,17 @@ func (r *EntryRepo) CreateEntry(ctx context.Context, entry *model.Entry) error {
 		}
 
 		etr := toDbEntry(0, entry.UserId, entry.TypeId, entry.StartTime, entry.EndTime,
-			entry.ActivityId, projectId, entry.Description)
+			entry.ActivityId, projectId, entry.Description, entry.Billable, entry.AbsenceId)
+
+		now := time.Now().Truncate(time.Second)
+		n := *formatTimestamp(&now)
 
 		q := "INSERT INTO entry (user_id, type_id, start_time, end_time, activity_id, project_id, " +
-			"description) VALUES (?, ?, ?, ?, ?, ?, ?)"
+			"description, billable, absence_id, created_at, updated_at) " +
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 
 		id, cErr := r.insertWithTx(tx, q, etr.userId, etr.typeId, etr.startTime, etr.endTime,
-			etr.activityId, etr.projectId, etr.description)
+			etr.activityId, etr.projectId, etr.description, etr.billable, etr.absenceId, n, n)
 		if cErr != nil {
 			err := e.WrapError(e.SysDbInsertFailed, "Could not create entry in database.", cErr)
 			log.Error(err.StackTrace())
@@ -429,33 +679,58 @@ func (r *EntryRepo) CreateEntry(ctx context.Context, entry *model.Entry) error {
 		}
 
 		entry.Id = id
+		entry.CreatedAt = now
+		entry.UpdatedAt = now
+		entry.Version = 1
 
 		return r.setEntryLabels(tx, entry.Id, entry.Labels)
 	})
 }
 
-// UpdateEntry updates a entry.
-func (r *EntryRepo) UpdateEntry(ctx context.Context, entry *model.Entry) error {
-	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+// UpdateEntry updates a entry. If the entry has a version, the entry is only updated if its version
+// in the database matches. It returns false if the entry was not updated.
+func (r *EntryRepo) UpdateEntry(ctx context.Context, entry *model.Entry) (bool, error) {
+	updated := false
+	err := r.executeInTransaction(ctx, func(tx *sql.Tx) error {
 		projectId, cpErr := r.getOrCreateProject(tx, entry.Project)
 		if cpErr != nil {
 			return cpErr
 		}
 
 		etr := toDbEntry(entry.Id, entry.UserId, entry.TypeId, entry.StartTime, entry.EndTime,
-			entry.ActivityId, projectId, entry.Description)
+			entry.ActivityId, projectId, entry.Description, entry.Billable, entry.AbsenceId)
+
+		now := time.Now().Truncate(time.Second)
 
 		q := "UPDATE entry SET user_id = ?, type_id = ?, start_time = ?, end_time = ?, " +
-			"activity_id = ?, project_id = ?, description = ? WHERE id = ?"
+			"activity_id = ?, project_id = ?, description = ?, billable = ?, updated_at = ?, " +
+			"version = version + 1 WHERE id = ? AND deleted_at IS NULL"
+		args := []any{etr.userId, etr.typeId, etr.startTime, etr.endTime, etr.activityId,
+			etr.projectId, etr.description, etr.billable, *formatTimestamp(&now), etr.id}
+		if entry.Version != 0 {
+			q += " AND version = ?"
+			args = append(args, entry.Version)
+		}
 
-		uErr := r.execWithTx(tx, q, etr.userId, etr.typeId, etr.startTime, etr.endTime,
-			etr.activityId, etr.projectId, etr.description, etr.id)
+		cnt, uErr := r.execCountWithTx(tx, q, args...)
 		if uErr != nil {
 			err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update entry %d in "+
 				"database.", entry.Id), uErr)
 			log.Error(err.StackTrace())
 			return err
 		}
+		if cnt == 0 {
+			return nil
+		}
+
+		ver, vErr := r.getEntryVersion(tx, entry.Id)
+		if vErr != nil {
+			return vErr
+		}
+
+		updated = true
+		entry.Version = ver
+		entry.UpdatedAt = now
 
 		if ulErr := r.setEntryLabels(tx, entry.Id, entry.Labels); ulErr != nil {
 			return ulErr
@@ -467,14 +742,34 @@ func (r *EntryRepo) UpdateEntry(ctx context.Context, entry *model.Entry) error {
 
 		return r.deleteOrphanedLabels(tx)
 	})
+	return updated, err
 }
 
-// DeleteEntryById deletes a entry.
+func (r *EntryRepo) getEntryVersion(tx *sql.Tx, id int) (int, error) {
+	q := "SELECT version FROM entry WHERE id = ?"
+
+	var version int
+	qErr := r.queryValueWithTx(tx, &version, q, id)
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read version of entry %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return 0, err
+	}
+	return version, nil
+}
+
+// DeleteEntryById deletes a entry. The entry is kept as a tombstone (without activity, project,
+// labels and expenses) so that clients can synchronize the deletion.
 func (r *EntryRepo) DeleteEntryById(ctx context.Context, id int) error {
 	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
-		q := "DELETE FROM entry WHERE id = ?"
+		now := time.Now().Truncate(time.Second)
+		n := *formatTimestamp(&now)
+
+		q := "UPDATE entry SET activity_id = NULL, project_id = NULL, deleted_at = ?, " +
+			"updated_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL"
 
-		dErr := r.execWithTx(tx, q, id)
+		dErr := r.execWithTx(tx, q, n, n, id)
 		if dErr != nil {
 			err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete entry %d from "+
 				"database.", id), dErr)
@@ -482,6 +777,20 @@ func (r *EntryRepo) DeleteEntryById(ctx context.Context, id int) error {
 			return err
 		}
 
+		if dlErr := r.setEntryLabels(tx, id, nil); dlErr != nil {
+			return dlErr
+		}
+
+		q = "DELETE FROM expense WHERE entry_id = ?"
+
+		dxErr := r.execWithTx(tx, q, id)
+		if dxErr != nil {
+			err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete expenses of "+
+				"entry %d from database.", id), dxErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
 		if dpErr := r.deleteOrphanedProjects(tx); dpErr != nil {
 			return dpErr
 		}
@@ -630,11 +939,124 @@ func (r *EntryRepo) deleteOrphanedLabels(tx *sql.Tx) error {
 	return nil
 }
 
+// --- Entry type functions ---
+
+// GetEntryTypes retrieves all entry types.
+func (r *EntryRepo) GetEntryTypes(ctx context.Context) ([]*model.EntryType, error) {
+	q := "SELECT id, name, description, counts_as_work, reduces_target, consumes_vacation, " +
+		"allows_activities, allows_expenses, color FROM entry_type ORDER BY id ASC"
+
+	sh := newEntryTypeScanHelper()
+	types, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query entry types from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return types, nil
+}
+
+// GetEntryTypeById retrieves a entry type by its ID.
+func (r *EntryRepo) GetEntryTypeById(ctx context.Context, id int) (*model.EntryType, error) {
+	q := "SELECT id, name, description, counts_as_work, reduces_target, consumes_vacation, " +
+		"allows_activities, allows_expenses, color FROM entry_type WHERE id = ?"
+
+	sh := newEntryTypeScanHelper()
+	entryType, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query entry type %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return entryType, nil
+}
+
+// ExistsEntryByTypeId checks if a entry or absence (including deleted entries) exists for an entry
+// type.
+func (r *EntryRepo) ExistsEntryByTypeId(ctx context.Context, typeId int) (bool, error) {
+	cnt, cErr := r.count(ctx, "entry", "type_id = ?", typeId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count entries from database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	if cnt > 0 {
+		return true, nil
+	}
+
+	cnt, cErr = r.count(ctx, "absence", "type_id = ?", typeId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count absences from database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// CreateEntryType creates a new entry type.
+func (r *EntryRepo) CreateEntryType(ctx context.Context, entryType *model.EntryType) error {
+	q := "INSERT INTO entry_type (name, description, counts_as_work, reduces_target, " +
+		"consumes_vacation, allows_activities, allows_expenses, color) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	dbEt := toDbEntryType(entryType)
+	id, cErr := r.insert(ctx, q, dbEt.name, dbEt.description, dbEt.countsAsWork, dbEt.reducesTarget,
+		dbEt.consumesVacation, dbEt.allowsActivities, dbEt.allowsExpenses, dbEt.color)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create entry type in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	entryType.Id = id
+
+	return nil
+}
+
+// UpdateEntryType updates a entry type.
+func (r *EntryRepo) UpdateEntryType(ctx context.Context, entryType *model.EntryType) error {
+	q := "UPDATE entry_type SET description = ?, counts_as_work = ?, reduces_target = ?, " +
+		"consumes_vacation = ?, allows_activities = ?, allows_expenses = ?, color = ? WHERE id = ?"
+
+	dbEt := toDbEntryType(entryType)
+	uErr := r.exec(ctx, q, dbEt.description, dbEt.countsAsWork, dbEt.reducesTarget,
+		dbEt.consumesVacation, dbEt.allowsActivities, dbEt.allowsExpenses, dbEt.color, dbEt.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update entry type %d in "+
+			"database.", entryType.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteEntryTypeById deletes a entry type.
+func (r *EntryRepo) DeleteEntryTypeById(ctx context.Context, id int) error {
+	q := "DELETE FROM entry_type WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete entry type %d from "+
+			"database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
+
 // --- Entry activity functions ---
 
-// GetEntryActivities retrieves all entry activities.
+// GetEntryActivities retrieves all entry activities (including archived activities).
 func (r *EntryRepo) GetEntryActivities(ctx context.Context) ([]*model.EntryActivity, error) {
-	q := "SELECT id, description FROM entry_activity"
+	q := "SELECT " + r.getEntryActivitySelectColumns() + " FROM " +
+		r.getEntryActivitySelectTables() + " GROUP BY a.id, a.description, a.archived " +
+		"ORDER BY a.id ASC"
 
 	sh := newEntryActivityScanHelper()
 	activities, qErr := sh.scanRows(r.query(ctx, q))
@@ -647,10 +1069,33 @@ func (r *EntryRepo) GetEntryActivities(ctx context.Context) ([]*model.EntryActiv
 	return activities, nil
 }
 
+// GetEntryActivityById retrieves a entry activity by its ID.
+func (r *EntryRepo) GetEntryActivityById(ctx context.Context, id int) (*model.EntryActivity,
+	error) {
+	q := "SELECT " + r.getEntryActivitySelectColumns() + " FROM " +
+		r.getEntryActivitySelectTables() + " WHERE a.id = ? GROUP BY a.id, a.description, " +
+		"a.archived"
+
+	sh := newEntryActivityScanHelper()
+	activity, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query entry activity %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return activity, nil
+}
+
 // GetEntryActivityByDescription retrieves a entry activity by its description.
 func (r *EntryRepo) GetEntryActivityByDescription(ctx context.Context, description string) (
 	*model.EntryActivity, error) {
-	q := "SELECT id, description FROM entry_activity WHERE description = ?"
+	q := "SELECT " + r.getEntryActivitySelectColumns() + " FROM " +
+		r.getEntryActivitySelectTables() + " WHERE a.description = ? GROUP BY a.id, " +
+		"a.description, a.archived"
 
 	sh := newEntryActivityScanHelper()
 	activity, found, qErr := sh.scanRow(r.queryRow(ctx, q, description))
@@ -666,6 +1111,15 @@ func (r *EntryRepo) GetEntryActivityByDescription(ctx context.Context, descripti
 	return activity, nil
 }
 
+func (r *EntryRepo) getEntryActivitySelectColumns() string {
+	return "a.id, a.description, a.archived, GROUP_CONCAT(ta.entry_type_id ORDER BY " +
+		"ta.entry_type_id SEPARATOR ',') AS type_ids"
+}
+
+func (r *EntryRepo) getEntryActivitySelectTables() string {
+	return "entry_activity a LEFT JOIN entry_type_activity ta ON ta.entry_activity_id = a.id"
+}
+
 // ExistsEntryActivityById checks if a entry activity exists.
 func (r *EntryRepo) ExistsEntryActivityById(ctx context.Context, id int) (bool, error) {
 	cnt, cErr := r.count(ctx, "entry_activity", "id = ?", id)
@@ -681,36 +1135,99 @@ func (r *EntryRepo) ExistsEntryActivityById(ctx context.Context, id int) (bool,
 // CreateEntryActivity creates a new entry activity.
 func (r *EntryRepo) CreateEntryActivity(ctx context.Context,
 	entryActivity *model.EntryActivity) error {
-	q := "INSERT INTO entry_activity (description) VALUES (?)"
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		q := "INSERT INTO entry_activity (description, archived) VALUES (?, ?)"
 
-	id, cErr := r.insert(ctx, q, entryActivity.Description)
-	if cErr != nil {
-		err := e.WrapError(e.SysDbInsertFailed, "Could not create entry activity in database.", cErr)
-		log.Error(err.StackTrace())
-		return err
-	}
+		id, cErr := r.insertWithTx(tx, q, entryActivity.Description, entryActivity.Archived)
+		if cErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, "Could not create entry activity in database.",
+				cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
 
-	entryActivity.Id = id
+		entryActivity.Id = id
 
-	return nil
+		return r.setEntryActivityTypes(tx, entryActivity.Id, entryActivity.TypeIds)
+	})
 }
 
 // UpdateEntryActivity updates a entry activity.
 func (r *EntryRepo) UpdateEntryActivity(ctx context.Context,
 	entryActivity *model.EntryActivity) error {
-	q := "UPDATE entry_activity SET description = ? WHERE id = ?"
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		q := "UPDATE entry_activity SET description = ?, archived = ? WHERE id = ?"
 
-	uErr := r.exec(ctx, q, entryActivity.Description, entryActivity.Id)
-	if uErr != nil {
-		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update entry activity %d "+
-			"in database.", entryActivity.Id), uErr)
+		uErr := r.execWithTx(tx, q, entryActivity.Description, entryActivity.Archived,
+			entryActivity.Id)
+		if uErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update entry activity "+
+				"%d in database.", entryActivity.Id), uErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		return r.setEntryActivityTypes(tx, entryActivity.Id, entryActivity.TypeIds)
+	})
+}
+
+func (r *EntryRepo) setEntryActivityTypes(tx *sql.Tx, activityId int, typeIds []int) error {
+	q := "DELETE FROM entry_type_activity WHERE entry_activity_id = ?"
+	if dErr := r.execWithTx(tx, q, activityId); dErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, "Could not delete entry activity types from "+
+			"database.", dErr)
 		log.Error(err.StackTrace())
 		return err
 	}
 
+	seen := make(map[int]bool)
+	for _, typeId := range typeIds {
+		if seen[typeId] {
+			continue
+		}
+
+		q := "INSERT INTO entry_type_activity (entry_type_id, entry_activity_id) VALUES (?, ?)"
+		if cErr := r.execWithTx(tx, q, typeId, activityId); cErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, "Could not insert entry activity type into "+
+				"database.", cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		seen[typeId] = true
+	}
+
 	return nil
 }
 
+// MergeEntryActivities reassigns all entries of a entry activity to another entry activity and
+// deletes the merged activity afterwards. Reassigned entries get a new version, so that clients
+// can synchronize the change.
+func (r *EntryRepo) MergeEntryActivities(ctx context.Context, sourceId int, targetId int) error {
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		now := time.Now().Truncate(time.Second)
+
+		q := "UPDATE entry SET activity_id = ?, updated_at = ?, version = version + 1 " +
+			"WHERE activity_id = ?"
+		if uErr := r.execWithTx(tx, q, targetId, *formatTimestamp(&now), sourceId); uErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not reassign entries of "+
+				"entry activity %d in database.", sourceId), uErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		q = "DELETE FROM entry_activity WHERE id = ?"
+		if dErr := r.execWithTx(tx, q, sourceId); dErr != nil {
+			err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete entry activity "+
+				"%d from database.", sourceId), dErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		return nil
+	})
+}
+
 // DeleteEntryActivityById deletes a entry activity.
 func (r *EntryRepo) DeleteEntryActivityById(ctx context.Context, id int) error {
 	q := "DELETE FROM entry_activity WHERE id = ?"
@@ -726,17 +1243,85 @@ func (r *EntryRepo) DeleteEntryActivityById(ctx context.Context, id int) error {
 	return nil
 }
 
+// --- Project rounding policy functions ---
+
+// GetProjectRoundingPolicies retrieves all project rounding policies.
+func (r *EntryRepo) GetProjectRoundingPolicies(ctx context.Context) ([]*model.ProjectRoundingPolicy,
+	error) {
+	q := "SELECT project, mode, interval_minutes, scope FROM project_rounding_policy " +
+		"ORDER BY project ASC"
+
+	sh := newProjectRoundingPolicyScanHelper()
+	policies, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query project rounding policies from "+
+			"database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return policies, nil
+}
+
+// ExistsProjectRoundingPolicy checks if a rounding policy exists for a project.
+func (r *EntryRepo) ExistsProjectRoundingPolicy(ctx context.Context, project string) (bool,
+	error) {
+	cnt, cErr := r.count(ctx, "project_rounding_policy", "project = ?", project)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count project rounding policies in "+
+			"database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+
+	return cnt > 0, nil
+}
+
+// SetProjectRoundingPolicy creates or replaces the rounding policy of a project.
+func (r *EntryRepo) SetProjectRoundingPolicy(ctx context.Context,
+	policy *model.ProjectRoundingPolicy) error {
+	q := "REPLACE INTO project_rounding_policy (project, mode, interval_minutes, scope) " +
+		"VALUES (?, ?, ?, ?)"
+
+	uErr := r.exec(ctx, q, policy.Project, policy.Mode, policy.IntervalMinutes, policy.Scope)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not set rounding policy of "+
+			"project '%s' in database.", policy.Project), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteProjectRoundingPolicy deletes the rounding policy of a project.
+func (r *EntryRepo) DeleteProjectRoundingPolicy(ctx context.Context, project string) error {
+	q := "DELETE FROM project_rounding_policy WHERE project = ?"
+
+	dErr := r.exec(ctx, q, project)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete rounding policy of "+
+			"project '%s' from database.", project), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
+
 // --- Work summary functions ---
 
-// GetWorkSummary gets the work summary for a specific period.
+// GetWorkSummary gets the work summary for a specific period. Only entries of types which are
+// credited against the target hours (work time or target reducing types) are considered.
 func (r *EntryRepo) GetWorkSummary(ctx context.Context, userId int, start time.Time, end time.Time) (
 	*model.WorkSummary,
 	error) {
-	q := "SELECT type_id, SUM(TIMESTAMPDIFF(MINUTE, start_time , end_time)) " +
-		"FROM entry " +
-		"WHERE user_id = ? " +
-		"AND start_time >= ? AND end_time <= ? " +
-		"GROUP BY type_id"
+	q := "SELECT e.type_id, SUM(TIMESTAMPDIFF(MINUTE, e.start_time , e.end_time)) " +
+		"FROM entry e " +
+		"INNER JOIN entry_type et ON et.id = e.type_id " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
+		"AND (et.counts_as_work = 1 OR et.reduces_target = 1) " +
+		"AND e.start_time >= ? AND e.end_time <= ? " +
+		"GROUP BY e.type_id"
 
 	sh := newWorkDurationScanHelper()
 	workDurations, qErr := sh.scanRows(r.query(ctx, q, userId, *formatTimestamp(&start),
@@ -756,18 +1341,82 @@ func (r *EntryRepo) GetWorkSummary(ctx context.Context, userId int, start time.T
 	return workSummary, nil
 }
 
+// GetWorkDays gets the work days (days with entries of types which count as work time) for a
+// specific period. The days are determined in the supplied location.
+func (r *EntryRepo) GetWorkDays(ctx context.Context, userId int, start time.Time, end time.Time,
+	loc *time.Location) ([]*model.WorkDay, error) {
+	q := "SELECT e.start_time, e.end_time " +
+		"FROM entry e " +
+		"INNER JOIN entry_type et ON et.id = e.type_id " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL AND et.counts_as_work = 1 " +
+		"AND e.start_time >= ? AND e.end_time <= ? " +
+		"ORDER BY e.start_time"
+
+	sh := newWorkPeriodScanHelper()
+	workPeriods, qErr := sh.scanRows(r.query(ctx, q, userId, *formatTimestamp(&start),
+		*formatTimestamp(&end)))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query work days from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+
+	return toWorkDays(workPeriods, loc), nil
+}
+
+// GetYearMonthWorkDurations gets the durations of the entries of a year grouped by month and entry
+// type (entries of all types are considered). The months are determined by the start times of the
+// entries in the supplied location.
+func (r *EntryRepo) GetYearMonthWorkDurations(ctx context.Context, userId int, year int,
+	loc *time.Location) ([]*model.MonthWorkDuration, error) {
+	// Build month expression (the month boundaries are calculated in the location, so that the
+	// grouping also works if the database has no time zone information)
+	var mqas []any
+	mq := "CASE"
+	for month := time.January; month < time.December; month++ {
+		end := time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		mq = mq + fmt.Sprintf(" WHEN e.start_time < ? THEN %d", month)
+		mqas = append(mqas, *formatTimestamp(&end))
+	}
+	mq = mq + fmt.Sprintf(" ELSE %d END", time.December)
+
+	q := "SELECT " + mq + " AS entry_month, e.type_id, " +
+		"SUM(TIMESTAMPDIFF(MINUTE, e.start_time , e.end_time)) " +
+		"FROM entry e " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
+		"GROUP BY entry_month, e.type_id " +
+		"ORDER BY entry_month, e.type_id"
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	qas := append(mqas, userId, *formatTimestamp(&start), *formatTimestamp(&end))
+
+	sh := newMonthWorkDurationScanHelper()
+	workDurations, qErr := sh.scanRows(r.query(ctx, q, qas...))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query month work durations from "+
+			"database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+
+	return workDurations, nil
+}
+
 // --- Filter helper functions ---
 
 func (r *EntryRepo) buildEntryFilterQueryRestriction(filter model.EntryFilter) (string, []any) {
 	if filter == nil {
-		return "", nil
+		return "WHERE e.deleted_at IS NULL", nil
 	}
 
 	// Common query restrictions
-	cqr := ""
+	cqr := "e.deleted_at IS NULL"
 	var cqas []any
 	if filter.IsByUser() {
-		cqr = cqr + fmt.Sprintf("e.user_id = %d", filter.GetUserId())
+		cqr = cqr + " AND e.user_id = ?"
+		cqas = append(cqas, filter.GetUserId())
 	}
 
 	// Specific query restrictions
@@ -784,6 +1433,16 @@ func (r *EntryRepo) buildEntryFilterQueryRestriction(filter model.EntryFilter) (
 		tqrs, tqas := r.buildTextEntryFilterQueryRestriction(*f)
 		sqr = joinQueryRestrictions(tqrs, "OR")
 		sqas = tqas
+	case *model.ExpressionEntryFilter:
+		if f.Expression != nil {
+			loc := f.Location
+			if loc == nil {
+				loc = time.Local
+			}
+			zone := newEntryFilterZone(loc)
+			zone.start, zone.end = getEntryFilterStartTimeRange(f.Expression, zone.start, zone.end)
+			sqr, sqas = r.buildExpressionEntryFilterQueryRestriction(f.Expression, zone)
+		}
 	default:
 		err := e.NewError(e.SysUnknown, "Invalid filter type.")
 		log.Error(err.StackTrace())
@@ -818,19 +1477,21 @@ func (r *EntryRepo) buildFieldEntryFilterQueryRestriction(filter model.FieldEntr
 	var qas []any
 
 	if filter.ByType {
-		qrs = append(qrs, fmt.Sprintf("e.type_id = %d", filter.TypeId))
+		qrs = append(qrs, "e.type_id = ?")
+		qas = append(qas, filter.TypeId)
 	}
 
 	if filter.ByTime {
-		qrs = append(qrs, fmt.Sprintf("(e.start_time BETWEEN '%s' AND '%s')",
-			*formatTimestamp(&filter.StartTime), *formatTimestamp(&filter.EndTime)))
+		qrs = append(qrs, "(e.start_time BETWEEN ? AND ?)")
+		qas = append(qas, *formatTimestamp(&filter.StartTime), *formatTimestamp(&filter.EndTime))
 	}
 
 	if filter.ByActivity {
 		if filter.ActivityId == 0 {
 			qrs = append(qrs, "e.activity_id IS NULL")
 		} else {
-			qrs = append(qrs, fmt.Sprintf("e.activity_id = %d", filter.ActivityId))
+			qrs = append(qrs, "e.activity_id = ?")
+			qas = append(qas, filter.ActivityId)
 		}
 	}
 
@@ -892,6 +1553,221 @@ func (r *EntryRepo) buildTextEntryFilterQueryRestriction(filter model.TextEntryF
 	return qrs, qas
 }
 
+func (r *EntryRepo) buildExpressionEntryFilterQueryRestriction(
+	expression model.EntryFilterExpression, zone *entryFilterZone) (string, []any) {
+	switch x := expression.(type) {
+	case *model.AndEntryFilterExpression:
+		return r.buildJunctionEntryFilterQueryRestriction(x.Expressions, "AND", "TRUE", zone)
+	case *model.OrEntryFilterExpression:
+		return r.buildJunctionEntryFilterQueryRestriction(x.Expressions, "OR", "FALSE", zone)
+	case *model.NotEntryFilterExpression:
+		qr, qas := r.buildExpressionEntryFilterQueryRestriction(x.Expression, zone)
+		return "NOT (" + qr + ")", qas
+	case *model.ConditionEntryFilterExpression:
+		return r.buildConditionEntryFilterQueryRestriction(x, zone)
+	default:
+		err := e.NewError(e.SysUnknown, "Invalid filter expression type.")
+		log.Error(err.StackTrace())
+		panic(err)
+	}
+}
+
+func (r *EntryRepo) buildJunctionEntryFilterQueryRestriction(
+	expressions []model.EntryFilterExpression, conjunction string, empty string,
+	zone *entryFilterZone) (string, []any) {
+	if len(expressions) == 0 {
+		return empty, nil
+	}
+
+	qrs := make([]string, 0, len(expressions))
+	var qas []any
+	for _, expression := range expressions {
+		qr, eqas := r.buildExpressionEntryFilterQueryRestriction(expression, zone)
+		qrs = append(qrs, "("+qr+")")
+		qas = append(qas, eqas...)
+	}
+
+	return joinQueryRestrictions(qrs, conjunction), qas
+}
+
+func (r *EntryRepo) buildConditionEntryFilterQueryRestriction(
+	condition *model.ConditionEntryFilterExpression, zone *entryFilterZone) (string, []any) {
+	// Labels are stored in a separate table
+	if condition.Field == model.EntryFilterFieldLabels {
+		return r.buildLabelsConditionEntryFilterQueryRestriction(condition)
+	}
+
+	// Get column (nullable columns are compared via a non-null replacement, so that conditions
+	// evaluate to false instead of null and can be negated)
+	col, cmpCol, cqas := r.getEntryFilterFieldColumns(condition.Field, zone)
+
+	qas := make([]any, 0, len(cqas)+len(condition.Values))
+	qas = append(qas, cqas...)
+	for _, value := range condition.Values {
+		qas = append(qas, toDbEntryFilterValue(value))
+	}
+
+	switch condition.Operator {
+	case model.EntryFilterOpIsNull:
+		return col + " IS NULL", cqas
+	case model.EntryFilterOpEqual:
+		return cmpCol + " = ?", qas
+	case model.EntryFilterOpContains:
+		return cmpCol + " LIKE ?", append(cqas, "%"+escapeRestrictionString(qas[len(cqas)].(string))+
+			"%")
+	case model.EntryFilterOpBetween:
+		return cmpCol + " BETWEEN ? AND ?", qas
+	case model.EntryFilterOpIn:
+		return cmpCol + " IN (" + createPlaceholderString(len(condition.Values)) + ")", qas
+	case model.EntryFilterOpGreater:
+		return cmpCol + " > ?", qas
+	case model.EntryFilterOpLess:
+		return cmpCol + " < ?", qas
+	default:
+		err := e.NewError(e.SysUnknown, "Invalid filter operator.")
+		log.Error(err.StackTrace())
+		panic(err)
+	}
+}
+
+func (r *EntryRepo) buildLabelsConditionEntryFilterQueryRestriction(
+	condition *model.ConditionEntryFilterExpression) (string, []any) {
+	if condition.Operator == model.EntryFilterOpIsNull {
+		sq := "SELECT 1 FROM entry_label el WHERE el.entry_id = e.id"
+		return "NOT EXISTS (" + sq + ")", nil
+	}
+
+	sq := "SELECT 1 " +
+		"FROM entry_label el " +
+		"JOIN label l ON el.label_id = l.id " +
+		"WHERE el.entry_id = e.id " +
+		"AND l.name IN (" + createPlaceholderString(len(condition.Values)) + ")"
+	return "EXISTS (" + sq + ")", condition.Values
+}
+
+func (r *EntryRepo) getEntryFilterFieldColumns(field model.EntryFilterField,
+	zone *entryFilterZone) (string, string, []any) {
+	switch field {
+	case model.EntryFilterFieldType:
+		return "e.type_id", "e.type_id", nil
+	case model.EntryFilterFieldStartTime:
+		return "e.start_time", "e.start_time", nil
+	case model.EntryFilterFieldEndTime:
+		return "e.end_time", "e.end_time", nil
+	case model.EntryFilterFieldDuration:
+		col := "TIMESTAMPDIFF(MINUTE, e.start_time, e.end_time)"
+		return col, col, nil
+	case model.EntryFilterFieldWeekday:
+		// Timestamps are stored in UTC, so they are shifted by the UTC offset of the location that
+		// applied at their instant
+		oq, oqas := buildUtcOffsetExpression("e.start_time", zone)
+		col := "DAYOFWEEK(DATE_ADD(e.start_time, INTERVAL " + oq + " SECOND))"
+		return col, col, oqas
+	case model.EntryFilterFieldActivity:
+		return "e.activity_id", "IFNULL(e.activity_id, 0)", nil
+	case model.EntryFilterFieldProject:
+		return "p.name", "IFNULL(p.name, '')", nil
+	case model.EntryFilterFieldDescription:
+		return "e.description", "IFNULL(e.description, '')", nil
+	default:
+		err := e.NewError(e.SysUnknown, "Invalid filter field.")
+		log.Error(err.StackTrace())
+		panic(err)
+	}
+}
+
+// buildUtcOffsetExpression builds an expression which evaluates to the UTC offset (in seconds) of
+// the location at the instant of a timestamp column. The offset changes of the location are
+// resolved in Go, so that no time zone information is needed in the database. (Only the changes
+// inside the period of the zone are resolved.)
+func buildUtcOffsetExpression(col string, zone *entryFilterZone) (string, []any) {
+	var qas []any
+	q := "CASE"
+	loc := zone.loc
+	t := zone.start
+	end := zone.end
+	for t.Before(end) {
+		_, offset := t.In(loc).Zone()
+		_, zoneEnd := t.In(loc).ZoneBounds()
+		if zoneEnd.IsZero() || !zoneEnd.Before(end) {
+			break
+		}
+		q = q + " WHEN " + col + " < ? THEN ?"
+		qas = append(qas, *formatTimestamp(&zoneEnd), offset)
+		t = zoneEnd
+	}
+	_, offset := t.In(loc).Zone()
+	qas = append(qas, offset)
+
+	// If the offset never changes: Use it directly (a CASE needs at least one WHEN)
+	if len(qas) == 1 {
+		return "?", qas
+	}
+	return q + " ELSE ? END", qas
+}
+
+// getEntryFilterStartTimeRange returns the period (inside the supplied period) in which the start
+// times of all entries matching the expression lie.
+func getEntryFilterStartTimeRange(expression model.EntryFilterExpression, start time.Time,
+	end time.Time) (time.Time, time.Time) {
+	switch x := expression.(type) {
+	case *model.AndEntryFilterExpression:
+		// All expressions must match: Intersect their periods
+		for _, ex := range x.Expressions {
+			start, end = getEntryFilterStartTimeRange(ex, start, end)
+		}
+		return start, end
+	case *model.OrEntryFilterExpression:
+		// One expression must match: Unite their periods
+		if len(x.Expressions) == 0 {
+			return start, end
+		}
+		uStart, uEnd := getEntryFilterStartTimeRange(x.Expressions[0], start, end)
+		for _, ex := range x.Expressions[1:] {
+			eStart, eEnd := getEntryFilterStartTimeRange(ex, start, end)
+			uStart, uEnd = earlierTime(uStart, eStart), laterTime(uEnd, eEnd)
+		}
+		return uStart, uEnd
+	case *model.ConditionEntryFilterExpression:
+		if x.Field != model.EntryFilterFieldStartTime {
+			return start, end
+		}
+		values := make([]time.Time, 0, len(x.Values))
+		for _, value := range x.Values {
+			if t, ok := value.(time.Time); ok {
+				values = append(values, t)
+			}
+		}
+		if len(values) == 0 {
+			return start, end
+		}
+		switch x.Operator {
+		case model.EntryFilterOpEqual, model.EntryFilterOpBetween, model.EntryFilterOpIn:
+			return laterTime(start, slices.MinFunc(values, time.Time.Compare)),
+				earlierTime(end, slices.MaxFunc(values, time.Time.Compare))
+		case model.EntryFilterOpGreater:
+			return laterTime(start, values[0]), end
+		case model.EntryFilterOpLess:
+			return start, earlierTime(end, values[0])
+		}
+	}
+	return start, end
+}
+
+func earlierTime(a time.Time, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func laterTime(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
 func joinQueryRestrictions(qrs []string, conjunction string) string {
 	if len(qrs) == 0 {
 		return ""
@@ -907,16 +1783,59 @@ func (r *EntryRepo) buildEntrySortQueryClause(sort *model.EntrySort) string {
 	}
 
 	if sort.ByTime == model.NoSorting || sort.ByTime == model.AscSorting {
-		return "ORDER BY e.start_time ASC, e.end_time ASC"
+		return "ORDER BY e.start_time ASC, e.end_time ASC, e.id ASC"
 	} else {
-		return "ORDER BY e.start_time DESC, e.end_time DESC"
+		return "ORDER BY e.start_time DESC, e.end_time DESC, e.id DESC"
+	}
+}
+
+// buildEntryCursorQueryRestriction restricts the entries to the entries after the cursor in the
+// order of the supplied sort.
+func (r *EntryRepo) buildEntryCursorQueryRestriction(sort *model.EntrySort,
+	cursor *model.EntryCursor) (string, []any) {
+	op := ">"
+	if sort != nil && sort.ByTime == model.DescSorting {
+		op = "<"
 	}
+
+	cst := *formatTimestamp(&cursor.StartTime)
+	cet := *formatTimestamp(&cursor.EndTime)
+	qr := "(e.start_time " + op + " ? OR (e.start_time = ? AND (e.end_time " + op + " ? OR " +
+		"(e.end_time = ? AND e.id " + op + " ?))))"
+	return qr, []any{cst, cst, cet, cet, cursor.Id}
 }
 
 // --- Date range helper functions ---
 
-func (r *EntryRepo) getDateRange(ctx context.Context, query string, args ...any) (
-	string, string, error) {
+// getEntryFilterZone returns the zone of the supplied location restricted to the period of the
+// start times of the entries matching the filter.
+func getEntryFilterZone(filter model.EntryFilter, loc *time.Location) *entryFilterZone {
+	zone := newEntryFilterZone(loc)
+	switch f := filter.(type) {
+	case *model.FieldEntryFilter:
+		if f.ByTime {
+			zone.start = laterTime(zone.start, f.StartTime)
+			zone.end = earlierTime(zone.end, f.EndTime)
+		}
+	case *model.ExpressionEntryFilter:
+		if f.Expression != nil {
+			zone.start, zone.end = getEntryFilterStartTimeRange(f.Expression, zone.start, zone.end)
+		}
+	}
+	return zone
+}
+
+// buildEntryDateExpression builds an expression which evaluates to the date of the start time of
+// an entry in the location of the zone.
+func buildEntryDateExpression(zone *entryFilterZone) (string, []any) {
+	oq, oqas := buildUtcOffsetExpression("e.start_time", zone)
+	return "DATE(DATE_ADD(e.start_time, INTERVAL " + oq + " SECOND))", oqas
+}
+
+// getDateRange queries the dates of a page and returns the start and end timestamp of the range
+// of these dates in the supplied location. The end timestamp is exclusive.
+func (r *EntryRepo) getDateRange(ctx context.Context, loc *time.Location, query string,
+	args ...any) (string, string, error) {
 	rows, err := r.getDbHandle(ctx).Query(query, args...)
 	if err != nil {
 		return "", "", err
@@ -924,8 +1843,8 @@ func (r *EntryRepo) getDateRange(ctx context.Context, query string, args ...any)
 	defer rows.Close()
 
 	noRows := true
-	min := time.Date(9999, time.January, 1, 0, 0, 0, 0, time.Local)
-	max := time.Date(1000, time.January, 1, 0, 0, 0, 0, time.Local)
+	min := time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC)
 	for rows.Next() {
 		noRows = false
 		var s string
@@ -947,10 +1866,10 @@ func (r *EntryRepo) getDateRange(ctx context.Context, query string, args ...any)
 		return "", "", nil
 	}
 
-	start := *formatDate(&min)
-	end := *formatDate(&max)
+	start := time.Date(min.Year(), min.Month(), min.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(max.Year(), max.Month(), max.Day()+1, 0, 0, 0, 0, loc)
 
-	return start + " 00:00:00", end + " 23:59:59", nil
+	return *formatTimestamp(&start), *formatTimestamp(&end), nil
 }
 
 // --- Helper functions ---
@@ -963,7 +1882,7 @@ func scanEntryFunc(s scanner) (*model.Entry, error) {
 	var dbE dbReadEntry
 
 	err := s.Scan(&dbE.id, &dbE.userId, &dbE.typeId, &dbE.startTime, &dbE.endTime, &dbE.activityId,
-		&dbE.description, &dbE.project, &dbE.labels)
+		&dbE.description, &dbE.billable, &dbE.absenceId, &dbE.invoiceId, &dbE.createdAt, &dbE.updatedAt, &dbE.version, &dbE.project, &dbE.labels)
 	if err != nil {
 		return nil, err
 	}
@@ -973,19 +1892,73 @@ func scanEntryFunc(s scanner) (*model.Entry, error) {
 	return entry, nil
 }
 
+func newEntryChangeScanHelper() *scanHelper[*model.EntryChange] {
+	return newScanHelper(10, scanEntryChangeFunc)
+}
+
+func scanEntryChangeFunc(s scanner) (*model.EntryChange, error) {
+	var dbE dbReadEntry
+	var dbC dbEntryChange
+
+	err := s.Scan(&dbE.id, &dbE.userId, &dbE.typeId, &dbE.startTime, &dbE.endTime, &dbE.activityId,
+		&dbE.description, &dbE.billable, &dbE.absenceId, &dbE.invoiceId, &dbE.createdAt, &dbE.updatedAt, &dbE.version, &dbE.project, &dbE.labels, &dbC.deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &model.EntryChange{
+		Entry:   fromDbEntry(&dbE),
+		Deleted: dbC.deletedAt.Valid,
+	}
+
+	return change, nil
+}
+
+func newEntryTypeScanHelper() *scanHelper[*model.EntryType] {
+	return newScanHelper(10, scanEntryTypeFunc)
+}
+
+func scanEntryTypeFunc(s scanner) (*model.EntryType, error) {
+	var dbEt dbEntryType
+
+	err := s.Scan(&dbEt.id, &dbEt.name, &dbEt.description, &dbEt.countsAsWork,
+		&dbEt.reducesTarget, &dbEt.consumesVacation, &dbEt.allowsActivities, &dbEt.allowsExpenses,
+		&dbEt.color)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromDbEntryType(&dbEt), nil
+}
+
 func newEntryActivityScanHelper() *scanHelper[*model.EntryActivity] {
 	return newScanHelper(10, scanEntryActivityFunc)
 }
 
 func scanEntryActivityFunc(s scanner) (*model.EntryActivity, error) {
-	var et model.EntryActivity
+	var dbEa dbEntryActivity
+
+	err := s.Scan(&dbEa.id, &dbEa.description, &dbEa.archived, &dbEa.typeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromDbEntryActivity(&dbEa), nil
+}
 
-	err := s.Scan(&et.Id, &et.Description)
+func newProjectRoundingPolicyScanHelper() *scanHelper[*model.ProjectRoundingPolicy] {
+	return newScanHelper(10, scanProjectRoundingPolicyFunc)
+}
+
+func scanProjectRoundingPolicyFunc(s scanner) (*model.ProjectRoundingPolicy, error) {
+	p := model.NewProjectRoundingPolicy()
+
+	err := s.Scan(&p.Project, &p.Mode, &p.IntervalMinutes, &p.Scope)
 	if err != nil {
 		return nil, err
 	}
 
-	return &et, nil
+	return p, nil
 }
 
 func newWorkDurationScanHelper() *scanHelper[*model.WorkDuration] {
@@ -1005,8 +1978,46 @@ func scanWorkDurationFunc(s scanner) (*model.WorkDuration, error) {
 	return workDuration, nil
 }
 
+func newMonthWorkDurationScanHelper() *scanHelper[*model.MonthWorkDuration] {
+	return newScanHelper(50, scanMonthWorkDurationFunc)
+}
+
+func scanMonthWorkDurationFunc(s scanner) (*model.MonthWorkDuration, error) {
+	var month int
+	var dbWd dbWorkDuration
+
+	err := s.Scan(&month, &dbWd.typeId, &dbWd.workDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	workDuration := fromDbWorkDuration(&dbWd)
+
+	monthWorkDuration := model.NewMonthWorkDuration()
+	monthWorkDuration.Month = time.Month(month)
+	monthWorkDuration.TypeId = workDuration.TypeId
+	monthWorkDuration.WorkDuration = workDuration.WorkDuration
+	return monthWorkDuration, nil
+}
+
+func newWorkPeriodScanHelper() *scanHelper[*dbWorkPeriod] {
+	return newScanHelper(100, scanWorkPeriodFunc)
+}
+
+func scanWorkPeriodFunc(s scanner) (*dbWorkPeriod, error) {
+	var dbWp dbWorkPeriod
+
+	err := s.Scan(&dbWp.startTime, &dbWp.endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbWp, nil
+}
+
 func toDbEntry(id int, userId int, typeId int, startTime time.Time, endTime time.Time,
-	activityId int, projectId int, description string) *dbWriteEntry {
+	activityId int, projectId int, description string, billable bool,
+	absenceId int) *dbWriteEntry {
 	var out dbWriteEntry
 	out.id = id
 	out.userId = userId
@@ -1028,9 +2039,29 @@ func toDbEntry(id int, userId int, typeId int, startTime time.Time, endTime time
 	} else {
 		out.description = sql.NullString{String: "", Valid: false}
 	}
+	out.billable = billable
+	if absenceId != 0 {
+		out.absenceId = sql.NullInt64{Int64: int64(absenceId), Valid: true}
+	} else {
+		out.absenceId = sql.NullInt64{Int64: 0, Valid: false}
+	}
 	return &out
 }
 
+func toDbEntryFilterValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return *formatTimestamp(&v)
+	case time.Duration:
+		return *formatDuration(&v)
+	case time.Weekday:
+		// MySQL's DAYOFWEEK returns 1 for Sunday ... 7 for Saturday
+		return int(v) + 1
+	default:
+		return v
+	}
+}
+
 func fromDbEntry(in *dbReadEntry) *model.Entry {
 	var out model.Entry
 	out.Id = in.id
@@ -1053,14 +2084,70 @@ func fromDbEntry(in *dbReadEntry) *model.Entry {
 	} else {
 		out.Description = ""
 	}
+	out.Billable = in.billable
+	if in.absenceId.Valid {
+		out.AbsenceId = int(in.absenceId.Int64)
+	} else {
+		out.AbsenceId = 0
+	}
+	if in.invoiceId.Valid {
+		out.InvoiceId = int(in.invoiceId.Int64)
+	} else {
+		out.InvoiceId = 0
+	}
 	if in.labels.Valid && in.labels.String != "" {
 		out.Labels = strings.Split(in.labels.String, ",")
 	} else {
 		out.Labels = []string{}
 	}
+	out.CreatedAt = *parseTimestamp(&in.createdAt)
+	out.UpdatedAt = *parseTimestamp(&in.updatedAt)
+	out.Version = in.version
+	return &out
+}
+
+func toDbEntryType(in *model.EntryType) *dbEntryType {
+	var out dbEntryType
+	out.id = in.Id
+	out.name = toDbNullString(in.Name)
+	out.description = toDbNullString(in.Description)
+	out.countsAsWork = in.CountsAsWork
+	out.reducesTarget = in.ReducesTarget
+	out.consumesVacation = in.ConsumesVacation
+	out.allowsActivities = in.AllowsActivities
+	out.allowsExpenses = in.AllowsExpenses
+	out.color = in.Color
+	return &out
+}
+
+func fromDbEntryType(in *dbEntryType) *model.EntryType {
+	var out model.EntryType
+	out.Id = in.id
+	out.Name = in.name.String
+	out.Description = in.description.String
+	out.CountsAsWork = in.countsAsWork
+	out.ReducesTarget = in.reducesTarget
+	out.ConsumesVacation = in.consumesVacation
+	out.AllowsActivities = in.allowsActivities
+	out.AllowsExpenses = in.allowsExpenses
+	out.Color = in.color
 	return &out
 }
 
+func fromDbEntryActivity(in *dbEntryActivity) *model.EntryActivity {
+	out := model.NewEntryActivity()
+	out.Id = in.id
+	out.Description = in.description
+	out.Archived = in.archived
+	if in.typeIds.Valid && in.typeIds.String != "" {
+		for _, typeId := range strings.Split(in.typeIds.String, ",") {
+			id, _ := strconv.Atoi(typeId)
+			out.TypeIds = append(out.TypeIds, id)
+		}
+	}
+	return out
+}
+
 func toDbWorkDuration(in *model.WorkDuration) *dbWorkDuration {
 	var out dbWorkDuration
 	out.typeId = in.TypeId
@@ -1074,3 +2161,33 @@ func fromDbWorkDuration(in *dbWorkDuration) *model.WorkDuration {
 	out.WorkDuration = *parseDuration(&in.workDuration)
 	return &out
 }
+
+// toWorkDays groups the supplied work periods (sorted by start time) by the day of their start time
+// in the supplied location.
+func toWorkDays(in []*dbWorkPeriod, loc *time.Location) []*model.WorkDay {
+	out := make([]*model.WorkDay, 0, 31)
+	var wd *model.WorkDay
+	var spanStart, spanEnd time.Time
+	addBreak := func() {
+		if wd != nil && spanEnd.Sub(spanStart) > wd.WorkDuration {
+			wd.BreakDuration = spanEnd.Sub(spanStart) - wd.WorkDuration
+		}
+	}
+	for _, wp := range in {
+		startTime := parseTimestamp(&wp.startTime).In(loc)
+		endTime := parseTimestamp(&wp.endTime).In(loc)
+		date := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, loc)
+		if wd == nil || !wd.Date.Equal(date) {
+			addBreak()
+			wd = &model.WorkDay{Date: date}
+			out = append(out, wd)
+			spanStart, spanEnd = startTime, endTime
+		}
+		wd.WorkDuration = wd.WorkDuration + endTime.Sub(startTime)
+		if endTime.After(spanEnd) {
+			spanEnd = endTime
+		}
+	}
+	addBreak()
+	return out
+}
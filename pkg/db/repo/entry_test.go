@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+func TestBuildEntryFilterQueryRestrictionWeekdayIn(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("could not load location: %s", err)
+	}
+
+	filter := model.NewExpressionEntryFilter()
+	filter.Location = loc
+	filter.Expression = &model.ConditionEntryFilterExpression{
+		Field:    model.EntryFilterFieldWeekday,
+		Operator: model.EntryFilterOpIn,
+		Values:   []any{time.Monday, time.Tuesday},
+	}
+
+	r := &EntryRepo{}
+	qr, qas := r.buildEntryFilterQueryRestriction(filter)
+
+	// Every placeholder (of the offset expression and the IN list) must have an argument
+	if count := strings.Count(qr, "?"); count != len(qas) {
+		t.Errorf("restriction has %d placeholders, but %d arguments: %s", count, len(qas), qr)
+	}
+	if !strings.HasSuffix(qr, " IN (?,?))") {
+		t.Errorf("restriction has no IN list with 2 placeholders: %s", qr)
+	}
+	if qas[len(qas)-2] != 2 || qas[len(qas)-1] != 3 {
+		t.Errorf("IN arguments = %v, want [2 3]", qas[len(qas)-2:])
+	}
+}
+
+func TestBuildEntryFilterQueryRestrictionWeekdayInPeriod(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("could not load location: %s", err)
+	}
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2025, time.January, 1, 0, 0, 0, 0, loc)
+	filter := model.NewExpressionEntryFilter()
+	filter.Location = loc
+	filter.Expression = &model.AndEntryFilterExpression{Expressions: []model.EntryFilterExpression{
+		&model.ConditionEntryFilterExpression{
+			Field:    model.EntryFilterFieldStartTime,
+			Operator: model.EntryFilterOpBetween,
+			Values:   []any{start, end},
+		},
+		&model.NotEntryFilterExpression{Expression: &model.ConditionEntryFilterExpression{
+			Field:    model.EntryFilterFieldWeekday,
+			Operator: model.EntryFilterOpEqual,
+			Values:   []any{time.Sunday},
+		}},
+	}}
+
+	r := &EntryRepo{}
+	qr, qas := r.buildEntryFilterQueryRestriction(filter)
+
+	// Only the 2 offset changes of 2024 are resolved (2 arguments each, plus the last offset)
+	if count := strings.Count(qr, "?"); count != len(qas) {
+		t.Errorf("restriction has %d placeholders, but %d arguments: %s", count, len(qas), qr)
+	}
+	if count := strings.Count(qr, "WHEN"); count != 2 {
+		t.Errorf("offset expression has %d cases, want 2: %s", count, qr)
+	}
+	if len(qas) != 2+5+1 {
+		t.Errorf("got %d arguments, want %d: %v", len(qas), 2+5+1, qas)
+	}
+}
+
+func TestBuildGetDateEntriesRangeQuery(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("could not load location: %s", err)
+	}
+
+	filter := model.NewFieldEntryFilter()
+	filter.SetUserFilter(1)
+	filter.ByTime = true
+	filter.StartTime = time.Date(2024, time.March, 1, 0, 0, 0, 0, loc)
+	filter.EndTime = time.Date(2024, time.April, 1, 0, 0, 0, 0, loc)
+
+	r := &EntryRepo{}
+	q, qas := r.buildGetDateEntriesRangeQuery(filter, model.NewEntrySort(), 10, 5, loc)
+
+	// The dates are determined and paged in the database (with the offset change of March 2024)
+	if count := strings.Count(q, "?"); count != len(qas) {
+		t.Errorf("query has %d placeholders, but %d arguments: %s", count, len(qas), q)
+	}
+	if count := strings.Count(q, "WHEN"); count != 1 {
+		t.Errorf("date expression has %d cases, want 1: %s", count, q)
+	}
+	if !strings.HasSuffix(q, "LIMIT 10, 5") {
+		t.Errorf("query is not paged: %s", q)
+	}
+}
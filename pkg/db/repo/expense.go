@@ -0,0 +1,317 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbExpenseRate struct {
+	id       int
+	rateType string
+	name     string
+	amount   float64
+	currency string
+}
+
+type dbExpense struct {
+	id          int
+	entryId     int
+	expType     string
+	rateId      sql.NullInt64
+	quantity    float64
+	amount      float64
+	currency    string
+	description string
+}
+
+// ExpenseRepo retrieves and stores expense and expense rate records.
+type ExpenseRepo struct {
+	repo
+}
+
+// NewExpenseRepo creates a new expense repository.
+func NewExpenseRepo(db *sql.DB) *ExpenseRepo {
+	return &ExpenseRepo{repo{db}}
+}
+
+// --- Expense rate functions ---
+
+// GetExpenseRates retrieves all expense rates.
+func (r *ExpenseRepo) GetExpenseRates(ctx context.Context) ([]*model.ExpenseRate, error) {
+	q := "SELECT id, type, name, amount, currency FROM expense_rate ORDER BY type ASC, id ASC"
+
+	sh := newExpenseRateScanHelper()
+	rates, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query expense rates from database.",
+			qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return rates, nil
+}
+
+// GetExpenseRateById retrieves an expense rate by its ID.
+func (r *ExpenseRepo) GetExpenseRateById(ctx context.Context, id int) (*model.ExpenseRate,
+	error) {
+	q := "SELECT id, type, name, amount, currency FROM expense_rate WHERE id = ?"
+
+	sh := newExpenseRateScanHelper()
+	rate, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query expense rate %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return rate, nil
+}
+
+// CreateExpenseRate creates a new expense rate.
+func (r *ExpenseRepo) CreateExpenseRate(ctx context.Context, rate *model.ExpenseRate) error {
+	q := "INSERT INTO expense_rate (type, name, amount, currency) VALUES (?, ?, ?, ?)"
+
+	dbR := toDbExpenseRate(rate)
+	id, cErr := r.insert(ctx, q, dbR.rateType, dbR.name, dbR.amount, dbR.currency)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create expense rate in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	rate.Id = id
+	return nil
+}
+
+// UpdateExpenseRate updates an expense rate. The type of the rate can not be changed.
+func (r *ExpenseRepo) UpdateExpenseRate(ctx context.Context, rate *model.ExpenseRate) error {
+	q := "UPDATE expense_rate SET name = ?, amount = ?, currency = ? WHERE id = ?"
+
+	dbR := toDbExpenseRate(rate)
+	uErr := r.exec(ctx, q, dbR.name, dbR.amount, dbR.currency, dbR.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update expense rate %d in "+
+			"database.", rate.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteExpenseRateById deletes an expense rate by its ID.
+func (r *ExpenseRepo) DeleteExpenseRateById(ctx context.Context, id int) error {
+	q := "DELETE FROM expense_rate WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete expense rate %d "+
+			"from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// ExistsExpenseByRateId checks if an expense exists which was calculated with an expense rate.
+func (r *ExpenseRepo) ExistsExpenseByRateId(ctx context.Context, rateId int) (bool, error) {
+	cnt, cErr := r.count(ctx, "expense", "rate_id = ?", rateId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count expenses from database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// --- Expense functions ---
+
+// GetExpensesByEntryId retrieves all expenses of an entry.
+func (r *ExpenseRepo) GetExpensesByEntryId(ctx context.Context, entryId int) ([]*model.Expense,
+	error) {
+	q := "SELECT id, entry_id, type, rate_id, quantity, amount, currency, description " +
+		"FROM expense WHERE entry_id = ? ORDER BY id ASC"
+
+	sh := newExpenseScanHelper()
+	expenses, qErr := sh.scanRows(r.query(ctx, q, entryId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query expenses of entry %d "+
+			"from database.", entryId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// GetExpensesByUserId retrieves all expenses of entries of a user which start in a specific time
+// interval (ordered by start time of the entries).
+func (r *ExpenseRepo) GetExpensesByUserId(ctx context.Context, userId int, start time.Time,
+	end time.Time) ([]*model.Expense, error) {
+	q := "SELECT x.id, x.entry_id, x.type, x.rate_id, x.quantity, x.amount, x.currency, " +
+		"x.description " +
+		"FROM expense x " +
+		"INNER JOIN entry e ON e.id = x.entry_id " +
+		"WHERE e.user_id = ? AND e.deleted_at IS NULL " +
+		"AND e.start_time >= ? AND e.start_time < ? " +
+		"ORDER BY e.start_time ASC, x.id ASC"
+
+	sh := newExpenseScanHelper()
+	expenses, qErr := sh.scanRows(r.query(ctx, q, userId, *formatTimestamp(&start),
+		*formatTimestamp(&end)))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query expenses of user %d "+
+			"from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// GetExpenseById retrieves an expense by its ID.
+func (r *ExpenseRepo) GetExpenseById(ctx context.Context, id int) (*model.Expense, error) {
+	q := "SELECT id, entry_id, type, rate_id, quantity, amount, currency, description " +
+		"FROM expense WHERE id = ?"
+
+	sh := newExpenseScanHelper()
+	expense, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query expense %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return expense, nil
+}
+
+// CreateExpense creates a new expense.
+func (r *ExpenseRepo) CreateExpense(ctx context.Context, expense *model.Expense) error {
+	q := "INSERT INTO expense (entry_id, type, rate_id, quantity, amount, currency, " +
+		"description) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	dbX := toDbExpense(expense)
+	id, cErr := r.insert(ctx, q, dbX.entryId, dbX.expType, dbX.rateId, dbX.quantity, dbX.amount,
+		dbX.currency, dbX.description)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create expense in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	expense.Id = id
+	return nil
+}
+
+// UpdateExpense updates an expense. The entry of the expense can not be changed.
+func (r *ExpenseRepo) UpdateExpense(ctx context.Context, expense *model.Expense) error {
+	q := "UPDATE expense SET type = ?, rate_id = ?, quantity = ?, amount = ?, currency = ?, " +
+		"description = ? WHERE id = ?"
+
+	dbX := toDbExpense(expense)
+	uErr := r.exec(ctx, q, dbX.expType, dbX.rateId, dbX.quantity, dbX.amount, dbX.currency,
+		dbX.description, dbX.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update expense %d in "+
+			"database.", expense.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteExpenseById deletes an expense by its ID.
+func (r *ExpenseRepo) DeleteExpenseById(ctx context.Context, id int) error {
+	q := "DELETE FROM expense WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete expense %d from "+
+			"database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newExpenseRateScanHelper() *scanHelper[*model.ExpenseRate] {
+	return newScanHelper(10, scanExpenseRateFunc)
+}
+
+func scanExpenseRateFunc(s scanner) (*model.ExpenseRate, error) {
+	var dbR dbExpenseRate
+	err := s.Scan(&dbR.id, &dbR.rateType, &dbR.name, &dbR.amount, &dbR.currency)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbExpenseRate(&dbR), nil
+}
+
+func toDbExpenseRate(in *model.ExpenseRate) *dbExpenseRate {
+	var out dbExpenseRate
+	out.id = in.Id
+	out.rateType = in.Type
+	out.name = in.Name
+	out.amount = in.Amount
+	out.currency = in.Currency
+	return &out
+}
+
+func fromDbExpenseRate(in *dbExpenseRate) *model.ExpenseRate {
+	var out model.ExpenseRate
+	out.Id = in.id
+	out.Type = in.rateType
+	out.Name = in.name
+	out.Amount = in.amount
+	out.Currency = in.currency
+	return &out
+}
+
+func newExpenseScanHelper() *scanHelper[*model.Expense] {
+	return newScanHelper(10, scanExpenseFunc)
+}
+
+func scanExpenseFunc(s scanner) (*model.Expense, error) {
+	var dbX dbExpense
+	err := s.Scan(&dbX.id, &dbX.entryId, &dbX.expType, &dbX.rateId, &dbX.quantity, &dbX.amount,
+		&dbX.currency, &dbX.description)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbExpense(&dbX), nil
+}
+
+func toDbExpense(in *model.Expense) *dbExpense {
+	var out dbExpense
+	out.id = in.Id
+	out.entryId = in.EntryId
+	out.expType = in.Type
+	out.rateId = toDbNullId(in.RateId)
+	out.quantity = in.Quantity
+	out.amount = in.Amount
+	out.currency = in.Currency
+	out.description = in.Description
+	return &out
+}
+
+func fromDbExpense(in *dbExpense) *model.Expense {
+	var out model.Expense
+	out.Id = in.id
+	out.EntryId = in.entryId
+	out.Type = in.expType
+	out.RateId = int(in.rateId.Int64)
+	out.Quantity = in.quantity
+	out.Amount = in.amount
+	out.Currency = in.currency
+	out.Description = in.description
+	return &out
+}
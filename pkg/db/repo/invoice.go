@@ -0,0 +1,687 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbClient struct {
+	id       int
+	name     string
+	address  string
+	currency string
+	projects sql.NullString
+}
+
+type dbHourlyRate struct {
+	id         int
+	userId     sql.NullInt64
+	project    sql.NullString
+	activityId sql.NullInt64
+	amount     float64
+	currency   string
+	validFrom  string
+	validTo    sql.NullString
+}
+
+type dbInvoice struct {
+	id          int
+	number      string
+	clientId    int
+	startDate   string
+	endDate     string
+	currency    string
+	netAmount   float64
+	taxRate     float64
+	taxAmount   float64
+	totalAmount float64
+	createdAt   string
+}
+
+type dbInvoiceItem struct {
+	id          int
+	invoiceId   int
+	entryId     int
+	userId      int
+	date        string
+	project     string
+	description string
+	hours       float64
+	rate        float64
+	amount      float64
+}
+
+// InvoiceRepo retrieves and stores client, hourly rate and invoice records.
+type InvoiceRepo struct {
+	repo
+}
+
+// NewInvoiceRepo creates a new invoice repository.
+func NewInvoiceRepo(db *sql.DB) *InvoiceRepo {
+	return &InvoiceRepo{repo{db}}
+}
+
+// --- Client functions ---
+
+// GetClients retrieves all clients (ordered by name).
+func (r *InvoiceRepo) GetClients(ctx context.Context) ([]*model.Client, error) {
+	q := "SELECT " + r.getClientSelectColumns() + " " +
+		"FROM " + r.getClientSelectTables() + " " +
+		"GROUP BY c.id, c.name, c.address, c.currency " +
+		"ORDER BY c.name ASC"
+
+	sh := newClientScanHelper()
+	clients, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query clients from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return clients, nil
+}
+
+// GetClientById retrieves a client by its ID.
+func (r *InvoiceRepo) GetClientById(ctx context.Context, id int) (*model.Client, error) {
+	q := "SELECT " + r.getClientSelectColumns() + " " +
+		"FROM " + r.getClientSelectTables() + " " +
+		"WHERE c.id = ? " +
+		"GROUP BY c.id, c.name, c.address, c.currency"
+
+	sh := newClientScanHelper()
+	client, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query client %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return client, nil
+}
+
+func (r *InvoiceRepo) getClientSelectColumns() string {
+	return "c.id, c.name, c.address, c.currency, " +
+		"GROUP_CONCAT(cp.project ORDER BY cp.project SEPARATOR '\\n') AS projects"
+}
+
+func (r *InvoiceRepo) getClientSelectTables() string {
+	return "client c LEFT JOIN client_project cp ON cp.client_id = c.id"
+}
+
+// ExistsClientByName checks if another client with the supplied name exists.
+func (r *InvoiceRepo) ExistsClientByName(ctx context.Context, name string, exceptId int) (bool,
+	error) {
+	cnt, cErr := r.count(ctx, "client", "name = ? AND id != ?", name, exceptId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count clients from database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// ExistsClientProject checks if the supplied project is assigned to another client.
+func (r *InvoiceRepo) ExistsClientProject(ctx context.Context, project string, exceptClientId int,
+) (bool, error) {
+	cnt, cErr := r.count(ctx, "client_project", "project = ? AND client_id != ?", project,
+		exceptClientId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count client projects from database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// CreateClient creates a new client.
+func (r *InvoiceRepo) CreateClient(ctx context.Context, client *model.Client) error {
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		q := "INSERT INTO client (name, address, currency) VALUES (?, ?, ?)"
+
+		dbC := toDbClient(client)
+		id, cErr := r.insertWithTx(tx, q, dbC.name, dbC.address, dbC.currency)
+		if cErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, "Could not create client in database.", cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+		client.Id = id
+
+		return r.setClientProjects(tx, client.Id, client.Projects)
+	})
+}
+
+// UpdateClient updates a client.
+func (r *InvoiceRepo) UpdateClient(ctx context.Context, client *model.Client) error {
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		q := "UPDATE client SET name = ?, address = ?, currency = ? WHERE id = ?"
+
+		dbC := toDbClient(client)
+		uErr := r.execWithTx(tx, q, dbC.name, dbC.address, dbC.currency, dbC.id)
+		if uErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update client %d in "+
+				"database.", client.Id), uErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		return r.setClientProjects(tx, client.Id, client.Projects)
+	})
+}
+
+func (r *InvoiceRepo) setClientProjects(tx *sql.Tx, clientId int, projects []string) error {
+	q := "DELETE FROM client_project WHERE client_id = ?"
+
+	dErr := r.execWithTx(tx, q, clientId)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete projects of client "+
+			"%d from database.", clientId), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+
+	q = "INSERT INTO client_project (project, client_id) VALUES (?, ?)"
+
+	for _, project := range projects {
+		cErr := r.execWithTx(tx, q, project, clientId)
+		if cErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, fmt.Sprintf("Could not create project '%s' "+
+				"of client %d in database.", project, clientId), cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteClientById deletes a client by its ID.
+func (r *InvoiceRepo) DeleteClientById(ctx context.Context, id int) error {
+	q := "DELETE FROM client WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete client %d from "+
+			"database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Hourly rate functions ---
+
+// GetHourlyRates retrieves all hourly rates (ordered by start of validity).
+func (r *InvoiceRepo) GetHourlyRates(ctx context.Context) ([]*model.HourlyRate, error) {
+	q := "SELECT id, user_id, project, activity_id, amount, currency, valid_from, valid_to " +
+		"FROM hourly_rate ORDER BY valid_from ASC, id ASC"
+
+	sh := newHourlyRateScanHelper()
+	rates, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query hourly rates from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return rates, nil
+}
+
+// GetHourlyRateById retrieves an hourly rate by its ID.
+func (r *InvoiceRepo) GetHourlyRateById(ctx context.Context, id int) (*model.HourlyRate, error) {
+	q := "SELECT id, user_id, project, activity_id, amount, currency, valid_from, valid_to " +
+		"FROM hourly_rate WHERE id = ?"
+
+	sh := newHourlyRateScanHelper()
+	rate, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query hourly rate %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return rate, nil
+}
+
+// CreateHourlyRate creates a new hourly rate.
+func (r *InvoiceRepo) CreateHourlyRate(ctx context.Context, rate *model.HourlyRate) error {
+	q := "INSERT INTO hourly_rate (user_id, project, activity_id, amount, currency, valid_from, " +
+		"valid_to) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	dbR := toDbHourlyRate(rate)
+	id, cErr := r.insert(ctx, q, dbR.userId, dbR.project, dbR.activityId, dbR.amount,
+		dbR.currency, dbR.validFrom, dbR.validTo)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create hourly rate in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	rate.Id = id
+	return nil
+}
+
+// UpdateHourlyRate updates an hourly rate.
+func (r *InvoiceRepo) UpdateHourlyRate(ctx context.Context, rate *model.HourlyRate) error {
+	q := "UPDATE hourly_rate SET user_id = ?, project = ?, activity_id = ?, amount = ?, " +
+		"currency = ?, valid_from = ?, valid_to = ? WHERE id = ?"
+
+	dbR := toDbHourlyRate(rate)
+	uErr := r.exec(ctx, q, dbR.userId, dbR.project, dbR.activityId, dbR.amount, dbR.currency,
+		dbR.validFrom, dbR.validTo, dbR.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update hourly rate %d in "+
+			"database.", rate.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteHourlyRateById deletes an hourly rate by its ID.
+func (r *InvoiceRepo) DeleteHourlyRateById(ctx context.Context, id int) error {
+	q := "DELETE FROM hourly_rate WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete hourly rate %d "+
+			"from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Invoice functions ---
+
+// GetInvoices retrieves all invoices without their items (ordered by number, newest first).
+func (r *InvoiceRepo) GetInvoices(ctx context.Context) ([]*model.Invoice, error) {
+	q := "SELECT " + r.getInvoiceSelectColumns() + " FROM invoice ORDER BY number DESC"
+
+	sh := newInvoiceScanHelper()
+	invoices, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query invoices from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// GetInvoiceById retrieves an invoice including its items by its ID.
+func (r *InvoiceRepo) GetInvoiceById(ctx context.Context, id int) (*model.Invoice, error) {
+	q := "SELECT " + r.getInvoiceSelectColumns() + " FROM invoice WHERE id = ?"
+
+	sh := newInvoiceScanHelper()
+	invoice, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query invoice %d from "+
+			"database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	q = "SELECT id, invoice_id, entry_id, user_id, date, project, description, hours, rate, " +
+		"amount FROM invoice_item WHERE invoice_id = ? ORDER BY date ASC, id ASC"
+
+	ish := newInvoiceItemScanHelper()
+	items, qErr := ish.scanRows(r.query(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query items of invoice %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	invoice.Items = items
+
+	return invoice, nil
+}
+
+func (r *InvoiceRepo) getInvoiceSelectColumns() string {
+	return "id, number, client_id, start_date, end_date, currency, net_amount, tax_rate, " +
+		"tax_amount, total_amount, created_at"
+}
+
+// NextInvoiceNumber increments and returns the sequence number of invoices with the supplied
+// prefix. The sequence row stays locked until the transaction ends, so that concurrent
+// transactions get distinct numbers.
+func (r *InvoiceRepo) NextInvoiceNumber(ctx context.Context, prefix string) (int, error) {
+	var number int
+	err := r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		iq := "INSERT IGNORE INTO invoice_number_sequence (prefix, last_number) VALUES (?, 0)"
+		if iErr := r.execWithTx(tx, iq, prefix); iErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, "Could not insert invoice number sequence "+
+				"into database.", iErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		sq := "SELECT last_number FROM invoice_number_sequence WHERE prefix = ? FOR UPDATE"
+		if qErr := r.queryValueWithTx(tx, &number, sq, prefix); qErr != nil {
+			err := e.WrapError(e.SysDbQueryFailed, "Could not query invoice number sequence "+
+				"from database.", qErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+		number++
+
+		uq := "UPDATE invoice_number_sequence SET last_number = ? WHERE prefix = ?"
+		if uErr := r.execWithTx(tx, uq, number, prefix); uErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, "Could not update invoice number sequence "+
+				"in database.", uErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return number, nil
+}
+
+// ExistsInvoiceByClientId checks if an invoice exists for a client.
+func (r *InvoiceRepo) ExistsInvoiceByClientId(ctx context.Context, clientId int) (bool, error) {
+	cnt, cErr := r.count(ctx, "invoice", "client_id = ?", clientId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count invoices from database.", cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// CreateInvoice creates a new invoice with its items and marks the entries of the items as
+// invoiced. It returns the number of entries which were marked. (Entries which were invoiced or
+// deleted in the meantime are not marked.)
+func (r *InvoiceRepo) CreateInvoice(ctx context.Context, invoice *model.Invoice) (int, error) {
+	marked := 0
+	err := r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		now := time.Now().Truncate(time.Second)
+		n := *formatTimestamp(&now)
+
+		q := "INSERT INTO invoice (number, client_id, start_date, end_date, currency, " +
+			"net_amount, tax_rate, tax_amount, total_amount, created_at) " +
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+		dbI := toDbInvoice(invoice)
+		id, cErr := r.insertWithTx(tx, q, dbI.number, dbI.clientId, dbI.startDate, dbI.endDate,
+			dbI.currency, dbI.netAmount, dbI.taxRate, dbI.taxAmount, dbI.totalAmount, n)
+		if cErr != nil {
+			err := e.WrapError(e.SysDbInsertFailed, "Could not create invoice in database.", cErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+		invoice.Id = id
+		invoice.CreatedAt = now
+
+		q = "INSERT INTO invoice_item (invoice_id, entry_id, user_id, date, project, " +
+			"description, hours, rate, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		uq := "UPDATE entry SET invoice_id = ?, updated_at = ?, version = version + 1 " +
+			"WHERE id = ? AND invoice_id IS NULL AND deleted_at IS NULL"
+
+		for _, item := range invoice.Items {
+			item.InvoiceId = invoice.Id
+			dbII := toDbInvoiceItem(item)
+			itemId, ciErr := r.insertWithTx(tx, q, dbII.invoiceId, dbII.entryId, dbII.userId,
+				dbII.date, dbII.project, dbII.description, dbII.hours, dbII.rate, dbII.amount)
+			if ciErr != nil {
+				err := e.WrapError(e.SysDbInsertFailed, fmt.Sprintf("Could not create item of "+
+					"invoice %d in database.", invoice.Id), ciErr)
+				log.Error(err.StackTrace())
+				return err
+			}
+			item.Id = itemId
+
+			cnt, uErr := r.execCountWithTx(tx, uq, invoice.Id, n, item.EntryId)
+			if uErr != nil {
+				err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not mark entry %d as "+
+					"invoiced in database.", item.EntryId), uErr)
+				log.Error(err.StackTrace())
+				return err
+			}
+			marked += cnt
+		}
+
+		return nil
+	})
+	return marked, err
+}
+
+// DeleteInvoiceById deletes an invoice by its ID. The entries of the invoice are released, so
+// that they can be changed and invoiced again.
+func (r *InvoiceRepo) DeleteInvoiceById(ctx context.Context, id int) error {
+	return r.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		now := time.Now().Truncate(time.Second)
+
+		q := "UPDATE entry SET invoice_id = NULL, updated_at = ?, version = version + 1 " +
+			"WHERE invoice_id = ?"
+
+		uErr := r.execWithTx(tx, q, *formatTimestamp(&now), id)
+		if uErr != nil {
+			err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not release entries of "+
+				"invoice %d in database.", id), uErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		q = "DELETE FROM invoice WHERE id = ?"
+
+		dErr := r.execWithTx(tx, q, id)
+		if dErr != nil {
+			err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete invoice %d "+
+				"from database.", id), dErr)
+			log.Error(err.StackTrace())
+			return err
+		}
+
+		return nil
+	})
+}
+
+// --- Helper functions ---
+
+func newClientScanHelper() *scanHelper[*model.Client] {
+	return newScanHelper(10, scanClientFunc)
+}
+
+func scanClientFunc(s scanner) (*model.Client, error) {
+	var dbC dbClient
+	err := s.Scan(&dbC.id, &dbC.name, &dbC.address, &dbC.currency, &dbC.projects)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbClient(&dbC), nil
+}
+
+func toDbClient(in *model.Client) *dbClient {
+	var out dbClient
+	out.id = in.Id
+	out.name = in.Name
+	out.address = in.Address
+	out.currency = in.Currency
+	return &out
+}
+
+func fromDbClient(in *dbClient) *model.Client {
+	var out model.Client
+	out.Id = in.id
+	out.Name = in.name
+	out.Address = in.address
+	out.Currency = in.currency
+	if in.projects.Valid && in.projects.String != "" {
+		out.Projects = strings.Split(in.projects.String, "\n")
+	} else {
+		out.Projects = []string{}
+	}
+	return &out
+}
+
+func newHourlyRateScanHelper() *scanHelper[*model.HourlyRate] {
+	return newScanHelper(10, scanHourlyRateFunc)
+}
+
+func scanHourlyRateFunc(s scanner) (*model.HourlyRate, error) {
+	var dbR dbHourlyRate
+	err := s.Scan(&dbR.id, &dbR.userId, &dbR.project, &dbR.activityId, &dbR.amount, &dbR.currency,
+		&dbR.validFrom, &dbR.validTo)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbHourlyRate(&dbR), nil
+}
+
+func toDbHourlyRate(in *model.HourlyRate) *dbHourlyRate {
+	var out dbHourlyRate
+	out.id = in.Id
+	if in.UserId != 0 {
+		out.userId = sql.NullInt64{Int64: int64(in.UserId), Valid: true}
+	} else {
+		out.userId = sql.NullInt64{Int64: 0, Valid: false}
+	}
+	if in.Project != "" {
+		out.project = sql.NullString{String: in.Project, Valid: true}
+	} else {
+		out.project = sql.NullString{String: "", Valid: false}
+	}
+	if in.ActivityId != 0 {
+		out.activityId = sql.NullInt64{Int64: int64(in.ActivityId), Valid: true}
+	} else {
+		out.activityId = sql.NullInt64{Int64: 0, Valid: false}
+	}
+	out.amount = in.Amount
+	out.currency = in.Currency
+	out.validFrom = *formatDate(&in.ValidFrom)
+	if !in.ValidTo.IsZero() {
+		out.validTo = sql.NullString{String: *formatDate(&in.ValidTo), Valid: true}
+	} else {
+		out.validTo = sql.NullString{String: "", Valid: false}
+	}
+	return &out
+}
+
+func fromDbHourlyRate(in *dbHourlyRate) *model.HourlyRate {
+	var out model.HourlyRate
+	out.Id = in.id
+	if in.userId.Valid {
+		out.UserId = int(in.userId.Int64)
+	}
+	if in.project.Valid {
+		out.Project = in.project.String
+	}
+	if in.activityId.Valid {
+		out.ActivityId = int(in.activityId.Int64)
+	}
+	out.Amount = in.amount
+	out.Currency = in.currency
+	out.ValidFrom = *parseDate(&in.validFrom)
+	if in.validTo.Valid {
+		out.ValidTo = *parseDate(&in.validTo.String)
+	}
+	return &out
+}
+
+func newInvoiceScanHelper() *scanHelper[*model.Invoice] {
+	return newScanHelper(10, scanInvoiceFunc)
+}
+
+func scanInvoiceFunc(s scanner) (*model.Invoice, error) {
+	var dbI dbInvoice
+	err := s.Scan(&dbI.id, &dbI.number, &dbI.clientId, &dbI.startDate, &dbI.endDate,
+		&dbI.currency, &dbI.netAmount, &dbI.taxRate, &dbI.taxAmount, &dbI.totalAmount,
+		&dbI.createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbInvoice(&dbI), nil
+}
+
+func toDbInvoice(in *model.Invoice) *dbInvoice {
+	var out dbInvoice
+	out.id = in.Id
+	out.number = in.Number
+	out.clientId = in.ClientId
+	out.startDate = *formatDate(&in.StartDate)
+	out.endDate = *formatDate(&in.EndDate)
+	out.currency = in.Currency
+	out.netAmount = in.NetAmount
+	out.taxRate = in.TaxRate
+	out.taxAmount = in.TaxAmount
+	out.totalAmount = in.TotalAmount
+	return &out
+}
+
+func fromDbInvoice(in *dbInvoice) *model.Invoice {
+	var out model.Invoice
+	out.Id = in.id
+	out.Number = in.number
+	out.ClientId = in.clientId
+	out.StartDate = *parseDate(&in.startDate)
+	out.EndDate = *parseDate(&in.endDate)
+	out.Currency = in.currency
+	out.NetAmount = in.netAmount
+	out.TaxRate = in.taxRate
+	out.TaxAmount = in.taxAmount
+	out.TotalAmount = in.totalAmount
+	out.CreatedAt = *parseTimestamp(&in.createdAt)
+	out.Items = []*model.InvoiceItem{}
+	return &out
+}
+
+func newInvoiceItemScanHelper() *scanHelper[*model.InvoiceItem] {
+	return newScanHelper(50, scanInvoiceItemFunc)
+}
+
+func scanInvoiceItemFunc(s scanner) (*model.InvoiceItem, error) {
+	var dbII dbInvoiceItem
+	err := s.Scan(&dbII.id, &dbII.invoiceId, &dbII.entryId, &dbII.userId, &dbII.date,
+		&dbII.project, &dbII.description, &dbII.hours, &dbII.rate, &dbII.amount)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbInvoiceItem(&dbII), nil
+}
+
+func toDbInvoiceItem(in *model.InvoiceItem) *dbInvoiceItem {
+	var out dbInvoiceItem
+	out.id = in.Id
+	out.invoiceId = in.InvoiceId
+	out.entryId = in.EntryId
+	out.userId = in.UserId
+	out.date = *formatDate(&in.Date)
+	out.project = in.Project
+	out.description = in.Description
+	out.hours = in.Hours
+	out.rate = in.Rate
+	out.amount = in.Amount
+	return &out
+}
+
+func fromDbInvoiceItem(in *dbInvoiceItem) *model.InvoiceItem {
+	var out model.InvoiceItem
+	out.Id = in.id
+	out.InvoiceId = in.invoiceId
+	out.EntryId = in.entryId
+	out.UserId = in.userId
+	out.Date = *parseDate(&in.date)
+	out.Project = in.project
+	out.Description = in.description
+	out.Hours = in.hours
+	out.Rate = in.rate
+	out.Amount = in.amount
+	return &out
+}
@@ -98,6 +98,38 @@ func (sh *scanHelper[T]) scanRow(row *sql.Row) (T, bool, error) {
 	}
 }
 
+// rowIterator scans the rows of a query result one by one. In contrast to scanHelper.scanRows, only
+// the current row is held in memory.
+type rowIterator[T any] struct {
+	rows   *sql.Rows
+	scanFn scanFunc[T]
+	item   T
+	err    error
+}
+
+func newRowIterator[T any](rows *sql.Rows, scanFn scanFunc[T]) *rowIterator[T] {
+	return &rowIterator[T]{rows: rows, scanFn: scanFn}
+}
+
+func (ri *rowIterator[T]) next() bool {
+	if ri.err != nil || !ri.rows.Next() {
+		return false
+	}
+	ri.item, ri.err = ri.scanFn(ri.rows)
+	return ri.err == nil
+}
+
+func (ri *rowIterator[T]) error() error {
+	if ri.err != nil {
+		return ri.err
+	}
+	return ri.rows.Err()
+}
+
+func (ri *rowIterator[T]) close() error {
+	return ri.rows.Close()
+}
+
 func newScanHelper[T any](capacity int, scanFn scanFunc[T]) *scanHelper[T] {
 	return &scanHelper[T]{capacity: capacity, scanFn: scanFn}
 }
@@ -281,14 +313,38 @@ func execInternal(db dbHandle, query string, args ...any) error {
 	return err
 }
 
+func (r *repo) execCount(ctx context.Context, query string, args ...any) (int, error) {
+	return execCountInternal(r.getDbHandle(ctx), query, args...)
+}
+
+func (r *repo) execCountWithTx(tx *sql.Tx, query string, args ...any) (int, error) {
+	return execCountInternal(tx, query, args...)
+}
+
+func execCountInternal(db dbHandle, query string, args ...any) (int, error) {
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(cnt), nil
+}
+
 // --- Helper functions ---
 
+// parseDate parses a date. Dates have no time zone, so they are returned as midnight UTC. (Callers
+// have to interpret the date in the time zone of the user.)
 func parseDate(ts *string) *time.Time {
 	if ts == nil {
 		return nil
 	}
 
-	t, pErr := time.ParseInLocation(constant.DbDateFormat, *ts, time.Local)
+	t, pErr := time.ParseInLocation(constant.DbDateFormat, *ts, time.UTC)
 	if pErr != nil {
 		err := e.WrapError(e.SysUnknown, "Could not parse date.", pErr)
 		log.Error(err.StackTrace())
@@ -302,8 +358,7 @@ func formatDate(t *time.Time) *string {
 		return nil
 	}
 
-	tl := t.Local()
-	ts := tl.Format(constant.DbDateFormat)
+	ts := t.Format(constant.DbDateFormat)
 	return &ts
 }
 
@@ -312,7 +367,7 @@ func parseTimestamp(ts *string) *time.Time {
 		return nil
 	}
 
-	t, pErr := time.ParseInLocation(constant.DbTimestampFormat, *ts, time.Local)
+	t, pErr := time.ParseInLocation(constant.DbTimestampFormat, *ts, time.UTC)
 	if pErr != nil {
 		err := e.WrapError(e.SysUnknown, "Could not parse timestamp.", pErr)
 		log.Error(err.StackTrace())
@@ -326,8 +381,8 @@ func formatTimestamp(t *time.Time) *string {
 		return nil
 	}
 
-	tl := t.Local()
-	ts := tl.Format(constant.DbTimestampFormat)
+	tu := t.UTC()
+	ts := tu.Format(constant.DbTimestampFormat)
 	return &ts
 }
 
@@ -5,12 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 
 	e "kellnhofer.com/work-log/pkg/error"
 	"kellnhofer.com/work-log/pkg/log"
 	"kellnhofer.com/work-log/pkg/model"
 )
 
+type dbExportProfile struct {
+	id               int
+	userId           int
+	name             string
+	columns          string
+	delimiter        string
+	dateFormat       string
+	durationUnit     string
+	decimalSeparator string
+	language         string
+	labelSeparator   string
+}
+
 // UserRepo retrieves and stores user related entities.
 type UserRepo struct {
 	repo
@@ -25,7 +39,7 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 
 // GetUsers retrieves all users.
 func (r *UserRepo) GetUsers(ctx context.Context) ([]*model.User, error) {
-	q := "SELECT id, name, username, password, must_change_password FROM user"
+	q := "SELECT id, name, username, password, must_change_password, time_zone FROM user"
 
 	sh := newUserScanHelper()
 	users, qErr := sh.scanRows(r.query(ctx, q))
@@ -39,7 +53,8 @@ func (r *UserRepo) GetUsers(ctx context.Context) ([]*model.User, error) {
 
 // GetUserById retrieves a user by its ID.
 func (r *UserRepo) GetUserById(ctx context.Context, id int) (*model.User, error) {
-	q := "SELECT id, name, username, password, must_change_password FROM user WHERE id = ?"
+	q := "SELECT id, name, username, password, must_change_password, time_zone FROM user " +
+		"WHERE id = ?"
 
 	sh := newUserScanHelper()
 	user, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
@@ -57,7 +72,8 @@ func (r *UserRepo) GetUserById(ctx context.Context, id int) (*model.User, error)
 
 // GetUserByUsername retrieves a user by its username.
 func (r *UserRepo) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
-	q := "SELECT id, name, username, password, must_change_password FROM user WHERE username = ?"
+	q := "SELECT id, name, username, password, must_change_password, time_zone FROM user " +
+		"WHERE username = ?"
 
 	sh := newUserScanHelper()
 	user, found, qErr := sh.scanRow(r.queryRow(ctx, q, username))
@@ -88,9 +104,11 @@ func (r *UserRepo) ExistsUserById(ctx context.Context, id int) (bool, error) {
 
 // CreateUser creates a new user.
 func (r *UserRepo) CreateUser(ctx context.Context, user *model.User) error {
-	q := "INSERT INTO user (name, username, password, must_change_password) VALUES (?, ?, ?, ?)"
+	q := "INSERT INTO user (name, username, password, must_change_password, time_zone) " +
+		"VALUES (?, ?, ?, ?, ?)"
 
-	id, cErr := r.insert(ctx, q, user.Name, user.Username, user.Password, user.MustChangePassword)
+	id, cErr := r.insert(ctx, q, user.Name, user.Username, user.Password, user.MustChangePassword,
+		user.TimeZone)
 	if cErr != nil {
 		err := e.WrapError(e.SysDbInsertFailed, "Could not create user in database.", cErr)
 		log.Error(err.StackTrace())
@@ -104,9 +122,11 @@ func (r *UserRepo) CreateUser(ctx context.Context, user *model.User) error {
 
 // UpdateUser updates a user.
 func (r *UserRepo) UpdateUser(ctx context.Context, user *model.User) error {
-	q := "UPDATE user SET name = ?, username = ?, password = ?, must_change_password = ? WHERE id = ?"
+	q := "UPDATE user SET name = ?, username = ?, password = ?, must_change_password = ?, " +
+		"time_zone = ? WHERE id = ?"
 
-	uErr := r.exec(ctx, q, user.Name, user.Username, user.Password, user.MustChangePassword, user.Id)
+	uErr := r.exec(ctx, q, user.Name, user.Username, user.Password, user.MustChangePassword,
+		user.TimeZone, user.Id)
 	if uErr != nil {
 		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update user %d in database.",
 			user.Id), uErr)
@@ -181,6 +201,19 @@ func (r *UserRepo) SetUserRoles(ctx context.Context, userId int, roles []model.R
 
 // --- User settings functions ---
 
+// ExistsUserSetting checks if a setting of a user exists.
+func (r *UserRepo) ExistsUserSetting(ctx context.Context, userId int, key string) (bool, error) {
+	cnt, cErr := r.count(ctx, "user_setting", "user_id = ? AND setting_key = ?", userId, key)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read user setting '%s' for "+
+			"user %d from database.", key, userId), cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+
+	return cnt > 0, nil
+}
+
 // GetUserIntSetting retrieves a integer setting of a user.
 func (r *UserRepo) GetUserIntSetting(ctx context.Context, userId int, key string) (int, error) {
 	v, qErr := r.GetUserStringSetting(ctx, userId, key)
@@ -299,6 +332,112 @@ func (r *UserRepo) UpdateUserStringSetting(ctx context.Context, userId int, key
 	return nil
 }
 
+// --- Export profile functions ---
+
+// GetExportProfilesByUserId retrieves all export profiles of a user.
+func (r *UserRepo) GetExportProfilesByUserId(ctx context.Context, userId int) (
+	[]*model.ExportProfile, error) {
+	q := "SELECT id, user_id, name, export_columns, delimiter, date_format, duration_unit, " +
+		"decimal_separator, language, label_separator FROM export_profile WHERE user_id = ? " +
+		"ORDER BY name"
+
+	sh := newExportProfileScanHelper()
+	profiles, qErr := sh.scanRows(r.query(ctx, q, userId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not query export profiles of "+
+			"user %d from database.", userId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// GetExportProfileById retrieves a export profile by its ID.
+func (r *UserRepo) GetExportProfileById(ctx context.Context, id int) (*model.ExportProfile,
+	error) {
+	q := "SELECT id, user_id, name, export_columns, delimiter, date_format, duration_unit, " +
+		"decimal_separator, language, label_separator FROM export_profile WHERE id = ?"
+
+	sh := newExportProfileScanHelper()
+	profile, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read export profile %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return profile, nil
+}
+
+// ExistsExportProfileByName checks if a user has another export profile with the supplied name.
+func (r *UserRepo) ExistsExportProfileByName(ctx context.Context, userId int, name string,
+	exceptId int) (bool, error) {
+	cnt, cErr := r.count(ctx, "export_profile", "user_id = ? AND name = ? AND id != ?", userId,
+		name, exceptId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not count export profiles from database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// CreateExportProfile creates a new export profile.
+func (r *UserRepo) CreateExportProfile(ctx context.Context, profile *model.ExportProfile) error {
+	dbP := toDbExportProfile(profile)
+
+	q := "INSERT INTO export_profile (user_id, name, export_columns, delimiter, date_format, " +
+		"duration_unit, decimal_separator, language, label_separator) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbP.userId, dbP.name, dbP.columns, dbP.delimiter, dbP.dateFormat,
+		dbP.durationUnit, dbP.decimalSeparator, dbP.language, dbP.labelSeparator)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create export profile in database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	profile.Id = id
+	return nil
+}
+
+// UpdateExportProfile updates a export profile.
+func (r *UserRepo) UpdateExportProfile(ctx context.Context, profile *model.ExportProfile) error {
+	dbP := toDbExportProfile(profile)
+
+	q := "UPDATE export_profile SET name = ?, export_columns = ?, delimiter = ?, date_format = ?, " +
+		"duration_unit = ?, decimal_separator = ?, language = ?, label_separator = ? WHERE id = ?"
+
+	uErr := r.exec(ctx, q, dbP.name, dbP.columns, dbP.delimiter, dbP.dateFormat,
+		dbP.durationUnit, dbP.decimalSeparator, dbP.language, dbP.labelSeparator, dbP.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update export profile %d "+
+			"in database.", profile.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteExportProfileById deletes a export profile by its ID.
+func (r *UserRepo) DeleteExportProfileById(ctx context.Context, id int) error {
+	q := "DELETE FROM export_profile WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete export profile %d "+
+			"from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
 // --- Helper functions ---
 
 func newRoleScanHelper() *scanHelper[model.Role] {
@@ -323,10 +462,58 @@ func newUserScanHelper() *scanHelper[*model.User] {
 func scanUserFunc(s scanner) (*model.User, error) {
 	var u model.User
 
-	err := s.Scan(&u.Id, &u.Name, &u.Username, &u.Password, &u.MustChangePassword)
+	err := s.Scan(&u.Id, &u.Name, &u.Username, &u.Password, &u.MustChangePassword, &u.TimeZone)
 	if err != nil {
 		return nil, err
 	}
 
 	return &u, nil
 }
+
+func newExportProfileScanHelper() *scanHelper[*model.ExportProfile] {
+	return newScanHelper(10, scanExportProfileFunc)
+}
+
+func scanExportProfileFunc(s scanner) (*model.ExportProfile, error) {
+	var dbP dbExportProfile
+	err := s.Scan(&dbP.id, &dbP.userId, &dbP.name, &dbP.columns, &dbP.delimiter, &dbP.dateFormat,
+		&dbP.durationUnit, &dbP.decimalSeparator, &dbP.language, &dbP.labelSeparator)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbExportProfile(&dbP), nil
+}
+
+func toDbExportProfile(in *model.ExportProfile) *dbExportProfile {
+	var out dbExportProfile
+	out.id = in.Id
+	out.userId = in.UserId
+	out.name = in.Name
+	out.columns = strings.Join(in.Columns, ",")
+	out.delimiter = in.Delimiter
+	out.dateFormat = in.DateFormat
+	out.durationUnit = in.DurationUnit
+	out.decimalSeparator = in.DecimalSeparator
+	out.language = in.Language
+	out.labelSeparator = in.LabelSeparator
+	return &out
+}
+
+func fromDbExportProfile(in *dbExportProfile) *model.ExportProfile {
+	var out model.ExportProfile
+	out.Id = in.id
+	out.UserId = in.userId
+	out.Name = in.name
+	if in.columns != "" {
+		out.Columns = strings.Split(in.columns, ",")
+	} else {
+		out.Columns = []string{}
+	}
+	out.Delimiter = in.delimiter
+	out.DateFormat = in.dateFormat
+	out.DurationUnit = in.durationUnit
+	out.DecimalSeparator = in.decimalSeparator
+	out.Language = in.language
+	out.LabelSeparator = in.labelSeparator
+	return &out
+}
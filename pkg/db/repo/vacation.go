@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbVacationRequest struct {
+	id              int
+	userId          int
+	startDate       string
+	endDate         string
+	dayFraction     string
+	description     sql.NullString
+	days            float32
+	status          string
+	requestedAt     string
+	decidedByUserId sql.NullInt64
+	decidedAt       sql.NullString
+	decisionComment sql.NullString
+	absenceId       sql.NullInt64
+}
+
+// VacationRepo retrieves and stores vacation related entities.
+type VacationRepo struct {
+	repo
+}
+
+// NewVacationRepo creates a new vacation repository.
+func NewVacationRepo(db *sql.DB) *VacationRepo {
+	return &VacationRepo{repo{db}}
+}
+
+// --- Vacation request functions ---
+
+// GetVacationRequests retrieves vacation requests. If a user ID is supplied, only requests of this
+// user are retrieved. If a status is supplied, only requests with this status are retrieved.
+func (r *VacationRepo) GetVacationRequests(ctx context.Context, userId int, status string) (
+	[]*model.VacationRequest, error) {
+	var rs []string
+	var args []any
+	if userId != 0 {
+		rs = append(rs, "user_id = ?")
+		args = append(args, userId)
+	}
+	if status != "" {
+		rs = append(rs, "status = ?")
+		args = append(args, status)
+	}
+	q := "SELECT id, user_id, start_date, end_date, day_fraction, description, days, status, " +
+		"requested_at, decided_by_user_id, decided_at, decision_comment, absence_id " +
+		"FROM vacation_request"
+	if len(rs) > 0 {
+		q = q + " WHERE " + strings.Join(rs, " AND ")
+	}
+	q = q + " ORDER BY start_date DESC, id DESC"
+
+	sh := newVacationRequestScanHelper()
+	requests, qErr := sh.scanRows(r.query(ctx, q, args...))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query vacation requests from database.",
+			qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetVacationRequestById retrieves a vacation request by its ID.
+func (r *VacationRepo) GetVacationRequestById(ctx context.Context, id int) (
+	*model.VacationRequest, error) {
+	q := "SELECT id, user_id, start_date, end_date, day_fraction, description, days, status, " +
+		"requested_at, decided_by_user_id, decided_at, decision_comment, absence_id " +
+		"FROM vacation_request WHERE id = ?"
+
+	sh := newVacationRequestScanHelper()
+	request, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf("Could not read vacation request %d "+
+			"from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return request, nil
+}
+
+// CreateVacationRequest creates a new vacation request.
+func (r *VacationRepo) CreateVacationRequest(ctx context.Context,
+	request *model.VacationRequest) error {
+	dbR := toDbVacationRequest(request)
+
+	q := "INSERT INTO vacation_request (user_id, start_date, end_date, day_fraction, " +
+		"description, days, status, requested_at, decided_by_user_id, decided_at, " +
+		"decision_comment, absence_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbR.userId, dbR.startDate, dbR.endDate, dbR.dayFraction,
+		dbR.description, dbR.days, dbR.status, dbR.requestedAt, dbR.decidedByUserId, dbR.decidedAt,
+		dbR.decisionComment, dbR.absenceId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create vacation request in database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	request.Id = id
+	return nil
+}
+
+// UpdateVacationRequestDecision updates the status and decision of a vacation request. The request
+// is only updated if it is still pending. It returns false if the request was not updated.
+func (r *VacationRepo) UpdateVacationRequestDecision(ctx context.Context,
+	request *model.VacationRequest) (bool, error) {
+	dbR := toDbVacationRequest(request)
+
+	q := "UPDATE vacation_request SET status = ?, decided_by_user_id = ?, decided_at = ?, " +
+		"decision_comment = ?, absence_id = ? WHERE id = ? AND status = ?"
+
+	cnt, uErr := r.execCount(ctx, q, dbR.status, dbR.decidedByUserId, dbR.decidedAt,
+		dbR.decisionComment, dbR.absenceId, dbR.id, model.VacationRequestStatusPending)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf("Could not update vacation request %d "+
+			"in database.", request.Id), uErr)
+		log.Error(err.StackTrace())
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// DeleteVacationRequestById deletes a vacation request by its ID.
+func (r *VacationRepo) DeleteVacationRequestById(ctx context.Context, id int) error {
+	q := "DELETE FROM vacation_request WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf("Could not delete vacation request %d "+
+			"from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newVacationRequestScanHelper() *scanHelper[*model.VacationRequest] {
+	return newScanHelper(10, scanVacationRequestFunc)
+}
+
+func scanVacationRequestFunc(s scanner) (*model.VacationRequest, error) {
+	var dbR dbVacationRequest
+	err := s.Scan(&dbR.id, &dbR.userId, &dbR.startDate, &dbR.endDate, &dbR.dayFraction,
+		&dbR.description, &dbR.days, &dbR.status, &dbR.requestedAt, &dbR.decidedByUserId,
+		&dbR.decidedAt, &dbR.decisionComment, &dbR.absenceId)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbVacationRequest(&dbR), nil
+}
+
+func toDbVacationRequest(in *model.VacationRequest) *dbVacationRequest {
+	var out dbVacationRequest
+	out.id = in.Id
+	out.userId = in.UserId
+	out.startDate = *formatDate(&in.StartDate)
+	out.endDate = *formatDate(&in.EndDate)
+	out.dayFraction = in.DayFraction
+	out.description = toDbNullString(in.Description)
+	out.days = in.Days
+	out.status = in.Status
+	out.requestedAt = *formatTimestamp(&in.RequestedAt)
+	out.decidedByUserId = toDbNullId(in.DecidedByUserId)
+	out.decidedAt = toDbNullTimestamp(in.DecidedAt)
+	out.decisionComment = toDbNullString(in.DecisionComment)
+	out.absenceId = toDbNullId(in.AbsenceId)
+	return &out
+}
+
+func fromDbVacationRequest(in *dbVacationRequest) *model.VacationRequest {
+	var out model.VacationRequest
+	out.Id = in.id
+	out.UserId = in.userId
+	out.StartDate = *parseDate(&in.startDate)
+	out.EndDate = *parseDate(&in.endDate)
+	out.DayFraction = in.dayFraction
+	out.Description = in.description.String
+	out.Days = in.days
+	out.Status = in.status
+	out.RequestedAt = *parseTimestamp(&in.requestedAt)
+	out.DecidedByUserId = int(in.decidedByUserId.Int64)
+	out.DecidedAt = fromDbNullTimestamp(in.decidedAt)
+	out.DecisionComment = in.decisionComment.String
+	out.AbsenceId = int(in.absenceId.Int64)
+	out.Warnings = []string{}
+	return &out
+}
+
+func toDbNullString(s string) sql.NullString {
+	if strings.TrimSpace(s) == "" {
+		return sql.NullString{String: "", Valid: false}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func toDbNullId(id int) sql.NullInt64 {
+	if id == 0 {
+		return sql.NullInt64{Int64: 0, Valid: false}
+	}
+	return sql.NullInt64{Int64: int64(id), Valid: true}
+}
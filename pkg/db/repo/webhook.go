@@ -0,0 +1,352 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type dbWebhook struct {
+	id         int
+	url        string
+	secret     string
+	eventTypes string
+	active     bool
+}
+
+type dbWebhookDelivery struct {
+	id             int
+	webhookId      int
+	eventType      string
+	payload        string
+	status         string
+	attempts       int
+	createdAt      string
+	nextAttemptAt  sql.NullString
+	lastAttemptAt  sql.NullString
+	lastStatusCode sql.NullInt64
+	lastError      sql.NullString
+}
+
+// WebhookRepo retrieves and stores webhook related entities.
+type WebhookRepo struct {
+	repo
+}
+
+// NewWebhookRepo creates a new webhook repository.
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{repo{db}}
+}
+
+// --- Webhook functions ---
+
+// GetWebhooks retrieves all webhooks.
+func (r *WebhookRepo) GetWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	q := "SELECT id, url, secret, event_types, active FROM webhook ORDER BY id"
+
+	sh := newWebhookScanHelper()
+	webhooks, qErr := sh.scanRows(r.query(ctx, q))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query webhooks from database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// GetActiveWebhooksByEventType retrieves all active webhooks subscribed to an event type.
+func (r *WebhookRepo) GetActiveWebhooksByEventType(ctx context.Context, eventType string) (
+	[]*model.Webhook, error) {
+	q := "SELECT id, url, secret, event_types, active FROM webhook " +
+		"WHERE active = 1 AND FIND_IN_SET(?, event_types) > 0 ORDER BY id"
+
+	sh := newWebhookScanHelper()
+	webhooks, qErr := sh.scanRows(r.query(ctx, q, eventType))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf(
+			"Could not query webhooks for event type '%s' from database.", eventType), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// GetWebhookById retrieves a webhook by its ID.
+func (r *WebhookRepo) GetWebhookById(ctx context.Context, id int) (*model.Webhook, error) {
+	q := "SELECT id, url, secret, event_types, active FROM webhook WHERE id = ?"
+
+	sh := newWebhookScanHelper()
+	webhook, found, qErr := sh.scanRow(r.queryRow(ctx, q, id))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf(
+			"Could not read webhook %d from database.", id), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return webhook, nil
+}
+
+// CreateWebhook creates a new webhook.
+func (r *WebhookRepo) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	dbW := toDbWebhook(webhook)
+
+	q := "INSERT INTO webhook (url, secret, event_types, active) VALUES (?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbW.url, dbW.secret, dbW.eventTypes, dbW.active)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create webhook in database.", cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	webhook.Id = id
+	return nil
+}
+
+// UpdateWebhook updates a webhook.
+func (r *WebhookRepo) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	dbW := toDbWebhook(webhook)
+
+	q := "UPDATE webhook SET url = ?, secret = ?, event_types = ?, active = ? WHERE id = ?"
+
+	uErr := r.exec(ctx, q, dbW.url, dbW.secret, dbW.eventTypes, dbW.active, dbW.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf(
+			"Could not update webhook %d in database.", webhook.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// DeleteWebhookById deletes a webhook (and its deliveries) by its ID.
+func (r *WebhookRepo) DeleteWebhookById(ctx context.Context, id int) error {
+	q := "DELETE FROM webhook WHERE id = ?"
+
+	dErr := r.exec(ctx, q, id)
+	if dErr != nil {
+		err := e.WrapError(e.SysDbDeleteFailed, fmt.Sprintf(
+			"Could not delete webhook %d from database.", id), dErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Webhook delivery functions ---
+
+// GetWebhookDeliveriesByWebhookId retrieves the deliveries of a webhook (newest first).
+func (r *WebhookRepo) GetWebhookDeliveriesByWebhookId(ctx context.Context, webhookId int,
+	offset int, limit int) ([]*model.WebhookDelivery, error) {
+	q := "SELECT id, webhook_id, event_type, payload, status, attempts, created_at, " +
+		"next_attempt_at, last_attempt_at, last_status_code, last_error FROM webhook_delivery " +
+		"WHERE webhook_id = ? ORDER BY id DESC " + createQueryLimitString(offset, limit)
+
+	sh := newWebhookDeliveryScanHelper()
+	deliveries, qErr := sh.scanRows(r.query(ctx, q, webhookId))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf(
+			"Could not query deliveries of webhook %d from database.", webhookId), qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CountWebhookDeliveriesByWebhookId counts the deliveries of a webhook.
+func (r *WebhookRepo) CountWebhookDeliveriesByWebhookId(ctx context.Context, webhookId int) (int,
+	error) {
+	cnt, cErr := r.count(ctx, "webhook_delivery", "webhook_id = ?", webhookId)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, fmt.Sprintf(
+			"Could not count deliveries of webhook %d in database.", webhookId), cErr)
+		log.Error(err.StackTrace())
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// GetDueWebhookDeliveries retrieves pending deliveries whose next attempt is due.
+func (r *WebhookRepo) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) (
+	[]*model.WebhookDelivery, error) {
+	q := "SELECT id, webhook_id, event_type, payload, status, attempts, created_at, " +
+		"next_attempt_at, last_attempt_at, last_status_code, last_error FROM webhook_delivery " +
+		"WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id " +
+		createQueryLimitString(0, limit)
+
+	sh := newWebhookDeliveryScanHelper()
+	deliveries, qErr := sh.scanRows(r.query(ctx, q, model.WebhookDeliveryStatusPending,
+		*formatTimestamp(&now)))
+	if qErr != nil {
+		err := e.WrapError(e.SysDbQueryFailed, "Could not query due webhook deliveries from "+
+			"database.", qErr)
+		log.Error(err.StackTrace())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CreateWebhookDelivery creates a new webhook delivery.
+func (r *WebhookRepo) CreateWebhookDelivery(ctx context.Context,
+	delivery *model.WebhookDelivery) error {
+	dbD := toDbWebhookDelivery(delivery)
+
+	q := "INSERT INTO webhook_delivery (webhook_id, event_type, payload, status, attempts, " +
+		"created_at, next_attempt_at, last_attempt_at, last_status_code, last_error) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	id, cErr := r.insert(ctx, q, dbD.webhookId, dbD.eventType, dbD.payload, dbD.status,
+		dbD.attempts, dbD.createdAt, dbD.nextAttemptAt, dbD.lastAttemptAt, dbD.lastStatusCode,
+		dbD.lastError)
+	if cErr != nil {
+		err := e.WrapError(e.SysDbInsertFailed, "Could not create webhook delivery in database.",
+			cErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	delivery.Id = id
+	return nil
+}
+
+// UpdateWebhookDelivery updates the state of a webhook delivery.
+func (r *WebhookRepo) UpdateWebhookDelivery(ctx context.Context,
+	delivery *model.WebhookDelivery) error {
+	dbD := toDbWebhookDelivery(delivery)
+
+	q := "UPDATE webhook_delivery SET status = ?, attempts = ?, next_attempt_at = ?, " +
+		"last_attempt_at = ?, last_status_code = ?, last_error = ? WHERE id = ?"
+
+	uErr := r.exec(ctx, q, dbD.status, dbD.attempts, dbD.nextAttemptAt, dbD.lastAttemptAt,
+		dbD.lastStatusCode, dbD.lastError, dbD.id)
+	if uErr != nil {
+		err := e.WrapError(e.SysDbUpdateFailed, fmt.Sprintf(
+			"Could not update webhook delivery %d in database.", delivery.Id), uErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func newWebhookScanHelper() *scanHelper[*model.Webhook] {
+	return newScanHelper(10, scanWebhookFunc)
+}
+
+func scanWebhookFunc(s scanner) (*model.Webhook, error) {
+	var dbW dbWebhook
+	err := s.Scan(&dbW.id, &dbW.url, &dbW.secret, &dbW.eventTypes, &dbW.active)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbWebhook(&dbW), nil
+}
+
+func newWebhookDeliveryScanHelper() *scanHelper[*model.WebhookDelivery] {
+	return newScanHelper(10, scanWebhookDeliveryFunc)
+}
+
+func scanWebhookDeliveryFunc(s scanner) (*model.WebhookDelivery, error) {
+	var dbD dbWebhookDelivery
+	err := s.Scan(&dbD.id, &dbD.webhookId, &dbD.eventType, &dbD.payload, &dbD.status,
+		&dbD.attempts, &dbD.createdAt, &dbD.nextAttemptAt, &dbD.lastAttemptAt,
+		&dbD.lastStatusCode, &dbD.lastError)
+	if err != nil {
+		return nil, err
+	}
+	return fromDbWebhookDelivery(&dbD), nil
+}
+
+func toDbWebhook(in *model.Webhook) *dbWebhook {
+	var out dbWebhook
+	out.id = in.Id
+	out.url = in.Url
+	out.secret = in.Secret
+	out.eventTypes = strings.Join(in.EventTypes, ",")
+	out.active = in.Active
+	return &out
+}
+
+func fromDbWebhook(in *dbWebhook) *model.Webhook {
+	var out model.Webhook
+	out.Id = in.id
+	out.Url = in.url
+	out.Secret = in.secret
+	if in.eventTypes != "" {
+		out.EventTypes = strings.Split(in.eventTypes, ",")
+	} else {
+		out.EventTypes = []string{}
+	}
+	out.Active = in.active
+	return &out
+}
+
+func toDbWebhookDelivery(in *model.WebhookDelivery) *dbWebhookDelivery {
+	var out dbWebhookDelivery
+	out.id = in.Id
+	out.webhookId = in.WebhookId
+	out.eventType = in.EventType
+	out.payload = in.Payload
+	out.status = in.Status
+	out.attempts = in.Attempts
+	out.createdAt = *formatTimestamp(&in.CreatedAt)
+	out.nextAttemptAt = toDbNullTimestamp(in.NextAttemptAt)
+	out.lastAttemptAt = toDbNullTimestamp(in.LastAttemptAt)
+	if in.LastStatusCode != 0 {
+		out.lastStatusCode = sql.NullInt64{Int64: int64(in.LastStatusCode), Valid: true}
+	} else {
+		out.lastStatusCode = sql.NullInt64{Int64: 0, Valid: false}
+	}
+	if in.LastError != "" {
+		out.lastError = sql.NullString{String: in.LastError, Valid: true}
+	} else {
+		out.lastError = sql.NullString{String: "", Valid: false}
+	}
+	return &out
+}
+
+func fromDbWebhookDelivery(in *dbWebhookDelivery) *model.WebhookDelivery {
+	var out model.WebhookDelivery
+	out.Id = in.id
+	out.WebhookId = in.webhookId
+	out.EventType = in.eventType
+	out.Payload = in.payload
+	out.Status = in.status
+	out.Attempts = in.attempts
+	out.CreatedAt = *parseTimestamp(&in.createdAt)
+	out.NextAttemptAt = fromDbNullTimestamp(in.nextAttemptAt)
+	out.LastAttemptAt = fromDbNullTimestamp(in.lastAttemptAt)
+	if in.lastStatusCode.Valid {
+		out.LastStatusCode = int(in.lastStatusCode.Int64)
+	} else {
+		out.LastStatusCode = 0
+	}
+	if in.lastError.Valid {
+		out.LastError = in.lastError.String
+	} else {
+		out.LastError = ""
+	}
+	return &out
+}
+
+func toDbNullTimestamp(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{String: "", Valid: false}
+	}
+	return sql.NullString{String: *formatTimestamp(&t), Valid: true}
+}
+
+func fromDbNullTimestamp(ts sql.NullString) time.Time {
+	if !ts.Valid {
+		return time.Time{}
+	}
+	return *parseTimestamp(&ts.String)
+}
@@ -118,6 +118,9 @@ func (tm *TransactionManager) Commit(ctx context.Context) error {
 		return err
 	}
 
+	// Clear transaction holder
+	th.Clear()
+
 	// Commit transaction
 	cErr := tx.Commit()
 	if cErr != nil {
@@ -142,6 +145,9 @@ func (tm *TransactionManager) Rollback(ctx context.Context) error {
 		return err
 	}
 
+	// Clear transaction holder
+	th.Clear()
+
 	// Rollback transaction
 	rErr := tx.Rollback()
 	if rErr != nil {
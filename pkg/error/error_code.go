@@ -21,6 +21,12 @@ const (
 	PermChangeAllEntries    = -208
 	PermGetOwnEntries       = -209
 	PermChangeOwnEntries    = -210
+	PermGetWebhooks         = -211
+	PermChangeWebhooks      = -212
+	PermApproveVacations    = -213
+	PermGetInvoices         = -214
+	PermChangeInvoices      = -215
+	PermDecideOwnVacations  = -216
 
 	// General validation erros
 	ValUnknown                 = -300
@@ -44,6 +50,31 @@ const (
 	ValPasswordInvalid         = -318
 	ValLabelInvalid            = -319
 	ValContentTypeNotSupported = -320
+	ValEmailInvalid            = -321
+	ValUrlInvalid              = -322
+	ValEventTypeInvalid        = -323
+	ValCursorInvalid           = -324
+	ValVersionInvalid          = -325
+	ValDayFractionInvalid      = -326
+	ValStatusInvalid           = -327
+	ValAdjustmentTypeInvalid   = -328
+	ValYearInvalid             = -329
+	ValColorInvalid            = -330
+	ValRoundingPolicyInvalid   = -331
+	ValTimeZoneInvalid         = -332
+	ValExpenseTypeInvalid      = -333
+	ValCurrencyInvalid         = -334
+	ValFileMissing             = -335
+	ValExportFormatInvalid     = -336
+	ValExportColumnInvalid     = -337
+	ValDateFormatInvalid       = -338
+	ValDelimiterInvalid        = -339
+	ValDurationUnitInvalid     = -340
+	ValDecimalSeparatorInvalid = -341
+	ValLanguageInvalid         = -342
+	ValGroupingInvalid         = -343
+	ValIntervalInvalid         = -344
+	ValPeriodTooLong           = -345
 	// View validation errors
 	ValStartDateInvalid     = -350
 	ValEndDateInvalid       = -351
@@ -76,6 +107,42 @@ const (
 	LogicContractVacationDaysInvalid   = -411
 	LogicEntryActivityNotAllowed       = -412
 	LogicTokenNotFound                 = -413
+	LogicWebhookNotFound               = -414
+	LogicEntryVersionConflict          = -415
+	LogicContractBreakRulesInvalid     = -416
+	LogicAbsenceNotFound               = -417
+	LogicAbsenceTypeInvalid            = -418
+	LogicAbsenceWithoutWorkDays        = -419
+	LogicVacationRequestNotFound       = -420
+	LogicVacationRequestNotPending     = -421
+	LogicContractVacationRulesInvalid  = -422
+	LogicBalanceAdjustmentNotFound     = -423
+	LogicYearClosingInvalid            = -424
+	LogicYearClosed                    = -425
+	LogicYearClosingNotFound           = -426
+	LogicEntryTypeDeleteNotAllowed     = -427
+	LogicEntryActivityMergeInvalid     = -428
+	LogicProjectRoundingPolicyNotFound = -429
+	LogicExpenseRateNotFound           = -430
+	LogicExpenseRateDeleteNotAllowed   = -431
+	LogicExpenseRateInvalid            = -432
+	LogicExpenseNotFound               = -433
+	LogicExpensesNotAllowed            = -434
+	LogicAttachmentNotFound            = -435
+	LogicAttachmentTooLarge            = -436
+	LogicAttachmentTypeNotAllowed      = -437
+	LogicEntryInvoiced                 = -438
+	LogicClientNotFound                = -439
+	LogicClientAlreadyExists           = -440
+	LogicClientDeleteNotAllowed        = -441
+	LogicProjectAlreadyAssigned        = -442
+	LogicHourlyRateNotFound            = -443
+	LogicHourlyRateMissing             = -444
+	LogicInvoiceNotFound               = -445
+	LogicInvoiceWithoutEntries         = -446
+	LogicExportProfileNotFound         = -447
+	LogicExportProfileAlreadyExists    = -448
+	LogicEntryOfAbsence                = -449
 
 	// System errors
 	SysUnknown             = -500
@@ -87,4 +154,6 @@ const (
 	SysDbUpdateFailed      = -506
 	SysDbDeleteFailed      = -507
 	SysJobFailed           = -508
+	SysMailFailed          = -509
+	SysStorageFailed       = -510
 )
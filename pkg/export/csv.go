@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+type csvWriterToAdapter struct {
+	data *EntriesData
+}
+
+func (cta *csvWriterToAdapter) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	writer := csv.NewWriter(cw)
+
+	// Get profile
+	profile := cta.data.Profile
+	if profile == nil {
+		profile = model.NewExportProfile()
+	}
+	if delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter); delimiter != utf8.RuneError {
+		writer.Comma = delimiter
+	}
+
+	// Write header
+	if err := writer.Write(cta.createHeader(profile)); err != nil {
+		return cw.n, err
+	}
+
+	// Write records (the CSV writer is buffered, so only the current record is held in memory)
+	err = cta.data.ForEachRecord(func(record *EntryRecord) error {
+		return writer.Write(cta.createRow(profile, record))
+	})
+	if err != nil {
+		return cw.n, err
+	}
+
+	writer.Flush()
+	return cw.n, writer.Error()
+}
+
+func (cta *csvWriterToAdapter) createHeader(profile *model.ExportProfile) []string {
+	header := make([]string, 0, len(profile.Columns))
+	for _, name := range profile.Columns {
+		if col := getColumnByName(name); col != nil {
+			header = append(header, loc.CreateLanguageString(profile.Language, col.titleKey))
+		}
+	}
+	return header
+}
+
+func (cta *csvWriterToAdapter) createRow(profile *model.ExportProfile, record *EntryRecord,
+) []string {
+	row := make([]string, 0, len(profile.Columns))
+	for _, name := range profile.Columns {
+		switch name {
+		case model.ExportColumnStartTime:
+			row = append(row, profile.FormatTime(record.StartTime))
+		case model.ExportColumnEndTime:
+			row = append(row, profile.FormatTime(record.EndTime))
+		case model.ExportColumnType:
+			row = append(row, record.Type)
+		case model.ExportColumnActivity:
+			row = append(row, record.Activity)
+		case model.ExportColumnProject:
+			row = append(row, record.Project)
+		case model.ExportColumnDescription:
+			row = append(row, record.Description)
+		case model.ExportColumnLabels:
+			row = append(row, strings.Join(record.Labels, profile.LabelSeparator))
+		case model.ExportColumnDuration:
+			row = append(row, profile.FormatDuration(record.Duration))
+		case model.ExportColumnBilledDuration:
+			row = append(row, profile.FormatDuration(record.BilledDuration))
+		case model.ExportColumnRounding:
+			row = append(row, record.Rounding)
+		}
+	}
+	return row
+}
+
+// CsvExporter exports entries to a CSV file.
+type CsvExporter struct {
+}
+
+// NewCsvExporter creates a new CSV exporter.
+func NewCsvExporter() *CsvExporter {
+	return &CsvExporter{}
+}
+
+// Format returns the name of the format.
+func (e *CsvExporter) Format() string {
+	return "csv"
+}
+
+// ContentType returns the media type of the exported file.
+func (e *CsvExporter) ContentType() string {
+	return "text/csv"
+}
+
+// Export creates the CSV file for the supplied data and returns it as an io.WriterTo that can be
+// used to write the file to a writer. The columns and formats are defined by the profile of the
+// data.
+func (e *CsvExporter) Export(data *EntriesData) io.WriterTo {
+	return &csvWriterToAdapter{
+		data: data,
+	}
+}
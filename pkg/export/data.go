@@ -0,0 +1,263 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// EntryRecord stores the exported data of an entry.
+type EntryRecord struct {
+	StartTime      time.Time
+	EndTime        time.Time
+	Type           string
+	Activity       string
+	Project        string
+	Description    string
+	Labels         []string
+	Duration       int    // Duration in minutes
+	BilledDuration int    // Duration in minutes after rounding with report scope
+	Rounding       string // Description of the rounding policy which applies to the entry
+}
+
+// EntryIterator iterates over entries (e.g. the rows of a database query).
+type EntryIterator interface {
+	// Next advances the iterator to the next entry. It returns false if there are no more entries
+	// or an error occurred.
+	Next() bool
+	// Entry returns the current entry.
+	Entry() *model.Entry
+	// Err returns the error which occurred during the iteration.
+	Err() error
+}
+
+// RoundingPoliciesLookup gets the rounding policies of a user.
+type RoundingPoliciesLookup func(userId int) (*model.RoundingPolicies, error)
+
+// EntriesSource provides the master data and the rounding policies of an entries export.
+type EntriesSource interface {
+	GetEntryTypesMap(ctx context.Context) (map[int]*model.EntryType, error)
+	GetEntryActivitiesMap(ctx context.Context) (map[int]*model.EntryActivity, error)
+	GetRoundingPoliciesByUserId(ctx context.Context, userId int) (*model.RoundingPolicies, error)
+}
+
+// EntriesData stores the data of an entries export. It is the data source of all formats, so the
+// exported content is the same regardless of the format. The records are created one by one while
+// the file is written, so the data can only be iterated once.
+type EntriesData struct {
+	Title   string // Title of the export (only written by spreadsheet formats)
+	Details string // Details of the export, e.g. the filter (only written by spreadsheet formats)
+
+	// Profile of the export (only applied by the CSV format; nil for the default profile)
+	Profile *model.ExportProfile
+
+	entries                EntryIterator
+	entryTypesMap          map[int]*model.EntryType
+	entryActivitiesMap     map[int]*model.EntryActivity
+	roundingPolicies       map[int]*model.RoundingPolicies
+	lookupRoundingPolicies RoundingPoliciesLookup
+	loc                    *time.Location
+}
+
+type column struct {
+	name     string  // Name of the column (used by export profiles)
+	titleKey string  // Localization key of the column title
+	width    float64 // Width of the column in spreadsheets (in characters)
+}
+
+// Columns of the exported entries (in the order of the EntryRecord fields).
+var columns = []*column{
+	{model.ExportColumnStartTime, "exportColStartTime", 17},
+	{model.ExportColumnEndTime, "exportColEndTime", 17},
+	{model.ExportColumnType, "exportColType", 12},
+	{model.ExportColumnActivity, "exportColActivity", 16.5},
+	{model.ExportColumnProject, "exportColProject", 16.5},
+	{model.ExportColumnDescription, "exportColDescription", 42},
+	{model.ExportColumnLabels, "exportColLabels", 16.5},
+	{model.ExportColumnDuration, "exportColDuration", 10.5},
+	{model.ExportColumnBilledDuration, "exportColBilledDuration", 10.5},
+	{model.ExportColumnRounding, "exportColRounding", 21},
+}
+
+// NewEntriesData creates the export data for the supplied entries. Times are converted to the
+// supplied location. The rounding policies are looked up by user ID.
+func NewEntriesData(title string, details string, entries []*model.Entry,
+	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
+	roundingPolicies map[int]*model.RoundingPolicies, loc *time.Location) *EntriesData {
+	if roundingPolicies == nil {
+		roundingPolicies = make(map[int]*model.RoundingPolicies)
+	}
+	return &EntriesData{
+		Title:              title,
+		Details:            details,
+		entries:            &sliceEntryIterator{entries: entries, index: -1},
+		entryTypesMap:      entryTypesMap,
+		entryActivitiesMap: entryActivitiesMap,
+		roundingPolicies:   roundingPolicies,
+		loc:                loc,
+	}
+}
+
+// NewStreamingEntriesData creates the export data for the entries of an iterator. Times are
+// converted to the supplied location. The rounding policies are looked up once per user when the
+// first entry of the user is exported.
+func NewStreamingEntriesData(title string, details string, entries EntryIterator,
+	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
+	lookupRoundingPolicies RoundingPoliciesLookup, loc *time.Location) *EntriesData {
+	return &EntriesData{
+		Title:                  title,
+		Details:                details,
+		entries:                entries,
+		entryTypesMap:          entryTypesMap,
+		entryActivitiesMap:     entryActivitiesMap,
+		roundingPolicies:       make(map[int]*model.RoundingPolicies),
+		lookupRoundingPolicies: lookupRoundingPolicies,
+		loc:                    loc,
+	}
+}
+
+// CreateEntriesData creates the export data for the entries of an iterator which were selected by
+// the supplied filter. All entry exports are created by this function, so the title, the filter
+// details and the rounding of an export do not depend on where the export was requested. If the
+// iterator is nil, no entries are exported.
+func CreateEntriesData(ctx context.Context, source EntriesSource, filter model.EntryFilter,
+	entries EntryIterator, loc *time.Location) (*EntriesData, error) {
+	// Get entry master data
+	entryTypesMap, err := source.GetEntryTypesMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entryActivitiesMap, err := source.GetEntryActivitiesMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = &sliceEntryIterator{index: -1}
+	}
+
+	// Rounding policies are looked up for the users of the entries
+	lookupRoundingPolicies := func(userId int) (*model.RoundingPolicies, error) {
+		return source.GetRoundingPoliciesByUserId(ctx, userId)
+	}
+
+	title := createString("entryExportTitle", createString("appName"))
+	details := getFilterDetails(filter, entryTypesMap, entryActivitiesMap, loc)
+	return NewStreamingEntriesData(title, details, entries, entryTypesMap, entryActivitiesMap,
+		lookupRoundingPolicies, loc), nil
+}
+
+// ForEachRecord calls the supplied function for each record. The iteration stops at the first
+// error.
+func (d *EntriesData) ForEachRecord(fn func(record *EntryRecord) error) error {
+	for d.entries.Next() {
+		record, err := d.createRecord(d.entries.Entry())
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return d.entries.Err()
+}
+
+func (d *EntriesData) createRecord(entry *model.Entry) (*EntryRecord, error) {
+	policies, err := d.getRoundingPolicies(entry.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return newEntryRecord(entry, d.entryTypesMap, d.entryActivitiesMap, policies, d.loc), nil
+}
+
+func (d *EntriesData) getRoundingPolicies(userId int) (*model.RoundingPolicies, error) {
+	if policies, ok := d.roundingPolicies[userId]; ok || d.lookupRoundingPolicies == nil {
+		return policies, nil
+	}
+	policies, err := d.lookupRoundingPolicies(userId)
+	if err != nil {
+		return nil, err
+	}
+	d.roundingPolicies[userId] = policies
+	return policies, nil
+}
+
+type sliceEntryIterator struct {
+	entries []*model.Entry
+	index   int
+}
+
+func (it *sliceEntryIterator) Next() bool {
+	it.index++
+	return it.index < len(it.entries)
+}
+
+func (it *sliceEntryIterator) Entry() *model.Entry {
+	return it.entries[it.index]
+}
+
+func (it *sliceEntryIterator) Err() error {
+	return nil
+}
+
+func newEntryRecord(entry *model.Entry, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity, roundingPolicies *model.RoundingPolicies,
+	loc *time.Location) *EntryRecord {
+	duration := entry.EndTime.Sub(entry.StartTime)
+	billedDuration := duration
+	rounding := ""
+	if model.IsWorkEntryType(entryTypesMap, entry.TypeId) && roundingPolicies != nil {
+		policy := roundingPolicies.GetPolicy(entry.Project)
+		if policy != nil {
+			if policy.Scope == model.RoundingScopeReport {
+				billedDuration = policy.Round(duration)
+			}
+			rounding = getRoundingPolicyDescription(policy)
+		}
+	}
+
+	typeDescription := ""
+	if entryType, ok := entryTypesMap[entry.TypeId]; ok {
+		typeDescription = entryType.Description
+	}
+	activityDescription := ""
+	if entryActivity, ok := entryActivitiesMap[entry.ActivityId]; ok {
+		activityDescription = entryActivity.Description
+	}
+
+	return &EntryRecord{
+		StartTime:      entry.StartTime.In(loc),
+		EndTime:        entry.EndTime.In(loc),
+		Type:           typeDescription,
+		Activity:       activityDescription,
+		Project:        entry.Project,
+		Description:    entry.Description,
+		Labels:         entry.Labels,
+		Duration:       int(duration.Minutes()),
+		BilledDuration: int(billedDuration.Minutes()),
+		Rounding:       rounding,
+	}
+}
+
+func getRoundingPolicyDescription(policy *model.RoundingPolicy) string {
+	return fmt.Sprintf("%s %d min (%s)", policy.Mode, policy.IntervalMinutes, policy.Scope)
+}
+
+func getColumnTitles() []string {
+	titles := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = createString(col.titleKey)
+	}
+	return titles
+}
+
+func getColumnByName(name string) *column {
+	for _, col := range columns {
+		if col.name == name {
+			return col
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/log"
+)
+
+// DefaultFormat is the format of exports which do not specify a format. It is the same for the web
+// and the API, so the same export request results in the same file.
+const DefaultFormat = "xlsx"
+
+// Exporter exports entries to a file of a specific format.
+type Exporter interface {
+	// Format returns the name of the format. It is also used as file extension.
+	Format() string
+	// ContentType returns the media type of the exported file.
+	ContentType() string
+	// Export creates the file for the supplied data and returns it as an io.WriterTo that can be
+	// used to write the file to a writer.
+	Export(data *EntriesData) io.WriterTo
+}
+
+// Registry stores the available exporters by format.
+type Registry struct {
+	exporters map[string]Exporter
+	formats   []string
+}
+
+// NewRegistry creates a new registry with exporters for all supported formats (CSV, XLSX, JSON,
+// JSON Lines and ODS).
+func NewRegistry() *Registry {
+	r := &Registry{
+		exporters: make(map[string]Exporter),
+	}
+	r.Register(NewCsvExporter())
+	r.Register(NewXlsxExporter())
+	r.Register(NewJsonExporter())
+	r.Register(NewJsonLinesExporter())
+	r.Register(NewOdsExporter())
+	return r
+}
+
+// Register adds an exporter. An existing exporter for the same format is replaced.
+func (r *Registry) Register(exporter Exporter) {
+	if _, ok := r.exporters[exporter.Format()]; !ok {
+		r.formats = append(r.formats, exporter.Format())
+	}
+	r.exporters[exporter.Format()] = exporter
+}
+
+// Formats returns the names of all registered formats.
+func (r *Registry) Formats() []string {
+	return r.formats
+}
+
+// Get returns the exporter for a format or nil if the format is not supported.
+func (r *Registry) Get(format string) Exporter {
+	return r.exporters[strings.ToLower(format)]
+}
+
+// Select selects the exporter for a request. An explicitly requested format takes precedence over
+// the media types of the Accept header. If neither is specified (or the Accept header accepts any
+// media type), the exporter of the default format is returned.
+func (r *Registry) Select(format string, accept string) (Exporter, error) {
+	// Was a format requested?
+	if format != "" {
+		exporter := r.Get(format)
+		if exporter == nil {
+			return nil, r.createFormatError(fmt.Sprintf("Invalid format '%s'. (Supported formats: "+
+				"%s.)", format, strings.Join(r.formats, ", ")))
+		}
+		return exporter, nil
+	}
+
+	// Was a media type requested?
+	if accept == "" {
+		return r.exporters[DefaultFormat], nil
+	}
+	for _, mediaType := range parseAcceptHeader(accept) {
+		if mediaType == "*/*" {
+			return r.exporters[DefaultFormat], nil
+		}
+		for _, f := range r.formats {
+			if r.exporters[f].ContentType() == mediaType {
+				return r.exporters[f], nil
+			}
+		}
+	}
+	return nil, r.createFormatError(fmt.Sprintf("Invalid Accept header '%s'. (No supported media "+
+		"type.)", accept))
+}
+
+func (r *Registry) createFormatError(message string) error {
+	err := e.NewError(e.ValExportFormatInvalid, message)
+	log.Debug(err.StackTrace())
+	return err
+}
+
+// parseAcceptHeader returns the media types of an Accept header ordered by their quality. Media
+// types with a quality of 0 are omitted.
+func parseAcceptHeader(accept string) []string {
+	type acceptedType struct {
+		mediaType string
+		quality   float64
+	}
+
+	var types []acceptedType
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		types = append(types, acceptedType{mediaType, quality})
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i].quality > types[j].quality
+	})
+
+	mediaTypes := make([]string, len(types))
+	for i, t := range types {
+		mediaTypes[i] = t.mediaType
+	}
+	return mediaTypes
+}
+
+// --- Helper types and functions ---
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func createString(key string, args ...any) string {
+	return loc.CreateString(key, args...)
+}
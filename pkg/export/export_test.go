@@ -0,0 +1,249 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// Sizes of the synthetic data sets. If the memory usage grew with the number of entries, the large
+// data set would need about four times the memory of the small one.
+const (
+	exportTestSmallCount = 25000
+	exportTestLargeCount = 100000
+)
+
+// Number of heap samples taken while an export is written.
+const exportTestHeapSamples = 20
+
+// exportTestPrefixSize is the number of bytes of an export that are kept to check its shape.
+const exportTestPrefixSize = 4096
+
+func TestExportCsvStreamsEntries(t *testing.T) {
+	testExportStreamsEntries(t, NewCsvExporter(), func(t *testing.T, out *exportTestOutput,
+		count int) {
+		// The header is followed by one line per entry
+		if out.lines != count+1 {
+			t.Errorf("lines = %d, want %d", out.lines, count+1)
+		}
+		reader := csv.NewReader(bytes.NewReader(out.completePrefix()))
+		records, err := reader.ReadAll()
+		if err != nil {
+			t.Fatalf("could not read CSV: %s", err)
+		}
+		if len(records) < 2 {
+			t.Fatalf("got %d records, want header and entries", len(records))
+		}
+		if len(records[0]) != len(columns) {
+			t.Errorf("header has %d columns, want %d", len(records[0]), len(columns))
+		}
+		profile := model.NewExportProfile()
+		start := time.Date(2026, time.January, 1, 8, 0, 0, 0, time.UTC)
+		if records[1][0] != profile.FormatTime(start) || records[1][5] != "Entry 0" {
+			t.Errorf("first entry = %v", records[1])
+		}
+	})
+}
+
+func TestExportJsonLinesStreamsEntries(t *testing.T) {
+	testExportStreamsEntries(t, NewJsonLinesExporter(), func(t *testing.T, out *exportTestOutput,
+		count int) {
+		// Every entry is written as one line
+		if out.lines != count {
+			t.Errorf("lines = %d, want %d", out.lines, count)
+		}
+		line, _, _ := bytes.Cut(out.prefix, []byte("\n"))
+		var record map[string]any
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("could not decode first line: %s", err)
+		}
+		if record["description"] != "Entry 0" || record["duration"] != 480.0 {
+			t.Errorf("first entry = %v", record)
+		}
+	})
+}
+
+func TestExportXlsxStreamsEntries(t *testing.T) {
+	testExportStreamsEntries(t, NewXlsxExporter(), func(t *testing.T, out *exportTestOutput,
+		count int) {
+		f, err := excelize.OpenFile(out.file)
+		if err != nil {
+			t.Fatalf("could not open XLSX: %s", err)
+		}
+		defer f.Close()
+		rows, err := f.Rows(f.GetSheetName(0))
+		if err != nil {
+			t.Fatalf("could not read rows: %s", err)
+		}
+		defer rows.Close()
+
+		// The header is followed by one row per entry
+		rowCount := 0
+		for rows.Next() {
+			rowCount++
+			cols, err := rows.Columns(excelize.Options{RawCellValue: true})
+			if err != nil {
+				t.Fatalf("could not read row %d: %s", rowCount, err)
+			}
+			if rowCount == 1 && len(cols) != len(columns) {
+				t.Errorf("header has %d columns, want %d", len(cols), len(columns))
+			}
+			if rowCount == 2 && cols[5] != "Entry 0" {
+				t.Errorf("first entry = %v", cols)
+			}
+		}
+		if rowCount != count+1 {
+			t.Errorf("rows = %d, want %d", rowCount, count+1)
+		}
+	})
+}
+
+func testExportStreamsEntries(t *testing.T, exporter Exporter, check func(t *testing.T,
+	out *exportTestOutput, count int)) {
+	if testing.Short() {
+		t.Skip("skipping large export in short mode")
+	}
+
+	smallGrowth := runExportTest(t, exporter, exportTestSmallCount, check)
+	largeGrowth := runExportTest(t, exporter, exportTestLargeCount, check)
+	t.Logf("heap growth: %d entries = %d KiB, %d entries = %d KiB", exportTestSmallCount,
+		smallGrowth/1024, exportTestLargeCount, largeGrowth/1024)
+
+	// The memory usage must not grow with the number of entries (buffers may grow a bit)
+	if largeGrowth > 2*smallGrowth+1024*1024 {
+		t.Errorf("heap growth of %d entries (%d KiB) is not bounded by the growth of %d entries "+
+			"(%d KiB)", exportTestLargeCount, largeGrowth/1024, exportTestSmallCount,
+			smallGrowth/1024)
+	}
+}
+
+// runExportTest exports a synthetic data set, checks the output and returns the maximum heap
+// growth while the export was written.
+func runExportTest(t *testing.T, exporter Exporter, count int, check func(t *testing.T,
+	out *exportTestOutput, count int)) uint64 {
+	out := &exportTestOutput{file: filepath.Join(t.TempDir(), "export."+exporter.Format())}
+	file, err := os.Create(out.file)
+	if err != nil {
+		t.Fatalf("could not create file: %s", err)
+	}
+	defer file.Close()
+
+	// (Without title the spreadsheet starts with the header)
+	entries := newSyntheticEntryIterator(count)
+	data := NewStreamingEntriesData("", "", entries, newExportTestEntryTypesMap(),
+		nil, nil, time.UTC)
+	w := bufio.NewWriter(io.MultiWriter(file, out))
+	if _, err := exporter.Export(data).WriteTo(w); err != nil {
+		t.Fatalf("could not export %d entries: %s", count, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("could not write file: %s", err)
+	}
+	if entries.index != count {
+		t.Fatalf("exported %d entries, want %d", entries.index, count)
+	}
+
+	check(t, out, count)
+	return entries.maxHeapGrowth
+}
+
+// exportTestOutput counts the lines of an export and keeps its beginning.
+type exportTestOutput struct {
+	file   string
+	prefix []byte
+	lines  int
+}
+
+func (o *exportTestOutput) Write(p []byte) (int, error) {
+	if n := exportTestPrefixSize - len(o.prefix); n > 0 {
+		o.prefix = append(o.prefix, p[:min(n, len(p))]...)
+	}
+	o.lines += bytes.Count(p, []byte("\n"))
+	return len(p), nil
+}
+
+// completePrefix returns the beginning of the export up to the last complete line.
+func (o *exportTestOutput) completePrefix() []byte {
+	return o.prefix[:bytes.LastIndexByte(o.prefix, '\n')+1]
+}
+
+// syntheticEntryIterator creates entries while it is iterated and samples the heap usage.
+type syntheticEntryIterator struct {
+	count          int
+	index          int
+	entry          *model.Entry
+	sampleInterval int
+	baseHeap       uint64
+	maxHeapGrowth  uint64
+}
+
+func newSyntheticEntryIterator(count int) *syntheticEntryIterator {
+	it := &syntheticEntryIterator{count: count, sampleInterval: count / exportTestHeapSamples}
+	it.baseHeap = readHeapAlloc()
+	return it
+}
+
+func (it *syntheticEntryIterator) Next() bool {
+	if it.index > 0 && it.index%it.sampleInterval == 0 {
+		it.sampleHeap()
+	}
+	if it.index >= it.count {
+		return false
+	}
+
+	start := time.Date(2026, time.January, 1, 8, 0, 0, 0, time.UTC).AddDate(0, 0, it.index)
+	it.entry = &model.Entry{
+		Id:          it.index + 1,
+		UserId:      1,
+		TypeId:      model.EntryTypeIdWork,
+		StartTime:   start,
+		EndTime:     start.Add(8 * time.Hour),
+		Project:     fmt.Sprintf("Project %d", it.index%10),
+		Description: fmt.Sprintf("Entry %d", it.index),
+		Labels:      []string{"label-a", "label-b"},
+	}
+	it.index++
+	return true
+}
+
+func (it *syntheticEntryIterator) Entry() *model.Entry {
+	return it.entry
+}
+
+func (it *syntheticEntryIterator) Err() error {
+	return nil
+}
+
+func (it *syntheticEntryIterator) sampleHeap() {
+	heap := readHeapAlloc()
+	if heap > it.baseHeap && heap-it.baseHeap > it.maxHeapGrowth {
+		it.maxHeapGrowth = heap - it.baseHeap
+	}
+}
+
+// readHeapAlloc returns the size of the live heap (after a garbage collection).
+func readHeapAlloc() uint64 {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+func newExportTestEntryTypesMap() map[int]*model.EntryType {
+	return map[int]*model.EntryType{
+		model.EntryTypeIdWork: {Id: model.EntryTypeIdWork, Description: "Work",
+			CountsAsWork: true},
+	}
+}
@@ -0,0 +1,189 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// Formats of dates and times in the filter details.
+const (
+	filterDateFormat     = "02.01.2006"
+	filterDateTimeFormat = "02.01.2006 15:04"
+)
+
+// Separator of the conditions in the filter details.
+const filterDetailsSeparator = " | "
+
+var filterFieldLabelKeys = map[model.EntryFilterField]string{
+	model.EntryFilterFieldType:        "formLabelType",
+	model.EntryFilterFieldStartTime:   "formLabelStart",
+	model.EntryFilterFieldEndTime:     "formLabelEnd",
+	model.EntryFilterFieldDuration:    "exportFilterLabelDuration",
+	model.EntryFilterFieldWeekday:     "exportFilterLabelWeekday",
+	model.EntryFilterFieldActivity:    "formLabelActivity",
+	model.EntryFilterFieldProject:     "formLabelProject",
+	model.EntryFilterFieldDescription: "formLabelDescription",
+	model.EntryFilterFieldLabels:      "formLabelLabels",
+}
+
+// getFilterDetails describes the supplied filter, e.g. "Type: Work | Date: 01.02.2024 -
+// 29.02.2024". Entry types and activities are described by their names and times are formatted
+// in the supplied location.
+func getFilterDetails(filter model.EntryFilter, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity, loc *time.Location) string {
+	switch f := filter.(type) {
+	case *model.TextEntryFilter:
+		return createString("formLabelText") + " " + quoteFilterValue(f.Text)
+	case *model.FieldEntryFilter:
+		return getFieldFilterDetails(f, entryTypesMap, entryActivitiesMap, loc)
+	case *model.ExpressionEntryFilter:
+		if f.Expression == nil {
+			return ""
+		}
+		d := &filterExpressionDescriber{entryTypesMap, entryActivitiesMap, loc}
+		return d.describe(f.Expression, false)
+	default:
+		return ""
+	}
+}
+
+func getFieldFilterDetails(filter *model.FieldEntryFilter, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity, loc *time.Location) string {
+	var details []string
+	if filter.ByType {
+		details = append(details, createString("formLabelType")+" "+
+			getEntryTypeName(filter.TypeId, entryTypesMap))
+	}
+	if filter.ByTime {
+		start := filter.StartTime.In(loc).Format(filterDateFormat)
+		end := filter.EndTime.In(loc).Format(filterDateFormat)
+		date := start
+		if start != end {
+			date = start + " - " + end
+		}
+		details = append(details, createString("formLabelDate")+" "+date)
+	}
+	if filter.ByActivity {
+		details = append(details, createString("formLabelActivity")+" "+
+			getEntryActivityName(filter.ActivityId, entryActivitiesMap))
+	}
+	if filter.ByProject {
+		details = append(details, createString("formLabelProject")+" "+
+			quoteFilterValue(filter.Project))
+	}
+	if filter.ByDescription {
+		details = append(details, createString("formLabelDescription")+" "+
+			quoteFilterValue(filter.Description))
+	}
+	if filter.ByLabel {
+		labels := make([]string, len(filter.Labels))
+		for i, label := range filter.Labels {
+			labels[i] = quoteFilterValue(label)
+		}
+		details = append(details, createString("formLabelLabels")+" "+
+			strings.Join(labels, ", "))
+	}
+	return strings.Join(details, filterDetailsSeparator)
+}
+
+// filterExpressionDescriber describes filter expressions of the API.
+type filterExpressionDescriber struct {
+	entryTypesMap      map[int]*model.EntryType
+	entryActivitiesMap map[int]*model.EntryActivity
+	loc                *time.Location
+}
+
+func (d *filterExpressionDescriber) describe(expr model.EntryFilterExpression, nested bool,
+) string {
+	switch x := expr.(type) {
+	case *model.AndEntryFilterExpression:
+		return d.describeGroup(x.Expressions, filterDetailsSeparator, nested)
+	case *model.OrEntryFilterExpression:
+		return d.describeGroup(x.Expressions, " "+createString("exportFilterOr")+" ", nested)
+	case *model.NotEntryFilterExpression:
+		return createString("exportFilterNot") + " " + d.describe(x.Expression, true)
+	case *model.ConditionEntryFilterExpression:
+		return d.describeCondition(x)
+	default:
+		return ""
+	}
+}
+
+func (d *filterExpressionDescriber) describeGroup(exprs []model.EntryFilterExpression,
+	separator string, nested bool) string {
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = d.describe(expr, true)
+	}
+	details := strings.Join(parts, separator)
+	if nested && len(parts) > 1 {
+		return "(" + details + ")"
+	}
+	return details
+}
+
+func (d *filterExpressionDescriber) describeCondition(cond *model.ConditionEntryFilterExpression,
+) string {
+	label := createString(filterFieldLabelKeys[cond.Field])
+	values := make([]string, len(cond.Values))
+	for i, value := range cond.Values {
+		values[i] = d.formatValue(cond.Field, value)
+	}
+
+	switch cond.Operator {
+	case model.EntryFilterOpIsNull:
+		return label + " -"
+	case model.EntryFilterOpBetween:
+		return label + " " + strings.Join(values, " - ")
+	case model.EntryFilterOpGreater:
+		return label + " > " + strings.Join(values, ", ")
+	case model.EntryFilterOpLess:
+		return label + " < " + strings.Join(values, ", ")
+	default:
+		return label + " " + strings.Join(values, ", ")
+	}
+}
+
+func (d *filterExpressionDescriber) formatValue(field model.EntryFilterField, value any) string {
+	switch v := value.(type) {
+	case int:
+		if field == model.EntryFilterFieldActivity {
+			return getEntryActivityName(v, d.entryActivitiesMap)
+		}
+		return getEntryTypeName(v, d.entryTypesMap)
+	case time.Time:
+		return v.In(d.loc).Format(filterDateTimeFormat)
+	case time.Duration:
+		return fmt.Sprintf("%d:%02d", int(v.Hours()), int(v.Minutes())%60)
+	case time.Weekday:
+		return createString(timesheetWeekdayKeys[v])
+	case string:
+		return quoteFilterValue(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// --- Helper functions ---
+
+func getEntryTypeName(id int, entryTypesMap map[int]*model.EntryType) string {
+	if t, ok := entryTypesMap[id]; ok {
+		return t.Description
+	}
+	return strconv.Itoa(id)
+}
+
+func getEntryActivityName(id int, entryActivitiesMap map[int]*model.EntryActivity) string {
+	if a, ok := entryActivitiesMap[id]; ok {
+		return a.Description
+	}
+	return strconv.Itoa(id)
+}
+
+func quoteFilterValue(value string) string {
+	return "\"" + value + "\""
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+func TestGetFilterDetails(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("could not load location: %s", err)
+	}
+	entryTypesMap := newExportTestEntryTypesMap()
+	start := time.Date(2024, time.February, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2024, time.February, 29, 23, 59, 59, 0, loc)
+
+	// Web filter
+	fieldFilter := model.NewFieldEntryFilter()
+	fieldFilter.ByType = true
+	fieldFilter.TypeId = model.EntryTypeIdWork
+	fieldFilter.ByTime = true
+	fieldFilter.StartTime = start
+	fieldFilter.EndTime = end
+	fieldFilter.ByProject = true
+	fieldFilter.Project = "P"
+	want := createString("formLabelType") + " Work | " + createString("formLabelDate") +
+		" 01.02.2024 - 29.02.2024 | " + createString("formLabelProject") + " \"P\""
+	if got := getFilterDetails(fieldFilter, entryTypesMap, nil, loc); got != want {
+		t.Errorf("field filter details = %q, want %q", got, want)
+	}
+
+	// API filter
+	exprFilter := model.NewExpressionEntryFilter()
+	exprFilter.Expression = &model.AndEntryFilterExpression{
+		Expressions: []model.EntryFilterExpression{
+			&model.ConditionEntryFilterExpression{Field: model.EntryFilterFieldType,
+				Operator: model.EntryFilterOpEqual, Values: []any{model.EntryTypeIdWork}},
+			&model.ConditionEntryFilterExpression{Field: model.EntryFilterFieldStartTime,
+				Operator: model.EntryFilterOpBetween, Values: []any{start.UTC(), end.UTC()}},
+			&model.OrEntryFilterExpression{Expressions: []model.EntryFilterExpression{
+				&model.ConditionEntryFilterExpression{Field: model.EntryFilterFieldProject,
+					Operator: model.EntryFilterOpContains, Values: []any{"P"}},
+				&model.ConditionEntryFilterExpression{Field: model.EntryFilterFieldProject,
+					Operator: model.EntryFilterOpIsNull},
+			}},
+		}}
+	want = createString("formLabelType") + " Work | " + createString("formLabelStart") +
+		" 01.02.2024 00:00 - 29.02.2024 23:59 | (" + createString("formLabelProject") +
+		" \"P\" " + createString("exportFilterOr") + " " + createString("formLabelProject") + " -)"
+	if got := getFilterDetails(exprFilter, entryTypesMap, nil, loc); got != want {
+		t.Errorf("expression filter details = %q, want %q", got, want)
+	}
+
+	// Unrestricted API filter
+	if got := getFilterDetails(model.NewExpressionEntryFilter(), entryTypesMap, nil,
+		loc); got != "" {
+		t.Errorf("empty filter details = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type jsonEntryRecord struct {
+	StartTime      string   `json:"startTime"`
+	EndTime        string   `json:"endTime"`
+	Type           string   `json:"type"`
+	Activity       string   `json:"activity"`
+	Project        string   `json:"project"`
+	Description    string   `json:"description"`
+	Labels         []string `json:"labels"`
+	Duration       int      `json:"duration"`
+	BilledDuration int      `json:"billedDuration"`
+	Rounding       string   `json:"rounding"`
+}
+
+func newJsonEntryRecord(record *EntryRecord) *jsonEntryRecord {
+	labels := record.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return &jsonEntryRecord{
+		StartTime:      record.StartTime.Format(time.RFC3339),
+		EndTime:        record.EndTime.Format(time.RFC3339),
+		Type:           record.Type,
+		Activity:       record.Activity,
+		Project:        record.Project,
+		Description:    record.Description,
+		Labels:         labels,
+		Duration:       record.Duration,
+		BilledDuration: record.BilledDuration,
+		Rounding:       record.Rounding,
+	}
+}
+
+type jsonWriterToAdapter struct {
+	data  *EntriesData
+	lines bool
+}
+
+func (jta *jsonWriterToAdapter) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	if err := jta.writeRecords(bw); err != nil {
+		return cw.n, err
+	}
+
+	err = bw.Flush()
+	return cw.n, err
+}
+
+func (jta *jsonWriterToAdapter) writeRecords(w *bufio.Writer) error {
+	// The encoder writes one object per line
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	// JSON Lines: one object per line
+	if jta.lines {
+		return jta.data.ForEachRecord(func(record *EntryRecord) error {
+			return encoder.Encode(newJsonEntryRecord(record))
+		})
+	}
+
+	// JSON: array of objects
+	w.WriteString("[")
+	first := true
+	err := jta.data.ForEachRecord(func(record *EntryRecord) error {
+		if !first {
+			w.WriteString(",")
+		}
+		first = false
+		return encoder.Encode(newJsonEntryRecord(record))
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.WriteString("]\n")
+	return err
+}
+
+// JsonExporter exports entries to a JSON file (an array of entry objects).
+type JsonExporter struct {
+}
+
+// NewJsonExporter creates a new JSON exporter.
+func NewJsonExporter() *JsonExporter {
+	return &JsonExporter{}
+}
+
+// Format returns the name of the format.
+func (e *JsonExporter) Format() string {
+	return "json"
+}
+
+// ContentType returns the media type of the exported file.
+func (e *JsonExporter) ContentType() string {
+	return "application/json"
+}
+
+// Export creates the JSON file for the supplied data and returns it as an io.WriterTo that can be
+// used to write the file to a writer.
+func (e *JsonExporter) Export(data *EntriesData) io.WriterTo {
+	return &jsonWriterToAdapter{
+		data: data,
+	}
+}
+
+// JsonLinesExporter exports entries to a JSON Lines file (one entry object per line).
+type JsonLinesExporter struct {
+}
+
+// NewJsonLinesExporter creates a new JSON Lines exporter.
+func NewJsonLinesExporter() *JsonLinesExporter {
+	return &JsonLinesExporter{}
+}
+
+// Format returns the name of the format.
+func (e *JsonLinesExporter) Format() string {
+	return "jsonl"
+}
+
+// ContentType returns the media type of the exported file.
+func (e *JsonLinesExporter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// Export creates the JSON Lines file for the supplied data and returns it as an io.WriterTo that
+// can be used to write the file to a writer.
+func (e *JsonLinesExporter) Export(data *EntriesData) io.WriterTo {
+	return &jsonWriterToAdapter{
+		data:  data,
+		lines: true,
+	}
+}
@@ -0,0 +1,208 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const odsMediaType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:media-type="` + odsMediaType + `"/>
+ <manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+
+const odsContentStart = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+	`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+	`xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" office:version="1.2">
+<office:automatic-styles>
+ <number:date-style style:name="N1"><number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text><number:day number:style="long"/>` +
+	`<number:text> </number:text><number:hours number:style="long"/><number:text>:</number:text>` +
+	`<number:minutes number:style="long"/></number:date-style>
+ <style:style style:name="ceTitle" style:family="table-cell"><style:text-properties fo:font-size="14pt" fo:font-weight="bold"/></style:style>
+ <style:style style:name="ceBold" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>
+ <style:style style:name="ceHeader" style:family="table-cell"><style:table-cell-properties fo:background-color="#efefef"/><style:text-properties fo:font-weight="bold"/></style:style>
+ <style:style style:name="ceTime" style:family="table-cell" style:data-style-name="N1"/>
+`
+
+const odsContentEnd = `</table:table>
+</office:spreadsheet>
+</office:body>
+</office:document-content>
+`
+
+// Format of the date values of the start and end time cells.
+const odsDateValueFormat = "2006-01-02T15:04:05"
+
+type odsWriterToAdapter struct {
+	data *EntriesData
+}
+
+func (ota *odsWriterToAdapter) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	zw := zip.NewWriter(cw)
+
+	// The MIME type must be the first file and must not be compressed
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return cw.n, err
+	}
+	if _, err := io.WriteString(mw, odsMediaType); err != nil {
+		return cw.n, err
+	}
+
+	// Write manifest
+	fw, err := zw.Create("META-INF/manifest.xml")
+	if err != nil {
+		return cw.n, err
+	}
+	if _, err := io.WriteString(fw, odsManifest); err != nil {
+		return cw.n, err
+	}
+
+	// Write content
+	fw, err = zw.Create("content.xml")
+	if err != nil {
+		return cw.n, err
+	}
+	bw := bufio.NewWriter(fw)
+	if err := ota.writeContent(bw); err != nil {
+		return cw.n, err
+	}
+	if err := bw.Flush(); err != nil {
+		return cw.n, err
+	}
+
+	err = zw.Close()
+	return cw.n, err
+}
+
+func (ota *odsWriterToAdapter) writeContent(w *bufio.Writer) error {
+	w.WriteString(odsContentStart)
+
+	// Write column styles (the width is converted from characters to centimeters)
+	for i, col := range columns {
+		fmt.Fprintf(w, ` <style:style style:name="co%d" style:family="table-column">`+
+			`<style:table-column-properties style:column-width="%.2fcm"/></style:style>`+"\n", i,
+			col.width*0.2)
+	}
+	w.WriteString("</office:automatic-styles>\n<office:body>\n<office:spreadsheet>\n")
+
+	// Write table and columns
+	w.WriteString(`<table:table table:name="`)
+	xml.EscapeText(w, []byte(createString("exportSheetName")))
+	w.WriteString("\">\n")
+	for i := range columns {
+		fmt.Fprintf(w, `<table:table-column table:style-name="co%d"/>`, i)
+	}
+	w.WriteString("\n")
+
+	// Write title and details
+	if ota.data.Title != "" {
+		ota.writeRow(w, func() { ota.writeStringCell(w, ota.data.Title, "ceTitle") })
+		if ota.data.Details != "" {
+			ota.writeRow(w, func() { ota.writeStringCell(w, ota.data.Details, "ceBold") })
+		}
+		ota.writeRow(w, func() {})
+	}
+
+	// Write header
+	ota.writeRow(w, func() {
+		for _, title := range getColumnTitles() {
+			ota.writeStringCell(w, title, "ceHeader")
+		}
+	})
+
+	// Write records
+	err := ota.data.ForEachRecord(func(record *EntryRecord) error {
+		ota.writeRow(w, func() {
+			ota.writeDateCell(w, record.StartTime.Format(odsDateValueFormat))
+			ota.writeDateCell(w, record.EndTime.Format(odsDateValueFormat))
+			ota.writeStringCell(w, record.Type, "")
+			ota.writeStringCell(w, record.Activity, "")
+			ota.writeStringCell(w, record.Project, "")
+			ota.writeStringCell(w, record.Description, "")
+			ota.writeStringCell(w, strings.Join(record.Labels, " "), "")
+			ota.writeFloatCell(w, record.Duration)
+			ota.writeFloatCell(w, record.BilledDuration)
+			ota.writeStringCell(w, record.Rounding, "")
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(odsContentEnd)
+	return err
+}
+
+func (ota *odsWriterToAdapter) writeRow(w *bufio.Writer, writeCells func()) {
+	w.WriteString("<table:table-row>")
+	writeCells()
+	w.WriteString("</table:table-row>\n")
+}
+
+func (ota *odsWriterToAdapter) writeStringCell(w *bufio.Writer, value string, style string) {
+	w.WriteString("<table:table-cell")
+	if style != "" {
+		w.WriteString(` table:style-name="` + style + `"`)
+	}
+	if value == "" {
+		w.WriteString("/>")
+		return
+	}
+	w.WriteString(` office:value-type="string"><text:p>`)
+	xml.EscapeText(w, []byte(value))
+	w.WriteString("</text:p></table:table-cell>")
+}
+
+func (ota *odsWriterToAdapter) writeDateCell(w *bufio.Writer, value string) {
+	w.WriteString(`<table:table-cell table:style-name="ceTime" office:value-type="date" ` +
+		`office:date-value="` + value + `"><text:p>` + value + "</text:p></table:table-cell>")
+}
+
+func (ota *odsWriterToAdapter) writeFloatCell(w *bufio.Writer, value int) {
+	v := strconv.Itoa(value)
+	w.WriteString(`<table:table-cell office:value-type="float" office:value="` + v + `"><text:p>` +
+		v + "</text:p></table:table-cell>")
+}
+
+// OdsExporter exports entries to an OpenDocument spreadsheet.
+type OdsExporter struct {
+}
+
+// NewOdsExporter creates a new OpenDocument spreadsheet exporter.
+func NewOdsExporter() *OdsExporter {
+	return &OdsExporter{}
+}
+
+// Format returns the name of the format.
+func (e *OdsExporter) Format() string {
+	return "ods"
+}
+
+// ContentType returns the media type of the exported file.
+func (e *OdsExporter) ContentType() string {
+	return odsMediaType
+}
+
+// Export creates the OpenDocument spreadsheet for the supplied data and returns it as an
+// io.WriterTo that can be used to write the file to a writer.
+func (e *OdsExporter) Export(data *EntriesData) io.WriterTo {
+	return &odsWriterToAdapter{
+		data: data,
+	}
+}
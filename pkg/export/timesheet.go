@@ -0,0 +1,361 @@
+package export
+
+import (
+	"sort"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// TimesheetData stores the data of the monthly timesheet of a user. It is the data source of the
+// overview page and of the PDF timesheet, so both show the same content.
+type TimesheetData struct {
+	UserName    string
+	Year        int
+	Month       int
+	TargetHours float32            // Target hours of the month
+	ActualHours float32            // Hours of credited entry types (minus break deductions)
+	Types       []*TimesheetType   // Hours per entry type (ordered by type ID)
+	Rounding    *TimesheetRounding // Rounding of the month (nil if no policy applies)
+	Days        []*TimesheetDay    // Days of the month
+}
+
+// GetBalanceHours returns the difference between actual and target hours.
+func (d *TimesheetData) GetBalanceHours() float32 {
+	return d.ActualHours - d.TargetHours
+}
+
+// TimesheetType stores the hours of an entry type in a month.
+type TimesheetType struct {
+	Type  *model.EntryType
+	Hours float32
+}
+
+// TimesheetRounding stores the rounding policies which apply to the entries of a month.
+type TimesheetRounding struct {
+	Policies       []string      // Descriptions of the policies of the user and of the projects
+	IsReportScope  bool          // True if a policy rounds the entries in reports
+	BilledDuration time.Duration // Billed duration of the work entries of the month
+}
+
+// TimesheetDay stores the entries of a day.
+type TimesheetDay struct {
+	Date          time.Time         // Start of the day (in the location of the user)
+	StartTime     time.Time         // Start time of the first entry (zero if there are no entries)
+	EndTime       time.Time         // End time of the last entry (zero if there are no entries)
+	BreakDuration time.Duration     // Time between the first and the last entry without entries
+	Duration      time.Duration     // Duration of the entries (minus break deduction)
+	BreakCheck    *model.BreakCheck // Check of the break rules (nil if the rules are met)
+	Entries       []*TimesheetEntry // Entries of the day (ordered by start time)
+}
+
+// HasEntries returns true if entries exist for the day.
+func (d *TimesheetDay) HasEntries() bool {
+	return len(d.Entries) > 0
+}
+
+// TimesheetEntry stores an entry of a day.
+type TimesheetEntry struct {
+	Entry          *model.Entry  // Entry (with times in the location of the user)
+	Type           string        // Description of the entry type
+	Activity       string        // Description of the entry activity
+	BilledDuration time.Duration // Duration after rounding with a policy of the scope "report"
+}
+
+// NewTimesheetData creates the timesheet data of a month from the entries of the month. Times are
+// converted to the supplied location.
+func NewTimesheetData(userName string, userContract *model.Contract, year int, month int,
+	entries []*model.Entry, loc *time.Location, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity, roundingPolicies *model.RoundingPolicies,
+) *TimesheetData {
+	// Convert entries to time zone of user
+	locEntries := make([]*model.Entry, 0, len(entries))
+	for _, entry := range entries {
+		locEntry := *entry
+		locEntry.StartTime = entry.StartTime.In(loc)
+		locEntry.EndTime = entry.EndTime.In(loc)
+		locEntries = append(locEntries, &locEntry)
+	}
+
+	// Check breaks
+	breakChecks := checkDaysBreaks(userContract, locEntries, entryTypesMap)
+
+	data := &TimesheetData{
+		UserName: userName,
+		Year:     year,
+		Month:    month,
+	}
+
+	// Calculate hours
+	typeHours := calculateTypeHours(locEntries, entryTypesMap, breakChecks)
+	for _, entryType := range getSortedEntryTypes(entryTypesMap) {
+		hours, ok := typeHours[entryType.Id]
+		if !ok {
+			continue
+		}
+		if entryType.IsCredited() {
+			data.ActualHours = data.ActualHours + hours
+		}
+		data.Types = append(data.Types, &TimesheetType{Type: entryType, Hours: hours})
+	}
+	data.TargetHours = calculateTargetHours(userContract, year, month)
+
+	// Create rounding
+	data.Rounding = createTimesheetRounding(locEntries, entryTypesMap, roundingPolicies)
+
+	// Create days
+	data.Days = make([]*TimesheetDay, 0, 31)
+	curEntryIndex := 0
+	curDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	for curDate.Month() == time.Month(month) {
+		var day *TimesheetDay
+		curEntryIndex, day = createTimesheetDay(curDate, curEntryIndex, locEntries, entryTypesMap,
+			entryActivitiesMap, roundingPolicies, breakChecks)
+		data.Days = append(data.Days, day)
+		curDate = curDate.AddDate(0, 0, 1)
+	}
+
+	return data
+}
+
+func checkDaysBreaks(userContract *model.Contract, entries []*model.Entry,
+	entryTypesMap map[int]*model.EntryType) map[time.Time]*model.BreakCheck {
+	breakChecks := make(map[time.Time]*model.BreakCheck)
+
+	// If no break rules were set: Abort
+	if userContract == nil || len(userContract.BreakRules) == 0 {
+		return breakChecks
+	}
+
+	// Group entries by day
+	dayEntries := make(map[time.Time][]*model.Entry)
+	for _, entry := range entries {
+		date := util.ToCivilDate(entry.StartTime)
+		dayEntries[date] = append(dayEntries[date], entry)
+	}
+
+	// Check breaks of each day
+	for date, des := range dayEntries {
+		check := userContract.CheckBreaks(model.NewWorkDay(des[0].StartTime, des, entryTypesMap))
+		if check.IsViolated() {
+			// If missing breaks should not be deducted: Reset deduction
+			if !userContract.DeductMissingBreaks {
+				check.DeductionDuration = 0
+			}
+			breakChecks[date] = check
+		}
+	}
+
+	return breakChecks
+}
+
+func calculateTypeHours(entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
+	breakChecks map[time.Time]*model.BreakCheck) map[int]float32 {
+	// Calculate actual durations
+	durations := make(map[int]time.Duration)
+	for _, entry := range entries {
+		durations[entry.TypeId] = durations[entry.TypeId] + entry.EndTime.Sub(entry.StartTime)
+	}
+
+	// Deduct missing breaks (from the work type with the longest duration)
+	workTypeId := 0
+	for typeId, duration := range durations {
+		if model.IsWorkEntryType(entryTypesMap, typeId) &&
+			(workTypeId == 0 || duration > durations[workTypeId]) {
+			workTypeId = typeId
+		}
+	}
+	if workTypeId != 0 {
+		for _, check := range breakChecks {
+			durations[workTypeId] = durations[workTypeId] - check.DeductionDuration
+		}
+	}
+
+	// Return rounded hours
+	hours := make(map[int]float32)
+	for typeId, duration := range durations {
+		hours[typeId] = getRoundedHours(duration)
+	}
+	return hours
+}
+
+func calculateTargetHours(userContract *model.Contract, year int, month int) float32 {
+	// Calculate days (the days of a month don't depend on the time zone)
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	workDays := util.CalculateWorkingDays(start, end)
+
+	// Find working hours which are valid at the start of the month
+	var dailyHours float32
+	for _, wh := range getSortedWorkingHours(userContract) {
+		if util.ToCivilDate(wh.FirstDay).After(start) {
+			break
+		}
+		dailyHours = wh.Hours
+	}
+	dailyDuration := time.Duration(int(dailyHours*60.0)) * time.Minute
+
+	// Return rounded hours
+	return getRoundedHours(time.Duration(workDays) * dailyDuration)
+}
+
+func getSortedWorkingHours(userContract *model.Contract) []model.ContractWorkingHours {
+	if userContract == nil {
+		return nil
+	}
+	workingHours := make([]model.ContractWorkingHours, len(userContract.WorkingHours))
+	copy(workingHours, userContract.WorkingHours)
+	sort.SliceStable(workingHours, func(i, j int) bool {
+		return workingHours[i].FirstDay.Before(workingHours[j].FirstDay)
+	})
+	return workingHours
+}
+
+func createTimesheetRounding(entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
+	roundingPolicies *model.RoundingPolicies) *TimesheetRounding {
+	// Collect policies of the user and of the projects of the month
+	policies := make([]string, 0)
+	if roundingPolicies.UserPolicy != nil {
+		policies = append(policies, getRoundingPolicyText(roundingPolicies.UserPolicy))
+	}
+	projects := make(map[string]bool)
+	for _, entry := range entries {
+		policy, ok := roundingPolicies.ProjectPolicies[entry.Project]
+		if !ok || projects[entry.Project] {
+			continue
+		}
+		projects[entry.Project] = true
+		policies = append(policies, createString("roundingPolicyProject", entry.Project,
+			getRoundingPolicyText(policy)))
+	}
+
+	// If no policy applies: Abort
+	if len(policies) == 0 {
+		return nil
+	}
+
+	// Calculate billed duration
+	rounding := &TimesheetRounding{Policies: policies}
+	for _, entry := range entries {
+		if !model.IsWorkEntryType(entryTypesMap, entry.TypeId) {
+			continue
+		}
+		if roundingPolicies.GetScopePolicy(entry.Project, model.RoundingScopeReport) != nil {
+			rounding.IsReportScope = true
+		}
+		rounding.BilledDuration += getBilledDuration(entry, entryTypesMap, roundingPolicies)
+	}
+	return rounding
+}
+
+// getBilledDuration returns the duration of the entry rounded by a rounding policy with the scope
+// "report". (Entries rounded at save time are already stored with the rounded duration.)
+func getBilledDuration(entry *model.Entry, entryTypesMap map[int]*model.EntryType,
+	roundingPolicies *model.RoundingPolicies) time.Duration {
+	duration := entry.EndTime.Sub(entry.StartTime)
+	if !model.IsWorkEntryType(entryTypesMap, entry.TypeId) {
+		return duration
+	}
+	policy := roundingPolicies.GetScopePolicy(entry.Project, model.RoundingScopeReport)
+	if policy == nil {
+		return duration
+	}
+	return policy.Round(duration)
+}
+
+func createTimesheetDay(curDate time.Time, curEntryIndex int, entries []*model.Entry,
+	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
+	roundingPolicies *model.RoundingPolicies, breakChecks map[time.Time]*model.BreakCheck) (int,
+	*TimesheetDay) {
+	day := &TimesheetDay{
+		Date:    curDate,
+		Entries: make([]*TimesheetEntry, 0, 10),
+	}
+
+	// Add entries of the day
+	entryIndex := curEntryIndex
+	var dailyDuration time.Duration
+	for ; entryIndex < len(entries); entryIndex++ {
+		// If entry date does not match: Abort (All enties have been added for this day)
+		entry := entries[entryIndex]
+		if entry.StartTime.Day() != curDate.Day() {
+			break
+		}
+
+		if day.StartTime.IsZero() {
+			day.StartTime = entry.StartTime
+		}
+		day.EndTime = entry.EndTime
+		dailyDuration = dailyDuration + entry.EndTime.Sub(entry.StartTime)
+
+		day.Entries = append(day.Entries, &TimesheetEntry{
+			Entry:          entry,
+			Type:           getEntryTypeDescription(entryTypesMap, entry.TypeId),
+			Activity:       getEntryActivityDescription(entryActivitiesMap, entry.ActivityId),
+			BilledDuration: getBilledDuration(entry, entryTypesMap, roundingPolicies),
+		})
+	}
+
+	// Calculate break and daily duration
+	if day.HasEntries() {
+		if breakDuration := day.EndTime.Sub(day.StartTime) - dailyDuration; breakDuration > 0 {
+			day.BreakDuration = breakDuration
+		}
+	}
+	day.Duration = dailyDuration
+	if check, ok := breakChecks[util.ToCivilDate(curDate)]; ok {
+		day.BreakCheck = check
+		day.Duration = dailyDuration - check.DeductionDuration
+	}
+
+	// Return updated entry index and new day
+	return entryIndex, day
+}
+
+func getSortedEntryTypes(entryTypesMap map[int]*model.EntryType) []*model.EntryType {
+	entryTypes := make([]*model.EntryType, 0, len(entryTypesMap))
+	for _, entryType := range entryTypesMap {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool {
+		return entryTypes[i].Id < entryTypes[j].Id
+	})
+	return entryTypes
+}
+
+func getEntryTypeDescription(entryTypesMap map[int]*model.EntryType, id int) string {
+	if entryType, ok := entryTypesMap[id]; ok {
+		return entryType.Description
+	}
+	return ""
+}
+
+func getEntryActivityDescription(entryActivitiesMap map[int]*model.EntryActivity, id int) string {
+	if entryActivity, ok := entryActivitiesMap[id]; ok {
+		return entryActivity.Description
+	}
+	return ""
+}
+
+func getRoundedHours(d time.Duration) float32 {
+	return float32(d.Round(time.Minute).Hours())
+}
+
+// getRoundingPolicyText returns the localized description of a rounding policy.
+func getRoundingPolicyText(policy *model.RoundingPolicy) string {
+	var modeKey string
+	switch policy.Mode {
+	case model.RoundingModeUp:
+		modeKey = "roundingModeUp"
+	case model.RoundingModeDown:
+		modeKey = "roundingModeDown"
+	default:
+		modeKey = "roundingModeNearest"
+	}
+	scopeKey := "roundingScopeSave"
+	if policy.Scope == model.RoundingScopeReport {
+		scopeKey = "roundingScopeReport"
+	}
+	return createString(modeKey, policy.IntervalMinutes) + " (" + createString(scopeKey) + ")"
+}
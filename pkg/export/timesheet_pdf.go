@@ -0,0 +1,303 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/pdf"
+)
+
+// Height of the logo in the header (in mm).
+const pdfLogoHeight = 15.0
+
+// Formats of dates and times in the timesheet.
+const (
+	timesheetShortDateFormat = "02.01."
+	timesheetTimeFormat      = "15:04"
+)
+
+var timesheetMonthKeys = map[time.Month]string{
+	time.January:   "monthJan",
+	time.February:  "monthFeb",
+	time.March:     "monthMar",
+	time.April:     "monthApr",
+	time.May:       "monthMay",
+	time.June:      "monthJun",
+	time.July:      "monthJul",
+	time.August:    "monthAug",
+	time.September: "monthSep",
+	time.October:   "monthOct",
+	time.November:  "monthNov",
+	time.December:  "monthDec",
+}
+
+var timesheetWeekdayKeys = map[time.Weekday]string{
+	time.Sunday:    "weekdaySun",
+	time.Monday:    "weekdayMon",
+	time.Tuesday:   "weekdayTue",
+	time.Wednesday: "weekdayWed",
+	time.Thursday:  "weekdayThu",
+	time.Friday:    "weekdayFri",
+	time.Saturday:  "weekdaySat",
+}
+
+// TimesheetPdfExporter exports the timesheet of a month to a PDF file.
+type TimesheetPdfExporter struct {
+	header         string
+	logo           []byte
+	signatureLines bool
+}
+
+// NewTimesheetPdfExporter creates a new timesheet PDF exporter. The header text and the logo (a
+// JPEG or PNG file) are printed at the top of the first page. If signature lines are enabled,
+// lines for the signatures of the employee and the supervisor are printed at the end.
+func NewTimesheetPdfExporter(header string, logoFile string, signatureLines bool,
+) *TimesheetPdfExporter {
+	var logo []byte
+	if logoFile != "" {
+		var err error
+		logo, err = os.ReadFile(logoFile)
+		if err != nil {
+			log.Errorf("Could not read timesheet logo '%s': %s", logoFile, err)
+		}
+	}
+	return &TimesheetPdfExporter{
+		header:         header,
+		logo:           logo,
+		signatureLines: signatureLines,
+	}
+}
+
+// ExportTimesheet creates the PDF file for the supplied data and returns it as an io.WriterTo that
+// can be used to write the file to a writer. The user name is printed below the title.
+func (e *TimesheetPdfExporter) ExportTimesheet(data *TimesheetData) io.WriterTo {
+	title := createString("overviewExportTitle", createString("appName"))
+	monthName := getTimesheetMonthName(data.Year, data.Month)
+	doc := pdf.NewDocument(title+" "+monthName, createString("appName"))
+
+	// Write header
+	e.writeHeader(doc)
+	// Write title
+	doc.SetFont(true, 14)
+	doc.WriteLine(title)
+	doc.SetFont(true, 10)
+	doc.WriteLine(monthName)
+	doc.SetFont(false, 10)
+	doc.WriteLine(data.UserName)
+	doc.Space(4)
+	// Write summary
+	e.writeSummary(doc, data)
+	// Write rounding
+	if data.Rounding != nil {
+		e.writeRounding(doc, data.Rounding)
+	}
+	// Write days
+	e.writeDays(doc, data)
+	// Write entries
+	e.writeEntries(doc, data)
+	// Write signature lines
+	if e.signatureLines {
+		e.writeSignatureLines(doc)
+	}
+
+	return doc
+}
+
+func (e *TimesheetPdfExporter) writeHeader(doc *pdf.Document) {
+	startY := doc.Y()
+	endY := startY
+
+	// Draw logo at the right margin
+	if e.logo != nil {
+		img, err := doc.AddImage(e.logo)
+		if err != nil {
+			log.Errorf("Could not add timesheet logo: %s", err)
+		} else {
+			w := pdfLogoHeight * float64(img.Width()) / float64(img.Height())
+			doc.DrawImage(img, doc.Right()-w, startY, w, pdfLogoHeight)
+			endY = startY + pdfLogoHeight
+		}
+	}
+
+	// Write header text
+	if e.header != "" {
+		doc.SetFont(true, 12)
+		doc.WriteLine(e.header)
+	}
+
+	if doc.Y() > endY {
+		endY = doc.Y()
+	}
+	if endY > startY {
+		doc.SetY(endY + 6)
+	}
+}
+
+func (e *TimesheetPdfExporter) writeSummary(doc *pdf.Document, data *TimesheetData) {
+	e.writeHeading(doc, createString("overviewExportHeadingSummary"))
+	doc.SetFont(false, 9)
+	cols := []*pdf.Column{
+		{Width: 40},
+		{Width: 25, AlignRight: true},
+	}
+	rows := [][]string{
+		{createString("overviewExportSummaryLabelTarget"), formatTimesheetHours(data.TargetHours)},
+		{createString("overviewExportSummaryLabelActual"), formatTimesheetHours(data.ActualHours)},
+		{createString("overviewExportSummaryLabelBalance"),
+			formatTimesheetHours(data.GetBalanceHours())},
+	}
+	for _, t := range data.Types {
+		rows = append(rows, []string{t.Type.Description, formatTimesheetHours(t.Hours)})
+	}
+	doc.Table(cols, rows, map[int]bool{0: true, 1: true, 2: true})
+	doc.Space(6)
+}
+
+func (e *TimesheetPdfExporter) writeRounding(doc *pdf.Document, rounding *TimesheetRounding) {
+	e.writeHeading(doc, createString("overviewExportHeadingRounding"))
+	doc.SetFont(false, 9)
+	for _, policy := range rounding.Policies {
+		doc.WriteLine(policy)
+	}
+	if rounding.IsReportScope {
+		doc.WriteLine(createString("overviewRoundingBilledHours") + ": " +
+			formatTimesheetDuration(rounding.BilledDuration))
+	}
+	doc.Space(6)
+}
+
+func (e *TimesheetPdfExporter) writeDays(doc *pdf.Document, data *TimesheetData) {
+	e.writeHeading(doc, createString("overviewExportHeadingDays"))
+	doc.SetFont(false, 8)
+	cols := []*pdf.Column{
+		{Title: createString("tableColDate"), Width: 30},
+		{Title: createString("tableColStart"), Width: 20, AlignRight: true},
+		{Title: createString("tableColEnd"), Width: 20, AlignRight: true},
+		{Title: createString("overviewExportColBreak"), Width: 20, AlignRight: true},
+		{Title: createString("tableColNet"), Width: 20, AlignRight: true},
+		{Title: "", Width: 60},
+	}
+
+	var rows [][]string
+	for _, day := range data.Days {
+		row := []string{formatTimesheetDay(day.Date), "", "", "", "", ""}
+		if day.HasEntries() {
+			row[1] = day.StartTime.Format(timesheetTimeFormat)
+			row[2] = day.EndTime.Format(timesheetTimeFormat)
+			if day.BreakDuration > 0 {
+				row[3] = formatTimesheetDuration(day.BreakDuration)
+			}
+			row[4] = formatTimesheetDuration(day.Duration)
+		}
+		if day.BreakCheck != nil {
+			row[5] = formatTimesheetDuration(day.BreakCheck.MissingBreakDuration) + " " +
+				createString("labelMissingBreak")
+		}
+		rows = append(rows, row)
+	}
+	doc.Table(cols, rows, nil)
+	doc.Space(6)
+}
+
+func (e *TimesheetPdfExporter) writeEntries(doc *pdf.Document, data *TimesheetData) {
+	showBilled := data.Rounding != nil && data.Rounding.IsReportScope
+
+	e.writeHeading(doc, createString("overviewExportHeadingEntries"))
+	doc.SetFont(false, 8)
+	cols := []*pdf.Column{
+		{Title: createString("tableColDate"), Width: 22},
+		{Title: createString("tableColType"), Width: 18},
+		{Title: createString("tableColStart"), Width: 12, AlignRight: true},
+		{Title: createString("tableColEnd"), Width: 12, AlignRight: true},
+		{Title: createString("tableColNet"), Width: 12, AlignRight: true},
+		{Title: createString("tableColActivity"), Width: 25},
+		{Title: createString("tableColProject"), Width: 25},
+		{Title: createString("tableColDescription"), Width: 44},
+	}
+	if showBilled {
+		cols[7].Width -= 14
+		cols = append(cols, &pdf.Column{Title: createString("tableColBilled"), Width: 14,
+			AlignRight: true})
+	}
+
+	var rows [][]string
+	boldRows := make(map[int]bool)
+	for _, day := range data.Days {
+		date := formatTimesheetDay(day.Date)
+		if !day.HasEntries() {
+			rows = append(rows, []string{date, "-", "-", "-", "-"})
+			continue
+		}
+		for _, te := range day.Entries {
+			entry := te.Entry
+			row := []string{date, te.Type, entry.StartTime.Format(timesheetTimeFormat),
+				entry.EndTime.Format(timesheetTimeFormat),
+				formatTimesheetDuration(entry.EndTime.Sub(entry.StartTime)), te.Activity,
+				entry.Project, entry.Description}
+			if showBilled {
+				row = append(row, formatTimesheetDuration(te.BilledDuration))
+			}
+			rows = append(rows, row)
+			date = ""
+		}
+		if len(day.Entries) > 1 || day.BreakCheck != nil {
+			row := []string{"", "", "", "", formatTimesheetDuration(day.Duration), "", "", ""}
+			if day.BreakCheck != nil {
+				row[5] = formatTimesheetDuration(day.BreakCheck.MissingBreakDuration) + " " +
+					createString("labelMissingBreak")
+			}
+			boldRows[len(rows)] = true
+			rows = append(rows, row)
+		}
+	}
+	doc.Table(cols, rows, boldRows)
+	doc.Space(6)
+}
+
+func (e *TimesheetPdfExporter) writeSignatureLines(doc *pdf.Document) {
+	const lineWidth = 70.0
+
+	doc.SetFont(false, 9)
+	doc.EnsureSpace(30)
+	doc.Space(20)
+	y := doc.Y()
+	rightX := doc.Right() - lineWidth
+	doc.Line(doc.Left(), y, doc.Left()+lineWidth, y)
+	doc.Line(rightX, y, doc.Right(), y)
+	y += doc.LineHeight()
+	doc.Text(doc.Left(), y, createString("overviewExportSignatureDate"))
+	doc.Text(rightX, y, createString("overviewExportSignatureDate"))
+	y += doc.LineHeight()
+	doc.Text(doc.Left(), y, createString("overviewExportSignatureEmployee"))
+	doc.Text(rightX, y, createString("overviewExportSignatureSupervisor"))
+	doc.SetY(y)
+}
+
+func (e *TimesheetPdfExporter) writeHeading(doc *pdf.Document, heading string) {
+	doc.SetFont(true, 10)
+	doc.EnsureSpace(4 * doc.LineHeight())
+	doc.WriteLine(heading)
+	doc.Space(1)
+}
+
+// --- Helper functions ---
+
+func getTimesheetMonthName(year int, month int) string {
+	return fmt.Sprintf("%s %d", createString(timesheetMonthKeys[time.Month(month)]), year)
+}
+
+func formatTimesheetDuration(d time.Duration) string {
+	return createString("%.2f", d.Hours())
+}
+
+func formatTimesheetHours(hours float32) string {
+	return createString("%.2f", hours)
+}
+
+func formatTimesheetDay(date time.Time) string {
+	weekday := createString(timesheetWeekdayKeys[date.Weekday()])
+	return weekday[0:2] + ". " + date.Format(timesheetShortDateFormat)
+}
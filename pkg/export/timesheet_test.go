@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+func TestNewTimesheetData(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("could not load location: %s", err)
+	}
+
+	contract := model.NewContract()
+	contract.WorkingHours = []model.ContractWorkingHours{
+		{FirstDay: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), Hours: 8},
+	}
+	policies := &model.RoundingPolicies{ProjectPolicies: map[string]*model.RoundingPolicy{
+		"P": {Mode: model.RoundingModeUp, IntervalMinutes: 15, Scope: model.RoundingScopeReport},
+	}}
+
+	// Two entries on the 1st of February (stored in UTC) with a break of 30 minutes between them
+	entries := []*model.Entry{
+		{Id: 1, UserId: 1, TypeId: model.EntryTypeIdWork, Project: "P",
+			StartTime: time.Date(2024, time.February, 1, 7, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.February, 1, 11, 10, 0, 0, time.UTC)},
+		{Id: 2, UserId: 1, TypeId: model.EntryTypeIdWork,
+			StartTime: time.Date(2024, time.February, 1, 11, 40, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.February, 1, 15, 40, 0, 0, time.UTC)},
+	}
+
+	data := NewTimesheetData("User", contract, 2024, 2, entries, loc,
+		newExportTestEntryTypesMap(), nil, policies)
+
+	// February 2024 has 29 days and 21 working days
+	if len(data.Days) != 29 {
+		t.Fatalf("got %d days, want 29", len(data.Days))
+	}
+	if data.TargetHours != 168 {
+		t.Errorf("target hours = %.2f, want 168", data.TargetHours)
+	}
+	if data.ActualHours != 8+1.0/6 {
+		t.Errorf("actual hours = %.2f, want 8.17", data.ActualHours)
+	}
+
+	// Times are in the location of the user
+	day := data.Days[0]
+	if len(day.Entries) != 2 {
+		t.Fatalf("got %d entries on the first day, want 2", len(day.Entries))
+	}
+	if day.StartTime.Hour() != 8 || day.EndTime.Hour() != 16 {
+		t.Errorf("day = %s - %s, want 08:00 - 16:40", day.StartTime, day.EndTime)
+	}
+	if day.BreakDuration != 30*time.Minute {
+		t.Errorf("break = %s, want 30m", day.BreakDuration)
+	}
+	if data.Days[1].HasEntries() {
+		t.Errorf("second day has %d entries, want none", len(data.Days[1].Entries))
+	}
+
+	// Only the entry of the project is rounded in reports
+	if day.Entries[0].BilledDuration != 4*time.Hour+15*time.Minute {
+		t.Errorf("billed duration = %s, want 4h15m", day.Entries[0].BilledDuration)
+	}
+	if data.Rounding == nil || !data.Rounding.IsReportScope ||
+		data.Rounding.BilledDuration != 8*time.Hour+15*time.Minute {
+		t.Errorf("rounding = %+v, want report scope with 8h15m", data.Rounding)
+	}
+
+	// The PDF timesheet can be written
+	var buf bytes.Buffer
+	if _, err := NewTimesheetPdfExporter("", "", true).ExportTimesheet(data).WriteTo(
+		&buf); err != nil {
+		t.Fatalf("could not write timesheet: %s", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Errorf("timesheet is not a PDF file")
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"archive/zip"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"kellnhofer.com/work-log/pkg/loc"
+)
+
+// Number format of the start and end time cells.
+const xlsxTimeNumFmt = "yyyy-mm-dd hh:mm"
+
+type xlsxWriterToAdapter struct {
+	data *EntriesData
+}
+
+func (xta *xlsxWriterToAdapter) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	f := excelize.NewFile()
+	// Remove temporary files of the stream writer
+	defer f.Close()
+
+	if err := xta.writeFile(f); err != nil {
+		return 0, err
+	}
+
+	// excelize writes the ZIP archive to an in-memory buffer, which is then copied to the writer.
+	// To avoid holding the whole file in memory, the archive is written directly to the writer.
+	f.SetZipWriter(func(io.Writer) excelize.ZipWriter {
+		return zip.NewWriter(cw)
+	})
+	_, err = f.WriteTo(cw)
+	return cw.n, err
+}
+
+func (xta *xlsxWriterToAdapter) writeFile(f *excelize.File) error {
+	data := xta.data
+	sheet := createString("exportSheetName")
+	f.SetSheetName("Sheet1", sheet)
+
+	// Configure document properties
+	now := time.Now()
+	f.SetDocProps(&excelize.DocProperties{
+		Created:        now.Format(time.RFC3339),
+		Creator:        createString("appName"),
+		Modified:       now.Format(time.RFC3339),
+		LastModifiedBy: createString("appName"),
+		Description:    createString("exportPropDescription", createString("appName")),
+		Language:       loc.LngTag.String(),
+	})
+
+	// Create styles
+	timeNumFmt := xlsxTimeNumFmt
+	styleTitle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 14, Bold: true}})
+	styleBold, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 10, Bold: true}})
+	styleHeader, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Size: 10, Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"EFEFEF"}},
+	})
+	styleTime, _ := f.NewStyle(&excelize.Style{CustomNumFmt: &timeNumFmt,
+		Font: &excelize.Font{Size: 10}})
+
+	// Create stream writer (rows are written to a temporary file if they exceed the chunk size)
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	// Configure work sheet
+	for i, col := range columns {
+		if err := sw.SetColWidth(i+1, i+1, col.width); err != nil {
+			return err
+		}
+	}
+
+	// Write title and details
+	row := 1
+	if data.Title != "" {
+		title := []any{excelize.Cell{StyleID: styleTitle, Value: data.Title}}
+		if err := sw.SetRow(getCellName("A", row), title); err != nil {
+			return err
+		}
+		row++
+		if data.Details != "" {
+			details := []any{excelize.Cell{StyleID: styleBold, Value: data.Details}}
+			if err := sw.SetRow(getCellName("A", row), details); err != nil {
+				return err
+			}
+			row++
+		}
+		row++
+	}
+
+	// Write header
+	var header []any
+	for _, title := range getColumnTitles() {
+		header = append(header, excelize.Cell{StyleID: styleHeader, Value: title})
+	}
+	if err := sw.SetRow(getCellName("A", row), header); err != nil {
+		return err
+	}
+	row++
+
+	// Write records
+	err = data.ForEachRecord(func(record *EntryRecord) error {
+		values := []any{
+			excelize.Cell{StyleID: styleTime, Value: record.StartTime},
+			excelize.Cell{StyleID: styleTime, Value: record.EndTime},
+			record.Type,
+			record.Activity,
+			record.Project,
+			record.Description,
+			strings.Join(record.Labels, " "),
+			record.Duration,
+			record.BilledDuration,
+			record.Rounding,
+		}
+		if err := sw.SetRow(getCellName("A", row), values); err != nil {
+			return err
+		}
+		row++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return sw.Flush()
+}
+
+// XlsxExporter exports entries to an Excel file.
+type XlsxExporter struct {
+}
+
+// NewXlsxExporter creates a new Excel exporter.
+func NewXlsxExporter() *XlsxExporter {
+	return &XlsxExporter{}
+}
+
+// Format returns the name of the format.
+func (e *XlsxExporter) Format() string {
+	return "xlsx"
+}
+
+// ContentType returns the media type of the exported file.
+func (e *XlsxExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// Export creates the Excel file for the supplied data and returns it as an io.WriterTo that can be
+// used to write the file to a writer.
+func (e *XlsxExporter) Export(data *EntriesData) io.WriterTo {
+	return &xlsxWriterToAdapter{
+		data: data,
+	}
+}
+
+// --- Helper functions ---
+
+func getCellName(col string, row int) string {
+	return col + strconv.Itoa(row)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
@@ -28,8 +30,30 @@ type Message struct {
 // LngTag holds the language tag of the configured localization.
 var LngTag language.Tag
 
-// LoadLocalization loads a localization.
+// lngTags holds the language tags of all loaded localizations by language (e.g. "de").
+var lngTags = make(map[string]language.Tag)
+
+// LoadLocalization loads the localization of the configured language. The localizations of the
+// other languages are loaded as well, so strings can be created for a specific language (e.g. the
+// header of an export).
 func LoadLocalization(lang string) {
+	LngTag = loadLocalizationFile(lang)
+
+	// Load other localizations
+	fileNames, gErr := filepath.Glob("resources/localizations/localization-*.xml")
+	if gErr != nil {
+		log.Fatalf("Could not list localization files: %s", gErr)
+	}
+	for _, fileName := range fileNames {
+		l := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(fileName), "localization-"),
+			".xml")
+		if _, ok := lngTags[l]; !ok {
+			loadLocalizationFile(l)
+		}
+	}
+}
+
+func loadLocalizationFile(lang string) language.Tag {
 	fileName := fmt.Sprintf("localization-%s.xml", lang)
 
 	// Open file
@@ -53,8 +77,7 @@ func LoadLocalization(lang string) {
 	}
 
 	// Parse language tag
-	var pltErr error
-	LngTag, pltErr = language.Parse(loc.Language)
+	tag, pltErr := language.Parse(loc.Language)
 	if pltErr != nil {
 		log.Fatalf("Invalid language tag '%s': %s", loc.Language, pltErr)
 	}
@@ -64,11 +87,20 @@ func LoadLocalization(lang string) {
 		if m.Key == "" {
 			log.Fatalf("Message missing key!")
 		}
-		rmErr := message.SetString(LngTag, m.Key, m.Text)
+		rmErr := message.SetString(tag, m.Key, m.Text)
 		if rmErr != nil {
 			log.Fatalf("Invalid message for key '%s': %s", m.Key, rmErr)
 		}
 	}
+
+	lngTags[lang] = tag
+	return tag
+}
+
+// IsLanguageAvailable checks if a localization is available for a language (e.g. "de").
+func IsLanguageAvailable(lang string) bool {
+	_, ok := lngTags[lang]
+	return ok
 }
 
 // CreateString creates a localized string.
@@ -77,6 +109,17 @@ func CreateString(key string, args ...any) string {
 	return printer.Sprintf(key, args...)
 }
 
+// CreateLanguageString creates a string localized in a specific language (e.g. "de"). If the
+// language is empty or not available, the configured language is used.
+func CreateLanguageString(lang string, key string, args ...any) string {
+	tag, ok := lngTags[lang]
+	if !ok {
+		tag = LngTag
+	}
+	printer := message.NewPrinter(tag)
+	return printer.Sprintf(key, args...)
+}
+
 var errorMessageKeys = map[int]string{
 	// Authentication errors
 	e.AuthUnknown:            "errAuthUnknown",
@@ -112,19 +155,38 @@ var errorMessageKeys = map[int]string{
 	e.ValQueryInvalid:         "errValQueryInvalid",
 	e.ValQueryEmpty:           "errValQueryEmpty",
 	e.ValMonthInvalid:         "errValMonthInvalid",
+	e.ValYearInvalid:          "errValYearInvalid",
 	e.ValPasswordEmpty:        "errValPasswordEmpty",
 	e.ValPasswordTooShort:     "errValPasswordTooShort",
 	e.ValPasswordTooLong:      "errValPasswordTooLong",
 	e.ValPasswordInvalid:      "errValPasswordInvalid",
 	e.ValPasswordsNotMatching: "errValPasswordsNotMatching",
+	e.ValVersionInvalid:       "errValVersionInvalid",
+	e.ValDayFractionInvalid:   "errValDayFractionInvalid",
+	e.ValFileMissing:          "errValFileMissing",
+	e.ValExportFormatInvalid:  "errValExportFormatInvalid",
+	e.ValGroupingInvalid:      "errValGroupingInvalid",
+	e.ValIntervalInvalid:      "errValIntervalInvalid",
+	e.ValPeriodTooLong:        "errValPeriodTooLong",
 
 	// Logic errors
 	e.LogicUnknown:                  "errLogicUnknown",
 	e.LogicEntryNotFound:            "errLogicEntryNotFound",
 	e.LogicEntryTypeNotFound:        "errLogicEntryTypeNotFound",
 	e.LogicEntryActivityNotFound:    "errLogicEntryActivityNotFound",
+	e.LogicEntryActivityNotAllowed:  "errLogicEntryActivityNotAllowed",
 	e.LogicEntryTimeIntervalInvalid: "errLogicEntryTimeIntervalInvalid",
 	e.LogicEntryDateIntervalInvalid: "errLogicEntryDateIntervalInvalid",
+	e.LogicEntryVersionConflict:     "errLogicEntryVersionConflict",
+	e.LogicAbsenceNotFound:          "errLogicAbsenceNotFound",
+	e.LogicAbsenceTypeInvalid:       "errLogicAbsenceTypeInvalid",
+	e.LogicAbsenceWithoutWorkDays:   "errLogicAbsenceWithoutWorkDays",
+	e.LogicYearClosed:               "errLogicYearClosed",
+	e.LogicAttachmentNotFound:       "errLogicAttachmentNotFound",
+	e.LogicAttachmentTooLarge:       "errLogicAttachmentTooLarge",
+	e.LogicAttachmentTypeNotAllowed: "errLogicAttachmentTypeNotAllowed",
+	e.LogicEntryInvoiced:            "errLogicEntryInvoiced",
+	e.LogicEntryOfAbsence:           "errLogicEntryOfAbsence",
 
 	// System errors
 	e.SysUnknown:             "errSysUnknown",
@@ -135,6 +197,7 @@ var errorMessageKeys = map[int]string{
 	e.SysDbInsertFailed:      "errSysDbInsertFailed",
 	e.SysDbUpdateFailed:      "errSysDbUpdateFailed",
 	e.SysDbDeleteFailed:      "errSysDbDeleteFailed",
+	e.SysStorageFailed:       "errSysStorageFailed",
 }
 
 // GetErrorMessageString returns a localized error message string.
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/config"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+)
+
+// Mailer sends emails via the configured SMTP server.
+type Mailer struct {
+	enabled  bool
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// NewMailer creates a new mailer for the supplied configuration.
+func NewMailer(conf *config.Config) *Mailer {
+	return &Mailer{
+		enabled:  conf.MailEnabled,
+		addr:     conf.MailHost + ":" + strconv.Itoa(conf.MailPort),
+		host:     conf.MailHost,
+		username: conf.MailUsername,
+		password: conf.MailPassword,
+		from:     conf.MailFrom,
+	}
+}
+
+// IsEnabled returns true if sending emails is enabled.
+func (m *Mailer) IsEnabled() bool {
+	return m.enabled
+}
+
+// SendMail sends a plain text email to the supplied recipient.
+func (m *Mailer) SendMail(to string, subject string, body string) error {
+	if !m.enabled {
+		log.Debugf("Sending emails is disabled. Skipping email to '%s'.", to)
+		return nil
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	msg := m.createMessage(to, subject, body)
+
+	sErr := smtp.SendMail(m.addr, auth, m.from, []string{to}, msg)
+	if sErr != nil {
+		err := e.WrapError(e.SysMailFailed, fmt.Sprintf("Could not send email to '%s'.", to), sErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (m *Mailer) createMessage(to string, subject string, body string) []byte {
+	var msg strings.Builder
+	msg.WriteString("From: " + m.from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(msg.String())
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Absence day fractions.
+const (
+	AbsenceDayFractionFull = "full"
+	AbsenceDayFractionHalf = "half"
+)
+
+// AbsenceDayFractions holds a list of all absence day fractions.
+var AbsenceDayFractions = []string{
+	AbsenceDayFractionFull,
+	AbsenceDayFractionHalf,
+}
+
+const (
+	// AbsenceMaxDays is the maximum number of days an absence can span.
+	AbsenceMaxDays = 366
+	// AbsenceEntryStartHour is the hour at which the entries of an absence start.
+	AbsenceEntryStartHour = 8
+)
+
+// Absence stores information about a multi-day absence (e.g. vacation or illness). An absence is
+// expanded into one entry per working day.
+type Absence struct {
+	Id          int       // ID of the absence
+	UserId      int       // ID of the user
+	TypeId      int       // ID of the entry type
+	StartDate   time.Time // First day of the absence
+	EndDate     time.Time // Last day of the absence
+	DayFraction string    // Fraction of the daily working hours per day
+	Description string    // Description for the absence
+	EntryIds    []int     // IDs of the entries of the absence
+}
+
+// NewAbsence creates a new Absence model.
+func NewAbsence() *Absence {
+	return &Absence{DayFraction: AbsenceDayFractionFull, EntryIds: []int{}}
+}
+
+// GetDayFactor returns the factor of the daily working hours which is recorded per day.
+func (a *Absence) GetDayFactor() float32 {
+	if a.DayFraction == AbsenceDayFractionHalf {
+		return 0.5
+	}
+	return 1.0
+}
+
+// IsValidAbsenceDayFraction returns true if the supplied day fraction is valid.
+func IsValidAbsenceDayFraction(dayFraction string) bool {
+	for _, df := range AbsenceDayFractions {
+		if df == dayFraction {
+			return true
+		}
+	}
+	return false
+}
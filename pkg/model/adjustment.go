@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// Balance adjustment types.
+const (
+	BalanceAdjustmentTypeOvertime = "overtime"
+	BalanceAdjustmentTypeVacation = "vacation"
+)
+
+// BalanceAdjustmentTypes holds a list of all balance adjustment types.
+var BalanceAdjustmentTypes = []string{
+	BalanceAdjustmentTypeOvertime,
+	BalanceAdjustmentTypeVacation,
+}
+
+// BalanceAdjustment stores information about a manual change of the overtime or vacation balance
+// of a user (e.g. an overtime payout or a correction).
+type BalanceAdjustment struct {
+	Id              int       // ID of the adjustment
+	UserId          int       // ID of the user whose balance is adjusted
+	Type            string    // Type of the adjustment
+	Date            time.Time // Date from which the adjustment is effective
+	Value           float32   // Hours (overtime) or days (vacation) to add (negative to subtract)
+	Reason          string    // Reason for the adjustment
+	CreatedByUserId int       // ID of the user who created the adjustment (0 if deleted)
+	CreatedAt       time.Time // Time of creation
+}
+
+// NewBalanceAdjustment creates a new BalanceAdjustment model.
+func NewBalanceAdjustment() *BalanceAdjustment {
+	return &BalanceAdjustment{}
+}
+
+// IsEffective returns true if the adjustment is effective at the supplied time. (The day of the time
+// is determined in its location.)
+func (a *BalanceAdjustment) IsEffective(t time.Time) bool {
+	return !a.Date.After(util.ToCivilDate(t))
+}
+
+// IsValidBalanceAdjustmentType returns true if the supplied type is valid.
+func IsValidBalanceAdjustmentType(t string) bool {
+	for _, at := range BalanceAdjustmentTypes {
+		if at == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Attachment stores information about a file which is attached to an entry (e.g. a doctor's note
+// or a receipt). The content of the file is kept in a storage under the storage key.
+type Attachment struct {
+	Id         int       // ID of the attachment
+	EntryId    int       // ID of the entry the attachment belongs to
+	FileName   string    // Original name of the file
+	MimeType   string    // MIME type of the file
+	Size       int64     // Size of the file in bytes
+	StorageKey string    // Key of the file in the storage
+	CreatedAt  time.Time // Time of creation
+}
+
+// NewAttachment creates a new Attachment model.
+func NewAttachment() *Attachment {
+	return &Attachment{}
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// YearClosing stores the closing balances of a user at the end of a year. Balance calculations
+// start from the latest closed year, and changes inside closed years are rejected.
+type YearClosing struct {
+	UserId         int                 // ID of the user
+	Year           int                 // Closed year
+	OvertimeHours  float32             // Overtime balance at the end of the year
+	Vacation       *VacationLedgerYear // Vacation account of the year
+	ClosedByUserId int                 // ID of the user who closed the year (0 if deleted)
+	ClosedAt       time.Time           // Time of the closing
+}
+
+// NewYearClosing creates a new YearClosing model.
+func NewYearClosing() *YearClosing {
+	return &YearClosing{
+		Vacation: NewVacationLedgerYear(),
+	}
+}
+
+// GetEndDate returns the start of the first day after the closed year in the supplied location.
+func (c *YearClosing) GetEndDate(loc *time.Location) time.Time {
+	return time.Date(c.Year+1, time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// IsClosed returns true if the supplied date lies inside the closed year or before. The year is
+// determined in the location of the date, so times must be in the time zone of the user.
+func (c *YearClosing) IsClosed(date time.Time) bool {
+	return date.Year() <= c.Year
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// ComplianceRule specifies a working time compliance rule.
+type ComplianceRule string
+
+// Compliance rule constants.
+const (
+	ComplianceRuleMaxDailyHours  ComplianceRule = "max_daily_hours"
+	ComplianceRuleMaxWeeklyHours ComplianceRule = "max_weekly_hours"
+	ComplianceRuleMinRestPeriod  ComplianceRule = "min_rest_period"
+	ComplianceRuleSundayWork     ComplianceRule = "sunday_work"
+	ComplianceRuleNightWork      ComplianceRule = "night_work"
+)
+
+// ComplianceSettings stores the limits of the working time compliance rules. A limit of zero
+// disables the corresponding rule.
+type ComplianceSettings struct {
+	MaxDailyHours   int  // Maximum work hours per day
+	MaxWeeklyHours  int  // Maximum work hours per week
+	MinRestHours    int  // Minimum rest hours between two work days
+	CheckSundayWork bool // Determines if work on Sundays is reported
+	NightStartHour  int  // Start hour of the night period
+	NightEndHour    int  // End hour of the night period
+}
+
+// ComplianceViolation stores information about a violation of a compliance rule.
+type ComplianceViolation struct {
+	UserId int            // ID of the user
+	Rule   ComplianceRule // Violated rule
+	Date   time.Time      // Day of the violation
+	Actual time.Duration  // Actual duration
+	Limit  time.Duration  // Limit of the rule
+}
+
+// UserComplianceViolations stores the compliance violations of a user.
+type UserComplianceViolations struct {
+	User       *User                  // User
+	Violations []*ComplianceViolation // Violations of the user
+}
+
+// ComplianceReport stores the compliance violations of all users in a period.
+type ComplianceReport struct {
+	StartDate time.Time                   // First day of the period
+	EndDate   time.Time                   // Last day of the period
+	Users     []*UserComplianceViolations // Users with violations
+}
@@ -14,16 +14,94 @@ type ContractVacationDays struct {
 	Days     float32   // Number of days
 }
 
+// ContractBreakRule stores information about a statutory break rule of a work contract. The break
+// is required if the daily work time exceeds the work hours.
+type ContractBreakRule struct {
+	WorkHours    float32 // Number of work hours after which the break is required
+	BreakMinutes int     // Number of required break minutes
+}
+
+// ContractVacationRules stores information about the carry-over and expiry of unused vacation days
+// of a work contract.
+type ContractVacationRules struct {
+	CarryOverMaxDays float32 // Maximum number of unused days carried over to the next year
+	ExpiryMonths     int     // Number of months after which carried over days expire (0 if never)
+}
+
 // Contract stores information about the work contract of a user.
 type Contract struct {
-	FirstDay          time.Time              // First day
-	InitOvertimeHours float32                // Initial overtime hours
-	InitVacationDays  float32                // Initial vacation days
-	WorkingHours      []ContractWorkingHours // Daily working hours
-	VacationDays      []ContractVacationDays // Monthly vacation days
+	FirstDay            time.Time              // First day
+	InitOvertimeHours   float32                // Initial overtime hours
+	InitVacationDays    float32                // Initial vacation days
+	WorkingHours        []ContractWorkingHours // Daily working hours
+	VacationDays        []ContractVacationDays // Monthly vacation days
+	BreakRules          []ContractBreakRule    // Break rules
+	DeductMissingBreaks bool                   // Determines if missing breaks are deducted
+	VacationRules       *ContractVacationRules // Vacation rules (nil if unused days never expire)
+	RoundingPolicy      *RoundingPolicy        // Rounding policy (nil if durations are not rounded)
 }
 
 // NewContract creates a new Contract model.
 func NewContract() *Contract {
 	return &Contract{}
 }
+
+// GetRequiredBreakDuration returns the break duration required by the break rules for the supplied
+// work duration.
+func (c *Contract) GetRequiredBreakDuration(workDuration time.Duration) time.Duration {
+	var required time.Duration
+	for _, br := range c.BreakRules {
+		if workDuration > br.getWorkDuration() && br.getBreakDuration() > required {
+			required = br.getBreakDuration()
+		}
+	}
+	return required
+}
+
+// CheckBreaks checks the breaks of a work day against the break rules.
+func (c *Contract) CheckBreaks(workDay *WorkDay) *BreakCheck {
+	check := &BreakCheck{
+		RequiredBreakDuration: c.GetRequiredBreakDuration(workDay.WorkDuration),
+	}
+
+	// If enough break was taken: Abort
+	missing := check.RequiredBreakDuration - workDay.BreakDuration
+	if missing <= 0 {
+		return check
+	}
+	check.MissingBreakDuration = missing
+
+	// Calculate deduction (the deducted time is considered as break, so only as much time is
+	// deducted as is needed to fulfill the rules for the reduced work duration)
+	deduction := missing
+	for _, br := range c.BreakRules {
+		d := workDay.WorkDuration - br.getWorkDuration()
+		if d < 0 || d >= deduction {
+			continue
+		}
+		if workDay.BreakDuration+d >= c.GetRequiredBreakDuration(br.getWorkDuration()) {
+			deduction = d
+		}
+	}
+	check.DeductionDuration = deduction
+
+	return check
+}
+
+// GetCarryOverExpiryDate returns the date from which on the days carried over into the supplied year
+// are expired (e.g. April 1 if they can be taken until the end of March) in the supplied location.
+// If the days don't expire, the zero time is returned.
+func (vr *ContractVacationRules) GetCarryOverExpiryDate(year int, loc *time.Location) time.Time {
+	if vr.ExpiryMonths <= 0 {
+		return time.Time{}
+	}
+	return time.Date(year, time.Month(vr.ExpiryMonths)+1, 1, 0, 0, 0, 0, loc)
+}
+
+func (br *ContractBreakRule) getWorkDuration() time.Duration {
+	return time.Duration(int(br.WorkHours*60.0)) * time.Minute
+}
+
+func (br *ContractBreakRule) getBreakDuration() time.Duration {
+	return time.Duration(br.BreakMinutes) * time.Minute
+}
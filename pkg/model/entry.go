@@ -13,9 +13,20 @@ type Entry struct {
 	Project     string    // Related project name of the entry
 	Description string    // Description for the entry
 	Labels      []string  // Labels for the entry
+	Billable    bool      // Whether the entry is billed to a client
+	AbsenceId   int       // ID of the absence the entry belongs to (0 if none)
+	InvoiceId   int       // ID of the invoice the entry was billed with (0 if not invoiced)
+	CreatedAt   time.Time // Time the entry was created
+	UpdatedAt   time.Time // Time the entry was last changed
+	Version     int       // Version of the entry (incremented on every change)
 }
 
 // NewEntry create a new Entry model.
 func NewEntry() *Entry {
 	return &Entry{}
 }
+
+// IsInvoiced returns true if the entry was already billed with an invoice.
+func (e *Entry) IsInvoiced() bool {
+	return e.InvoiceId != 0
+}
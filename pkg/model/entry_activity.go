@@ -1,12 +1,23 @@
 package model
 
+import "slices"
+
 // EntryActivity specifies the activity of a entry.
 type EntryActivity struct {
 	Id          int    // ID of the entry activity
 	Description string // Description of the entry activity
+	Archived    bool   // Archived activities can not be used for new entries
+	TypeIds     []int  // IDs of the entry types the activity is allowed for
 }
 
 // NewEntryActivity creates a new EntryActivity model.
 func NewEntryActivity() *EntryActivity {
-	return &EntryActivity{}
+	return &EntryActivity{
+		TypeIds: []int{},
+	}
+}
+
+// IsAllowedForType returns true if the activity is allowed for the supplied entry type.
+func (a *EntryActivity) IsAllowedForType(typeId int) bool {
+	return slices.Contains(a.TypeIds, typeId)
 }
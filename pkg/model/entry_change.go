@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// EntryChange stores information about a created, updated or deleted entry.
+type EntryChange struct {
+	Entry   *Entry // Entry (for deleted entries only ID, user ID and change times are meaningful)
+	Deleted bool   // Determines if the entry was deleted
+}
+
+// EntryChangeCursor marks the position of the last change a client has received.
+type EntryChangeCursor struct {
+	UpdatedAt time.Time // Change time of the last received entry
+	Id        int       // ID of the last received entry
+}
+
+// NewEntryChangeCursor creates a new EntryChangeCursor model.
+func NewEntryChangeCursor(updatedAt time.Time, id int) *EntryChangeCursor {
+	return &EntryChangeCursor{updatedAt, id}
+}
+
+// EntryChanges stores a page of entry changes.
+type EntryChanges struct {
+	Changes []*EntryChange     // Changes ordered by change time
+	Cursor  *EntryChangeCursor // Cursor to continue with (nil if there are no changes at all)
+	HasMore bool               // Determines if there are more changes available
+}
@@ -74,3 +74,84 @@ type TextEntryFilter struct {
 func NewTextEntryFilter() *TextEntryFilter {
 	return &TextEntryFilter{}
 }
+
+// ExpressionEntryFilter stores an expression to filter entries.
+type ExpressionEntryFilter struct {
+	baseEntryFilter
+	Expression EntryFilterExpression // Filter expression (nil if entries are not restricted)
+	Location   *time.Location        // Location weekdays are determined in (nil for server zone)
+}
+
+// NewExpressionEntryFilter create a new ExpressionEntryFilter model.
+func NewExpressionEntryFilter() *ExpressionEntryFilter {
+	return &ExpressionEntryFilter{}
+}
+
+// EntryFilterField defines a field entries can be filtered by.
+type EntryFilterField int
+
+// Entry filter field constants.
+const (
+	EntryFilterFieldType EntryFilterField = iota + 1
+	EntryFilterFieldStartTime
+	EntryFilterFieldEndTime
+	EntryFilterFieldDuration
+	EntryFilterFieldWeekday
+	EntryFilterFieldActivity
+	EntryFilterFieldProject
+	EntryFilterFieldDescription
+	EntryFilterFieldLabels
+)
+
+// EntryFilterOperator defines how a entry field is compared to the filter values.
+type EntryFilterOperator int
+
+// Entry filter operator constants.
+const (
+	EntryFilterOpIsNull EntryFilterOperator = iota + 1
+	EntryFilterOpEqual
+	EntryFilterOpContains
+	EntryFilterOpBetween
+	EntryFilterOpIn
+	EntryFilterOpGreater
+	EntryFilterOpLess
+)
+
+// EntryFilterExpression is an interface for entry filter expressions.
+type EntryFilterExpression interface {
+	isEntryFilterExpression()
+}
+
+// AndEntryFilterExpression combines expressions via logical conjunction.
+type AndEntryFilterExpression struct {
+	Expressions []EntryFilterExpression // Combined expressions
+}
+
+func (*AndEntryFilterExpression) isEntryFilterExpression() {}
+
+// OrEntryFilterExpression combines expressions via logical disjunction.
+type OrEntryFilterExpression struct {
+	Expressions []EntryFilterExpression // Combined expressions
+}
+
+func (*OrEntryFilterExpression) isEntryFilterExpression() {}
+
+// NotEntryFilterExpression negates an expression.
+type NotEntryFilterExpression struct {
+	Expression EntryFilterExpression // Negated expression
+}
+
+func (*NotEntryFilterExpression) isEntryFilterExpression() {}
+
+// ConditionEntryFilterExpression stores a condition for a single entry field.
+//
+// The type of the values depends on the field: int for type and activity IDs, time.Time for
+// start and end times, time.Duration for durations, time.Weekday for weekdays and string for
+// project names, descriptions and labels.
+type ConditionEntryFilterExpression struct {
+	Field    EntryFilterField    // Filtered field
+	Operator EntryFilterOperator // Comparison operator
+	Values   []any               // Compared values (empty for "is null" conditions)
+}
+
+func (*ConditionEntryFilterExpression) isEntryFilterExpression() {}
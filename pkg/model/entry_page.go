@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// EntryCursor marks the position of the last entry of a page in the time sorted entry list.
+type EntryCursor struct {
+	StartTime time.Time // Start time of the last received entry
+	EndTime   time.Time // End time of the last received entry
+	Id        int       // ID of the last received entry
+}
+
+// NewEntryCursor creates a new EntryCursor model.
+func NewEntryCursor(startTime time.Time, endTime time.Time, id int) *EntryCursor {
+	return &EntryCursor{startTime, endTime, id}
+}
+
+// EntryPage stores a page of entries.
+type EntryPage struct {
+	Entries []*Entry     // Entries of the page
+	Total   int          // Total count of entries matching the filter
+	Cursor  *EntryCursor // Cursor to request the next page with (nil if there are no more entries)
+}
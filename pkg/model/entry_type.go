@@ -1,20 +1,63 @@
 package model
 
+// IDs of the built-in entry types. The built-in types can be changed but not deleted, because
+// they are used as defaults (e.g. for new entries or vacation requests).
 const (
 	EntryTypeIdWork     int = 1
-	EntryTypeIdTravel   int = 2
 	EntryTypeIdVacation int = 3
-	EntryTypeIdHoliday  int = 4
-	EntryTypeIdIllness  int = 5
 )
 
-// EntryType specifies the type of a entry.
+// Names of the built-in entry types. Types with a special meaning (e.g. holidays) are identified
+// by their name.
+const (
+	EntryTypeNameWork     = "work"
+	EntryTypeNameTravel   = "travel"
+	EntryTypeNameVacation = "vacation"
+	EntryTypeNameHoliday  = "holiday"
+	EntryTypeNameIllness  = "illness"
+)
+
+// EntryType specifies the type of a entry. The flags of the type control how entries of the type
+// are considered in summaries, balances and exports.
 type EntryType struct {
-	Id          int    // ID of the entry type
-	Description string // Description of the entry type
+	Id               int    // ID of the entry type
+	Name             string // Name of a built-in entry type (empty for user-defined types)
+	Description      string // Description of the entry type
+	CountsAsWork     bool   // Entries are counted as work time (break rules, compliance checks)
+	ReducesTarget    bool   // Entries are credited against the target hours (e.g. absences)
+	ConsumesVacation bool   // Entries consume vacation days
+	AllowsActivities bool   // Entries can have an activity
+	AllowsExpenses   bool   // Entries can have expenses (e.g. mileage or per diem of travels)
+	Color            string // Color of the entry type (e.g. "#0c63e4")
 }
 
 // NewEntryType creates a new EntryType model.
-func NewEntryType(id int, description string) *EntryType {
-	return &EntryType{id, description}
+func NewEntryType() *EntryType {
+	return &EntryType{}
+}
+
+// IsBuiltIn returns true if the entry type is a built-in type.
+func (t *EntryType) IsBuiltIn() bool {
+	return t.Name != ""
+}
+
+// IsIllness returns true if the entry type is the built-in illness type.
+func (t *EntryType) IsIllness() bool {
+	return t.Name == EntryTypeNameIllness
+}
+
+// IsCredited returns true if entries of the type are counted for the overtime balance.
+func (t *EntryType) IsCredited() bool {
+	return t.CountsAsWork || t.ReducesTarget
+}
+
+// IsAbsenceType returns true if entries of the type can be created as absence.
+func (t *EntryType) IsAbsenceType() bool {
+	return t.ReducesTarget && !t.CountsAsWork
+}
+
+// IsWorkEntryType returns true if entries of the supplied type are counted as work time.
+func IsWorkEntryType(entryTypesMap map[int]*EntryType, typeId int) bool {
+	t, ok := entryTypesMap[typeId]
+	return ok && t.CountsAsWork
 }
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Expense types.
+const (
+	ExpenseTypeMileage = "mileage"
+	ExpenseTypePerDiem = "per_diem"
+	ExpenseTypeReceipt = "receipt"
+)
+
+// ExpenseTypes holds a list of all expense types.
+var ExpenseTypes = []string{
+	ExpenseTypeMileage,
+	ExpenseTypePerDiem,
+	ExpenseTypeReceipt,
+}
+
+// ExpenseRateTypes holds a list of all expense types which are calculated with a rate.
+var ExpenseRateTypes = []string{
+	ExpenseTypeMileage,
+	ExpenseTypePerDiem,
+}
+
+// ExpenseRate stores information about a rate which is used to calculate mileage or per diem
+// expenses.
+type ExpenseRate struct {
+	Id       int     // ID of the rate
+	Type     string  // Type of the expenses the rate is used for (mileage or per diem)
+	Name     string  // Name of the rate (e.g. vehicle type "Car" or "Full day")
+	Amount   float64 // Amount per kilometer (mileage) or per day (per diem)
+	Currency string  // Currency of the amount (ISO 4217 code)
+}
+
+// NewExpenseRate creates a new ExpenseRate model.
+func NewExpenseRate() *ExpenseRate {
+	return &ExpenseRate{}
+}
+
+// Expense stores information about an expense of an entry (e.g. of a travel).
+type Expense struct {
+	Id          int     // ID of the expense
+	EntryId     int     // ID of the entry the expense belongs to
+	Type        string  // Type of the expense
+	RateId      int     // ID of the rate (0 for receipts)
+	Quantity    float64 // Distance in kilometers (mileage) or days (per diem), 0 for receipts
+	Amount      float64 // Amount of the expense (calculated for mileage and per diem)
+	Currency    string  // Currency of the amount (ISO 4217 code)
+	Description string  // Description of the expense
+}
+
+// NewExpense creates a new Expense model.
+func NewExpense() *Expense {
+	return &Expense{}
+}
+
+// IsRateBased returns true if the amount of the expense is calculated with a rate.
+func (x *Expense) IsRateBased() bool {
+	return IsExpenseRateType(x.Type)
+}
+
+// ApplyRate calculates amount and currency of the expense with the supplied rate.
+func (x *Expense) ApplyRate(rate *ExpenseRate) {
+	x.RateId = rate.Id
+	x.Amount = RoundAmount(x.Quantity * rate.Amount)
+	x.Currency = rate.Currency
+}
+
+// ExpenseReport stores the expenses of a user in a specific period.
+type ExpenseReport struct {
+	UserId    int                  // ID of the user
+	StartDate time.Time            // First day of the period
+	EndDate   time.Time            // Last day of the period
+	Items     []*ExpenseReportItem // Expenses (ordered by date)
+	Totals    []*ExpenseTotal      // Total amounts per currency (ordered by currency)
+}
+
+// ExpenseReportItem stores an expense of an expense report.
+type ExpenseReportItem struct {
+	Date    time.Time    // Start time of the entry the expense belongs to
+	Entry   *Entry       // Entry the expense belongs to
+	Expense *Expense     // Expense
+	Rate    *ExpenseRate // Rate of the expense (nil for receipts)
+}
+
+// ExpenseTotal stores the total amount of expenses in a currency.
+type ExpenseTotal struct {
+	Currency string  // Currency (ISO 4217 code)
+	Amount   float64 // Total amount
+}
+
+// IsValidExpenseType returns true if the supplied type is valid.
+func IsValidExpenseType(t string) bool {
+	for _, et := range ExpenseTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpenseRateType returns true if expenses of the supplied type are calculated with a rate.
+func IsExpenseRateType(t string) bool {
+	for _, rt := range ExpenseRateTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundAmount rounds an amount of money to cents.
+func RoundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
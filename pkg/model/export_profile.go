@@ -0,0 +1,170 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export column constants.
+const (
+	ExportColumnStartTime      = "start_time"
+	ExportColumnEndTime        = "end_time"
+	ExportColumnType           = "type"
+	ExportColumnActivity       = "activity"
+	ExportColumnProject        = "project"
+	ExportColumnDescription    = "description"
+	ExportColumnLabels         = "labels"
+	ExportColumnDuration       = "duration"
+	ExportColumnBilledDuration = "billed_duration"
+	ExportColumnRounding       = "rounding"
+)
+
+// ExportColumns holds a list of all export columns (in their default order).
+var ExportColumns = []string{
+	ExportColumnStartTime,
+	ExportColumnEndTime,
+	ExportColumnType,
+	ExportColumnActivity,
+	ExportColumnProject,
+	ExportColumnDescription,
+	ExportColumnLabels,
+	ExportColumnDuration,
+	ExportColumnBilledDuration,
+	ExportColumnRounding,
+}
+
+// Export duration unit constants.
+const (
+	ExportDurationUnitMinutes = "minutes"
+	ExportDurationUnitHours   = "hours"
+)
+
+// Export decimal separator constants.
+const (
+	ExportDecimalSeparatorPoint = "."
+	ExportDecimalSeparatorComma = ","
+)
+
+// Export date format tokens (in the order they are matched).
+var exportDateFormatTokens = []string{"yyyy", "yy", "MM", "dd", "HH", "mm", "ss"}
+
+// Characters which can be used between the tokens of a export date format.
+const exportDateFormatLiterals = " .,:;-/_T"
+
+// ExportProfile stores the settings of a CSV export.
+type ExportProfile struct {
+	Id               int
+	UserId           int      // ID of the user who owns the profile
+	Name             string   // Name of the profile (unique per user)
+	Columns          []string // Exported columns in the order they are written
+	Delimiter        string   // Field delimiter (a single character)
+	DateFormat       string   // Format of times (e.g. "dd.MM.yyyy HH:mm"; empty for RFC 3339)
+	DurationUnit     string   // Unit of durations (minutes or hours)
+	DecimalSeparator string   // Decimal separator of durations in hours
+	Language         string   // Language of the header (e.g. "de"; empty for the default)
+	LabelSeparator   string   // Separator between the labels of a entry
+}
+
+// NewExportProfile creates a new ExportProfile model with the default settings.
+func NewExportProfile() *ExportProfile {
+	columns := make([]string, len(ExportColumns))
+	copy(columns, ExportColumns)
+	return &ExportProfile{
+		Columns:          columns,
+		Delimiter:        ",",
+		DurationUnit:     ExportDurationUnitMinutes,
+		DecimalSeparator: ExportDecimalSeparatorPoint,
+		LabelSeparator:   " ",
+	}
+}
+
+// FormatTime formats a time with the date format of the profile.
+func (p *ExportProfile) FormatTime(t time.Time) string {
+	if p.DateFormat == "" {
+		return t.Format(time.RFC3339)
+	}
+
+	var sb strings.Builder
+	format := p.DateFormat
+	for len(format) > 0 {
+		token := getExportDateFormatToken(format)
+		switch token {
+		case "yyyy":
+			sb.WriteString(formatNumber(t.Year(), 4))
+		case "yy":
+			sb.WriteString(formatNumber(t.Year()%100, 2))
+		case "MM":
+			sb.WriteString(formatNumber(int(t.Month()), 2))
+		case "dd":
+			sb.WriteString(formatNumber(t.Day(), 2))
+		case "HH":
+			sb.WriteString(formatNumber(t.Hour(), 2))
+		case "mm":
+			sb.WriteString(formatNumber(t.Minute(), 2))
+		case "ss":
+			sb.WriteString(formatNumber(t.Second(), 2))
+		default:
+			token = format[:1]
+			sb.WriteString(token)
+		}
+		format = format[len(token):]
+	}
+	return sb.String()
+}
+
+// FormatDuration formats a duration (in minutes) with the duration unit and decimal separator of
+// the profile.
+func (p *ExportProfile) FormatDuration(minutes int) string {
+	if p.DurationUnit != ExportDurationUnitHours {
+		return strconv.Itoa(minutes)
+	}
+	hours := strconv.FormatFloat(float64(minutes)/60.0, 'f', 2, 64)
+	if p.DecimalSeparator == ExportDecimalSeparatorComma {
+		hours = strings.Replace(hours, ".", ",", 1)
+	}
+	return hours
+}
+
+// IsValidExportColumn checks if a export column is valid.
+func IsValidExportColumn(column string) bool {
+	for _, c := range ExportColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidExportDateFormat checks if a export date format only consists of tokens (yyyy, yy, MM,
+// dd, HH, mm and ss) and allowed separator characters.
+func IsValidExportDateFormat(format string) bool {
+	for len(format) > 0 {
+		token := getExportDateFormatToken(format)
+		if token == "" {
+			if !strings.Contains(exportDateFormatLiterals, format[:1]) {
+				return false
+			}
+			token = format[:1]
+		}
+		format = format[len(token):]
+	}
+	return true
+}
+
+func getExportDateFormatToken(format string) string {
+	for _, token := range exportDateFormatTokens {
+		if strings.HasPrefix(format, token) {
+			return token
+		}
+	}
+	return ""
+}
+
+func formatNumber(num int, digits int) string {
+	str := strconv.Itoa(num)
+	for len(str) < digits {
+		str = "0" + str
+	}
+	return str
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"time"
+
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// Client stores information about a client which is billed for the work on its projects.
+type Client struct {
+	Id       int      // ID of the client
+	Name     string   // Name of the client
+	Address  string   // Postal address of the client (lines separated by line breaks)
+	Currency string   // Currency the client is billed in (ISO 4217 code)
+	Projects []string // Names of the projects which are billed to the client
+}
+
+// NewClient creates a new Client model.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// HourlyRate stores the amount which is billed per hour of work. A rate can be restricted to a
+// user, a project and/or an activity. If several rates match an entry, the most specific rate is
+// used (activity before project before user). If these are equal, the rate which became valid last
+// is used.
+type HourlyRate struct {
+	Id         int       // ID of the rate
+	UserId     int       // ID of the user the rate applies to (0 for all users)
+	Project    string    // Name of the project the rate applies to (empty for all projects)
+	ActivityId int       // ID of the activity the rate applies to (0 for all activities)
+	Amount     float64   // Amount per hour
+	Currency   string    // Currency of the amount (ISO 4217 code)
+	ValidFrom  time.Time // First day the rate is valid
+	ValidTo    time.Time // Last day the rate is valid (zero if the rate is valid indefinitely)
+}
+
+// NewHourlyRate creates a new HourlyRate model.
+func NewHourlyRate() *HourlyRate {
+	return &HourlyRate{}
+}
+
+// IsValidOn returns true if the rate is valid on the date of the supplied time.
+func (r *HourlyRate) IsValidOn(t time.Time) bool {
+	date := util.ToCivilDate(t)
+	if date.Before(util.ToCivilDate(r.ValidFrom)) {
+		return false
+	}
+	return r.ValidTo.IsZero() || !date.After(util.ToCivilDate(r.ValidTo))
+}
+
+// Matches returns true if the rate applies to the supplied entry. The validity is checked with the
+// start time of the entry in the supplied location.
+func (r *HourlyRate) Matches(entry *Entry, loc *time.Location) bool {
+	if r.UserId != 0 && r.UserId != entry.UserId {
+		return false
+	}
+	if r.Project != "" && r.Project != entry.Project {
+		return false
+	}
+	if r.ActivityId != 0 && r.ActivityId != entry.ActivityId {
+		return false
+	}
+	return r.IsValidOn(entry.StartTime.In(loc))
+}
+
+func (r *HourlyRate) getSpecificity() int {
+	specificity := 0
+	if r.ActivityId != 0 {
+		specificity += 4
+	}
+	if r.Project != "" {
+		specificity += 2
+	}
+	if r.UserId != 0 {
+		specificity += 1
+	}
+	return specificity
+}
+
+// FindHourlyRate returns the rate which applies to the supplied entry. It returns nil if no rate
+// applies.
+func FindHourlyRate(rates []*HourlyRate, entry *Entry, loc *time.Location) *HourlyRate {
+	var found *HourlyRate
+	for _, rate := range rates {
+		if !rate.Matches(entry, loc) {
+			continue
+		}
+		if found == nil || rate.getSpecificity() > found.getSpecificity() ||
+			(rate.getSpecificity() == found.getSpecificity() &&
+				rate.ValidFrom.After(found.ValidFrom)) {
+			found = rate
+		}
+	}
+	return found
+}
+
+// Invoice stores information about an invoice. The billed entries are copied into the items of the
+// invoice, so that later changes (e.g. of activities) do not change the invoice.
+type Invoice struct {
+	Id          int            // ID of the invoice
+	Number      string         // Number of the invoice (e.g. "2024-0001")
+	ClientId    int            // ID of the client
+	StartDate   time.Time      // First day of the billed period
+	EndDate     time.Time      // Last day of the billed period
+	Currency    string         // Currency of the amounts (ISO 4217 code)
+	NetAmount   float64        // Sum of the amounts of the items
+	TaxRate     float64        // Tax rate in percent
+	TaxAmount   float64        // Tax amount
+	TotalAmount float64        // Net amount plus tax amount
+	CreatedAt   time.Time      // Time the invoice was created
+	Items       []*InvoiceItem // Items of the invoice (ordered by date)
+}
+
+// NewInvoice creates a new Invoice model.
+func NewInvoice() *Invoice {
+	return &Invoice{}
+}
+
+// CalculateAmounts calculates net, tax and total amount of the invoice from its items.
+func (i *Invoice) CalculateAmounts() {
+	var net float64
+	for _, item := range i.Items {
+		net += item.Amount
+	}
+	i.NetAmount = RoundAmount(net)
+	i.TaxAmount = RoundAmount(i.NetAmount * i.TaxRate / 100)
+	i.TotalAmount = RoundAmount(i.NetAmount + i.TaxAmount)
+}
+
+// InvoiceItem stores a billed entry of an invoice.
+type InvoiceItem struct {
+	Id          int       // ID of the item
+	InvoiceId   int       // ID of the invoice
+	EntryId     int       // ID of the billed entry
+	UserId      int       // ID of the user of the entry
+	Date        time.Time // Date of the entry
+	Project     string    // Project of the entry
+	Description string    // Description of the work (activity and description of the entry)
+	Hours       float64   // Billed hours
+	Rate        float64   // Hourly rate
+	Amount      float64   // Billed hours multiplied with the hourly rate
+}
+
+// NewInvoiceItem creates a new InvoiceItem model.
+func NewInvoiceItem() *InvoiceItem {
+	return &InvoiceItem{}
+}
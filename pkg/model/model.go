@@ -34,12 +34,24 @@ const (
 	MaxLengthUserName                 = 100
 	MaxLengthUserUsername             = 100
 	MaxLengthUserPassword             = 100
+	MaxLengthUserEmail                = 100
+	MaxLengthUserTimeZone             = 64
 	MaxLengthTokenName                = 30
+	MaxLengthWebhookUrl               = 500
+	MaxLengthWebhookSecret            = 100
 	MaxLengthEntryTypeDescription     = 50
 	MaxLengthEntryActivityDescription = 50
 	MaxLengthEntryProjectName         = 30
 	MaxLengthEntryDescription         = 200
 	MaxLengthLabelName                = 20
+	MaxLengthExpenseRateName          = 50
+	MaxLengthExpenseDescription       = 200
+	MaxLengthAttachmentFileName       = 255
+	MaxLengthClientName               = 100
+	MaxLengthClientAddress            = 500
+	MaxLengthExportProfileName        = 50
+	MaxLengthExportDateFormat         = 30
+	MaxLengthExportLabelSeparator     = 5
 )
 
 // Other constants.
@@ -0,0 +1,22 @@
+package model
+
+// User setting keys of the notification settings.
+const (
+	UserSettingKeyEmail                  = "email"
+	UserSettingKeyMissingEntriesReminder = "missing_entries_reminder"
+	UserSettingKeyWeeklyDigest           = "weekly_digest"
+	UserSettingKeyReminderLastSent       = "reminder_last_sent"
+	UserSettingKeyDigestLastSent         = "digest_last_sent"
+)
+
+// NotificationSettings stores the notification settings of a user.
+type NotificationSettings struct {
+	Email                  string // Email address of the user
+	MissingEntriesReminder bool   // Determines if reminders for missing entries are sent
+	WeeklyDigest           bool   // Determines if the weekly digest is sent (evaluators only)
+}
+
+// NewNotificationSettings creates a new NotificationSettings model.
+func NewNotificationSettings() *NotificationSettings {
+	return &NotificationSettings{}
+}
@@ -40,6 +40,11 @@ const (
 	RightChangeAllEntries    Right = "change_all_entries"
 	RightGetOwnEntries       Right = "get_own_entries"
 	RightChangeOwnEntries    Right = "change_own_entries"
+	RightGetWebhooks         Right = "get_webhooks"
+	RightChangeWebhooks      Right = "change_webhooks"
+	RightApproveVacations    Right = "approve_vacations"
+	RightGetInvoices         Right = "get_invoices"
+	RightChangeInvoices      Right = "change_invoices"
 )
 
 // RolesRights holds a mapping of roles and rights.
@@ -59,6 +64,11 @@ var roleAdminRights = []Right{
 	RightChangeEntryCharacts,
 	RightGetAllEntries,
 	RightChangeAllEntries,
+	RightGetWebhooks,
+	RightChangeWebhooks,
+	RightApproveVacations,
+	RightGetInvoices,
+	RightChangeInvoices,
 }
 
 // Rights of the evaluator role.
@@ -68,6 +78,8 @@ var roleEvaluatorRights = []Right{
 	RightChangeUserAccount,
 	RightGetEntryCharacts,
 	RightGetAllEntries,
+	RightApproveVacations,
+	RightGetInvoices,
 }
 
 // Rights of the user role.
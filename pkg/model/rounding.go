@@ -0,0 +1,127 @@
+package model
+
+import "time"
+
+// Rounding modes.
+const (
+	RoundingModeNearest = "nearest"
+	RoundingModeUp      = "up"
+	RoundingModeDown    = "down"
+)
+
+// RoundingModes holds a list of all rounding modes.
+var RoundingModes = []string{
+	RoundingModeNearest,
+	RoundingModeUp,
+	RoundingModeDown,
+}
+
+// Rounding scopes.
+const (
+	// The rounded duration is stored when an entry is saved.
+	RoundingScopeSave = "save"
+	// The stored duration is kept, only reports and exports show the rounded duration.
+	RoundingScopeReport = "report"
+)
+
+// RoundingScopes holds a list of all rounding scopes.
+var RoundingScopes = []string{
+	RoundingScopeSave,
+	RoundingScopeReport,
+}
+
+// RoundingPolicy stores information about how the durations of work entries are rounded.
+type RoundingPolicy struct {
+	Mode            string // Rounding mode (nearest, up or down)
+	IntervalMinutes int    // Interval in minutes the durations are rounded to
+	Scope           string // Rounding scope (save or report)
+}
+
+// NewRoundingPolicy creates a new RoundingPolicy model.
+func NewRoundingPolicy() *RoundingPolicy {
+	return &RoundingPolicy{}
+}
+
+// Round rounds the supplied duration to the interval of the policy. If the duration would be
+// rounded to zero, the duration is returned unchanged.
+func (p *RoundingPolicy) Round(d time.Duration) time.Duration {
+	interval := time.Duration(p.IntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return d
+	}
+
+	var rounded time.Duration
+	switch p.Mode {
+	case RoundingModeUp:
+		rounded = d.Truncate(interval)
+		if rounded < d {
+			rounded += interval
+		}
+	case RoundingModeDown:
+		rounded = d.Truncate(interval)
+	default:
+		rounded = d.Round(interval)
+	}
+
+	if rounded <= 0 {
+		return d
+	}
+	return rounded
+}
+
+// ProjectRoundingPolicy stores the rounding policy of a project. It overrides the rounding policy
+// of the user for all entries of the project.
+type ProjectRoundingPolicy struct {
+	RoundingPolicy
+	Project string // Name of the project
+}
+
+// NewProjectRoundingPolicy creates a new ProjectRoundingPolicy model.
+func NewProjectRoundingPolicy() *ProjectRoundingPolicy {
+	return &ProjectRoundingPolicy{}
+}
+
+// RoundingPolicies stores the rounding policies which apply to the entries of a user.
+type RoundingPolicies struct {
+	UserPolicy      *RoundingPolicy            // Policy of the user (nil if not rounded)
+	ProjectPolicies map[string]*RoundingPolicy // Policies of projects (by project name)
+}
+
+// NewRoundingPolicies creates a new RoundingPolicies model.
+func NewRoundingPolicies() *RoundingPolicies {
+	return &RoundingPolicies{
+		ProjectPolicies: make(map[string]*RoundingPolicy),
+	}
+}
+
+// GetPolicy returns the rounding policy for entries of the supplied project. It returns nil if
+// entries of the project are not rounded.
+func (p *RoundingPolicies) GetPolicy(project string) *RoundingPolicy {
+	if pp, ok := p.ProjectPolicies[project]; ok && project != "" {
+		return pp
+	}
+	return p.UserPolicy
+}
+
+// GetScopePolicy returns the rounding policy for entries of the supplied project if the policy
+// has the supplied scope. Otherwise it returns nil.
+func (p *RoundingPolicies) GetScopePolicy(project string, scope string) *RoundingPolicy {
+	policy := p.GetPolicy(project)
+	if policy == nil || policy.Scope != scope {
+		return nil
+	}
+	return policy
+}
+
+// HasScopePolicy returns true if any of the rounding policies has the supplied scope.
+func (p *RoundingPolicies) HasScopePolicy(scope string) bool {
+	if p.UserPolicy != nil && p.UserPolicy.Scope == scope {
+		return true
+	}
+	for _, pp := range p.ProjectPolicies {
+		if pp.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,18 @@
 package model
 
+import "time"
+
 // SecurityContext stores information about user who interacts with the application.
 type SecurityContext struct {
-	UserId    int    // ID of the current user
-	UserRoles []Role // Roles of the current user
+	UserId       int            // ID of the current user
+	UserRoles    []Role         // Roles of the current user
+	UserLocation *time.Location // Location of the time zone of the current user
 }
 
 // NewSecurityContext creates a new SecurityContext model.
-func NewSecurityContext(userId int, userRoles []Role) *SecurityContext {
-	return &SecurityContext{userId, userRoles}
+func NewSecurityContext(userId int, userRoles []Role, userLocation *time.Location,
+) *SecurityContext {
+	return &SecurityContext{userId, userRoles, userLocation}
 }
 
 // IsSystemUser returns true for if this is the context for the system user.
@@ -25,10 +29,10 @@ func (sc SecurityContext) IsAnonymousUser() bool {
 
 // GetSystemUserSecurityContext returns the security context for the system user.
 func GetSystemUserSecurityContext() *SecurityContext {
-	return NewSecurityContext(SystemUserId, []Role{RoleAdmin, RoleEvaluator, RoleUser})
+	return NewSecurityContext(SystemUserId, []Role{RoleAdmin, RoleEvaluator, RoleUser}, time.Local)
 }
 
 // GetAnonymousUserSecurityContext returns the security context for a anonymous user.
 func GetAnonymousUserSecurityContext() *SecurityContext {
-	return NewSecurityContext(AnonymousUserId, []Role{})
+	return NewSecurityContext(AnonymousUserId, []Role{}, time.Local)
 }
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// StatisticsGrouping specifies the entry characteristic durations are grouped by.
+type StatisticsGrouping string
+
+// Statistics grouping constants.
+const (
+	StatisticsGroupingType     StatisticsGrouping = "type"
+	StatisticsGroupingActivity StatisticsGrouping = "activity"
+	StatisticsGroupingProject  StatisticsGrouping = "project"
+	StatisticsGroupingLabel    StatisticsGrouping = "label"
+	StatisticsGroupingUser     StatisticsGrouping = "user"
+)
+
+// StatisticsGroupings holds a list of all statistics groupings.
+var StatisticsGroupings = []StatisticsGrouping{
+	StatisticsGroupingType,
+	StatisticsGroupingActivity,
+	StatisticsGroupingProject,
+	StatisticsGroupingLabel,
+	StatisticsGroupingUser,
+}
+
+// StatisticsInterval specifies the length of the time buckets durations are summed up in.
+type StatisticsInterval string
+
+// Statistics interval constants.
+const (
+	StatisticsIntervalDay   StatisticsInterval = "day"
+	StatisticsIntervalWeek  StatisticsInterval = "week"
+	StatisticsIntervalMonth StatisticsInterval = "month"
+)
+
+// StatisticsIntervals holds a list of all statistics intervals.
+var StatisticsIntervals = []StatisticsInterval{
+	StatisticsIntervalDay,
+	StatisticsIntervalWeek,
+	StatisticsIntervalMonth,
+}
+
+// StatisticsMaxBuckets is the maximum number of buckets a period can be split into.
+const StatisticsMaxBuckets = 1000
+
+// IsValidStatisticsPeriod returns true if the period from the start day until and including the
+// end day is not split into more than StatisticsMaxBuckets buckets of the supplied interval.
+func IsValidStatisticsPeriod(start time.Time, end time.Time, interval StatisticsInterval) bool {
+	// (Very long periods saturate the duration, but still exceed the maximum)
+	days := int(util.ToCivilDate(end).Sub(util.ToCivilDate(start)).Hours()/24) + 1
+	var buckets int
+	switch interval {
+	case StatisticsIntervalWeek:
+		// The first and the last week can be partial weeks
+		buckets = days/7 + 2
+	case StatisticsIntervalMonth:
+		buckets = (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()) + 1
+	default:
+		buckets = days
+	}
+	return buckets <= StatisticsMaxBuckets
+}
+
+// Statistics stores the durations of entries grouped by an entry characteristic and split into
+// time buckets.
+//
+// An entry is assigned to the bucket its start time lies in. If durations are grouped by label,
+// an entry is counted for each of its labels. The durations of the statistics itself count each
+// entry only once.
+type Statistics struct {
+	Grouping      StatisticsGrouping // Entry characteristic the durations are grouped by
+	Interval      StatisticsInterval // Length of the time buckets
+	StartDate     time.Time          // First day of the period
+	EndDate       time.Time          // Last day of the period
+	Buckets       []time.Time        // Start times of the buckets
+	Durations     []time.Duration    // Durations of all entries per bucket
+	TotalDuration time.Duration      // Duration of all entries
+	Groups        []*StatisticsGroup // Groups (sorted descending by total duration)
+}
+
+// NewStatistics creates a new Statistics model.
+func NewStatistics() *Statistics {
+	return &Statistics{}
+}
+
+// StatisticsGroup stores the durations of the entries of a group.
+type StatisticsGroup struct {
+	Id            int             // ID of the entry type, entry activity or user (0 if none)
+	Name          string          // Type/activity description, project, label or user name
+	Durations     []time.Duration // Durations per bucket
+	TotalDuration time.Duration   // Duration of all entries of the group
+}
+
+// NewStatisticsGroup creates a new StatisticsGroup model.
+func NewStatisticsGroup() *StatisticsGroup {
+	return &StatisticsGroup{}
+}
+
+// IsNone returns true if the group contains the entries without activity, project or label.
+func (g *StatisticsGroup) IsNone() bool {
+	return g.Id == 0 && g.Name == ""
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Standard user IDs.
 const (
 	SystemUserId    int = -1
@@ -13,9 +15,29 @@ type User struct {
 	Username           string // Username of the user
 	Password           string // Password of the user
 	MustChangePassword bool   // Determines if user must change password
+	TimeZone           string // IANA time zone of the user (empty for the server time zone)
 }
 
 // NewUser creates a new User model.
 func NewUser() *User {
 	return &User{}
 }
+
+// GetLocation returns the location of the time zone of the user. If no or an unknown time zone
+// was set, the location of the server is returned.
+func (u *User) GetLocation() *time.Location {
+	return GetLocation(u.TimeZone)
+}
+
+// GetLocation returns the location of the supplied IANA time zone. If no or an unknown time zone
+// was supplied, the location of the server is returned.
+func GetLocation(timeZone string) *time.Location {
+	if timeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,99 @@
+package model
+
+import "time"
+
+// Vacation request status.
+const (
+	VacationRequestStatusPending  = "pending"
+	VacationRequestStatusApproved = "approved"
+	VacationRequestStatusDenied   = "denied"
+)
+
+// VacationRequestStatuses holds a list of all vacation request status.
+var VacationRequestStatuses = []string{
+	VacationRequestStatusPending,
+	VacationRequestStatusApproved,
+	VacationRequestStatusDenied,
+}
+
+// Vacation request warnings.
+const (
+	// VacationRequestWarningBalanceExceeded is issued if the requested days together with the days
+	// of the other pending requests exceed the remaining vacation days of the user.
+	VacationRequestWarningBalanceExceeded = "balance_exceeded"
+)
+
+// VacationRequest stores information about a request for leave. If the request is approved, a
+// vacation absence is created.
+type VacationRequest struct {
+	Id              int       // ID of the request
+	UserId          int       // ID of the requesting user
+	StartDate       time.Time // First day of the vacation
+	EndDate         time.Time // Last day of the vacation
+	DayFraction     string    // Fraction of the daily working hours per day
+	Description     string    // Description for the vacation
+	Days            float32   // Number of requested vacation days
+	Status          string    // Status of the request
+	RequestedAt     time.Time // Time of the request
+	DecidedByUserId int       // ID of the user who approved/denied the request (0 if not decided)
+	DecidedAt       time.Time // Time of the decision (zero if not decided)
+	DecisionComment string    // Comment of the decision
+	AbsenceId       int       // ID of the created absence (0 if none)
+	Warnings        []string  // Warnings of the request
+}
+
+// NewVacationRequest creates a new VacationRequest model.
+func NewVacationRequest() *VacationRequest {
+	return &VacationRequest{
+		DayFraction: AbsenceDayFractionFull,
+		Status:      VacationRequestStatusPending,
+		Warnings:    []string{},
+	}
+}
+
+// IsPending returns true if the request was not yet approved or denied.
+func (r *VacationRequest) IsPending() bool {
+	return r.Status == VacationRequestStatusPending
+}
+
+// IsValidVacationRequestStatus returns true if the supplied status is valid.
+func IsValidVacationRequestStatus(status string) bool {
+	for _, s := range VacationRequestStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// VacationBalance stores information about the vacation balance of a user in the current year.
+type VacationBalance struct {
+	UserId        int     // ID of the user
+	Year          int     // Year of the balance
+	EntitledDays  float32 // Vacation days available until the end of the year (incl. adjustments)
+	TakenDays     float32 // Vacation days taken until today
+	PlannedDays   float32 // Vacation days booked after today
+	PendingDays   float32 // Vacation days of pending requests
+	RemainingDays float32 // Vacation days which are neither taken nor booked
+}
+
+// NewVacationBalance creates a new VacationBalance model.
+func NewVacationBalance() *VacationBalance {
+	return &VacationBalance{}
+}
+
+// VacationLedgerYear stores the vacation account of a user for a single year.
+type VacationLedgerYear struct {
+	Year            int     // Year
+	CarriedOverDays float32 // Vacation days carried over from the previous year
+	AccruedDays     float32 // Vacation days accrued in the year (incl. initial days in first year)
+	AdjustedDays    float32 // Vacation days added (or subtracted) by manual adjustments
+	TakenDays       float32 // Vacation days taken or booked in the year
+	ExpiredDays     float32 // Vacation days expired in the year
+	RemainingDays   float32 // Vacation days remaining at the end of the year
+}
+
+// NewVacationLedgerYear creates a new VacationLedgerYear model.
+func NewVacationLedgerYear() *VacationLedgerYear {
+	return &VacationLedgerYear{}
+}
@@ -0,0 +1,97 @@
+package model
+
+import "time"
+
+// Webhook event types.
+const (
+	WebhookEventPing         = "ping"
+	WebhookEventEntryCreated = "entry.created"
+	WebhookEventEntryUpdated = "entry.updated"
+	WebhookEventEntryDeleted = "entry.deleted"
+	WebhookEventUserCreated  = "user.created"
+)
+
+// WebhookEventTypes holds a list of event types a webhook can subscribe to.
+var WebhookEventTypes = []string{
+	WebhookEventEntryCreated,
+	WebhookEventEntryUpdated,
+	WebhookEventEntryDeleted,
+	WebhookEventUserCreated,
+}
+
+// Webhook delivery states.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+const (
+	WebhookSecretLength = 32
+)
+
+// Webhook stores information about a webhook subscription.
+type Webhook struct {
+	Id         int      // ID of the webhook
+	Url        string   // URL events are sent to
+	Secret     string   // Secret used to sign deliveries
+	EventTypes []string // Subscribed event types
+	Active     bool     // Determines if deliveries are created for the webhook
+}
+
+// NewWebhook creates a new Webhook model with a generated secret.
+func NewWebhook() *Webhook {
+	return &Webhook{
+		Secret:     generateRandomString(WebhookSecretLength),
+		EventTypes: []string{},
+		Active:     true,
+	}
+}
+
+// HasEventType returns true if the webhook is subscribed to the supplied event type.
+func (w *Webhook) HasEventType(eventType string) bool {
+	for _, et := range w.EventTypes {
+		if et == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidWebhookEventType returns true if a webhook can subscribe to the supplied event type.
+func IsValidWebhookEventType(eventType string) bool {
+	for _, et := range WebhookEventTypes {
+		if et == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery stores information about the delivery of an event to a webhook.
+type WebhookDelivery struct {
+	Id             int       // ID of the delivery
+	WebhookId      int       // ID of the webhook
+	EventType      string    // Type of the delivered event
+	Payload        string    // JSON payload of the delivered event
+	Status         string    // Status of the delivery
+	Attempts       int       // Number of delivery attempts
+	CreatedAt      time.Time // Time the delivery was created
+	NextAttemptAt  time.Time // Time of the next delivery attempt (zero if there is none)
+	LastAttemptAt  time.Time // Time of the last delivery attempt (zero if there was none)
+	LastStatusCode int       // HTTP status code of the last delivery attempt
+	LastError      string    // Error of the last delivery attempt
+}
+
+// NewWebhookDelivery creates a new pending WebhookDelivery model.
+func NewWebhookDelivery(webhookId int, eventType string, payload string) *WebhookDelivery {
+	n := now()
+	return &WebhookDelivery{
+		WebhookId:     webhookId,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		CreatedAt:     n,
+		NextAttemptAt: n,
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// WorkDay stores the work and break durations of a single day.
+type WorkDay struct {
+	Date          time.Time     // Date of the day
+	WorkDuration  time.Duration // Duration of all work entries
+	BreakDuration time.Duration // Duration between the work entries
+}
+
+// NewWorkDay creates a new WorkDay model from the entries of a day. Only entries of types which
+// count as work time are considered. The break duration is the time between the start of the first
+// and the end of the last work entry which is not covered by work entries.
+func NewWorkDay(date time.Time, entries []*Entry, entryTypesMap map[int]*EntryType) *WorkDay {
+	wd := &WorkDay{Date: date}
+
+	var start, end time.Time
+	for _, entry := range entries {
+		if !IsWorkEntryType(entryTypesMap, entry.TypeId) {
+			continue
+		}
+		wd.WorkDuration = wd.WorkDuration + entry.EndTime.Sub(entry.StartTime)
+		if start.IsZero() || entry.StartTime.Before(start) {
+			start = entry.StartTime
+		}
+		if end.IsZero() || entry.EndTime.After(end) {
+			end = entry.EndTime
+		}
+	}
+
+	if breakDuration := end.Sub(start) - wd.WorkDuration; breakDuration > 0 {
+		wd.BreakDuration = breakDuration
+	}
+
+	return wd
+}
+
+// BreakCheck stores the result of checking the breaks of a work day against the break rules.
+type BreakCheck struct {
+	RequiredBreakDuration time.Duration // Break duration required by the break rules
+	MissingBreakDuration  time.Duration // Break duration missing to fulfill the break rules
+	DeductionDuration     time.Duration // Duration which is deducted from the work time
+}
+
+// IsViolated returns true if the break rules were violated.
+func (bc *BreakCheck) IsViolated() bool {
+	return bc.MissingBreakDuration > 0
+}
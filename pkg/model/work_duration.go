@@ -12,3 +12,15 @@ type WorkDuration struct {
 func NewWorkDuration() *WorkDuration {
 	return &WorkDuration{}
 }
+
+// MonthWorkDuration stores the work duration of a entry type in a month.
+type MonthWorkDuration struct {
+	Month        time.Month    // Month
+	TypeId       int           // ID of the entry type
+	WorkDuration time.Duration // Work duration
+}
+
+// NewMonthWorkDuration creates a new MonthWorkDuration model.
+func NewMonthWorkDuration() *MonthWorkDuration {
+	return &MonthWorkDuration{}
+}
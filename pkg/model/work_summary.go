@@ -4,10 +4,11 @@ import "time"
 
 // WorkSummary stores information about the work of a user.
 type WorkSummary struct {
-	UserId        int             // ID of the user
-	StartTime     time.Time       // Start time
-	EndTime       time.Time       // End time
-	WorkDurations []*WorkDuration // Work durations (for each entry type)
+	UserId         int             // ID of the user
+	StartTime      time.Time       // Start time
+	EndTime        time.Time       // End time
+	WorkDurations  []*WorkDuration // Work durations (for each entry type)
+	BreakDeduction time.Duration   // Duration deducted because of missing breaks
 }
 
 // NewWorkSummary creates a new WorkSummary model.
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// YearOverview stores the monthly work, overtime and absence figures of a user for a year.
+type YearOverview struct {
+	UserId               int                  // ID of the user
+	Year                 int                  // Year
+	StartOvertimeHours   float32              // Overtime balance at the start of the year
+	VacationEntitledDays float32              // Vacation days carried over, accrued and adjusted
+	Months               []*YearOverviewMonth // Figures of the months (January to December)
+}
+
+// NewYearOverview creates a new YearOverview model.
+func NewYearOverview() *YearOverview {
+	return &YearOverview{}
+}
+
+// YearOverviewMonth stores the work, overtime and absence figures of a user for a month.
+type YearOverviewMonth struct {
+	Month               time.Month // Month
+	IsDue               bool       // The month has started (months in the future are not due)
+	TargetHours         float32    // Target hours of the whole month
+	DueTargetHours      float32    // Target hours until today (or the end of the month)
+	ActualHours         float32    // Hours of credited entry types (minus break deductions)
+	AdjustedHours       float32    // Hours added (or subtracted) by overtime adjustments
+	OvertimeHours       float32    // Overtime balance at the end of the month (or today)
+	VacationTakenDays   float32    // Vacation days taken in the month
+	VacationExpiredDays float32    // Vacation days expired at the end of the month
+	VacationRemainDays  float32    // Vacation days remaining at the end of the month
+	IllnessDays         float32    // Days of illness in the month
+}
+
+// NewYearOverviewMonth creates a new YearOverviewMonth model.
+func NewYearOverviewMonth() *YearOverviewMonth {
+	return &YearOverviewMonth{}
+}
+
+// GetBalanceHours returns the balance of the actual and due target hours (incl. adjustments).
+func (m *YearOverviewMonth) GetBalanceHours() float32 {
+	return m.ActualHours + m.AdjustedHours - m.DueTargetHours
+}
@@ -0,0 +1,89 @@
+package pdf
+
+import (
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+type font struct {
+	name   string  // Resource name of the font (e.g. "F1")
+	base   string  // Name of the PDF standard font
+	widths [95]int // Widths of the characters 32 to 126 (in 1/1000 of the font size)
+}
+
+// Widths of the standard fonts "Helvetica" and "Helvetica-Bold" (from the Adobe font metrics).
+var (
+	fontRegular = &font{"F1", "Helvetica", [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}}
+	fontBold = &font{"F2", "Helvetica-Bold", [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}}
+)
+
+// Widths of special characters which have no ASCII base character.
+var specialWidths = map[rune]int{
+	'ß': 611,
+	'€': 556,
+	'–': 556,
+	'—': 1000,
+	'…': 1000,
+	'•': 350,
+	'„': 333,
+	'“': 333,
+	'”': 333,
+	'‘': 222,
+	'’': 222,
+	'°': 400,
+	'§': 556,
+}
+
+const defaultWidth = 556
+
+// runeWidth returns the width of a character (in 1/1000 of the font size).
+func (f *font) runeWidth(r rune) int {
+	if r >= 32 && r <= 126 {
+		return f.widths[r-32]
+	}
+	if w, ok := specialWidths[r]; ok {
+		return w
+	}
+	// Use the width of the base character of accented characters (e.g. "A" for "Ä")
+	if d := []rune(norm.NFD.String(string(r))); len(d) > 1 && d[0] >= 32 && d[0] <= 126 {
+		return f.widths[d[0]-32]
+	}
+	return defaultWidth
+}
+
+// textWidth returns the width of a text (in 1/1000 of the font size).
+func (f *font) textWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += f.runeWidth(r)
+	}
+	return w
+}
+
+// encodeText converts a text into the WinAnsi encoding of the standard fonts. Characters which can
+// not be encoded are replaced with "?".
+func encodeText(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		out = append(out, b)
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+// Image is an image which can be drawn on the pages of a document. JPEG images are embedded as
+// they are, PNG images are converted to RGB (transparent areas become white).
+type Image struct {
+	name       string
+	width      int
+	height     int
+	colorSpace string
+	decode     string
+	filter     string
+	data       []byte
+}
+
+// Width returns the width of the image in pixels.
+func (i *Image) Width() int {
+	return i.width
+}
+
+// Height returns the height of the image in pixels.
+func (i *Image) Height() int {
+	return i.height
+}
+
+// AddImage adds a JPEG or PNG image to the document. The returned image can be drawn on any page.
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	var img *Image
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("\xff\xd8")):
+		img, err = createJpegImage(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		img, err = createPngImage(data)
+	default:
+		err = errors.New("unsupported image format (only JPEG and PNG are supported)")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img.name = fmt.Sprintf("Im%d", len(d.images)+1)
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+// DrawImage draws an image. The position specifies the top left corner.
+func (d *Document) DrawImage(img *Image, x float64, y float64, w float64, h float64) {
+	fmt.Fprintf(d.page, "q %s 0 0 %s %s %s cm /%s Do Q\n", fmtNum(w*ptPerMm), fmtNum(h*ptPerMm),
+		fmtNum(x*ptPerMm), fmtNum((pageHeight-y-h)*ptPerMm), img.name)
+}
+
+func createJpegImage(data []byte) (*Image, error) {
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	img := &Image{width: cfg.Width, height: cfg.Height, filter: "DCTDecode", data: data}
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		img.colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		// Adobe applications write inverted CMYK values
+		img.colorSpace = "DeviceCMYK"
+		img.decode = "[1 0 1 0 1 0 1 0]"
+	default:
+		img.colorSpace = "DeviceRGB"
+	}
+	return img, nil
+}
+
+func createPngImage(data []byte) (*Image, error) {
+	src, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert pixels to RGB and blend transparent pixels with a white background
+	bounds := src.Bounds()
+	pixels := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixels = append(pixels, blendWithWhite(src, x, y)...)
+		}
+	}
+
+	// Compress pixels
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(pixels); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &Image{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB",
+		filter: "FlateDecode", data: buf.Bytes()}, nil
+}
+
+func blendWithWhite(src image.Image, x int, y int) []byte {
+	r, g, b, a := src.At(x, y).RGBA()
+	// Colors are alpha-premultiplied, so the white background only has to be added
+	white := 0xffff - a
+	return []byte{byte((r + white) >> 8), byte((g + white) >> 8), byte((b + white) >> 8)}
+}
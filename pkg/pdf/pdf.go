@@ -0,0 +1,399 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Size of an A4 page and page margins (in mm).
+const (
+	pageWidth    = 210.0
+	pageHeight   = 297.0
+	marginLeft   = 20.0
+	marginRight  = 20.0
+	marginTop    = 20.0
+	marginBottom = 20.0
+)
+
+// Factor to convert mm into PDF points.
+const ptPerMm = 72 / 25.4
+
+// Factor of the font size which is used as line height.
+const lineHeightFactor = 1.25
+
+// Document is a simple PDF document with A4 pages. It supports text in the standard fonts
+// Helvetica and Helvetica-Bold, lines, filled rectangles, images and tables. All positions are specified
+// in mm from the top left corner of a page. The document keeps a vertical cursor, which is moved
+// by the flow functions (e.g. WriteLine or Table) and starts a new page if there is not enough
+// space left.
+type Document struct {
+	title   string
+	creator string
+	pages   []*bytes.Buffer
+	page    *bytes.Buffer
+	images  []*Image
+	font    *font
+	size    float64
+	y       float64
+}
+
+// Column describes a column of a table.
+type Column struct {
+	Title      string  // Title of the column
+	Width      float64 // Width of the column (in mm)
+	AlignRight bool    // Whether the content of the column is right aligned
+}
+
+// NewDocument creates a new document with a first page.
+func NewDocument(title string, creator string) *Document {
+	d := &Document{title: title, creator: creator}
+	d.SetFont(false, 10)
+	d.AddPage()
+	return d
+}
+
+// --- Page functions ---
+
+// AddPage starts a new page and moves the cursor to the top margin.
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = marginTop
+}
+
+// ContentWidth returns the width of the page without margins.
+func (d *Document) ContentWidth() float64 {
+	return pageWidth - marginLeft - marginRight
+}
+
+// Left returns the position of the left margin.
+func (d *Document) Left() float64 {
+	return marginLeft
+}
+
+// Right returns the position of the right margin.
+func (d *Document) Right() float64 {
+	return pageWidth - marginRight
+}
+
+// Y returns the vertical position of the cursor.
+func (d *Document) Y() float64 {
+	return d.y
+}
+
+// SetY sets the vertical position of the cursor.
+func (d *Document) SetY(y float64) {
+	d.y = y
+}
+
+// Space moves the cursor down.
+func (d *Document) Space(h float64) {
+	d.y += h
+}
+
+// EnsureSpace starts a new page if there is less vertical space left than supplied.
+func (d *Document) EnsureSpace(h float64) {
+	if d.y+h > pageHeight-marginBottom {
+		d.AddPage()
+	}
+}
+
+// --- Text functions ---
+
+// SetFont sets the font which is used for following texts.
+func (d *Document) SetFont(bold bool, size float64) {
+	if bold {
+		d.font = fontBold
+	} else {
+		d.font = fontRegular
+	}
+	d.size = size
+}
+
+// LineHeight returns the line height of the current font (in mm).
+func (d *Document) LineHeight() float64 {
+	return d.size * lineHeightFactor / ptPerMm
+}
+
+// TextWidth returns the width of a text in the current font (in mm).
+func (d *Document) TextWidth(s string) float64 {
+	return float64(d.font.textWidth(s)) * d.size / 1000 / ptPerMm
+}
+
+// Text writes a text. The position specifies the left end of the baseline.
+func (d *Document) Text(x float64, y float64, s string) {
+	fmt.Fprintf(d.page, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", d.font.name, fmtNum(d.size),
+		fmtNum(x*ptPerMm), fmtNum((pageHeight-y)*ptPerMm), escapeText(encodeText(s)))
+}
+
+// TextRight writes a right aligned text. The position specifies the right end of the baseline.
+func (d *Document) TextRight(x float64, y float64, s string) {
+	d.Text(x-d.TextWidth(s), y, s)
+}
+
+// WriteLine writes a text at the cursor and moves the cursor to the next line. Texts which are
+// wider than the page are wrapped.
+func (d *Document) WriteLine(s string) {
+	for _, line := range d.SplitText(s, d.ContentWidth()) {
+		d.EnsureSpace(d.LineHeight())
+		d.y += d.LineHeight()
+		d.Text(marginLeft, d.y, line)
+	}
+}
+
+// WriteLineRight writes a right aligned text at the cursor and moves the cursor to the next line.
+func (d *Document) WriteLineRight(s string) {
+	d.EnsureSpace(d.LineHeight())
+	d.y += d.LineHeight()
+	d.TextRight(pageWidth-marginRight, d.y, s)
+}
+
+// SplitText splits a text into lines which fit into the supplied width. Lines are split at spaces
+// if possible. Line breaks in the text are kept.
+func (d *Document) SplitText(s string, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if d.TextWidth(candidate) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Split words which are wider than a line
+			for d.TextWidth(word) > width {
+				runes := []rune(word)
+				n := len(runes) - 1
+				for n > 1 && d.TextWidth(string(runes[:n])) > width {
+					n--
+				}
+				lines = append(lines, string(runes[:n]))
+				word = string(runes[n:])
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// --- Graphic functions ---
+
+// Line draws a line.
+func (d *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64) {
+	fmt.Fprintf(d.page, "0.5 w %s %s m %s %s l S\n", fmtNum(x1*ptPerMm),
+		fmtNum((pageHeight-y1)*ptPerMm), fmtNum(x2*ptPerMm), fmtNum((pageHeight-y2)*ptPerMm))
+}
+
+// FillRect draws a rectangle filled with a gray level (0 = black, 1 = white). The position
+// specifies the top left corner.
+func (d *Document) FillRect(x float64, y float64, w float64, h float64, gray float64) {
+	fmt.Fprintf(d.page, "%s g %s %s %s %s re f 0 g\n", fmtNum(gray), fmtNum(x*ptPerMm),
+		fmtNum((pageHeight-y-h)*ptPerMm), fmtNum(w*ptPerMm), fmtNum(h*ptPerMm))
+}
+
+// --- Table functions ---
+
+// Table writes a table at the cursor and moves the cursor below the table. Cell texts are wrapped
+// to the width of their column. If a table does not fit on the page, it is continued on a new
+// page and the header is repeated. If no column has a title, the table has no header. The rows of
+// the supplied bold rows (by index) are written in bold, e.g. for total rows.
+func (d *Document) Table(cols []*Column, rows [][]string, boldRows map[int]bool) {
+	size := d.size
+	bold := d.font == fontBold
+
+	header := make([]string, len(cols))
+	hasHeader := false
+	for i, col := range cols {
+		header[i] = col.Title
+		hasHeader = hasHeader || col.Title != ""
+	}
+	if hasHeader {
+		d.tableRow(cols, header, true, true)
+	}
+	for i, row := range rows {
+		if d.tableRow(cols, row, boldRows[i], false) {
+			if hasHeader {
+				d.tableRow(cols, header, true, true)
+			}
+			d.tableRow(cols, row, boldRows[i], false)
+		}
+	}
+
+	d.SetFont(bold, size)
+}
+
+// tableRow writes a row of a table. If the row does not fit on the current page, a new page is
+// started and true is returned without writing the row.
+func (d *Document) tableRow(cols []*Column, cells []string, bold bool, header bool) bool {
+	const padding = 1.5
+
+	size := d.size
+	d.SetFont(bold, size)
+
+	// Split cells into lines
+	cellLines := make([][]string, len(cols))
+	maxLines := 1
+	for i, col := range cols {
+		text := ""
+		if i < len(cells) {
+			text = cells[i]
+		}
+		cellLines[i] = d.SplitText(text, col.Width-2*padding)
+		if len(cellLines[i]) > maxLines {
+			maxLines = len(cellLines[i])
+		}
+	}
+	h := float64(maxLines)*d.LineHeight() + padding
+
+	// Start new page if necessary
+	if d.y+h > pageHeight-marginBottom {
+		d.AddPage()
+		if !header {
+			return true
+		}
+	}
+
+	// Write cells
+	x := marginLeft
+	width := 0.0
+	for _, col := range cols {
+		width += col.Width
+	}
+	if header {
+		d.FillRect(x, d.y, width, h, 0.94)
+	}
+	for i, col := range cols {
+		for j, line := range cellLines[i] {
+			ly := d.y + float64(j+1)*d.LineHeight()
+			if col.AlignRight {
+				d.TextRight(x+col.Width-padding, ly, line)
+			} else {
+				d.Text(x+padding, ly, line)
+			}
+		}
+		x += col.Width
+	}
+	d.y += h
+	d.Line(marginLeft, d.y, marginLeft+width, d.y)
+
+	return false
+}
+
+// --- Output functions ---
+
+// WriteTo writes the PDF file of the document to a writer.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	startObject := func() {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4: Catalog, page tree and fonts
+	const firstPageObj = 6
+	startObject()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	startObject()
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n",
+		strings.Join(kids, " "), len(d.pages))
+	for _, f := range []*font{fontRegular, fontBold} {
+		startObject()
+		fmt.Fprintf(&buf, "<< /Type /Font /Subtype /Type1 /BaseFont /%s "+
+			"/Encoding /WinAnsiEncoding >>\nendobj\n", f.base)
+	}
+
+	// Object 5: Document information
+	startObject()
+	fmt.Fprintf(&buf, "<< /Title (%s) /Creator (%s) /Producer (%s) /CreationDate (D:%s) >>\n"+
+		"endobj\n", escapeText(encodeText(d.title)), escapeText(encodeText(d.creator)),
+		escapeText(encodeText(d.creator)), time.Now().Format("20060102150405"))
+
+	// Objects 6+: Pages and their contents
+	firstImageObj := firstPageObj + 2*len(d.pages)
+	xObjects := ""
+	for i, img := range d.images {
+		xObjects += fmt.Sprintf(" /%s %d 0 R", img.name, firstImageObj+i)
+	}
+	for i, page := range d.pages {
+		startObject()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> /XObject <<%s >> >> "+
+			"/Contents %d 0 R >>\nendobj\n", fmtNum(pageWidth*ptPerMm),
+			fmtNum(pageHeight*ptPerMm), xObjects, firstPageObj+2*i+1)
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		startObject()
+		fmt.Fprintf(&buf, "<< /Length %d /Filter /FlateDecode >>\nstream\n", content.Len())
+		buf.Write(content.Bytes())
+		buf.WriteString("\nendstream\nendobj\n")
+	}
+
+	// Objects after pages: Images
+	for _, img := range d.images {
+		startObject()
+		fmt.Fprintf(&buf, "<< /Type /XObject /Subtype /Image /Width %d /Height %d "+
+			"/ColorSpace /%s /BitsPerComponent 8 /Filter /%s", img.width, img.height,
+			img.colorSpace, img.filter)
+		if img.decode != "" {
+			fmt.Fprintf(&buf, " /Decode %s", img.decode)
+		}
+		fmt.Fprintf(&buf, " /Length %d >>\nstream\n", len(img.data))
+		buf.Write(img.data)
+		buf.WriteString("\nendstream\nendobj\n")
+	}
+
+	// Cross-reference table and trailer
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// --- Helper functions ---
+
+func fmtNum(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func escapeText(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// AbsenceService contains absence related logic.
+type AbsenceService struct {
+	service
+	uRepo *repo.UserRepo
+	aRepo *repo.AbsenceRepo
+	eRepo *repo.EntryRepo
+	cRepo *repo.ContractRepo
+	yRepo *repo.ClosingRepo
+	wServ *WebhookService
+}
+
+// NewAbsenceService create a new absence service.
+func NewAbsenceService(tm *tx.TransactionManager, ur *repo.UserRepo, ar *repo.AbsenceRepo,
+	er *repo.EntryRepo, cr *repo.ContractRepo, yr *repo.ClosingRepo, ws *WebhookService,
+) *AbsenceService {
+	return &AbsenceService{service{tm}, ur, ar, er, cr, yr, ws}
+}
+
+// --- Absence functions ---
+
+// GetAbsenceById gets an absence.
+func (s *AbsenceService) GetAbsenceById(ctx context.Context, id int) (*model.Absence, error) {
+	// Get absence
+	absence, err := s.aRepo.GetAbsenceById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if absence == nil {
+		return nil, nil
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, absence.UserId); err != nil {
+		return nil, err
+	}
+
+	// Get entries
+	if err := s.setAbsenceEntryIds(ctx, absence); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// GetAbsenceByIdAndUserId gets an absence of an user.
+func (s *AbsenceService) GetAbsenceByIdAndUserId(ctx context.Context, id int, userId int) (
+	*model.Absence, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get absence
+	absence, err := s.aRepo.GetAbsenceById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if absence == nil || absence.UserId != userId {
+		return nil, nil
+	}
+
+	// Get entries
+	if err := s.setAbsenceEntryIds(ctx, absence); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// CreateAbsence creates a new absence. For every working day of the absence an entry is created.
+func (s *AbsenceService) CreateAbsence(ctx context.Context, absence *model.Absence) error {
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, absence.UserId); err != nil {
+		return err
+	}
+
+	// Check absence
+	if err := s.checkAbsence(ctx, absence); err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, absence.UserId, absence.StartDate); err != nil {
+		return err
+	}
+
+	// Create entries of absence
+	entries, err := s.createAbsenceEntries(ctx, absence)
+	if err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		return s.insertAbsence(ctx, absence, entries)
+	})
+}
+
+// UpdateAbsence updates an absence. The entries of the absence are replaced.
+func (s *AbsenceService) UpdateAbsence(ctx context.Context, absence *model.Absence) error {
+	// Get existing absence
+	existingAbsence, err := s.aRepo.GetAbsenceById(ctx, absence.Id)
+	if err != nil {
+		return err
+	}
+
+	// Check if absence exists
+	if err := s.checkAbsenceExists(absence.Id, existingAbsence); err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, existingAbsence.UserId); err != nil {
+		return err
+	}
+	if err := s.checkHasCurrentUserChangeRight(ctx, absence.UserId); err != nil {
+		return err
+	}
+
+	// Check absence
+	if err := s.checkAbsence(ctx, absence); err != nil {
+		return err
+	}
+
+	// Check if years are closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, existingAbsence.UserId,
+		existingAbsence.StartDate); err != nil {
+		return err
+	}
+	if err := checkDatesNotClosed(ctx, s.yRepo, absence.UserId, absence.StartDate); err != nil {
+		return err
+	}
+
+	// Create entries of absence
+	entries, err := s.createAbsenceEntries(ctx, absence)
+	if err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Delete old entries
+		if err := s.deleteAbsenceEntries(ctx, absence.Id); err != nil {
+			return err
+		}
+		// Update absence
+		if err := s.aRepo.UpdateAbsence(ctx, absence); err != nil {
+			return err
+		}
+		// Create new entries
+		return s.saveAbsenceEntries(ctx, absence, entries)
+	})
+}
+
+// DeleteAbsenceById deletes an absence and its entries.
+func (s *AbsenceService) DeleteAbsenceById(ctx context.Context, id int) error {
+	// Get existing absence
+	existingAbsence, err := s.aRepo.GetAbsenceById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Check if absence exists
+	if err := s.checkAbsenceExists(id, existingAbsence); err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, existingAbsence.UserId); err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, existingAbsence.UserId,
+		existingAbsence.StartDate); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Delete entries
+		if err := s.deleteAbsenceEntries(ctx, id); err != nil {
+			return err
+		}
+		// Delete absence
+		return s.aRepo.DeleteAbsenceById(ctx, id)
+	})
+}
+
+// DeleteAbsenceByIdAndUserId deletes an absence of an user and its entries.
+func (s *AbsenceService) DeleteAbsenceByIdAndUserId(ctx context.Context, id int, userId int,
+) error {
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, userId); err != nil {
+		return err
+	}
+
+	// Get existing absence
+	existingAbsence, err := s.aRepo.GetAbsenceById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existingAbsence != nil && existingAbsence.UserId != userId {
+		existingAbsence = nil
+	}
+
+	// Check if absence exists
+	if err := s.checkAbsenceExists(id, existingAbsence); err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, existingAbsence.UserId,
+		existingAbsence.StartDate); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Delete entries
+		if err := s.deleteAbsenceEntries(ctx, id); err != nil {
+			return err
+		}
+		// Delete absence
+		return s.aRepo.DeleteAbsenceById(ctx, id)
+	})
+}
+
+func (s *AbsenceService) setAbsenceEntryIds(ctx context.Context, absence *model.Absence) error {
+	entries, err := s.eRepo.GetEntriesByAbsenceId(ctx, absence.Id)
+	if err != nil {
+		return err
+	}
+	absence.EntryIds = make([]int, 0, len(entries))
+	for _, entry := range entries {
+		absence.EntryIds = append(absence.EntryIds, entry.Id)
+	}
+	return nil
+}
+
+func (s *AbsenceService) createAbsenceEntries(ctx context.Context, absence *model.Absence) (
+	[]*model.Entry, error) {
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, absence.UserId)
+	if err != nil {
+		return nil, err
+	}
+	var workingHours []model.ContractWorkingHours
+	if contract != nil {
+		workingHours = make([]model.ContractWorkingHours, len(contract.WorkingHours))
+		copy(workingHours, contract.WorkingHours)
+		sort.SliceStable(workingHours, func(i, j int) bool {
+			return workingHours[i].FirstDay.Before(workingHours[j].FirstDay)
+		})
+	}
+
+	// Get location of the user (entries are created in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, absence.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get holidays
+	holidays, err := s.getHolidays(ctx, absence, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create an entry for every working day
+	var entries []*model.Entry
+	for d := absence.StartDate; !d.After(absence.EndDate); d = d.AddDate(0, 0, 1) {
+		// Skip days before contract start, weekend days and holidays
+		if contract == nil || util.ToCivilDate(d).Before(contract.FirstDay) ||
+			d.Weekday() == time.Saturday || d.Weekday() == time.Sunday ||
+			holidays[d.Format(notificationDateFormat)] {
+			continue
+		}
+
+		// Get working hours
+		hours := findWorkingHoursForDate(workingHours, d) * absence.GetDayFactor()
+		if hours <= 0 {
+			continue
+		}
+
+		entry := model.NewEntry()
+		entry.UserId = absence.UserId
+		entry.TypeId = absence.TypeId
+		entry.StartTime = time.Date(d.Year(), d.Month(), d.Day(), model.AbsenceEntryStartHour, 0, 0,
+			0, loc)
+		entry.EndTime = entry.StartTime.Add(time.Duration(float64(hours) * float64(time.Hour)).
+			Round(time.Minute))
+		entry.Description = absence.Description
+		entries = append(entries, entry)
+	}
+
+	// Check if there is at least one working day
+	if len(entries) == 0 {
+		err := e.NewError(e.LogicAbsenceWithoutWorkDays, fmt.Sprintf("The absence from %s to %s "+
+			"contains no working days.", absence.StartDate, absence.EndDate))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *AbsenceService) getHolidays(ctx context.Context, absence *model.Absence,
+	loc *time.Location) (map[string]bool, error) {
+	holidays := make(map[string]bool)
+
+	// Get holiday type (there are no holidays if it does not exist)
+	holidayType, err := getEntryTypeByName(ctx, s.eRepo, model.EntryTypeNameHoliday)
+	if err != nil {
+		return nil, err
+	}
+	if holidayType == nil {
+		return holidays, nil
+	}
+
+	sd, ed := absence.StartDate, absence.EndDate
+	filter := model.NewFieldEntryFilter()
+	filter.SetUserFilter(absence.UserId)
+	filter.ByType = true
+	filter.TypeId = holidayType.Id
+	filter.ByTime = true
+	filter.StartTime = time.Date(sd.Year(), sd.Month(), sd.Day(), 0, 0, 0, 0, loc)
+	filter.EndTime = time.Date(ed.Year(), ed.Month(), ed.Day()+1, 0, 0, 0, 0, loc).
+		Add(-time.Second)
+	entries, err := s.eRepo.GetEntries(ctx, filter, nil, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		holidays[entry.StartTime.In(loc).Format(notificationDateFormat)] = true
+	}
+	return holidays, nil
+}
+
+func (s *AbsenceService) insertAbsence(ctx context.Context, absence *model.Absence,
+	entries []*model.Entry) error {
+	// Create absence
+	if err := s.aRepo.CreateAbsence(ctx, absence); err != nil {
+		return err
+	}
+	// Create entries
+	return s.saveAbsenceEntries(ctx, absence, entries)
+}
+
+func (s *AbsenceService) saveAbsenceEntries(ctx context.Context, absence *model.Absence,
+	entries []*model.Entry) error {
+	absence.EntryIds = make([]int, 0, len(entries))
+	for _, entry := range entries {
+		entry.AbsenceId = absence.Id
+		if err := s.eRepo.CreateEntry(ctx, entry); err != nil {
+			return err
+		}
+		if err := s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryCreated, entry); err != nil {
+			return err
+		}
+		absence.EntryIds = append(absence.EntryIds, entry.Id)
+	}
+	return nil
+}
+
+func (s *AbsenceService) deleteAbsenceEntries(ctx context.Context, absenceId int) error {
+	entries, err := s.eRepo.GetEntriesByAbsenceId(ctx, absenceId)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := s.eRepo.DeleteEntryById(ctx, entry.Id); err != nil {
+			return err
+		}
+		if err := s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryDeleted, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AbsenceService) checkAbsenceExists(id int, absence *model.Absence) error {
+	if absence == nil {
+		err := e.NewError(e.LogicAbsenceNotFound, fmt.Sprintf("Could not find absence %d.", id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *AbsenceService) checkAbsence(ctx context.Context, absence *model.Absence) error {
+	entryType, err := getEntryType(ctx, s.eRepo, absence.TypeId)
+	if err != nil {
+		return err
+	}
+	if entryType == nil || !entryType.IsAbsenceType() {
+		err := e.NewError(e.LogicAbsenceTypeInvalid, fmt.Sprintf("Entry type %d can not be used "+
+			"for absences.", absence.TypeId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	if absence.StartDate.After(absence.EndDate) {
+		err := e.NewError(e.LogicEntryDateIntervalInvalid, fmt.Sprintf("End date %s before "+
+			"start date %s.", absence.EndDate, absence.StartDate))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if absence.StartDate.AddDate(0, 0, model.AbsenceMaxDays-1).Before(absence.EndDate) {
+		err := e.NewError(e.LogicEntryDateIntervalInvalid, fmt.Sprintf("Absence from %s to %s "+
+			"exceeds %d days.", absence.StartDate, absence.EndDate, model.AbsenceMaxDays))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
+
+// --- Permission helper functions ---
+
+func (s *AbsenceService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
+
+func (s *AbsenceService) checkHasCurrentUserChangeRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightChangeOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightChangeAllEntries)
+	}
+}
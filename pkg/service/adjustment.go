@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// AdjustmentService contains balance adjustment related logic.
+type AdjustmentService struct {
+	service
+	bRepo *repo.AdjustmentRepo
+	uRepo *repo.UserRepo
+	yRepo *repo.ClosingRepo
+}
+
+// NewAdjustmentService create a new adjustment service.
+func NewAdjustmentService(tm *tx.TransactionManager, br *repo.AdjustmentRepo,
+	ur *repo.UserRepo, yr *repo.ClosingRepo) *AdjustmentService {
+	return &AdjustmentService{service{tm}, br, ur, yr}
+}
+
+// GetBalanceAdjustmentsByUserId gets all balance adjustments of a user (ordered by date).
+func (s *AdjustmentService) GetBalanceAdjustmentsByUserId(ctx context.Context, userId int) (
+	[]*model.BalanceAdjustment, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get adjustments
+	return s.bRepo.GetBalanceAdjustmentsByUserId(ctx, userId)
+}
+
+// GetBalanceAdjustmentById gets a balance adjustment by its ID.
+func (s *AdjustmentService) GetBalanceAdjustmentById(ctx context.Context, id int) (
+	*model.BalanceAdjustment, error) {
+	// Get adjustment
+	adjustment, err := s.bRepo.GetBalanceAdjustmentById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment == nil {
+		return nil, nil
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, adjustment.UserId); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// CreateBalanceAdjustment creates a new balance adjustment. The current user is recorded as
+// creator.
+func (s *AdjustmentService) CreateBalanceAdjustment(ctx context.Context,
+	adjustment *model.BalanceAdjustment) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserData); err != nil {
+		return err
+	}
+
+	// Check if user exists
+	exist, err := s.uRepo.ExistsUserById(ctx, adjustment.UserId)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		err := e.NewError(e.LogicUserNotFound, fmt.Sprintf("Could not find user %d.",
+			adjustment.UserId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, adjustment.UserId, adjustment.Date); err != nil {
+		return err
+	}
+
+	// Create adjustment
+	adjustment.CreatedByUserId = getCurrentUserId(ctx)
+	adjustment.CreatedAt = time.Now()
+	return s.bRepo.CreateBalanceAdjustment(ctx, adjustment)
+}
+
+// DeleteBalanceAdjustmentById deletes a balance adjustment by its ID.
+func (s *AdjustmentService) DeleteBalanceAdjustmentById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserData); err != nil {
+		return err
+	}
+
+	// Check if adjustment exists
+	adjustment, err := s.bRepo.GetBalanceAdjustmentById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if adjustment == nil {
+		err := e.NewError(e.LogicBalanceAdjustmentNotFound, fmt.Sprintf("Could not find balance "+
+			"adjustment %d.", id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, adjustment.UserId, adjustment.Date); err != nil {
+		return err
+	}
+
+	// Delete adjustment
+	return s.bRepo.DeleteBalanceAdjustmentById(ctx, id)
+}
+
+// --- Permission helper functions ---
+
+func (s *AdjustmentService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/storage"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+const attachmentStorageKeyLength = 32
+const attachmentDefaultFileName = "attachment"
+
+// Margin for the multipart form which wraps an uploaded attachment.
+const attachmentFormOverhead = 64 * 1024
+
+// AttachmentService contains attachment related logic.
+type AttachmentService struct {
+	service
+	uRepo     *repo.UserRepo
+	eRepo     *repo.EntryRepo
+	yRepo     *repo.ClosingRepo
+	fRepo     *repo.AttachmentRepo
+	storage   storage.Storage
+	maxSize   int64
+	mimeTypes []string
+}
+
+// NewAttachmentService create a new attachment service. The maximum size of attachments is
+// supplied in megabytes.
+func NewAttachmentService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+	yr *repo.ClosingRepo, fr *repo.AttachmentRepo, st storage.Storage, maxSize int,
+	mimeTypes []string) *AttachmentService {
+	return &AttachmentService{service{tm}, ur, er, yr, fr, st, int64(maxSize) * 1024 * 1024,
+		mimeTypes}
+}
+
+// MaxRequestSize returns the maximum size (in bytes) of a request which uploads an attachment. It is
+// the maximum size of attachments plus a margin for the multipart form.
+func (s *AttachmentService) MaxRequestSize() int64 {
+	return s.maxSize + attachmentFormOverhead
+}
+
+// --- Attachment functions ---
+
+// GetAttachmentsByEntryId gets all attachments of an entry.
+func (s *AttachmentService) GetAttachmentsByEntryId(ctx context.Context, entryId int) (
+	[]*model.Attachment, error) {
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, entry.UserId); err != nil {
+		return nil, err
+	}
+
+	// Get attachments
+	return s.fRepo.GetAttachmentsByEntryId(ctx, entryId)
+}
+
+// GetAttachmentCountsByEntries gets the number of attachments of the supplied entries (by entry
+// ID).
+func (s *AttachmentService) GetAttachmentCountsByEntries(ctx context.Context,
+	entries []*model.Entry) (map[int]int, error) {
+	// Check permissions
+	entryIds := make([]int, 0, len(entries))
+	checkedUserIds := make(map[int]bool)
+	for _, entry := range entries {
+		if !checkedUserIds[entry.UserId] {
+			if err := s.checkHasCurrentUserGetRight(ctx, entry.UserId); err != nil {
+				return nil, err
+			}
+			checkedUserIds[entry.UserId] = true
+		}
+		entryIds = append(entryIds, entry.Id)
+	}
+
+	// Get attachment counts
+	return s.fRepo.GetAttachmentCountsByEntryIds(ctx, entryIds)
+}
+
+// GetAttachmentById gets an attachment by its ID.
+func (s *AttachmentService) GetAttachmentById(ctx context.Context, id int) (*model.Attachment,
+	error) {
+	// Get attachment
+	attachment, err := s.fRepo.GetAttachmentById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, nil
+	}
+
+	// Get entry (attachments of deleted entries are not available anymore)
+	entry, err := s.eRepo.GetEntryById(ctx, attachment.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, entry.UserId); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// OpenAttachmentById gets an attachment by its ID and opens its file. The caller must close the
+// returned reader.
+func (s *AttachmentService) OpenAttachmentById(ctx context.Context, id int) (*model.Attachment,
+	io.ReadCloser, error) {
+	// Get attachment
+	attachment, err := s.GetAttachmentById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil {
+		return nil, nil, s.createAttachmentNotFoundError(id)
+	}
+
+	// Open file
+	r, err := s.storage.Open(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, r, nil
+}
+
+// CreateAttachment creates a new attachment with the content of the supplied reader. The MIME
+// type is detected from the content and must be one of the allowed MIME types.
+func (s *AttachmentService) CreateAttachment(ctx context.Context, attachment *model.Attachment,
+	content io.Reader) error {
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, attachment.EntryId)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, entry.UserId); err != nil {
+		return err
+	}
+
+	// Check if entry can be changed
+	if err := s.checkEntryChangeable(ctx, entry); err != nil {
+		return err
+	}
+
+	// Read content (one byte more than allowed to detect too large files)
+	data, rErr := io.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if rErr != nil {
+		err := e.WrapError(e.SysStorageFailed, "Could not read attachment content.", rErr)
+		log.Error(err.StackTrace())
+		return err
+	}
+	if int64(len(data)) > s.maxSize {
+		err := e.NewError(e.LogicAttachmentTooLarge, fmt.Sprintf("Attachment must not be larger "+
+			"than %d bytes.", s.maxSize))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check MIME type
+	mimeType := detectMimeType(data)
+	if !slices.Contains(s.mimeTypes, mimeType) {
+		err := e.NewError(e.LogicAttachmentTypeNotAllowed, fmt.Sprintf("Attachments of type '%s' "+
+			"are not allowed.", mimeType))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Store file
+	attachment.FileName = sanitizeFileName(attachment.FileName)
+	attachment.MimeType = mimeType
+	attachment.Size = int64(len(data))
+	attachment.StorageKey = util.GenerateRandomString(attachmentStorageKeyLength)
+	attachment.CreatedAt = time.Now()
+	if err := s.storage.Save(attachment.StorageKey, bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	// Create attachment (if this fails, the stored file is removed again)
+	if err := s.fRepo.CreateAttachment(ctx, attachment); err != nil {
+		if dErr := s.storage.Delete(attachment.StorageKey); dErr != nil {
+			log.Errorf("Could not remove file of failed attachment: %s", dErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteAttachmentById deletes an attachment and its file.
+func (s *AttachmentService) DeleteAttachmentById(ctx context.Context, id int) error {
+	// Get attachment
+	attachment, err := s.fRepo.GetAttachmentById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return s.createAttachmentNotFoundError(id)
+	}
+
+	// Get entry
+	entry, err := s.eRepo.GetEntryById(ctx, attachment.EntryId)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return s.createAttachmentNotFoundError(id)
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, entry.UserId); err != nil {
+		return err
+	}
+
+	// Check if entry can be changed
+	if err := s.checkEntryChangeable(ctx, entry); err != nil {
+		return err
+	}
+
+	// Delete attachment
+	return s.deleteAttachment(ctx, attachment)
+}
+
+// DeleteOrphanedAttachments deletes all attachments (and their files) of deleted entries.
+func (s *AttachmentService) DeleteOrphanedAttachments(ctx context.Context) error {
+	// Get orphaned attachments
+	attachments, err := s.fRepo.GetOrphanedAttachments(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Delete attachments
+	for _, attachment := range attachments {
+		if err := s.deleteAttachment(ctx, attachment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AttachmentService) deleteAttachment(ctx context.Context,
+	attachment *model.Attachment) error {
+	// Delete record first, a remaining file is harmless, a remaining record is not
+	if err := s.fRepo.DeleteAttachmentById(ctx, attachment.Id); err != nil {
+		return err
+	}
+	return s.storage.Delete(attachment.StorageKey)
+}
+
+func (s *AttachmentService) getExistingEntry(ctx context.Context, entryId int) (*model.Entry,
+	error) {
+	entry, err := s.eRepo.GetEntryById(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		err := e.NewError(e.LogicEntryNotFound, fmt.Sprintf("Could not find entry %d.", entryId))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return entry, nil
+}
+
+// checkEntryChangeable checks that the attachments of an entry can be changed. Like the entry
+// itself, they must not be changed if the entry was invoiced or its year was closed.
+func (s *AttachmentService) checkEntryChangeable(ctx context.Context, entry *model.Entry) error {
+	if err := checkEntryNotInvoiced(entry); err != nil {
+		return err
+	}
+	return checkTimesNotClosed(ctx, s.uRepo, s.yRepo, entry.UserId, entry.StartTime)
+}
+
+func (s *AttachmentService) createAttachmentNotFoundError(id int) error {
+	err := e.NewError(e.LogicAttachmentNotFound, fmt.Sprintf("Could not find attachment %d.", id))
+	log.Debug(err.StackTrace())
+	return err
+}
+
+func detectMimeType(data []byte) string {
+	mimeType, _, pErr := mime.ParseMediaType(http.DetectContentType(data))
+	if pErr != nil {
+		return "application/octet-stream"
+	}
+	return mimeType
+}
+
+func sanitizeFileName(fileName string) string {
+	// Remove directories (browsers on Windows may send the full path)
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return attachmentDefaultFileName
+	}
+
+	// Shorten too long names (without breaking multi-byte characters)
+	for len(fileName) > model.MaxLengthAttachmentFileName {
+		_, size := utf8.DecodeLastRuneInString(fileName)
+		fileName = fileName[:len(fileName)-size]
+	}
+	return fileName
+}
+
+// --- Permission helper functions ---
+
+func (s *AttachmentService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
+
+func (s *AttachmentService) checkHasCurrentUserChangeRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightChangeOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightChangeAllEntries)
+	}
+}
@@ -0,0 +1,567 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// ClosingService contains year closing and balance related logic.
+type ClosingService struct {
+	service
+	yRepo *repo.ClosingRepo
+	cRepo *repo.ContractRepo
+	bRepo *repo.AdjustmentRepo
+	eServ *EntryService
+	vServ *VacationService
+}
+
+// NewClosingService create a new closing service.
+func NewClosingService(tm *tx.TransactionManager, yr *repo.ClosingRepo, cr *repo.ContractRepo,
+	br *repo.AdjustmentRepo, es *EntryService, vs *VacationService) *ClosingService {
+	return &ClosingService{service{tm}, yr, cr, br, es, vs}
+}
+
+// --- Year closing functions ---
+
+// GetYearClosingsByUserId gets all year closings of a user (ordered by year).
+func (s *ClosingService) GetYearClosingsByUserId(ctx context.Context, userId int) (
+	[]*model.YearClosing, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get closings
+	return s.yRepo.GetYearClosingsByUserId(ctx, userId)
+}
+
+// CloseYear closes a year of a user. The overtime and vacation balances at the end of the year are
+// stored and used as starting point for later calculations. Years must be closed in order,
+// starting with the year of the first work day.
+func (s *ClosingService) CloseYear(ctx context.Context, userId int, year int) (
+	*model.YearClosing, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserData); err != nil {
+		return nil, err
+	}
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil {
+		return nil, s.createYearClosingInvalidError(fmt.Sprintf("User %d has no contract.",
+			userId))
+	}
+
+	// Get latest closing
+	latestClosing, err := s.yRepo.GetLatestYearClosingByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check year
+	nextYear := contract.FirstDay.Year()
+	if latestClosing != nil {
+		nextYear = latestClosing.Year + 1
+	}
+	if year != nextYear {
+		return nil, s.createYearClosingInvalidError(fmt.Sprintf("Year %d can not be closed. "+
+			"The next year to close is %d.", year, nextYear))
+	}
+	loc, err := getUserLocation(ctx, s.eServ.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+	if year >= time.Now().In(loc).Year() {
+		return nil, s.createYearClosingInvalidError(fmt.Sprintf("Year %d has not yet ended.",
+			year))
+	}
+
+	// Calculate balances at the end of the year
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	overtimeHours, err := s.getOvertimeBalance(ctx, userId, contract, latestClosing, end, end)
+	if err != nil {
+		return nil, err
+	}
+	vacation, err := s.getVacationLedgerYear(ctx, userId, year, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create closing
+	closing := model.NewYearClosing()
+	closing.UserId = userId
+	closing.Year = year
+	closing.OvertimeHours = overtimeHours
+	closing.Vacation = vacation
+	closing.ClosedByUserId = getCurrentUserId(ctx)
+	closing.ClosedAt = time.Now()
+	if err := s.yRepo.CreateYearClosing(ctx, closing); err != nil {
+		return nil, err
+	}
+
+	return closing, nil
+}
+
+// ReopenYear reopens a closed year of a user. Only the latest closed year can be reopened.
+func (s *ClosingService) ReopenYear(ctx context.Context, userId int, year int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserData); err != nil {
+		return err
+	}
+
+	// Get latest closing
+	latestClosing, err := s.yRepo.GetLatestYearClosingByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	// Check year
+	if latestClosing == nil || year > latestClosing.Year {
+		err := e.NewError(e.LogicYearClosingNotFound, fmt.Sprintf("Could not find year closing "+
+			"%d of user %d.", year, userId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if year != latestClosing.Year {
+		return s.createYearClosingInvalidError(fmt.Sprintf("Year %d can not be reopened. Only "+
+			"the latest closed year %d can be reopened.", year, latestClosing.Year))
+	}
+
+	// Delete closing
+	return s.yRepo.DeleteYearClosing(ctx, userId, year)
+}
+
+func (s *ClosingService) createYearClosingInvalidError(msg string) error {
+	err := e.NewError(e.LogicYearClosingInvalid, msg)
+	log.Debug(err.StackTrace())
+	return err
+}
+
+// --- Balance functions ---
+
+// GetOvertimeBalanceByUserId gets the current overtime balance (in hours) of a user. The balance
+// is calculated from the latest closed year.
+func (s *ClosingService) GetOvertimeBalanceByUserId(ctx context.Context, userId int) (float32,
+	error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return 0.0, err
+	}
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return 0.0, err
+	}
+	if contract == nil {
+		return 0.0, nil
+	}
+
+	// Get latest closing
+	latestClosing, err := s.yRepo.GetLatestYearClosingByUserId(ctx, userId)
+	if err != nil {
+		return 0.0, err
+	}
+
+	// Calculate balance (the target hours of today are not yet due, the work of today is counted)
+	loc, err := getUserLocation(ctx, s.eServ.uRepo, userId)
+	if err != nil {
+		return 0.0, err
+	}
+	today := getDayStart(time.Now().In(loc))
+	return s.getOvertimeBalance(ctx, userId, contract, latestClosing, today,
+		today.AddDate(0, 0, 1))
+}
+
+// GetYearOverviewByUserId gets the year overview of a user. The overview contains the target,
+// actual and overtime hours as well as the vacation and illness days of each month. The overtime
+// balance is accumulated from the balance at the start of the year until today.
+func (s *ClosingService) GetYearOverviewByUserId(ctx context.Context, userId int, year int) (
+	*model.YearOverview, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get contract (without a contract there are no target hours and no vacation days)
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil {
+		contract = model.NewContract()
+	}
+
+	overview := model.NewYearOverview()
+	overview.UserId = userId
+	overview.Year = year
+	overview.Months = make([]*model.YearOverviewMonth, 0, 12)
+
+	// Get location of the user (months and days are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.eServ.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate overtime balance at the start of the year
+	overview.StartOvertimeHours, err = s.getYearStartOvertimeBalance(ctx, userId, contract, year,
+		loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get vacation entitlement of the year (there is no ledger for years in the future)
+	now := time.Now().In(loc)
+	vacation := model.NewVacationLedgerYear()
+	if year <= now.Year() {
+		vacation, err = s.getVacationLedgerYear(ctx, userId, year, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	overview.VacationEntitledDays = vacation.CarriedOverDays + vacation.AccruedDays +
+		vacation.AdjustedDays
+
+	// Get monthly work summaries
+	workSummaries, err := s.eServ.getYearMonthWorkSummaries(ctx, userId, year)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entry types and adjustments
+	entryTypesMap, err := getEntryTypesMap(ctx, s.eServ.eRepo)
+	if err != nil {
+		return nil, err
+	}
+	adjustments, err := s.bRepo.GetBalanceAdjustmentsByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate months (the target hours of today are not yet due, the work of today is counted)
+	today := getDayStart(now)
+	tomorrow := today.AddDate(0, 0, 1)
+	workingHours := sortContractWorkingHours(contract.WorkingHours)
+	for i, workSummary := range workSummaries {
+		om := model.NewYearOverviewMonth()
+		om.Month = time.Month(i + 1)
+		start := time.Date(year, om.Month, 1, 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 1, 0)
+		om.IsDue = !start.After(today)
+
+		// Calculate target hours (there is no target before the first work day)
+		targetStart := start
+		if contract.FirstDay.After(util.ToCivilDate(start)) {
+			targetStart = contract.FirstDay
+		}
+		dueEnd := end
+		if today.Before(end) {
+			dueEnd = today
+		}
+		om.TargetHours = durationToHours(calculateTargetDuration(workingHours, targetStart, end))
+		om.DueTargetHours = durationToHours(calculateTargetDuration(workingHours, targetStart,
+			dueEnd))
+
+		// Calculate actual hours and absence hours
+		var actualDuration, vacationDuration, illnessDuration time.Duration
+		for _, wd := range workSummary.WorkDurations {
+			entryType, ok := entryTypesMap[wd.TypeId]
+			if !ok {
+				continue
+			}
+			if entryType.IsCredited() {
+				actualDuration = actualDuration + wd.WorkDuration
+			}
+			if entryType.ConsumesVacation {
+				vacationDuration = vacationDuration + wd.WorkDuration
+			}
+			if entryType.IsIllness() {
+				illnessDuration = illnessDuration + wd.WorkDuration
+			}
+		}
+		om.ActualHours = durationToHours(actualDuration - workSummary.BreakDeduction)
+
+		// Calculate adjusted hours (adjustments are considered from their effective date)
+		var adjustedDuration time.Duration
+		for _, a := range adjustments {
+			if a.Type != model.BalanceAdjustmentTypeOvertime ||
+				a.Date.Before(util.ToCivilDate(start)) || !a.Date.Before(util.ToCivilDate(end)) ||
+				!a.Date.Before(util.ToCivilDate(tomorrow)) {
+				continue
+			}
+			adjustedDuration = adjustedDuration + hoursToDuration(a.Value)
+		}
+		om.AdjustedHours = durationToHours(adjustedDuration)
+
+		// Convert absence hours to days (with the working hours at the start of the month)
+		om.VacationTakenDays = durationToDays(workingHours, start, vacationDuration)
+		om.IllnessDays = durationToDays(workingHours, start, illnessDuration)
+
+		overview.Months = append(overview.Months, om)
+	}
+
+	// Calculate running balances
+	s.calculateYearOverviewBalances(overview, contract, vacation, now)
+
+	return overview, nil
+}
+
+// getYearStartOvertimeBalance calculates the overtime balance at the start of a year (in the
+// supplied location). If the previous year is closed, the balance of its closing is used.
+func (s *ClosingService) getYearStartOvertimeBalance(ctx context.Context, userId int,
+	contract *model.Contract, year int, loc *time.Location) (float32, error) {
+	// Abort if the year is before the first work day
+	if contract.FirstDay.IsZero() || year < contract.FirstDay.Year() {
+		return 0.0, nil
+	}
+
+	// Find latest closing before the year
+	closings, err := s.yRepo.GetYearClosingsByUserId(ctx, userId)
+	if err != nil {
+		return 0.0, err
+	}
+	var closing *model.YearClosing
+	for _, c := range closings {
+		if c.Year < year {
+			closing = c
+		}
+	}
+	if closing != nil && closing.Year == year-1 {
+		return closing.OvertimeHours, nil
+	}
+
+	// Calculate balance
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return s.getOvertimeBalance(ctx, userId, contract, closing, start, start)
+}
+
+// calculateYearOverviewBalances calculates the running overtime and vacation balances of the months
+// of a year overview. Carried over vacation days which were not taken until their expiry date
+// expire in the last month before the expiry date, days exceeding the carry-over limit expire in
+// December.
+func (s *ClosingService) calculateYearOverviewBalances(overview *model.YearOverview,
+	contract *model.Contract, vacation *model.VacationLedgerYear, now time.Time) {
+	// Distribute expired vacation days
+	expiredDays := vacation.ExpiredDays
+	if contract.VacationRules != nil && overview.Year > contract.FirstDay.Year() &&
+		expiredDays > 0 {
+		expiryDate := contract.VacationRules.GetCarryOverExpiryDate(overview.Year, now.Location())
+		lastDay := expiryDate.AddDate(0, 0, -1)
+		if !expiryDate.IsZero() && !now.Before(expiryDate) && lastDay.Year() == overview.Year {
+			takenDays := float32(0.0)
+			for _, om := range overview.Months[:lastDay.Month()] {
+				takenDays = takenDays + om.VacationTakenDays
+			}
+			carryOverExpiredDays := min(vacation.CarriedOverDays-takenDays, expiredDays)
+			if carryOverExpiredDays > 0 {
+				overview.Months[lastDay.Month()-1].VacationExpiredDays = carryOverExpiredDays
+				expiredDays = expiredDays - carryOverExpiredDays
+			}
+		}
+	}
+	overview.Months[len(overview.Months)-1].VacationExpiredDays += expiredDays
+
+	// Calculate running balances
+	overtimeHours := overview.StartOvertimeHours
+	remainingDays := overview.VacationEntitledDays
+	for _, om := range overview.Months {
+		if om.IsDue {
+			overtimeHours = overtimeHours + om.GetBalanceHours()
+			om.OvertimeHours = overtimeHours
+		}
+		remainingDays = remainingDays - om.VacationTakenDays - om.VacationExpiredDays
+		om.VacationRemainDays = remainingDays
+	}
+}
+
+// getOvertimeBalance calculates the overtime balance from the supplied closing (or the first work
+// day if there is none). Target hours are counted until targetEnd, work hours and adjustments
+// until workEnd (both exclusive). The days are determined in the location of workEnd.
+func (s *ClosingService) getOvertimeBalance(ctx context.Context, userId int,
+	contract *model.Contract, closing *model.YearClosing, targetEnd time.Time,
+	workEnd time.Time) (float32, error) {
+	// Abort if no working hours were set
+	if len(contract.WorkingHours) == 0 {
+		return 0.0, nil
+	}
+
+	// Get start balance
+	start := contract.FirstDay
+	workStart := time.Time{}
+	startHours := contract.InitOvertimeHours
+	if closing != nil {
+		start = closing.GetEndDate(workEnd.Location())
+		workStart = start
+		startHours = closing.OvertimeHours
+	}
+	startDuration := hoursToDuration(startHours)
+
+	// Calculate adjusted duration (adjustments are considered from their effective date)
+	adjustments, err := s.bRepo.GetBalanceAdjustmentsByUserId(ctx, userId)
+	if err != nil {
+		return 0.0, err
+	}
+	var adjustedDuration time.Duration
+	for _, a := range adjustments {
+		if a.Type != model.BalanceAdjustmentTypeOvertime || !a.Date.Before(util.ToCivilDate(workEnd)) ||
+			(closing != nil && closing.IsClosed(a.Date)) {
+			continue
+		}
+		adjustedDuration = adjustedDuration + hoursToDuration(a.Value)
+	}
+
+	// Calculate actual duration
+	workSummary, err := s.eServ.getWorkSummary(ctx, userId, workStart, workEnd)
+	if err != nil {
+		return 0.0, err
+	}
+	var actualWorkDuration time.Duration
+	for _, workDuration := range workSummary.WorkDurations {
+		actualWorkDuration = actualWorkDuration + workDuration.WorkDuration
+	}
+	actualWorkDuration = actualWorkDuration - workSummary.BreakDeduction
+
+	// Calculate target duration
+	workingHours := sortContractWorkingHours(contract.WorkingHours)
+	targetWorkDuration := calculateTargetDuration(workingHours, start, targetEnd)
+
+	// Calculate overtime
+	overtimeDuration := startDuration + adjustedDuration + actualWorkDuration - targetWorkDuration
+	return float32(overtimeDuration.Round(time.Minute).Hours()), nil
+}
+
+func (s *ClosingService) getVacationLedgerYear(ctx context.Context, userId int, year int,
+	end time.Time) (*model.VacationLedgerYear, error) {
+	// Get ledger
+	ledger, _, err := s.vServ.getVacationLedger(ctx, userId, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find year (there is no ledger if no vacation days were set)
+	for _, ly := range ledger {
+		if ly.Year == year {
+			return ly, nil
+		}
+	}
+	ly := model.NewVacationLedgerYear()
+	ly.Year = year
+	return ly, nil
+}
+
+// --- Permission helper functions ---
+
+func (s *ClosingService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
+
+// --- Helper functions ---
+
+// checkTimesNotClosed checks that none of the supplied times lies inside a closed year of a user.
+// (The year of a time is determined in the time zone of the user.)
+func checkTimesNotClosed(ctx context.Context, uRepo *repo.UserRepo, yRepo *repo.ClosingRepo,
+	userId int, times ...time.Time) error {
+	loc, err := getUserLocation(ctx, uRepo, userId)
+	if err != nil {
+		return err
+	}
+	dates := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		dates = append(dates, t.In(loc))
+	}
+	return checkDatesNotClosed(ctx, yRepo, userId, dates...)
+}
+
+// checkDatesNotClosed checks that none of the supplied dates lies inside a closed year of a user.
+// The year of a date is determined in its location.
+func checkDatesNotClosed(ctx context.Context, yRepo *repo.ClosingRepo, userId int,
+	dates ...time.Time) error {
+	// Get latest closing
+	closing, err := yRepo.GetLatestYearClosingByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if closing == nil {
+		return nil
+	}
+
+	// Check dates
+	for _, date := range dates {
+		if closing.IsClosed(date) {
+			err := e.NewError(e.LogicYearClosed, fmt.Sprintf("Year %d of user %d is closed.",
+				date.Year(), userId))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+	return nil
+}
+
+// calculateTargetDuration calculates the target duration of a period with the supplied (sorted)
+// working hours. The first working hours are also used before their first day. (The days of start
+// and end are determined in their locations.)
+func calculateTargetDuration(workingHours []model.ContractWorkingHours, start time.Time,
+	end time.Time) time.Duration {
+	start = util.ToCivilDate(start)
+	end = util.ToCivilDate(end)
+	targetWorkDuration := time.Duration(0)
+	for i, wh := range workingHours {
+		// Calculate interval start/end
+		intStart := start
+		if i > 0 && wh.FirstDay.After(start) {
+			intStart = wh.FirstDay
+		}
+		intEnd := end
+		if i+1 < len(workingHours) && workingHours[i+1].FirstDay.Before(end) {
+			intEnd = workingHours[i+1].FirstDay.AddDate(0, 0, -1)
+		}
+		if !intStart.Before(intEnd) {
+			continue
+		}
+
+		// Calculate interval target duration
+		intWorkDays := util.CalculateWorkingDays(intStart, intEnd)
+		targetWorkDuration = targetWorkDuration + time.Duration(intWorkDays)*hoursToDuration(wh.Hours)
+	}
+	return targetWorkDuration
+}
+
+// durationToDays converts a duration to days with the working hours at the supplied date. Durations
+// before the first working hours are converted with the first working hours.
+func durationToDays(workingHours []model.ContractWorkingHours, date time.Time,
+	duration time.Duration) float32 {
+	if len(workingHours) == 0 || duration == 0 {
+		return 0.0
+	}
+	wh := findWorkingHoursForDate(workingHours, date)
+	if wh <= 0 {
+		wh = workingHours[0].Hours
+	}
+	if wh <= 0 {
+		return 0.0
+	}
+	return float32(duration.Hours()) / wh
+}
+
+func durationToHours(d time.Duration) float32 {
+	return float32(d.Round(time.Minute).Hours())
+}
+
+func hoursToDuration(hours float32) time.Duration {
+	return time.Duration(int(hours*60.0)) * time.Minute
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// complianceDay stores the work entries of a single day.
+type complianceDay struct {
+	date         time.Time
+	entries      []*model.Entry
+	workDuration time.Duration
+	start        time.Time
+	end          time.Time
+}
+
+// complianceRule is an interface for working time compliance rules.
+type complianceRule interface {
+	check(userId int, days []*complianceDay) []*model.ComplianceViolation
+}
+
+// ComplianceService contains working time compliance related logic.
+type ComplianceService struct {
+	service
+	uRepo *repo.UserRepo
+	eRepo *repo.EntryRepo
+
+	rules []complianceRule
+}
+
+// NewComplianceService create a new compliance service.
+func NewComplianceService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+	settings *model.ComplianceSettings) *ComplianceService {
+	return &ComplianceService{service{tm}, ur, er, createComplianceRules(settings)}
+}
+
+func createComplianceRules(settings *model.ComplianceSettings) []complianceRule {
+	var rules []complianceRule
+	if settings.MaxDailyHours > 0 {
+		rules = append(rules, &maxDailyHoursRule{time.Duration(settings.MaxDailyHours) * time.Hour})
+	}
+	if settings.MaxWeeklyHours > 0 {
+		rules = append(rules, &maxWeeklyHoursRule{time.Duration(settings.MaxWeeklyHours) *
+			time.Hour})
+	}
+	if settings.MinRestHours > 0 {
+		rules = append(rules, &minRestPeriodRule{time.Duration(settings.MinRestHours) * time.Hour})
+	}
+	if settings.CheckSundayWork {
+		rules = append(rules, &sundayWorkRule{})
+	}
+	if settings.NightStartHour != settings.NightEndHour {
+		rules = append(rules, &nightWorkRule{settings.NightStartHour, settings.NightEndHour})
+	}
+	return rules
+}
+
+// --- Compliance functions ---
+
+// GetComplianceViolationsByUserId gets the compliance violations of an user in a specific period.
+func (s *ComplianceService) GetComplianceViolationsByUserId(ctx context.Context, userId int,
+	start time.Time, end time.Time) ([]*model.ComplianceViolation, error) {
+	// Check permissions
+	if userId == getCurrentUserId(ctx) {
+		if err := checkHasCurrentUserRight(ctx, model.RightGetOwnEntries); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := checkHasCurrentUserRight(ctx, model.RightGetAllEntries); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get violations
+	return s.getComplianceViolations(ctx, userId, start, end)
+}
+
+// GetMonthComplianceReport gets a report of the compliance violations of all users in a month.
+func (s *ComplianceService) GetMonthComplianceReport(ctx context.Context, year int,
+	month time.Month) (*model.ComplianceReport, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetAllEntries); err != nil {
+		return nil, err
+	}
+
+	// Get users
+	users, err := s.uRepo.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get violations of each user (the month is determined in the time zone of the user)
+	start := time.Date(year, month, 1, 0, 0, 0, 0, getCurrentUserLocation(ctx))
+	end := start.AddDate(0, 1, 0)
+	report := &model.ComplianceReport{
+		StartDate: start,
+		EndDate:   end.AddDate(0, 0, -1),
+		Users:     make([]*model.UserComplianceViolations, 0, len(users)),
+	}
+	for _, user := range users {
+		userStart := time.Date(year, month, 1, 0, 0, 0, 0, user.GetLocation())
+		userEnd := userStart.AddDate(0, 1, 0)
+		violations, err := s.getComplianceViolations(ctx, user.Id, userStart, userEnd)
+		if err != nil {
+			return nil, err
+		}
+		if len(violations) > 0 {
+			report.Users = append(report.Users, &model.UserComplianceViolations{
+				User:       user,
+				Violations: violations,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+func (s *ComplianceService) getComplianceViolations(ctx context.Context, userId int,
+	start time.Time, end time.Time) ([]*model.ComplianceViolation, error) {
+	// Get location of the user (days are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+
+	// Get entries (of complete weeks and the day before, so weekly limits and rest periods can be
+	// checked at the boundaries)
+	filter := model.NewFieldEntryFilter()
+	filter.SetUserFilter(userId)
+	filter.ByTime = true
+	filter.StartTime = getWeekStart(start).AddDate(0, 0, -1)
+	filter.EndTime = getWeekStart(end.AddDate(0, 0, -1)).AddDate(0, 0, 7).Add(-time.Second)
+	entries, err := s.eRepo.GetEntries(ctx, filter, nil, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entry types
+	entryTypesMap, err := getEntryTypesMap(ctx, s.eRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check rules
+	days := createComplianceDays(entries, entryTypesMap, loc)
+	violations := make([]*model.ComplianceViolation, 0, 10)
+	for _, rule := range s.rules {
+		for _, v := range rule.check(userId, days) {
+			if !v.Date.Before(start) && v.Date.Before(end) {
+				violations = append(violations, v)
+			}
+		}
+	}
+
+	// Sort violations by date
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Date.Before(violations[j].Date)
+	})
+
+	return violations, nil
+}
+
+func createComplianceDays(entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
+	loc *time.Location) []*complianceDay {
+	// Sort entries (only entries which count as work time are checked)
+	sorted := make([]*model.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if model.IsWorkEntryType(entryTypesMap, entry.TypeId) {
+			sorted = append(sorted, entry)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	// Group entries by day
+	var days []*complianceDay
+	var day *complianceDay
+	for _, entry := range sorted {
+		date := getDayStart(entry.StartTime.In(loc))
+		if day == nil || !day.date.Equal(date) {
+			day = &complianceDay{date: date, start: entry.StartTime}
+			days = append(days, day)
+		}
+		day.entries = append(day.entries, entry)
+		day.workDuration = day.workDuration + entry.EndTime.Sub(entry.StartTime)
+		if entry.EndTime.After(day.end) {
+			day.end = entry.EndTime
+		}
+	}
+	return days
+}
+
+// --- Rules ---
+
+// maxDailyHoursRule reports days with more work hours than allowed.
+type maxDailyHoursRule struct {
+	limit time.Duration
+}
+
+func (r *maxDailyHoursRule) check(userId int, days []*complianceDay,
+) []*model.ComplianceViolation {
+	var violations []*model.ComplianceViolation
+	for _, day := range days {
+		if day.workDuration > r.limit {
+			violations = append(violations, &model.ComplianceViolation{UserId: userId,
+				Rule: model.ComplianceRuleMaxDailyHours, Date: day.date, Actual: day.workDuration,
+				Limit: r.limit})
+		}
+	}
+	return violations
+}
+
+// maxWeeklyHoursRule reports weeks with more work hours than allowed. The violation is reported
+// for the day on which the limit was exceeded.
+type maxWeeklyHoursRule struct {
+	limit time.Duration
+}
+
+func (r *maxWeeklyHoursRule) check(userId int, days []*complianceDay,
+) []*model.ComplianceViolation {
+	var violations []*model.ComplianceViolation
+	var weekStart time.Time
+	var weekDuration time.Duration
+	var exceeded bool
+	for _, day := range days {
+		// If new week: Reset week duration
+		if ws := getWeekStart(day.date); !ws.Equal(weekStart) {
+			weekStart = ws
+			weekDuration = 0
+			exceeded = false
+		}
+
+		// Check if limit is exceeded (only once per week)
+		weekDuration = weekDuration + day.workDuration
+		if !exceeded && weekDuration > r.limit {
+			exceeded = true
+			violations = append(violations, &model.ComplianceViolation{UserId: userId,
+				Rule: model.ComplianceRuleMaxWeeklyHours, Date: day.date, Actual: weekDuration,
+				Limit: r.limit})
+		} else if exceeded {
+			violations[len(violations)-1].Actual = weekDuration
+		}
+	}
+	return violations
+}
+
+// minRestPeriodRule reports days whose rest period since the previous work day is too short.
+type minRestPeriodRule struct {
+	limit time.Duration
+}
+
+func (r *minRestPeriodRule) check(userId int, days []*complianceDay,
+) []*model.ComplianceViolation {
+	var violations []*model.ComplianceViolation
+	for i := 1; i < len(days); i++ {
+		rest := days[i].start.Sub(days[i-1].end)
+		if rest < 0 {
+			rest = 0
+		}
+		if rest < r.limit {
+			violations = append(violations, &model.ComplianceViolation{UserId: userId,
+				Rule: model.ComplianceRuleMinRestPeriod, Date: days[i].date, Actual: rest,
+				Limit: r.limit})
+		}
+	}
+	return violations
+}
+
+// sundayWorkRule reports work on Sundays.
+type sundayWorkRule struct{}
+
+func (r *sundayWorkRule) check(userId int, days []*complianceDay,
+) []*model.ComplianceViolation {
+	var violations []*model.ComplianceViolation
+	for _, day := range days {
+		if day.date.Weekday() == time.Sunday {
+			violations = append(violations, &model.ComplianceViolation{UserId: userId,
+				Rule: model.ComplianceRuleSundayWork, Date: day.date, Actual: day.workDuration})
+		}
+	}
+	return violations
+}
+
+// nightWorkRule reports work during the night period.
+type nightWorkRule struct {
+	startHour int
+	endHour   int
+}
+
+func (r *nightWorkRule) check(userId int, days []*complianceDay,
+) []*model.ComplianceViolation {
+	var violations []*model.ComplianceViolation
+	for _, day := range days {
+		var nightDuration time.Duration
+		for _, entry := range day.entries {
+			// Check night periods which started on the previous day and on the current day
+			for _, d := range []time.Time{day.date.AddDate(0, 0, -1), day.date} {
+				nightStart, nightEnd := r.getNightPeriod(d)
+				nightDuration = nightDuration + getOverlapDuration(entry.StartTime, entry.EndTime,
+					nightStart, nightEnd)
+			}
+		}
+		if nightDuration > 0 {
+			violations = append(violations, &model.ComplianceViolation{UserId: userId,
+				Rule: model.ComplianceRuleNightWork, Date: day.date, Actual: nightDuration})
+		}
+	}
+	return violations
+}
+
+func (r *nightWorkRule) getNightPeriod(date time.Time) (time.Time, time.Time) {
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, r.startHour, 0, 0, 0, date.Location())
+	end := time.Date(y, m, d, r.endHour, 0, 0, 0, date.Location())
+	if r.endHour < r.startHour {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+func getOverlapDuration(start1 time.Time, end1 time.Time, start2 time.Time, end2 time.Time,
+) time.Duration {
+	start := start1
+	if start2.After(start) {
+		start = start2
+	}
+	end := end1
+	if end2.Before(end) {
+		end = end2
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
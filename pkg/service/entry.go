@@ -13,15 +13,22 @@ import (
 	"kellnhofer.com/work-log/pkg/model"
 )
 
+const entryChangesSafetyLag = 5 * time.Second
+
 // EntryService contains entry related logic.
 type EntryService struct {
 	service
+	uRepo *repo.UserRepo
 	eRepo *repo.EntryRepo
+	cRepo *repo.ContractRepo
+	yRepo *repo.ClosingRepo
+	wServ *WebhookService
 }
 
 // NewEntryService create a new entry service.
-func NewEntryService(tm *tx.TransactionManager, er *repo.EntryRepo) *EntryService {
-	return &EntryService{service{tm}, er}
+func NewEntryService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+	cr *repo.ContractRepo, yr *repo.ClosingRepo, ws *WebhookService) *EntryService {
+	return &EntryService{service{tm}, ur, er, cr, yr, ws}
 }
 
 // --- Entry functions ---
@@ -44,14 +51,15 @@ func (s *EntryService) GetDateEntries(ctx context.Context, filter model.EntryFil
 		return nil, 0, err
 	}
 
-	// Get entries
-	entries, err := s.eRepo.GetDateEntries(ctx, filter, sort, offset, limit)
+	// Get entries (dates are determined in the time zone of the current user)
+	loc := getCurrentUserLocation(ctx)
+	entries, err := s.eRepo.GetDateEntries(ctx, filter, sort, offset, limit, loc)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Count all available entries
-	cnt, err := s.eRepo.CountDateEntries(ctx, filter)
+	cnt, err := s.eRepo.CountDateEntries(ctx, filter, loc)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -67,14 +75,15 @@ func (s *EntryService) GetDateEntriesByUserId(ctx context.Context, userId int, o
 		return nil, 0, err
 	}
 
-	// Get entries
-	entries, err := s.eRepo.GetDateEntriesByUserId(ctx, userId, offset, limit)
+	// Get entries (dates are determined in the time zone of the current user)
+	loc := getCurrentUserLocation(ctx)
+	entries, err := s.eRepo.GetDateEntriesByUserId(ctx, userId, offset, limit, loc)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Count all available entries
-	cnt, err := s.eRepo.CountDateEntriesByUserId(ctx, userId)
+	cnt, err := s.eRepo.CountDateEntriesByUserId(ctx, userId, loc)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -82,14 +91,21 @@ func (s *EntryService) GetDateEntriesByUserId(ctx context.Context, userId int, o
 	return entries, cnt, nil
 }
 
-// GetEntries gets all entries.
+// GetEntries gets a page of entries. The page either starts at the supplied offset or after the
+// supplied cursor. Only entries a user can see are returned.
 func (s *EntryService) GetEntries(ctx context.Context, filter model.EntryFilter,
-	sort *model.EntrySort, offset int, limit int) ([]*model.Entry, int, error) {
+	sort *model.EntrySort, cursor *model.EntryCursor, offset int, limit int) (*model.EntryPage,
+	error) {
 	// If filter is nil, create an empty filter
 	if filter == nil {
 		filter = model.NewEmptyEntryFilter()
 	}
 
+	// If sort is nil, create a default sort (the cursor needs a defined order)
+	if sort == nil {
+		sort = model.NewEntrySort()
+	}
+
 	// If user does not have right to get any entry: Add default user ID filter
 	if !hasCurrentUserRight(ctx, model.RightGetAllEntries) && !filter.IsByUser() {
 		filter.SetUserFilter(getCurrentUserId(ctx))
@@ -97,22 +113,94 @@ func (s *EntryService) GetEntries(ctx context.Context, filter model.EntryFilter,
 
 	// Check permissions
 	if err := s.checkHasCurrentUserGetRight(ctx, filter.GetUserId()); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	// Get entries
-	entries, err := s.eRepo.GetEntries(ctx, filter, sort, offset, limit)
+	// Get one entry more than requested to determine if there are more entries
+	entries, err := s.eRepo.GetEntries(ctx, filter, sort, cursor, offset, limit+1)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	hasMore := len(entries) > limit
+	if hasMore {
+		entries = entries[:limit]
 	}
 
 	// Count all available entries
 	cnt, err := s.eRepo.CountEntries(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return entries, cnt, nil
+	// Determine cursor to continue with
+	var nextCursor *model.EntryCursor
+	if hasMore {
+		last := entries[len(entries)-1]
+		nextCursor = model.NewEntryCursor(last.StartTime, last.EndTime, last.Id)
+	}
+
+	return &model.EntryPage{Entries: entries, Total: cnt, Cursor: nextCursor}, nil
+}
+
+// GetEntriesIterator gets all entries as an iterator. It is used instead of GetEntries for large
+// result sets (e.g. exports). The iterator must be closed after use.
+func (s *EntryService) GetEntriesIterator(ctx context.Context, filter model.EntryFilter,
+	sort *model.EntrySort) (*repo.EntryIterator, error) {
+	// If filter is nil, create an empty filter
+	if filter == nil {
+		filter = model.NewEmptyEntryFilter()
+	}
+
+	// If user does not have right to get any entry: Add default user ID filter
+	if !hasCurrentUserRight(ctx, model.RightGetAllEntries) && !filter.IsByUser() {
+		filter.SetUserFilter(getCurrentUserId(ctx))
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, filter.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	// Get entries iterator
+	return s.eRepo.GetEntriesIterator(ctx, filter, sort)
+}
+
+// GetEntryChanges gets the entries (including deleted entries) that were changed after the supplied
+// cursor. If the cursor is nil, all entries are returned. Only changes of entries a user can see
+// are returned.
+func (s *EntryService) GetEntryChanges(ctx context.Context, cursor *model.EntryChangeCursor,
+	limit int) (*model.EntryChanges, error) {
+	// If user does not have right to get any entry: Only get own entries
+	userId := 0
+	if !hasCurrentUserRight(ctx, model.RightGetAllEntries) {
+		userId = getCurrentUserId(ctx)
+		if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only get changes that are old enough to be committed (changes of running transactions
+	// must not be skipped by the cursor)
+	until := time.Now().Add(-entryChangesSafetyLag)
+
+	// Get one change more than requested to determine if there are more changes
+	changes, err := s.eRepo.GetEntryChanges(ctx, userId, cursor, until, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	// Determine cursor to continue with
+	nextCursor := cursor
+	if len(changes) > 0 {
+		last := changes[len(changes)-1].Entry
+		nextCursor = model.NewEntryChangeCursor(last.UpdatedAt, last.Id)
+	}
+
+	return &model.EntryChanges{Changes: changes, Cursor: nextCursor, HasMore: hasMore}, nil
 }
 
 // GetEntryById gets an entry.
@@ -154,11 +242,17 @@ func (s *EntryService) CreateEntry(ctx context.Context, entry *model.Entry) erro
 	}
 
 	// Check if entry type exists
-	if err := s.checkEntryTypeExists(entry.TypeId); err != nil {
+	entryType, err := s.getExistingEntryType(ctx, entry.TypeId)
+	if err != nil {
 		return err
 	}
 	// Check if entry activity exists
-	if err := s.checkEntryActivityExistsAllowed(ctx, entry.TypeId, entry.ActivityId); err != nil {
+	if err := s.checkEntryActivityExistsAllowed(ctx, entryType, entry.ActivityId, 0); err != nil {
+		return err
+	}
+
+	// Round entry duration (if a rounding policy applies at save time)
+	if err := s.roundEntry(ctx, entryType, entry); err != nil {
 		return err
 	}
 
@@ -167,11 +261,25 @@ func (s *EntryService) CreateEntry(ctx context.Context, entry *model.Entry) erro
 		return err
 	}
 
-	// Create entry
-	return s.eRepo.CreateEntry(ctx, entry)
+	// Check if year is closed
+	if err := checkTimesNotClosed(ctx, s.uRepo, s.yRepo, entry.UserId,
+		entry.StartTime); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Create entry
+		if err := s.eRepo.CreateEntry(ctx, entry); err != nil {
+			return err
+		}
+		// Notify webhooks
+		return s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryCreated, entry)
+	})
 }
 
-// UpdateEntry updates an entry.
+// UpdateEntry updates an entry. If the entry has a version, the entry is only updated if it was not
+// changed in the meantime.
 func (s *EntryService) UpdateEntry(ctx context.Context, entry *model.Entry) error {
 	// Get existing entry
 	existingEntry, err := s.eRepo.GetEntryByIdAndUserId(ctx, entry.Id, entry.UserId)
@@ -189,12 +297,33 @@ func (s *EntryService) UpdateEntry(ctx context.Context, entry *model.Entry) erro
 		return err
 	}
 
+	// Check if entry was invoiced
+	if err := checkEntryNotInvoiced(existingEntry); err != nil {
+		return err
+	}
+
+	// Check if entry belongs to an absence
+	if err := s.checkEntryNotOfAbsence(existingEntry); err != nil {
+		return err
+	}
+
 	// Check if entry type exists
-	if err := s.checkEntryTypeExists(entry.TypeId); err != nil {
+	entryType, err := s.getExistingEntryType(ctx, entry.TypeId)
+	if err != nil {
 		return err
 	}
-	// Check if entry activity exists
-	if err := s.checkEntryActivityExistsAllowed(ctx, entry.TypeId, entry.ActivityId); err != nil {
+	// Check if entry activity exists (an archived or no longer allowed activity can be kept)
+	keptActId := 0
+	if existingEntry.TypeId == entry.TypeId {
+		keptActId = existingEntry.ActivityId
+	}
+	if err := s.checkEntryActivityExistsAllowed(ctx, entryType, entry.ActivityId, keptActId); err !=
+		nil {
+		return err
+	}
+
+	// Round entry duration (if a rounding policy applies at save time)
+	if err := s.roundEntry(ctx, entryType, entry); err != nil {
 		return err
 	}
 
@@ -203,8 +332,31 @@ func (s *EntryService) UpdateEntry(ctx context.Context, entry *model.Entry) erro
 		return err
 	}
 
-	// Update entry
-	return s.eRepo.UpdateEntry(ctx, entry)
+	// Check if entry was changed in the meantime
+	if err := s.checkEntryVersion(entry, existingEntry.Version); err != nil {
+		return err
+	}
+
+	// Check if years are closed
+	if err := checkTimesNotClosed(ctx, s.uRepo, s.yRepo, existingEntry.UserId,
+		existingEntry.StartTime, entry.StartTime); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Update entry
+		updated, err := s.eRepo.UpdateEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return s.createEntryVersionConflictError(entry.Id)
+		}
+		entry.CreatedAt = existingEntry.CreatedAt
+		// Notify webhooks
+		return s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryUpdated, entry)
+	})
 }
 
 // DeleteEntryById deletes an entry.
@@ -226,7 +378,7 @@ func (s *EntryService) DeleteEntryById(ctx context.Context, id int) error {
 	}
 
 	// Delete entry
-	return s.eRepo.DeleteEntryById(ctx, id)
+	return s.deleteEntry(ctx, existingEntry)
 }
 
 // DeleteEntryByIdAndUserId deletes an entry of an user.
@@ -248,7 +400,7 @@ func (s *EntryService) DeleteEntryByIdAndUserId(ctx context.Context, id int, use
 	}
 
 	// Delete entry
-	return s.eRepo.DeleteEntryById(ctx, id)
+	return s.deleteEntry(ctx, existingEntry)
 }
 
 // GetMonthEntriesByUserId gets all entries of a month of an user.
@@ -259,8 +411,42 @@ func (s *EntryService) GetMonthEntriesByUserId(ctx context.Context, userId int,
 		return nil, err
 	}
 
+	// Get location of the user (the month is determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get entries
-	return s.eRepo.GetMonthEntries(ctx, userId, year, month)
+	return s.eRepo.GetMonthEntries(ctx, userId, year, month, loc)
+}
+
+func (s *EntryService) deleteEntry(ctx context.Context, entry *model.Entry) error {
+	// Check if entry was invoiced
+	if err := checkEntryNotInvoiced(entry); err != nil {
+		return err
+	}
+
+	// Check if entry belongs to an absence
+	if err := s.checkEntryNotOfAbsence(entry); err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkTimesNotClosed(ctx, s.uRepo, s.yRepo, entry.UserId,
+		entry.StartTime); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Delete entry
+		if err := s.eRepo.DeleteEntryById(ctx, entry.Id); err != nil {
+			return err
+		}
+		// Notify webhooks
+		return s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryDeleted, entry)
+	})
 }
 
 func (s *EntryService) checkEntryExists(id int, entry *model.Entry) error {
@@ -272,6 +458,62 @@ func (s *EntryService) checkEntryExists(id int, entry *model.Entry) error {
 	return nil
 }
 
+func checkEntryNotInvoiced(entry *model.Entry) error {
+	if entry.IsInvoiced() {
+		err := e.NewError(e.LogicEntryInvoiced, fmt.Sprintf("Entry %d was invoiced with invoice "+
+			"%d.", entry.Id, entry.InvoiceId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *EntryService) checkEntryNotOfAbsence(entry *model.Entry) error {
+	if entry.AbsenceId != 0 {
+		err := e.NewError(e.LogicEntryOfAbsence, fmt.Sprintf("Entry %d belongs to absence %d. "+
+			"(Entries of absences can only be changed via the absence.)", entry.Id,
+			entry.AbsenceId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *EntryService) checkEntryVersion(entry *model.Entry, currentVersion int) error {
+	if entry.Version != 0 && entry.Version != currentVersion {
+		return s.createEntryVersionConflictError(entry.Id)
+	}
+	return nil
+}
+
+func (s *EntryService) createEntryVersionConflictError(id int) error {
+	err := e.NewError(e.LogicEntryVersionConflict, fmt.Sprintf("Entry %d was changed in the "+
+		"meantime.", id))
+	log.Debug(err.StackTrace())
+	return err
+}
+
+// roundEntry rounds the duration of a work entry by moving its end time, if a rounding policy with
+// the scope "save" applies to the entry.
+func (s *EntryService) roundEntry(ctx context.Context, entryType *model.EntryType,
+	entry *model.Entry) error {
+	if !entryType.CountsAsWork || entry.EndTime.Before(entry.StartTime) {
+		return nil
+	}
+
+	policies, err := getRoundingPolicies(ctx, s.eRepo, s.cRepo, entry.UserId)
+	if err != nil {
+		return err
+	}
+	policy := policies.GetScopePolicy(entry.Project, model.RoundingScopeSave)
+	if policy == nil {
+		return nil
+	}
+
+	entry.EndTime = entry.StartTime.Add(policy.Round(entry.EndTime.Sub(entry.StartTime)))
+	return nil
+}
+
 func (s *EntryService) checkEntry(entry *model.Entry) error {
 	if entry.StartTime.After(entry.EndTime) {
 		err := e.NewError(e.LogicEntryTimeIntervalInvalid, fmt.Sprintf("End time %s before "+
@@ -293,40 +535,108 @@ func (s *EntryService) GetEntryTypes(ctx context.Context) ([]*model.EntryType, e
 	}
 
 	// Get entry types
-	return []*model.EntryType{
-		model.NewEntryType(model.EntryTypeIdWork, loc.CreateString("entryTypeWork")),
-		model.NewEntryType(model.EntryTypeIdTravel, loc.CreateString("entryTypeTravel")),
-		model.NewEntryType(model.EntryTypeIdVacation, loc.CreateString("entryTypeVacation")),
-		model.NewEntryType(model.EntryTypeIdHoliday, loc.CreateString("entryTypeHoliday")),
-		model.NewEntryType(model.EntryTypeIdIllness, loc.CreateString("entryTypeIllness")),
-	}, nil
+	return getEntryTypes(ctx, s.eRepo)
 }
 
 // GetEntryTypesMap gets a map of all entry types.
 func (s *EntryService) GetEntryTypesMap(ctx context.Context) (map[int]*model.EntryType, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetEntryCharacts); err != nil {
+		return nil, err
+	}
+
 	// Get entry types
-	entryTypes, err := s.GetEntryTypes(ctx)
+	return getEntryTypesMap(ctx, s.eRepo)
+}
+
+// CreateEntryType creates a new entry type.
+func (s *EntryService) CreateEntryType(ctx context.Context, entryType *model.EntryType) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Create entry type (user-defined types never have a built-in name)
+	entryType.Name = ""
+	return s.eRepo.CreateEntryType(ctx, entryType)
+}
+
+// UpdateEntryType updates an entry type. If the description of a built-in type is empty, the
+// default description is used.
+func (s *EntryService) UpdateEntryType(ctx context.Context, entryType *model.EntryType) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if entry type exists
+	existingEntryType, err := s.getExistingEntryType(ctx, entryType.Id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Convert into map
-	m := make(map[int]*model.EntryType)
-	for _, entryType := range entryTypes {
-		m[entryType.Id] = entryType
+	// Update entry type
+	entryType.Name = existingEntryType.Name
+	if err := s.eRepo.UpdateEntryType(ctx, entryType); err != nil {
+		return err
 	}
+	localizeEntryType(entryType)
+	return nil
+}
 
-	return m, nil
+// DeleteEntryTypeById deletes an entry type. Built-in types and types which are still used can
+// not be deleted.
+func (s *EntryService) DeleteEntryTypeById(ctx context.Context, typeId int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if entry type exists
+	entryType, err := s.getExistingEntryType(ctx, typeId)
+	if err != nil {
+		return err
+	}
+
+	// Check if entry type can be deleted
+	if entryType.IsBuiltIn() {
+		err := e.NewError(e.LogicEntryTypeDeleteNotAllowed, fmt.Sprintf("Could not delete entry "+
+			"type %d. Built-in entry types can not be deleted.", typeId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if err := s.checkEntryTypeIsUsed(ctx, typeId); err != nil {
+		return err
+	}
+
+	// Delete entry type
+	return s.eRepo.DeleteEntryTypeById(ctx, typeId)
 }
 
-func (s *EntryService) checkEntryTypeExists(typeId int) error {
-	exist := typeId == model.EntryTypeIdWork || typeId == model.EntryTypeIdTravel ||
-		typeId == model.EntryTypeIdVacation || typeId == model.EntryTypeIdHoliday ||
-		typeId == model.EntryTypeIdIllness
-	if !exist {
+func (s *EntryService) getExistingEntryType(ctx context.Context, typeId int) (*model.EntryType,
+	error) {
+	entryType, err := getEntryType(ctx, s.eRepo, typeId)
+	if err != nil {
+		return nil, err
+	}
+	if entryType == nil {
 		err := e.NewError(e.LogicEntryTypeNotFound, fmt.Sprintf("Could not find entry type %d.",
 			typeId))
 		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return entryType, nil
+}
+
+func (s *EntryService) checkEntryTypeIsUsed(ctx context.Context, typeId int) error {
+	existsEntry, err := s.eRepo.ExistsEntryByTypeId(ctx, typeId)
+	if err != nil {
+		return err
+	}
+	if existsEntry {
+		err := e.NewError(e.LogicEntryTypeDeleteNotAllowed, fmt.Sprintf("Could not delete entry "+
+			"type %d. There are still entries for this type.", typeId))
+		log.Debug(err.StackTrace())
 		return err
 	}
 	return nil
@@ -334,7 +644,7 @@ func (s *EntryService) checkEntryTypeExists(typeId int) error {
 
 // --- Entry activity functions ---
 
-// GetEntryActivities gets all entry activities.
+// GetEntryActivities gets all entry activities (including archived activities).
 func (s *EntryService) GetEntryActivities(ctx context.Context) ([]*model.EntryActivity, error) {
 	// Check permissions
 	if err := checkHasCurrentUserRight(ctx, model.RightGetEntryCharacts); err != nil {
@@ -376,6 +686,11 @@ func (s *EntryService) CreateEntryActivity(ctx context.Context, entryActivity *m
 		return err
 	}
 
+	// Check if entry types exist
+	if err := s.checkEntryTypesExist(ctx, entryActivity.TypeIds); err != nil {
+		return err
+	}
+
 	// Create entry activity
 	return s.eRepo.CreateEntryActivity(ctx, entryActivity)
 }
@@ -393,6 +708,11 @@ func (s *EntryService) UpdateEntryActivity(ctx context.Context, entryActivity *m
 		return err
 	}
 
+	// Check if entry types exist
+	if err := s.checkEntryTypesExist(ctx, entryActivity.TypeIds); err != nil {
+		return err
+	}
+
 	// Update entry activity
 	return s.eRepo.UpdateEntryActivity(ctx, entryActivity)
 }
@@ -418,15 +738,162 @@ func (s *EntryService) DeleteEntryActivityById(ctx context.Context, actId int) e
 	return s.eRepo.DeleteEntryActivityById(ctx, actId)
 }
 
-func (s *EntryService) checkEntryActivityExistsAllowed(ctx context.Context, typeId int, actId int,
+// MergeEntryActivities reassigns all entries of an entry activity to another entry activity and
+// deletes the merged entry activity.
+func (s *EntryService) MergeEntryActivities(ctx context.Context, sourceActId int, targetActId int,
 ) error {
-	if typeId != model.EntryTypeIdWork && actId != 0 {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if entry activities exist
+	if err := s.checkEntryActivityExists(ctx, sourceActId); err != nil {
+		return err
+	}
+	if err := s.checkEntryActivityExists(ctx, targetActId); err != nil {
+		return err
+	}
+
+	// Check if entry activities differ
+	if sourceActId == targetActId {
+		err := e.NewError(e.LogicEntryActivityMergeInvalid, fmt.Sprintf("Could not merge entry "+
+			"activity %d. An entry activity can not be merged into itself.", sourceActId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Get target entry activity and entries of the merged entry activity
+	targetActivity, err := s.eRepo.GetEntryActivityById(ctx, targetActId)
+	if err != nil {
+		return err
+	}
+	entries, err := s.eRepo.GetEntriesByActivityId(ctx, sourceActId)
+	if err != nil {
+		return err
+	}
+
+	// Check if entries can be reassigned
+	if err := s.checkEntriesReassignable(ctx, entries, targetActivity); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Merge entry activities
+		if err := s.eRepo.MergeEntryActivities(ctx, sourceActId, targetActId); err != nil {
+			return err
+		}
+		// Notify webhooks
+		for _, entry := range entries {
+			entry.ActivityId = targetActId
+			entry.Version++
+			if err := s.wServ.PublishEntryEvent(ctx, model.WebhookEventEntryUpdated,
+				entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkEntriesReassignable checks if entries can be reassigned to another entry activity. Entries
+// which were invoiced, lie in a closed year or have a type the activity is not allowed for are
+// rejected.
+func (s *EntryService) checkEntriesReassignable(ctx context.Context, entries []*model.Entry,
+	entryActivity *model.EntryActivity) error {
+	entryTypesMap, err := getEntryTypesMap(ctx, s.eRepo)
+	if err != nil {
+		return err
+	}
+
+	userTimes := make(map[int][]time.Time)
+	for _, entry := range entries {
+		// Check if entry was invoiced
+		if err := checkEntryNotInvoiced(entry); err != nil {
+			return err
+		}
+
+		// Check if entry activity is allowed for the entry type
+		entryType, ok := entryTypesMap[entry.TypeId]
+		if !ok || !entryType.AllowsActivities || !entryActivity.IsAllowedForType(entry.TypeId) {
+			err := e.NewError(e.LogicEntryActivityNotAllowed, fmt.Sprintf("The entry activity %d "+
+				"is not allowed for the entry type %d of entry %d.", entryActivity.Id,
+				entry.TypeId, entry.Id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		userTimes[entry.UserId] = append(userTimes[entry.UserId], entry.StartTime)
+	}
+
+	// Check if years are closed
+	for userId, times := range userTimes {
+		if err := checkTimesNotClosed(ctx, s.uRepo, s.yRepo, userId, times...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEntryActivityExistsAllowed checks if an entry activity exists and can be used for the
+// supplied entry type. Archived activities and activities which are not allowed for the type are
+// rejected, unless the activity is kept from the existing entry (keptActId).
+func (s *EntryService) checkEntryActivityExistsAllowed(ctx context.Context,
+	entryType *model.EntryType, actId int, keptActId int) error {
+	if actId == 0 {
+		return nil
+	}
+	if !entryType.AllowsActivities {
 		err := e.NewError(e.LogicEntryActivityNotAllowed, fmt.Sprintf("The entry activity %d is "+
-			"not allowed for the entry type %d.", actId, typeId))
+			"not allowed for the entry type %d.", actId, entryType.Id))
 		log.Debug(err.StackTrace())
 		return err
 	}
-	return s.checkEntryActivityExists(ctx, actId)
+
+	entryActivity, err := s.eRepo.GetEntryActivityById(ctx, actId)
+	if err != nil {
+		return err
+	}
+	if entryActivity == nil {
+		err := e.NewError(e.LogicEntryActivityNotFound, fmt.Sprintf("Could not find entry activity "+
+			"%d.", actId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if actId == keptActId {
+		return nil
+	}
+
+	if entryActivity.Archived {
+		err := e.NewError(e.LogicEntryActivityNotAllowed, fmt.Sprintf("The entry activity %d is "+
+			"archived.", actId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	if !entryActivity.IsAllowedForType(entryType.Id) {
+		err := e.NewError(e.LogicEntryActivityNotAllowed, fmt.Sprintf("The entry activity %d is "+
+			"not allowed for the entry type %d.", actId, entryType.Id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *EntryService) checkEntryTypesExist(ctx context.Context, typeIds []int) error {
+	entryTypesMap, err := getEntryTypesMap(ctx, s.eRepo)
+	if err != nil {
+		return err
+	}
+	for _, typeId := range typeIds {
+		if _, ok := entryTypesMap[typeId]; !ok {
+			err := e.NewError(e.LogicEntryTypeNotFound, fmt.Sprintf("Could not find entry type "+
+				"%d.", typeId))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *EntryService) checkEntryActivityExists(ctx context.Context, actId int) error {
@@ -460,23 +927,69 @@ func (s *EntryService) checkEntryActivityIsUsed(ctx context.Context, actId int)
 	return nil
 }
 
-// --- Work summary functions ---
+// --- Rounding policy functions ---
 
-// GetTotalWorkSummaryByUserId gets the total work summary of an user.
-func (s *EntryService) GetTotalWorkSummaryByUserId(ctx context.Context, userId int) (
-	*model.WorkSummary, error) {
+// GetRoundingPoliciesByUserId gets the rounding policies which apply to the entries of an user.
+func (s *EntryService) GetRoundingPoliciesByUserId(ctx context.Context, userId int) (
+	*model.RoundingPolicies, error) {
 	// Check permissions
 	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
 		return nil, err
 	}
 
-	// Get work summary
-	start := time.Time{}
-	now := time.Now()
-	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
-	return s.eRepo.GetWorkSummary(ctx, userId, start, end)
+	// Get rounding policies
+	return getRoundingPolicies(ctx, s.eRepo, s.cRepo, userId)
 }
 
+// GetProjectRoundingPolicies gets all project rounding policies.
+func (s *EntryService) GetProjectRoundingPolicies(ctx context.Context) (
+	[]*model.ProjectRoundingPolicy, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetEntryCharacts); err != nil {
+		return nil, err
+	}
+
+	// Get project rounding policies
+	return s.eRepo.GetProjectRoundingPolicies(ctx)
+}
+
+// SetProjectRoundingPolicy creates or replaces the rounding policy of a project.
+func (s *EntryService) SetProjectRoundingPolicy(ctx context.Context,
+	policy *model.ProjectRoundingPolicy) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Set project rounding policy
+	return s.eRepo.SetProjectRoundingPolicy(ctx, policy)
+}
+
+// DeleteProjectRoundingPolicy deletes the rounding policy of a project.
+func (s *EntryService) DeleteProjectRoundingPolicy(ctx context.Context, project string) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if project rounding policy exists
+	exists, err := s.eRepo.ExistsProjectRoundingPolicy(ctx, project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err := e.NewError(e.LogicProjectRoundingPolicyNotFound, fmt.Sprintf("Could not find "+
+			"rounding policy of project '%s'.", project))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Delete project rounding policy
+	return s.eRepo.DeleteProjectRoundingPolicy(ctx, project)
+}
+
+// --- Work summary functions ---
+
 // GetWorkSummaryByUserId gets the month work summary of an user.
 func (s *EntryService) GetMonthWorkSummaryByUserId(ctx context.Context, userId int, year int,
 	month time.Month) (*model.WorkSummary, error) {
@@ -485,10 +998,155 @@ func (s *EntryService) GetMonthWorkSummaryByUserId(ctx context.Context, userId i
 		return nil, err
 	}
 
+	// Get location of the user (the month is determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get work summary
+	start := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+	return s.getWorkSummary(ctx, userId, start, end)
+}
+
+// GetWorkDayBreakChecksByUserId checks the breaks of the work days of an user in a specific period
+// against the break rules of the user's contract. Only violated work days are returned.
+func (s *EntryService) GetWorkDayBreakChecksByUserId(ctx context.Context, userId int,
+	start time.Time, end time.Time) (map[time.Time]*model.BreakCheck, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil || len(contract.BreakRules) == 0 {
+		return map[time.Time]*model.BreakCheck{}, nil
+	}
+
+	// Get work days
+	workDays, err := s.getWorkDays(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check work days
+	checks := make(map[time.Time]*model.BreakCheck)
+	for _, workDay := range workDays {
+		check := contract.CheckBreaks(workDay)
+		if check.IsViolated() {
+			checks[workDay.Date] = check
+		}
+	}
+	return checks, nil
+}
+
+func (s *EntryService) getWorkSummary(ctx context.Context, userId int, start time.Time,
+	end time.Time) (*model.WorkSummary, error) {
 	// Get work summary
-	start := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
-	end := time.Date(year, month+1, 1, 0, 0, 0, 0, time.Local)
-	return s.eRepo.GetWorkSummary(ctx, userId, start, end)
+	workSummary, err := s.eRepo.GetWorkSummary(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// If missing breaks should not be deducted: Abort
+	if contract == nil || !contract.DeductMissingBreaks || len(contract.BreakRules) == 0 {
+		return workSummary, nil
+	}
+
+	// Calculate break deduction
+	workDays, err := s.getWorkDays(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, workDay := range workDays {
+		workSummary.BreakDeduction += contract.CheckBreaks(workDay).DeductionDuration
+	}
+
+	return workSummary, nil
+}
+
+// getYearMonthWorkSummaries gets the work summaries of the months of a year. The durations of all
+// entry types are fetched by a single aggregate query, the work days are only fetched if missing
+// breaks have to be deducted.
+func (s *EntryService) getYearMonthWorkSummaries(ctx context.Context, userId int, year int) (
+	[]*model.WorkSummary, error) {
+	// Get location of the user (the months are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create work summaries
+	workSummaries := make([]*model.WorkSummary, 0, 12)
+	for month := time.January; month <= time.December; month++ {
+		workSummary := model.NewWorkSummary()
+		workSummary.UserId = userId
+		workSummary.StartTime = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		workSummary.EndTime = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		workSummary.WorkDurations = make([]*model.WorkDuration, 0, 5)
+		workSummaries = append(workSummaries, workSummary)
+	}
+
+	// Get work durations
+	monthWorkDurations, err := s.eRepo.GetYearMonthWorkDurations(ctx, userId, year, loc)
+	if err != nil {
+		return nil, err
+	}
+	for _, mwd := range monthWorkDurations {
+		workSummary := workSummaries[mwd.Month-1]
+		workSummary.WorkDurations = append(workSummary.WorkDurations, &model.WorkDuration{
+			TypeId:       mwd.TypeId,
+			WorkDuration: mwd.WorkDuration,
+		})
+	}
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// If missing breaks should not be deducted: Abort
+	if contract == nil || !contract.DeductMissingBreaks || len(contract.BreakRules) == 0 {
+		return workSummaries, nil
+	}
+
+	// Calculate break deductions
+	start := workSummaries[0].StartTime
+	end := workSummaries[len(workSummaries)-1].EndTime
+	workDays, err := s.eRepo.GetWorkDays(ctx, userId, start, end, loc)
+	if err != nil {
+		return nil, err
+	}
+	for _, workDay := range workDays {
+		workSummary := workSummaries[workDay.Date.Month()-1]
+		workSummary.BreakDeduction += contract.CheckBreaks(workDay).DeductionDuration
+	}
+
+	return workSummaries, nil
+}
+
+func (s *EntryService) getWorkDays(ctx context.Context, userId int, start time.Time,
+	end time.Time) ([]*model.WorkDay, error) {
+	// Get location of the user (days are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get work days
+	return s.eRepo.GetWorkDays(ctx, userId, start, end, loc)
 }
 
 // --- Permission helper functions ---
@@ -508,3 +1166,102 @@ func (s *EntryService) checkHasCurrentUserChangeRight(ctx context.Context, userI
 		return checkHasCurrentUserRight(ctx, model.RightChangeAllEntries)
 	}
 }
+
+// --- Entry type helper functions ---
+
+// builtInEntryTypeDescriptionKeys contains the localization keys of the default descriptions of
+// the built-in entry types.
+var builtInEntryTypeDescriptionKeys = map[string]string{
+	model.EntryTypeNameWork:     "entryTypeWork",
+	model.EntryTypeNameTravel:   "entryTypeTravel",
+	model.EntryTypeNameVacation: "entryTypeVacation",
+	model.EntryTypeNameHoliday:  "entryTypeHoliday",
+	model.EntryTypeNameIllness:  "entryTypeIllness",
+}
+
+func getEntryTypes(ctx context.Context, eRepo *repo.EntryRepo) ([]*model.EntryType, error) {
+	entryTypes, err := eRepo.GetEntryTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entryType := range entryTypes {
+		localizeEntryType(entryType)
+	}
+	return entryTypes, nil
+}
+
+func getEntryTypesMap(ctx context.Context, eRepo *repo.EntryRepo) (map[int]*model.EntryType,
+	error) {
+	entryTypes, err := getEntryTypes(ctx, eRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[int]*model.EntryType)
+	for _, entryType := range entryTypes {
+		m[entryType.Id] = entryType
+	}
+	return m, nil
+}
+
+// getEntryTypeByName gets a built-in entry type by its name. If the type does not exist, nil is
+// returned.
+func getEntryTypeByName(ctx context.Context, eRepo *repo.EntryRepo, name string) (
+	*model.EntryType, error) {
+	entryTypes, err := getEntryTypes(ctx, eRepo)
+	if err != nil {
+		return nil, err
+	}
+	for _, entryType := range entryTypes {
+		if entryType.Name == name {
+			return entryType, nil
+		}
+	}
+	return nil, nil
+}
+
+func getEntryType(ctx context.Context, eRepo *repo.EntryRepo, typeId int) (*model.EntryType,
+	error) {
+	entryType, err := eRepo.GetEntryTypeById(ctx, typeId)
+	if err != nil || entryType == nil {
+		return nil, err
+	}
+	localizeEntryType(entryType)
+	return entryType, nil
+}
+
+func localizeEntryType(entryType *model.EntryType) {
+	if entryType.Description != "" {
+		return
+	}
+	if key, ok := builtInEntryTypeDescriptionKeys[entryType.Name]; ok {
+		entryType.Description = loc.CreateString(key)
+	} else {
+		entryType.Description = entryType.Name
+	}
+}
+
+// --- Rounding policy helper functions ---
+
+func getRoundingPolicies(ctx context.Context, eRepo *repo.EntryRepo, cRepo *repo.ContractRepo,
+	userId int) (*model.RoundingPolicies, error) {
+	policies := model.NewRoundingPolicies()
+
+	contract, err := cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if contract != nil {
+		policies.UserPolicy = contract.RoundingPolicy
+	}
+
+	projectPolicies, err := eRepo.GetProjectRoundingPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, projectPolicy := range projectPolicies {
+		policies.ProjectPolicies[projectPolicy.Project] = &projectPolicy.RoundingPolicy
+	}
+
+	return policies, nil
+}
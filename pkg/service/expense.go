@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// ExpenseService contains expense related logic.
+type ExpenseService struct {
+	service
+	uRepo *repo.UserRepo
+	eRepo *repo.EntryRepo
+	xRepo *repo.ExpenseRepo
+	yRepo *repo.ClosingRepo
+}
+
+// NewExpenseService create a new expense service.
+func NewExpenseService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+	xr *repo.ExpenseRepo, yr *repo.ClosingRepo) *ExpenseService {
+	return &ExpenseService{service{tm}, ur, er, xr, yr}
+}
+
+// --- Expense rate functions ---
+
+// GetExpenseRates gets all expense rates.
+func (s *ExpenseService) GetExpenseRates(ctx context.Context) ([]*model.ExpenseRate, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetEntryCharacts); err != nil {
+		return nil, err
+	}
+
+	// Get expense rates
+	return s.xRepo.GetExpenseRates(ctx)
+}
+
+// CreateExpenseRate creates a new expense rate.
+func (s *ExpenseService) CreateExpenseRate(ctx context.Context, rate *model.ExpenseRate) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Create expense rate
+	return s.xRepo.CreateExpenseRate(ctx, rate)
+}
+
+// UpdateExpenseRate updates an expense rate. The type of a rate can not be changed. Existing
+// expenses keep the amount which was calculated with the previous rate.
+func (s *ExpenseService) UpdateExpenseRate(ctx context.Context, rate *model.ExpenseRate) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if expense rate exists
+	existingRate, err := s.getExistingExpenseRate(ctx, rate.Id)
+	if err != nil {
+		return err
+	}
+
+	// Update expense rate
+	rate.Type = existingRate.Type
+	return s.xRepo.UpdateExpenseRate(ctx, rate)
+}
+
+// DeleteExpenseRateById deletes an expense rate. Rates which are still used can not be deleted.
+func (s *ExpenseService) DeleteExpenseRateById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeEntryCharacts); err != nil {
+		return err
+	}
+
+	// Check if expense rate exists
+	if _, err := s.getExistingExpenseRate(ctx, id); err != nil {
+		return err
+	}
+
+	// Check if expense rate is used
+	used, err := s.xRepo.ExistsExpenseByRateId(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		err := e.NewError(e.LogicExpenseRateDeleteNotAllowed, fmt.Sprintf("Could not delete "+
+			"expense rate %d. The rate is still used.", id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Delete expense rate
+	return s.xRepo.DeleteExpenseRateById(ctx, id)
+}
+
+func (s *ExpenseService) getExistingExpenseRate(ctx context.Context, id int) (*model.ExpenseRate,
+	error) {
+	rate, err := s.xRepo.GetExpenseRateById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rate == nil {
+		err := e.NewError(e.LogicExpenseRateNotFound, fmt.Sprintf("Could not find expense rate "+
+			"%d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return rate, nil
+}
+
+// --- Expense functions ---
+
+// GetExpensesByEntryId gets all expenses of an entry.
+func (s *ExpenseService) GetExpensesByEntryId(ctx context.Context, entryId int) ([]*model.Expense,
+	error) {
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, entry.UserId); err != nil {
+		return nil, err
+	}
+
+	// Get expenses
+	return s.xRepo.GetExpensesByEntryId(ctx, entryId)
+}
+
+// GetExpenseById gets an expense by its ID.
+func (s *ExpenseService) GetExpenseById(ctx context.Context, id int) (*model.Expense, error) {
+	// Get expense
+	expense, err := s.xRepo.GetExpenseById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if expense == nil {
+		return nil, nil
+	}
+
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, expense.EntryId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, entry.UserId); err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// CreateExpense creates a new expense. Amount and currency of mileage and per diem expenses are
+// calculated with the rate of the expense.
+func (s *ExpenseService) CreateExpense(ctx context.Context, expense *model.Expense) error {
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, expense.EntryId)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, entry.UserId); err != nil {
+		return err
+	}
+
+	// Check entry
+	if err := s.checkEntryAllowsExpenses(ctx, entry); err != nil {
+		return err
+	}
+
+	// Calculate amount
+	if err := s.applyExpenseRate(ctx, expense); err != nil {
+		return err
+	}
+
+	// Create expense
+	return s.xRepo.CreateExpense(ctx, expense)
+}
+
+// UpdateExpense updates an expense. The entry of an expense can not be changed.
+func (s *ExpenseService) UpdateExpense(ctx context.Context, expense *model.Expense) error {
+	// Get existing expense
+	existingExpense, err := s.getExistingExpense(ctx, expense.Id)
+	if err != nil {
+		return err
+	}
+
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, existingExpense.EntryId)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, entry.UserId); err != nil {
+		return err
+	}
+
+	// Check entry
+	if err := s.checkEntryAllowsExpenses(ctx, entry); err != nil {
+		return err
+	}
+
+	// Calculate amount
+	expense.EntryId = existingExpense.EntryId
+	if err := s.applyExpenseRate(ctx, expense); err != nil {
+		return err
+	}
+
+	// Update expense
+	return s.xRepo.UpdateExpense(ctx, expense)
+}
+
+// DeleteExpenseById deletes an expense.
+func (s *ExpenseService) DeleteExpenseById(ctx context.Context, id int) error {
+	// Get existing expense
+	expense, err := s.getExistingExpense(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Get entry
+	entry, err := s.getExistingEntry(ctx, expense.EntryId)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserChangeRight(ctx, entry.UserId); err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkTimesNotClosed(ctx, s.uRepo, s.yRepo, entry.UserId,
+		entry.StartTime); err != nil {
+		return err
+	}
+
+	// Delete expense
+	return s.xRepo.DeleteExpenseById(ctx, id)
+}
+
+func (s *ExpenseService) getExistingExpense(ctx context.Context, id int) (*model.Expense, error) {
+	expense, err := s.xRepo.GetExpenseById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if expense == nil {
+		err := e.NewError(e.LogicExpenseNotFound, fmt.Sprintf("Could not find expense %d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return expense, nil
+}
+
+func (s *ExpenseService) getExistingEntry(ctx context.Context, entryId int) (*model.Entry, error) {
+	entry, err := s.eRepo.GetEntryById(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		err := e.NewError(e.LogicEntryNotFound, fmt.Sprintf("Could not find entry %d.", entryId))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *ExpenseService) checkEntryAllowsExpenses(ctx context.Context, entry *model.Entry) error {
+	// Check entry type
+	entryType, err := getEntryType(ctx, s.eRepo, entry.TypeId)
+	if err != nil {
+		return err
+	}
+	if entryType == nil || !entryType.AllowsExpenses {
+		err := e.NewError(e.LogicExpensesNotAllowed, fmt.Sprintf("Entries of type %d can not "+
+			"have expenses.", entry.TypeId))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check if year is closed
+	return checkTimesNotClosed(ctx, s.uRepo, s.yRepo, entry.UserId, entry.StartTime)
+}
+
+func (s *ExpenseService) applyExpenseRate(ctx context.Context, expense *model.Expense) error {
+	// Receipts have no rate
+	if !expense.IsRateBased() {
+		expense.RateId = 0
+		expense.Quantity = 0
+		expense.Amount = model.RoundAmount(expense.Amount)
+		return nil
+	}
+
+	// Get rate
+	rate, err := s.xRepo.GetExpenseRateById(ctx, expense.RateId)
+	if err != nil {
+		return err
+	}
+	if rate == nil || rate.Type != expense.Type {
+		err := e.NewError(e.LogicExpenseRateInvalid, fmt.Sprintf("Expense rate %d can not be "+
+			"used for expenses of type '%s'.", expense.RateId, expense.Type))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Calculate amount
+	expense.ApplyRate(rate)
+	return nil
+}
+
+// --- Expense report functions ---
+
+// GetMonthExpenseReportByUserId gets the expense report of a user for a month. The month is
+// determined in the time zone of the user.
+func (s *ExpenseService) GetMonthExpenseReportByUserId(ctx context.Context, userId int, year int,
+	month int) (*model.ExpenseReport, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get location of the user
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entries and expenses
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 1, 0)
+	entries, err := s.eRepo.GetMonthEntries(ctx, userId, year, month, loc)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.xRepo.GetExpensesByUserId(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get rates
+	rates, err := s.xRepo.GetExpenseRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create report
+	return createExpenseReport(userId, start, end, entries, expenses, rates), nil
+}
+
+func createExpenseReport(userId int, start time.Time, end time.Time, entries []*model.Entry,
+	expenses []*model.Expense, rates []*model.ExpenseRate) *model.ExpenseReport {
+	entriesMap := make(map[int]*model.Entry, len(entries))
+	for _, entry := range entries {
+		entriesMap[entry.Id] = entry
+	}
+	ratesMap := make(map[int]*model.ExpenseRate, len(rates))
+	for _, rate := range rates {
+		ratesMap[rate.Id] = rate
+	}
+
+	report := &model.ExpenseReport{
+		UserId:    userId,
+		StartDate: start,
+		EndDate:   end.AddDate(0, 0, -1),
+		Items:     make([]*model.ExpenseReportItem, 0, len(expenses)),
+	}
+	totals := make(map[string]float64)
+	for _, expense := range expenses {
+		entry, ok := entriesMap[expense.EntryId]
+		if !ok {
+			continue
+		}
+		report.Items = append(report.Items, &model.ExpenseReportItem{
+			Date:    entry.StartTime.In(start.Location()),
+			Entry:   entry,
+			Expense: expense,
+			Rate:    ratesMap[expense.RateId],
+		})
+		totals[expense.Currency] += expense.Amount
+	}
+
+	report.Totals = make([]*model.ExpenseTotal, 0, len(totals))
+	for currency, amount := range totals {
+		report.Totals = append(report.Totals, &model.ExpenseTotal{
+			Currency: currency,
+			Amount:   model.RoundAmount(amount),
+		})
+	}
+	slices.SortFunc(report.Totals, func(a, b *model.ExpenseTotal) int {
+		return strings.Compare(a.Currency, b.Currency)
+	})
+
+	return report
+}
+
+// --- Permission helper functions ---
+
+func (s *ExpenseService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
+
+func (s *ExpenseService) checkHasCurrentUserChangeRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightChangeOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightChangeAllEntries)
+	}
+}
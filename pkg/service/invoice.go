@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// InvoiceService contains client, hourly rate and invoice related logic.
+type InvoiceService struct {
+	service
+	uRepo   *repo.UserRepo
+	eRepo   *repo.EntryRepo
+	cRepo   *repo.ContractRepo
+	iRepo   *repo.InvoiceRepo
+	taxRate float64
+}
+
+// NewInvoiceService create a new invoice service.
+func NewInvoiceService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+	cr *repo.ContractRepo, ir *repo.InvoiceRepo, taxRate int) *InvoiceService {
+	return &InvoiceService{service{tm}, ur, er, cr, ir, float64(taxRate)}
+}
+
+// --- Client functions ---
+
+// GetClients gets all clients.
+func (s *InvoiceService) GetClients(ctx context.Context) ([]*model.Client, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetInvoices); err != nil {
+		return nil, err
+	}
+
+	// Get clients
+	return s.iRepo.GetClients(ctx)
+}
+
+// GetClientById gets a client by its ID.
+func (s *InvoiceService) GetClientById(ctx context.Context, id int) (*model.Client, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetInvoices); err != nil {
+		return nil, err
+	}
+
+	// Get client
+	return s.iRepo.GetClientById(ctx, id)
+}
+
+// CreateClient creates a new client.
+func (s *InvoiceService) CreateClient(ctx context.Context, client *model.Client) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check client
+	if err := s.checkClient(ctx, client); err != nil {
+		return err
+	}
+
+	// Create client
+	return s.iRepo.CreateClient(ctx, client)
+}
+
+// UpdateClient updates a client. Existing invoices keep the currency they were created with.
+func (s *InvoiceService) UpdateClient(ctx context.Context, client *model.Client) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if client exists
+	if _, err := s.getExistingClient(ctx, client.Id); err != nil {
+		return err
+	}
+
+	// Check client
+	if err := s.checkClient(ctx, client); err != nil {
+		return err
+	}
+
+	// Update client
+	return s.iRepo.UpdateClient(ctx, client)
+}
+
+// DeleteClientById deletes a client. Clients which were already invoiced can not be deleted.
+func (s *InvoiceService) DeleteClientById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if client exists
+	if _, err := s.getExistingClient(ctx, id); err != nil {
+		return err
+	}
+
+	// Check if client was invoiced
+	invoiced, err := s.iRepo.ExistsInvoiceByClientId(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invoiced {
+		err := e.NewError(e.LogicClientDeleteNotAllowed, fmt.Sprintf("Could not delete client "+
+			"%d. The client was already invoiced.", id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Delete client
+	return s.iRepo.DeleteClientById(ctx, id)
+}
+
+func (s *InvoiceService) getExistingClient(ctx context.Context, id int) (*model.Client, error) {
+	client, err := s.iRepo.GetClientById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		err := e.NewError(e.LogicClientNotFound, fmt.Sprintf("Could not find client %d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return client, nil
+}
+
+func (s *InvoiceService) checkClient(ctx context.Context, client *model.Client) error {
+	// Check if name is unique
+	exists, err := s.iRepo.ExistsClientByName(ctx, client.Name, client.Id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err := e.NewError(e.LogicClientAlreadyExists, fmt.Sprintf("Client '%s' already exists.",
+			client.Name))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check if projects are assigned to other clients
+	for _, project := range client.Projects {
+		assigned, err := s.iRepo.ExistsClientProject(ctx, project, client.Id)
+		if err != nil {
+			return err
+		}
+		if assigned {
+			err := e.NewError(e.LogicProjectAlreadyAssigned, fmt.Sprintf("Project '%s' is "+
+				"already assigned to another client.", project))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// --- Hourly rate functions ---
+
+// GetHourlyRates gets all hourly rates.
+func (s *InvoiceService) GetHourlyRates(ctx context.Context) ([]*model.HourlyRate, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetInvoices); err != nil {
+		return nil, err
+	}
+
+	// Get hourly rates
+	return s.iRepo.GetHourlyRates(ctx)
+}
+
+// CreateHourlyRate creates a new hourly rate.
+func (s *InvoiceService) CreateHourlyRate(ctx context.Context, rate *model.HourlyRate) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check hourly rate
+	if err := s.checkHourlyRate(ctx, rate); err != nil {
+		return err
+	}
+
+	// Create hourly rate
+	return s.iRepo.CreateHourlyRate(ctx, rate)
+}
+
+// UpdateHourlyRate updates an hourly rate. Existing invoices keep the amounts which were
+// calculated with the previous rate.
+func (s *InvoiceService) UpdateHourlyRate(ctx context.Context, rate *model.HourlyRate) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if hourly rate exists
+	if _, err := s.getExistingHourlyRate(ctx, rate.Id); err != nil {
+		return err
+	}
+
+	// Check hourly rate
+	if err := s.checkHourlyRate(ctx, rate); err != nil {
+		return err
+	}
+
+	// Update hourly rate
+	return s.iRepo.UpdateHourlyRate(ctx, rate)
+}
+
+// DeleteHourlyRateById deletes an hourly rate.
+func (s *InvoiceService) DeleteHourlyRateById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if hourly rate exists
+	if _, err := s.getExistingHourlyRate(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete hourly rate
+	return s.iRepo.DeleteHourlyRateById(ctx, id)
+}
+
+func (s *InvoiceService) getExistingHourlyRate(ctx context.Context, id int) (*model.HourlyRate,
+	error) {
+	rate, err := s.iRepo.GetHourlyRateById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rate == nil {
+		err := e.NewError(e.LogicHourlyRateNotFound, fmt.Sprintf("Could not find hourly rate "+
+			"%d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *InvoiceService) checkHourlyRate(ctx context.Context, rate *model.HourlyRate) error {
+	// Check validity
+	if !rate.ValidTo.IsZero() && rate.ValidTo.Before(rate.ValidFrom) {
+		err := e.NewError(e.LogicEntryDateIntervalInvalid, fmt.Sprintf("End date %s before "+
+			"start date %s.", rate.ValidTo, rate.ValidFrom))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Check if user exists
+	if rate.UserId != 0 {
+		exists, err := s.uRepo.ExistsUserById(ctx, rate.UserId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			err := e.NewError(e.LogicUserNotFound, fmt.Sprintf("Could not find user %d.",
+				rate.UserId))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+
+	// Check if entry activity exists
+	if rate.ActivityId != 0 {
+		exists, err := s.eRepo.ExistsEntryActivityById(ctx, rate.ActivityId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			err := e.NewError(e.LogicEntryActivityNotFound, fmt.Sprintf("Could not find entry "+
+				"activity %d.", rate.ActivityId))
+			log.Debug(err.StackTrace())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// --- Invoice functions ---
+
+// GetInvoices gets all invoices (without their items).
+func (s *InvoiceService) GetInvoices(ctx context.Context) ([]*model.Invoice, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetInvoices); err != nil {
+		return nil, err
+	}
+
+	// Get invoices
+	return s.iRepo.GetInvoices(ctx)
+}
+
+// GetInvoiceById gets an invoice including its items by its ID.
+func (s *InvoiceService) GetInvoiceById(ctx context.Context, id int) (*model.Invoice, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetInvoices); err != nil {
+		return nil, err
+	}
+
+	// Get invoice
+	return s.iRepo.GetInvoiceById(ctx, id)
+}
+
+// CreateInvoice creates an invoice for a client. All billable entries of the projects of the
+// client, which start in the period of the invoice (determined in the time zone of the current
+// user) and were not invoiced yet, are billed. The entries are marked as invoiced, so they can no
+// longer be changed.
+func (s *InvoiceService) CreateInvoice(ctx context.Context, invoice *model.Invoice) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if client exists
+	client, err := s.getExistingClient(ctx, invoice.ClientId)
+	if err != nil {
+		return err
+	}
+
+	// Check period
+	if invoice.EndDate.Before(invoice.StartDate) {
+		err := e.NewError(e.LogicEntryDateIntervalInvalid, fmt.Sprintf("End date %s before "+
+			"start date %s.", invoice.EndDate, invoice.StartDate))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Create invoice items
+		if err := s.createInvoiceItems(ctx, client, invoice); err != nil {
+			return err
+		}
+		invoice.Currency = client.Currency
+		invoice.TaxRate = s.taxRate
+		invoice.CalculateAmounts()
+
+		// Get next invoice number
+		number, err := s.getNextInvoiceNumber(ctx)
+		if err != nil {
+			return err
+		}
+		invoice.Number = number
+
+		// Create invoice
+		marked, err := s.iRepo.CreateInvoice(ctx, invoice)
+		if err != nil {
+			return err
+		}
+		if marked != len(invoice.Items) {
+			err := e.NewError(e.LogicEntryInvoiced, fmt.Sprintf("Entries of client %d were "+
+				"changed or invoiced in the meantime.", client.Id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+		return nil
+	})
+}
+
+// DeleteInvoiceById deletes an invoice. The entries of the invoice are released, so that they can
+// be changed and invoiced again.
+func (s *InvoiceService) DeleteInvoiceById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeInvoices); err != nil {
+		return err
+	}
+
+	// Check if invoice exists
+	invoice, err := s.iRepo.GetInvoiceById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invoice == nil {
+		err := e.NewError(e.LogicInvoiceNotFound, fmt.Sprintf("Could not find invoice %d.", id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Delete invoice
+	return s.iRepo.DeleteInvoiceById(ctx, id)
+}
+
+func (s *InvoiceService) createInvoiceItems(ctx context.Context, client *model.Client,
+	invoice *model.Invoice) error {
+	loc := getCurrentUserLocation(ctx)
+
+	// Get entries
+	start := time.Date(invoice.StartDate.Year(), invoice.StartDate.Month(),
+		invoice.StartDate.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(invoice.EndDate.Year(), invoice.EndDate.Month(), invoice.EndDate.Day(), 0, 0,
+		0, 0, loc).AddDate(0, 0, 1)
+	entries, err := s.eRepo.GetUninvoicedBillableEntries(ctx, client.Projects, start, end)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		err := e.NewError(e.LogicInvoiceWithoutEntries, fmt.Sprintf("Client %d has no billable "+
+			"entries between %s and %s.", client.Id, start, end))
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	// Get rates in the currency of the client
+	allRates, err := s.iRepo.GetHourlyRates(ctx)
+	if err != nil {
+		return err
+	}
+	rates := make([]*model.HourlyRate, 0, len(allRates))
+	for _, rate := range allRates {
+		if rate.Currency == client.Currency {
+			rates = append(rates, rate)
+		}
+	}
+
+	// Get entry types, activities and rounding policies for the calculation of billed hours
+	entryTypesMap, err := getEntryTypesMap(ctx, s.eRepo)
+	if err != nil {
+		return err
+	}
+	activities, err := s.eRepo.GetEntryActivities(ctx)
+	if err != nil {
+		return err
+	}
+	activitiesMap := make(map[int]*model.EntryActivity, len(activities))
+	for _, activity := range activities {
+		activitiesMap[activity.Id] = activity
+	}
+	roundingPolicies := make(map[int]*model.RoundingPolicies)
+
+	// Create items
+	invoice.Items = make([]*model.InvoiceItem, 0, len(entries))
+	for _, entry := range entries {
+		rate := model.FindHourlyRate(rates, entry, loc)
+		if rate == nil {
+			err := e.NewError(e.LogicHourlyRateMissing, fmt.Sprintf("No hourly rate in %s "+
+				"applies to entry %d.", client.Currency, entry.Id))
+			log.Debug(err.StackTrace())
+			return err
+		}
+
+		policies, ok := roundingPolicies[entry.UserId]
+		if !ok {
+			policies, err = getRoundingPolicies(ctx, s.eRepo, s.cRepo, entry.UserId)
+			if err != nil {
+				return err
+			}
+			roundingPolicies[entry.UserId] = policies
+		}
+		duration := entry.EndTime.Sub(entry.StartTime)
+		if model.IsWorkEntryType(entryTypesMap, entry.TypeId) {
+			if policy := policies.GetScopePolicy(entry.Project, model.RoundingScopeReport); policy !=
+				nil {
+				duration = policy.Round(duration)
+			}
+		}
+
+		item := model.NewInvoiceItem()
+		item.EntryId = entry.Id
+		item.UserId = entry.UserId
+		item.Date = entry.StartTime.In(loc)
+		item.Project = entry.Project
+		item.Description = s.getInvoiceItemDescription(entry, activitiesMap)
+		item.Hours = model.RoundAmount(duration.Hours())
+		item.Rate = rate.Amount
+		item.Amount = model.RoundAmount(item.Hours * item.Rate)
+		invoice.Items = append(invoice.Items, item)
+	}
+
+	return nil
+}
+
+func (s *InvoiceService) getInvoiceItemDescription(entry *model.Entry,
+	activitiesMap map[int]*model.EntryActivity) string {
+	parts := make([]string, 0, 2)
+	if activity, ok := activitiesMap[entry.ActivityId]; ok {
+		parts = append(parts, activity.Description)
+	}
+	if entry.Description != "" {
+		parts = append(parts, entry.Description)
+	}
+	return strings.Join(parts, " - ")
+}
+
+// getNextInvoiceNumber returns the next number of the current year (e.g. "2024-0001").
+func (s *InvoiceService) getNextInvoiceNumber(ctx context.Context) (string, error) {
+	prefix := fmt.Sprintf("%d-", time.Now().In(getCurrentUserLocation(ctx)).Year())
+
+	// (The sequence is locked until the invoice is created)
+	seq, err := s.iRepo.NextInvoiceNumber(ctx, prefix)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%04d", prefix, seq), nil
+}
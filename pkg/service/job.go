@@ -13,15 +13,23 @@ import (
 )
 
 const sessionsCleanUpInterval = 15 * time.Minute
+const remindersInterval = 1 * time.Hour
+const digestsInterval = 1 * time.Hour
+const webhookDeliveriesInterval = 1 * time.Minute
+const attachmentsCleanUpInterval = 1 * time.Hour
 
 // JobService contains job related logic.
 type JobService struct {
 	sServ *SessionService
+	nServ *NotificationService
+	wServ *WebhookService
+	fServ *AttachmentService
 }
 
 // NewJobService create a new job service.
-func NewJobService(ss *SessionService) *JobService {
-	return &JobService{ss}
+func NewJobService(ss *SessionService, ns *NotificationService, ws *WebhookService,
+	fs *AttachmentService) *JobService {
+	return &JobService{ss, ns, ws, fs}
 }
 
 // --- Job functions ---
@@ -29,6 +37,10 @@ func NewJobService(ss *SessionService) *JobService {
 // ScheduleJobs schedules jobs.
 func (s *JobService) ScheduleJobs() {
 	s.scheduleSessionsCleanUpJob()
+	s.scheduleRemindersJob()
+	s.scheduleDigestsJob()
+	s.scheduleWebhookDeliveriesJob()
+	s.scheduleAttachmentsCleanUpJob()
 }
 
 // ScheduleJobs schedules jobs.
@@ -36,6 +48,25 @@ func (s *JobService) scheduleSessionsCleanUpJob() {
 	scheduleJob("sessions clean up job", s.sServ.DeleteExpiredSessions, sessionsCleanUpInterval)
 }
 
+func (s *JobService) scheduleRemindersJob() {
+	scheduleJob("missing entries reminders job", s.nServ.SendMissingEntriesReminders,
+		remindersInterval)
+}
+
+func (s *JobService) scheduleDigestsJob() {
+	scheduleJob("weekly digests job", s.nServ.SendWeeklyDigests, digestsInterval)
+}
+
+func (s *JobService) scheduleWebhookDeliveriesJob() {
+	scheduleJob("webhook deliveries job", s.wServ.ProcessWebhookDeliveries,
+		webhookDeliveriesInterval)
+}
+
+func (s *JobService) scheduleAttachmentsCleanUpJob() {
+	scheduleJob("attachments clean up job", s.fServ.DeleteOrphanedAttachments,
+		attachmentsCleanUpInterval)
+}
+
 type jobFunc func(context.Context) error
 
 func scheduleJob(jobName string, f jobFunc, interval time.Duration) {
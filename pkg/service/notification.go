@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/mail"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+const notificationDateFormat = "02.01.2006"
+
+// incompleteDay stores information about a working day with no or too few logged hours.
+type incompleteDay struct {
+	date        time.Time
+	actualHours float32
+	targetHours float32
+}
+
+// NotificationService contains notification related logic.
+type NotificationService struct {
+	service
+	uRepo  *repo.UserRepo
+	cRepo  *repo.ContractRepo
+	eRepo  *repo.EntryRepo
+	mailer *mail.Mailer
+
+	minHoursPercent int
+	checkDays       int
+}
+
+// NewNotificationService create a new notification service.
+func NewNotificationService(tm *tx.TransactionManager, ur *repo.UserRepo, cr *repo.ContractRepo,
+	er *repo.EntryRepo, m *mail.Mailer, minHoursPercent int, checkDays int) *NotificationService {
+	return &NotificationService{service{tm}, ur, cr, er, m, minHoursPercent, checkDays}
+}
+
+// --- Reminder functions ---
+
+// SendMissingEntriesReminders sends a reminder email to every user who opted in and has working
+// days with no or too few logged hours. A reminder is sent at most once per day.
+func (s *NotificationService) SendMissingEntriesReminders(ctx context.Context) error {
+	// Abort if sending emails is disabled
+	if !s.mailer.IsEnabled() {
+		return nil
+	}
+
+	// Get users
+	users, err := s.uRepo.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		// Check interval (all days before today in the time zone of the user)
+		today := getDayStart(time.Now().In(user.GetLocation()))
+		todayStr := today.Format(notificationDateFormat)
+		start := today.AddDate(0, 0, -s.checkDays)
+
+		// Get notification settings
+		settings, err := getUserNotificationSettings(ctx, s.uRepo, user.Id)
+		if err != nil {
+			return err
+		}
+		if !settings.MissingEntriesReminder || settings.Email == "" {
+			continue
+		}
+
+		// Skip user if reminder was already sent today
+		lastSent, err := getUserStringSetting(ctx, s.uRepo, user.Id,
+			model.UserSettingKeyReminderLastSent, "")
+		if err != nil {
+			return err
+		}
+		if lastSent == todayStr {
+			continue
+		}
+
+		// Find incomplete days
+		days, err := s.findIncompleteDays(ctx, user.Id, start, today)
+		if err != nil {
+			return err
+		}
+
+		// Send reminder
+		if len(days) > 0 {
+			log.Debugf("Sending missing entries reminder to user %d.", user.Id)
+			subject := loc.CreateString("mailReminderSubject")
+			body := s.createReminderBody(user, days)
+			// A failed email must not prevent the reminders of the other users (the error was
+			// already logged, the user is checked again on the next day)
+			if err := s.mailer.SendMail(settings.Email, subject, body); err != nil {
+				log.Warnf("Could not send missing entries reminder to user %d.", user.Id)
+			}
+		}
+
+		// Remember that user was checked today
+		if err := setUserStringSetting(ctx, s.uRepo, user.Id,
+			model.UserSettingKeyReminderLastSent, todayStr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) createReminderBody(user *model.User, days []*incompleteDay) string {
+	lines := []string{
+		loc.CreateString("mailGreeting", user.Name),
+		"",
+		loc.CreateString("mailReminderText"),
+		"",
+	}
+	lines = append(lines, s.createIncompleteDayLines(days)...)
+	lines = append(lines, "", loc.CreateString("mailReminderClosing"))
+	return strings.Join(lines, "\n")
+}
+
+// --- Digest functions ---
+
+// SendWeeklyDigests sends a digest of the missing entries of the previous week to every evaluator
+// who opted in. A digest is sent at most once per week.
+func (s *NotificationService) SendWeeklyDigests(ctx context.Context) error {
+	// Abort if sending emails is disabled
+	if !s.mailer.IsEnabled() {
+		return nil
+	}
+
+	// Get users
+	users, err := s.uRepo.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Find evaluators who should receive the digest (the week is determined in the location of
+	// the evaluator)
+	type recipient struct {
+		user      *model.User
+		email     string
+		weekStart time.Time
+	}
+	var recipients []recipient
+	for _, user := range users {
+		roles, err := s.uRepo.GetUserRoles(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+		if !containsRole(roles, model.RoleEvaluator) {
+			continue
+		}
+		settings, err := getUserNotificationSettings(ctx, s.uRepo, user.Id)
+		if err != nil {
+			return err
+		}
+		if !settings.WeeklyDigest || settings.Email == "" {
+			continue
+		}
+		weekStart := getWeekStart(time.Now().In(user.GetLocation()))
+		lastSent, err := getUserStringSetting(ctx, s.uRepo, user.Id,
+			model.UserSettingKeyDigestLastSent, "")
+		if err != nil {
+			return err
+		}
+		if lastSent == weekStart.Format(notificationDateFormat) {
+			continue
+		}
+		recipients = append(recipients, recipient{user, settings.Email, weekStart})
+	}
+
+	// Send digests (the incomplete days are only searched once per week)
+	weeksUsersDays := make(map[string]map[int][]*incompleteDay)
+	for _, r := range recipients {
+		weekStartStr := r.weekStart.Format(notificationDateFormat)
+		prevWeekStart := r.weekStart.AddDate(0, 0, -7)
+		usersDays, ok := weeksUsersDays[weekStartStr]
+		if !ok {
+			usersDays, err = s.findUsersIncompleteDays(ctx, users, prevWeekStart)
+			if err != nil {
+				return err
+			}
+			weeksUsersDays[weekStartStr] = usersDays
+		}
+
+		log.Debugf("Sending weekly digest to user %d.", r.user.Id)
+		subject := loc.CreateString("mailDigestSubject", formatNotificationDate(prevWeekStart),
+			formatNotificationDate(r.weekStart.AddDate(0, 0, -1)))
+		body := s.createDigestBody(r.user, users, usersDays, prevWeekStart, r.weekStart)
+		// A failed email must not prevent the digests of the other evaluators (the error was
+		// already logged, the evaluator gets the digest of the next week)
+		if err := s.mailer.SendMail(r.email, subject, body); err != nil {
+			log.Warnf("Could not send weekly digest to user %d.", r.user.Id)
+		}
+		if err := setUserStringSetting(ctx, s.uRepo, r.user.Id,
+			model.UserSettingKeyDigestLastSent, weekStartStr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findUsersIncompleteDays finds the incomplete days of all users in the week starting at the
+// supplied date. The days of a user are determined in the location of the user.
+func (s *NotificationService) findUsersIncompleteDays(ctx context.Context, users []*model.User,
+	weekStart time.Time) (map[int][]*incompleteDay, error) {
+	usersDays := make(map[int][]*incompleteDay)
+	for _, user := range users {
+		start := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0,
+			user.GetLocation())
+		days, err := s.findIncompleteDays(ctx, user.Id, start, start.AddDate(0, 0, 7))
+		if err != nil {
+			return nil, err
+		}
+		if len(days) > 0 {
+			usersDays[user.Id] = days
+		}
+	}
+	return usersDays, nil
+}
+
+func (s *NotificationService) createDigestBody(recipient *model.User, users []*model.User,
+	usersDays map[int][]*incompleteDay, start time.Time, end time.Time) string {
+	lines := []string{
+		loc.CreateString("mailGreeting", recipient.Name),
+		"",
+	}
+
+	if len(usersDays) == 0 {
+		lines = append(lines, loc.CreateString("mailDigestNone"))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, loc.CreateString("mailDigestText", formatNotificationDate(start),
+		formatNotificationDate(end.AddDate(0, 0, -1))))
+	for _, user := range users {
+		days, ok := usersDays[user.Id]
+		if !ok {
+			continue
+		}
+		lines = append(lines, "", loc.CreateString("mailDigestUser", user.Name, user.Username))
+		lines = append(lines, s.createIncompleteDayLines(days)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- Helper functions ---
+
+func (s *NotificationService) createIncompleteDayLines(days []*incompleteDay) []string {
+	lines := make([]string, 0, len(days))
+	for _, day := range days {
+		lines = append(lines, loc.CreateString("mailIncompleteDay",
+			formatNotificationDate(day.date), day.actualHours, day.targetHours))
+	}
+	return lines
+}
+
+func (s *NotificationService) findIncompleteDays(ctx context.Context, userId int, start time.Time,
+	end time.Time) ([]*incompleteDay, error) {
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil || len(contract.WorkingHours) == 0 {
+		return nil, nil
+	}
+
+	// Get entries
+	filter := model.NewFieldEntryFilter()
+	filter.SetUserFilter(userId)
+	filter.ByTime = true
+	filter.StartTime = start
+	filter.EndTime = end.Add(-time.Second)
+	entries, err := s.eRepo.GetEntries(ctx, filter, nil, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate logged durations per day
+	dayDurations := make(map[string]time.Duration)
+	for _, entry := range entries {
+		key := entry.StartTime.In(start.Location()).Format(notificationDateFormat)
+		dayDurations[key] = dayDurations[key] + entry.EndTime.Sub(entry.StartTime)
+	}
+
+	// Sort working hours
+	workingHours := make([]model.ContractWorkingHours, len(contract.WorkingHours))
+	copy(workingHours, contract.WorkingHours)
+	sort.SliceStable(workingHours, func(i, j int) bool {
+		return workingHours[i].FirstDay.Before(workingHours[j].FirstDay)
+	})
+
+	// Check days
+	var days []*incompleteDay
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		// Skip days before contract start and weekend days
+		if util.ToCivilDate(d).Before(contract.FirstDay) || d.Weekday() == time.Saturday ||
+			d.Weekday() == time.Sunday {
+			continue
+		}
+
+		// Get target hours
+		targetHours := findWorkingHoursForDate(workingHours, d)
+		if targetHours <= 0 {
+			continue
+		}
+
+		// Compare actual hours with required hours
+		actualHours := float32(dayDurations[d.Format(notificationDateFormat)].Hours())
+		requiredHours := targetHours * float32(s.minHoursPercent) / 100.0
+		if actualHours < requiredHours {
+			days = append(days, &incompleteDay{d, actualHours, targetHours})
+		}
+	}
+
+	return days, nil
+}
+
+func findWorkingHoursForDate(workingHours []model.ContractWorkingHours, date time.Time) float32 {
+	h := float32(0.0)
+	for _, wh := range workingHours {
+		if wh.FirstDay.After(util.ToCivilDate(date)) {
+			break
+		}
+		h = wh.Hours
+	}
+	return h
+}
+
+func getDayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func getWeekStart(t time.Time) time.Time {
+	offset := int(t.Weekday()) - 1
+	if offset < 0 {
+		offset = 6
+	}
+	return getDayStart(t).AddDate(0, 0, -offset)
+}
+
+func formatNotificationDate(t time.Time) string {
+	return t.Format(notificationDateFormat)
+}
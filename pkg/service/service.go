@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"kellnhofer.com/work-log/pkg/db/tx"
 	"kellnhofer.com/work-log/pkg/model"
@@ -16,6 +17,10 @@ func getCurrentUserId(ctx context.Context) int {
 	return security.GetCurrentUserId(ctx)
 }
 
+func getCurrentUserLocation(ctx context.Context) *time.Location {
+	return security.GetCurrentUserLocation(ctx)
+}
+
 func checkHasCurrentUserRight(ctx context.Context, right model.Right) error {
 	return security.CheckHasCurrentUserRight(ctx, right)
 }
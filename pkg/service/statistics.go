@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	"kellnhofer.com/work-log/pkg/model"
+)
+
+// statisticsGroupKey identifies a statistics group.
+type statisticsGroupKey struct {
+	id   int
+	name string
+}
+
+// StatisticsService contains statistics related logic.
+type StatisticsService struct {
+	service
+	uRepo *repo.UserRepo
+	eRepo *repo.EntryRepo
+}
+
+// NewStatisticsService create a new statistics service.
+func NewStatisticsService(tm *tx.TransactionManager, ur *repo.UserRepo, er *repo.EntryRepo,
+) *StatisticsService {
+	return &StatisticsService{service{tm}, ur, er}
+}
+
+// --- Statistics functions ---
+
+// GetStatistics gets the durations of the entries which start in a period (from the start day
+// until and including the end day). The durations are grouped by an entry characteristic and split
+// into day, week or month buckets. Days, weeks and months are determined in the time zone of the
+// current user. Only entries a user can see are included.
+func (s *StatisticsService) GetStatistics(ctx context.Context,
+	filter *model.ExpressionEntryFilter, start time.Time, end time.Time,
+	grouping model.StatisticsGrouping, interval model.StatisticsInterval) (*model.Statistics,
+	error) {
+	// If filter is nil, create an empty filter
+	if filter == nil {
+		filter = model.NewExpressionEntryFilter()
+	}
+
+	// If user does not have right to get any entry: Add default user ID filter
+	if !hasCurrentUserRight(ctx, model.RightGetAllEntries) && !filter.IsByUser() {
+		filter.SetUserFilter(getCurrentUserId(ctx))
+	}
+
+	// Check permissions
+	if filter.GetUserId() == getCurrentUserId(ctx) {
+		if err := checkHasCurrentUserRight(ctx, model.RightGetOwnEntries); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := checkHasCurrentUserRight(ctx, model.RightGetAllEntries); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create statistics with empty buckets
+	loc := getCurrentUserLocation(ctx)
+	stats := model.NewStatistics()
+	stats.Grouping = grouping
+	stats.Interval = interval
+	stats.StartDate = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	stats.EndDate = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+	periodEnd := stats.EndDate.AddDate(0, 0, 1)
+	bucketIndexes := make(map[int64]int)
+	for b := getStatisticsBucketStart(stats.StartDate, interval); b.Before(periodEnd); b =
+		getStatisticsNextBucketStart(b, interval) {
+		bucketIndexes[b.Unix()] = len(stats.Buckets)
+		stats.Buckets = append(stats.Buckets, b)
+	}
+	stats.Durations = make([]time.Duration, len(stats.Buckets))
+
+	// Restrict filter to period
+	periodFilter := *filter
+	expressions := []model.EntryFilterExpression{&model.ConditionEntryFilterExpression{
+		Field:    model.EntryFilterFieldStartTime,
+		Operator: model.EntryFilterOpBetween,
+		Values:   []any{stats.StartDate, periodEnd},
+	}}
+	if filter.Expression != nil {
+		expressions = append(expressions, filter.Expression)
+	}
+	periodFilter.Expression = &model.AndEntryFilterExpression{Expressions: expressions}
+
+	// Get entries
+	entries, err := s.eRepo.GetEntriesIterator(ctx, &periodFilter, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer entries.Close()
+
+	// Sum up durations
+	groups := make(map[statisticsGroupKey]*model.StatisticsGroup)
+	for entries.Next() {
+		entry := entries.Entry()
+		startTime := entry.StartTime.In(loc)
+		// The period end is included by the database query
+		if !startTime.Before(periodEnd) {
+			continue
+		}
+		bi := bucketIndexes[getStatisticsBucketStart(startTime, interval).Unix()]
+		duration := entry.EndTime.Sub(entry.StartTime)
+
+		stats.Durations[bi] += duration
+		stats.TotalDuration += duration
+		for _, key := range getStatisticsGroupKeys(entry, grouping) {
+			group, ok := groups[key]
+			if !ok {
+				group = model.NewStatisticsGroup()
+				group.Id = key.id
+				group.Name = key.name
+				group.Durations = make([]time.Duration, len(stats.Buckets))
+				groups[key] = group
+			}
+			group.Durations[bi] += duration
+			group.TotalDuration += duration
+		}
+	}
+	if err := entries.Err(); err != nil {
+		return nil, err
+	}
+
+	// Set names of entry types, entry activities and users
+	if err := s.setStatisticsGroupNames(ctx, groups, grouping); err != nil {
+		return nil, err
+	}
+
+	// Sort groups descending by total duration
+	stats.Groups = make([]*model.StatisticsGroup, 0, len(groups))
+	for _, group := range groups {
+		stats.Groups = append(stats.Groups, group)
+	}
+	sort.Slice(stats.Groups, func(i, j int) bool {
+		gi, gj := stats.Groups[i], stats.Groups[j]
+		if gi.TotalDuration != gj.TotalDuration {
+			return gi.TotalDuration > gj.TotalDuration
+		}
+		if gi.Name != gj.Name {
+			return gi.Name < gj.Name
+		}
+		return gi.Id < gj.Id
+	})
+
+	return stats, nil
+}
+
+func (s *StatisticsService) setStatisticsGroupNames(ctx context.Context,
+	groups map[statisticsGroupKey]*model.StatisticsGroup, grouping model.StatisticsGrouping) error {
+	names := make(map[int]string)
+	switch grouping {
+	case model.StatisticsGroupingType:
+		entryTypes, err := getEntryTypes(ctx, s.eRepo)
+		if err != nil {
+			return err
+		}
+		for _, entryType := range entryTypes {
+			names[entryType.Id] = entryType.Description
+		}
+	case model.StatisticsGroupingActivity:
+		entryActivities, err := s.eRepo.GetEntryActivities(ctx)
+		if err != nil {
+			return err
+		}
+		for _, entryActivity := range entryActivities {
+			names[entryActivity.Id] = entryActivity.Description
+		}
+	case model.StatisticsGroupingUser:
+		users, err := s.uRepo.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			names[user.Id] = user.Name
+		}
+	default:
+		// Project and label groups are already named
+		return nil
+	}
+
+	for _, group := range groups {
+		group.Name = names[group.Id]
+	}
+	return nil
+}
+
+// --- Helper functions ---
+
+func getStatisticsGroupKeys(entry *model.Entry, grouping model.StatisticsGrouping,
+) []statisticsGroupKey {
+	switch grouping {
+	case model.StatisticsGroupingType:
+		return []statisticsGroupKey{{id: entry.TypeId}}
+	case model.StatisticsGroupingActivity:
+		return []statisticsGroupKey{{id: entry.ActivityId}}
+	case model.StatisticsGroupingProject:
+		return []statisticsGroupKey{{name: entry.Project}}
+	case model.StatisticsGroupingLabel:
+		// Entries without labels are counted for the "none" group
+		if len(entry.Labels) == 0 {
+			return []statisticsGroupKey{{}}
+		}
+		keys := make([]statisticsGroupKey, 0, len(entry.Labels))
+		for _, label := range entry.Labels {
+			keys = append(keys, statisticsGroupKey{name: label})
+		}
+		return keys
+	default:
+		return []statisticsGroupKey{{id: entry.UserId}}
+	}
+}
+
+func getStatisticsBucketStart(t time.Time, interval model.StatisticsInterval) time.Time {
+	switch interval {
+	case model.StatisticsIntervalWeek:
+		return getWeekStart(t)
+	case model.StatisticsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return getDayStart(t)
+	}
+}
+
+func getStatisticsNextBucketStart(t time.Time, interval model.StatisticsInterval) time.Time {
+	switch interval {
+	case model.StatisticsIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case model.StatisticsIntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
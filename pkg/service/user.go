@@ -18,12 +18,13 @@ type UserService struct {
 	service
 	uRepo *repo.UserRepo
 	cRepo *repo.ContractRepo
+	wServ *WebhookService
 }
 
 // NewUserService create a new user service.
 func NewUserService(tm *tx.TransactionManager, ur *repo.UserRepo, cr *repo.ContractRepo,
-) *UserService {
-	return &UserService{service{tm}, ur, cr}
+	ws *WebhookService) *UserService {
+	return &UserService{service{tm}, ur, cr, ws}
 }
 
 // --- Role functions ---
@@ -327,6 +328,12 @@ func (s *UserService) checkUserContract(contract *model.Contract) error {
 	if err := s.checkUserContractVacationDays(contract.FirstDay, contract.VacationDays); err != nil {
 		return err
 	}
+	if err := s.checkUserContractBreakRules(contract.BreakRules); err != nil {
+		return err
+	}
+	if err := s.checkUserContractVacationRules(contract.VacationRules); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -521,7 +528,11 @@ func (s *UserService) CreateUserData(ctx context.Context, userData *model.UserDa
 			return err
 		}
 		// Create contract
-		return s.createUserContract(ctx, userData.Id, userData.Contract)
+		if err := s.createUserContract(ctx, userData.Id, userData.Contract); err != nil {
+			return err
+		}
+		// Notify webhooks
+		return s.wServ.PublishUserEvent(ctx, model.WebhookEventUserCreated, userData.User)
 	})
 }
 
@@ -550,6 +561,257 @@ func (s *UserService) UpdateUserData(ctx context.Context, userData *model.UserDa
 	})
 }
 
+// --- User notification settings functions ---
+
+// GetCurrentUserNotificationSettings gets the notification settings of the current user.
+func (s *UserService) GetCurrentUserNotificationSettings(ctx context.Context,
+) (*model.NotificationSettings, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetUserAccount); err != nil {
+		return nil, err
+	}
+
+	// Get notification settings
+	return getUserNotificationSettings(ctx, s.uRepo, getCurrentUserId(ctx))
+}
+
+// UpdateCurrentUserNotificationSettings updates the notification settings of the current user.
+func (s *UserService) UpdateCurrentUserNotificationSettings(ctx context.Context,
+	settings *model.NotificationSettings) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserAccount); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		return setUserNotificationSettings(ctx, s.uRepo, getCurrentUserId(ctx), settings)
+	})
+}
+
+func getUserNotificationSettings(ctx context.Context, ur *repo.UserRepo, userId int,
+) (*model.NotificationSettings, error) {
+	var err error
+	settings := model.NewNotificationSettings()
+	settings.Email, err = getUserStringSetting(ctx, ur, userId, model.UserSettingKeyEmail, "")
+	if err != nil {
+		return nil, err
+	}
+	settings.MissingEntriesReminder, err = getUserBoolSetting(ctx, ur, userId,
+		model.UserSettingKeyMissingEntriesReminder, false)
+	if err != nil {
+		return nil, err
+	}
+	settings.WeeklyDigest, err = getUserBoolSetting(ctx, ur, userId,
+		model.UserSettingKeyWeeklyDigest, false)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func setUserNotificationSettings(ctx context.Context, ur *repo.UserRepo, userId int,
+	settings *model.NotificationSettings) error {
+	if err := setUserStringSetting(ctx, ur, userId, model.UserSettingKeyEmail,
+		settings.Email); err != nil {
+		return err
+	}
+	if err := setUserBoolSetting(ctx, ur, userId, model.UserSettingKeyMissingEntriesReminder,
+		settings.MissingEntriesReminder); err != nil {
+		return err
+	}
+	return setUserBoolSetting(ctx, ur, userId, model.UserSettingKeyWeeklyDigest,
+		settings.WeeklyDigest)
+}
+
+// --- User export profile functions ---
+
+// GetCurrentUserExportProfiles gets the export profiles of the current user.
+func (s *UserService) GetCurrentUserExportProfiles(ctx context.Context) ([]*model.ExportProfile,
+	error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetUserAccount); err != nil {
+		return nil, err
+	}
+
+	// Get export profiles
+	return s.uRepo.GetExportProfilesByUserId(ctx, getCurrentUserId(ctx))
+}
+
+// GetCurrentUserExportProfileById gets a export profile of the current user by its ID.
+func (s *UserService) GetCurrentUserExportProfileById(ctx context.Context, id int,
+) (*model.ExportProfile, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetUserAccount); err != nil {
+		return nil, err
+	}
+
+	// Get export profile
+	return s.getCurrentUserExportProfileById(ctx, id)
+}
+
+// CreateCurrentUserExportProfile creates a new export profile for the current user.
+func (s *UserService) CreateCurrentUserExportProfile(ctx context.Context,
+	profile *model.ExportProfile) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserAccount); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		profile.UserId = getCurrentUserId(ctx)
+
+		// Check if name is unique
+		if err := s.checkExportProfileName(ctx, profile); err != nil {
+			return err
+		}
+
+		// Create export profile
+		return s.uRepo.CreateExportProfile(ctx, profile)
+	})
+}
+
+// UpdateCurrentUserExportProfile updates a export profile of the current user.
+func (s *UserService) UpdateCurrentUserExportProfile(ctx context.Context,
+	profile *model.ExportProfile) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserAccount); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Check if export profile exists
+		if _, err := s.getCurrentUserExportProfileById(ctx, profile.Id); err != nil {
+			return err
+		}
+		profile.UserId = getCurrentUserId(ctx)
+
+		// Check if name is unique
+		if err := s.checkExportProfileName(ctx, profile); err != nil {
+			return err
+		}
+
+		// Update export profile
+		return s.uRepo.UpdateExportProfile(ctx, profile)
+	})
+}
+
+// DeleteCurrentUserExportProfileById deletes a export profile of the current user by its ID.
+func (s *UserService) DeleteCurrentUserExportProfileById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeUserAccount); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Check if export profile exists
+		if _, err := s.getCurrentUserExportProfileById(ctx, id); err != nil {
+			return err
+		}
+
+		// Delete export profile
+		return s.uRepo.DeleteExportProfileById(ctx, id)
+	})
+}
+
+func (s *UserService) getCurrentUserExportProfileById(ctx context.Context, id int,
+) (*model.ExportProfile, error) {
+	profile, err := s.uRepo.GetExportProfileById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Profiles of other users are treated as non-existent
+	if profile == nil || profile.UserId != getCurrentUserId(ctx) {
+		err := e.NewError(e.LogicExportProfileNotFound, fmt.Sprintf("Could not find export "+
+			"profile %d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (s *UserService) checkExportProfileName(ctx context.Context,
+	profile *model.ExportProfile) error {
+	exists, err := s.uRepo.ExistsExportProfileByName(ctx, profile.UserId, profile.Name,
+		profile.Id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err := e.NewError(e.LogicExportProfileAlreadyExists, fmt.Sprintf("Export profile '%s' "+
+			"already exists.", profile.Name))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// --- User setting helper functions ---
+
+// --- Time zone helper functions ---
+
+func getUserLocation(ctx context.Context, ur *repo.UserRepo, userId int) (*time.Location, error) {
+	user, err := ur.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return time.Local, nil
+	}
+	return user.GetLocation(), nil
+}
+
+func getUserStringSetting(ctx context.Context, ur *repo.UserRepo, userId int, key string,
+	defaultValue string) (string, error) {
+	exists, err := ur.ExistsUserSetting(ctx, userId, key)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return defaultValue, nil
+	}
+	return ur.GetUserStringSetting(ctx, userId, key)
+}
+
+func setUserStringSetting(ctx context.Context, ur *repo.UserRepo, userId int, key string,
+	value string) error {
+	exists, err := ur.ExistsUserSetting(ctx, userId, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ur.CreateUserStringSetting(ctx, userId, key, value)
+	}
+	return ur.UpdateUserStringSetting(ctx, userId, key, value)
+}
+
+func getUserBoolSetting(ctx context.Context, ur *repo.UserRepo, userId int, key string,
+	defaultValue bool) (bool, error) {
+	exists, err := ur.ExistsUserSetting(ctx, userId, key)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return defaultValue, nil
+	}
+	return ur.GetUserBoolSetting(ctx, userId, key)
+}
+
+func setUserBoolSetting(ctx context.Context, ur *repo.UserRepo, userId int, key string,
+	value bool) error {
+	exists, err := ur.ExistsUserSetting(ctx, userId, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ur.CreateUserBoolSetting(ctx, userId, key, value)
+	}
+	return ur.UpdateUserBoolSetting(ctx, userId, key, value)
+}
+
 // --- Permission helper functions ---
 
 func (s *UserService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
@@ -567,3 +829,58 @@ func (s *UserService) checkHasCurrentUserChangeRight(ctx context.Context, userId
 		return checkHasCurrentUserRight(ctx, model.RightChangeUserData)
 	}
 }
+
+func (s *UserService) checkUserContractBreakRules(breakRules []model.ContractBreakRule) error {
+	errCode := e.LogicContractBreakRulesInvalid
+
+	// Check rules
+	workHours := make(map[float32]bool)
+	for _, br := range breakRules {
+		// Check if work hours are negative or zero
+		if br.WorkHours <= 0 {
+			err := e.NewError(errCode, "Break rule work hours must be greater than zero.")
+			log.Debug(err.StackTrace())
+			return err
+		}
+		// Check if break minutes are negative
+		if br.BreakMinutes < 0 {
+			err := e.NewError(errCode, "Break rule minutes cannot be negative.")
+			log.Debug(err.StackTrace())
+			return err
+		}
+		// Check if work hours are unique
+		if workHours[br.WorkHours] {
+			err := e.NewError(errCode, "Break rule work hours must be unique.")
+			log.Debug(err.StackTrace())
+			return err
+		}
+		workHours[br.WorkHours] = true
+	}
+
+	return nil
+}
+
+func (s *UserService) checkUserContractVacationRules(vacationRules *model.ContractVacationRules,
+) error {
+	errCode := e.LogicContractVacationRulesInvalid
+
+	// Vacation rules are optional
+	if vacationRules == nil {
+		return nil
+	}
+
+	// Check if carry-over days are negative
+	if vacationRules.CarryOverMaxDays < 0 {
+		err := e.NewError(errCode, "Vacation carry-over days cannot be negative.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+	// Check if expiry months are within a year
+	if vacationRules.ExpiryMonths < 0 || vacationRules.ExpiryMonths > 12 {
+		err := e.NewError(errCode, "Vacation expiry months must be between 0 and 12.")
+		log.Debug(err.StackTrace())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,683 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
+)
+
+// VacationService contains vacation related logic.
+type VacationService struct {
+	service
+	uRepo *repo.UserRepo
+	vRepo *repo.VacationRepo
+	eRepo *repo.EntryRepo
+	cRepo *repo.ContractRepo
+	bRepo *repo.AdjustmentRepo
+	yRepo *repo.ClosingRepo
+	aServ *AbsenceService
+}
+
+// NewVacationService create a new vacation service.
+func NewVacationService(tm *tx.TransactionManager, ur *repo.UserRepo, vr *repo.VacationRepo,
+	er *repo.EntryRepo, cr *repo.ContractRepo, br *repo.AdjustmentRepo, yr *repo.ClosingRepo,
+	as *AbsenceService) *VacationService {
+	return &VacationService{service{tm}, ur, vr, er, cr, br, yr, as}
+}
+
+// --- Vacation balance functions ---
+
+// GetVacationBalanceByUserId gets the vacation balance of an user in the current year.
+func (s *VacationService) GetVacationBalanceByUserId(ctx context.Context, userId int) (
+	*model.VacationBalance, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get balance
+	return s.getVacationBalance(ctx, userId, time.Now())
+}
+
+// GetVacationLedgerByUserId gets the vacation ledger of an user. The ledger contains the accrued,
+// taken, carried over and expired vacation days of every year since the first work day.
+func (s *VacationService) GetVacationLedgerByUserId(ctx context.Context, userId int) (
+	[]*model.VacationLedgerYear, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get ledger
+	ledger, _, err := s.getVacationLedger(ctx, userId, time.Now())
+	return ledger, err
+}
+
+// --- Vacation request functions ---
+
+// GetVacationRequests gets the vacation requests of all users. If a status is supplied, only
+// requests with this status are returned.
+func (s *VacationService) GetVacationRequests(ctx context.Context, status string) (
+	[]*model.VacationRequest, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetAllEntries); err != nil {
+		return nil, err
+	}
+
+	// Get requests
+	requests, err := s.vRepo.GetVacationRequests(ctx, 0, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set warnings
+	if err := s.setVacationRequestsWarnings(ctx, requests); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetVacationRequestsByUserId gets the vacation requests of an user. If a status is supplied, only
+// requests with this status are returned.
+func (s *VacationService) GetVacationRequestsByUserId(ctx context.Context, userId int,
+	status string) ([]*model.VacationRequest, error) {
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	// Get requests
+	requests, err := s.vRepo.GetVacationRequests(ctx, userId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set warnings
+	if err := s.setVacationRequestsWarnings(ctx, requests); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetVacationRequestById gets a vacation request.
+func (s *VacationService) GetVacationRequestById(ctx context.Context, id int) (
+	*model.VacationRequest, error) {
+	// Get request
+	request, err := s.vRepo.GetVacationRequestById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, nil
+	}
+
+	// Check permissions
+	if err := s.checkHasCurrentUserGetRight(ctx, request.UserId); err != nil {
+		return nil, err
+	}
+
+	// Set warnings
+	if err := s.setVacationRequestsWarnings(ctx, []*model.VacationRequest{request}); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// CreateVacationRequest creates a new vacation request for the current user.
+func (s *VacationService) CreateVacationRequest(ctx context.Context,
+	request *model.VacationRequest) error {
+	request.UserId = getCurrentUserId(ctx)
+
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeOwnEntries); err != nil {
+		return err
+	}
+
+	// Calculate requested days (this also checks the requested period)
+	days, err := s.calculateRequestedDays(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, request.UserId, request.StartDate); err != nil {
+		return err
+	}
+
+	// Create request
+	request.Days = days
+	request.Status = model.VacationRequestStatusPending
+	request.RequestedAt = time.Now()
+	request.DecidedByUserId = 0
+	request.DecidedAt = time.Time{}
+	request.DecisionComment = ""
+	request.AbsenceId = 0
+	if err := s.vRepo.CreateVacationRequest(ctx, request); err != nil {
+		return err
+	}
+
+	// Set warnings
+	return s.setVacationRequestsWarnings(ctx, []*model.VacationRequest{request})
+}
+
+// ApproveVacationRequest approves a pending vacation request. A vacation absence (with one entry
+// per working day) is created for the requested period.
+func (s *VacationService) ApproveVacationRequest(ctx context.Context, id int, comment string) (
+	*model.VacationRequest, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightApproveVacations); err != nil {
+		return nil, err
+	}
+
+	// Get pending request
+	request, err := s.getPendingVacationRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if request is a request of the current user
+	if err := s.checkVacationRequestNotOwn(ctx, request); err != nil {
+		return nil, err
+	}
+
+	// Check if year is closed
+	if err := checkDatesNotClosed(ctx, s.yRepo, request.UserId, request.StartDate); err != nil {
+		return nil, err
+	}
+
+	// Create absence entries
+	absence := s.createVacationAbsence(request)
+	entries, err := s.aServ.createAbsenceEntries(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute in transaction
+	err = s.tm.ExecuteInNewTransaction(ctx, func(ctx context.Context) error {
+		// Create absence
+		if err := s.aServ.insertAbsence(ctx, absence, entries); err != nil {
+			return err
+		}
+		// Update request
+		s.setVacationRequestDecision(ctx, request, model.VacationRequestStatusApproved, comment)
+		request.AbsenceId = absence.Id
+		return s.updateVacationRequestDecision(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// DenyVacationRequest denies a pending vacation request.
+func (s *VacationService) DenyVacationRequest(ctx context.Context, id int, comment string) (
+	*model.VacationRequest, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightApproveVacations); err != nil {
+		return nil, err
+	}
+
+	// Get pending request
+	request, err := s.getPendingVacationRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if request is a request of the current user
+	if err := s.checkVacationRequestNotOwn(ctx, request); err != nil {
+		return nil, err
+	}
+
+	// Update request
+	s.setVacationRequestDecision(ctx, request, model.VacationRequestStatusDenied, comment)
+	if err := s.updateVacationRequestDecision(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// DeleteVacationRequestById withdraws a pending vacation request of the current user.
+func (s *VacationService) DeleteVacationRequestById(ctx context.Context, id int) error {
+	// Get existing request
+	request, err := s.vRepo.GetVacationRequestById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Check if request exists (requests of other users are not visible)
+	if request == nil || request.UserId != getCurrentUserId(ctx) {
+		return s.createVacationRequestNotFoundError(id)
+	}
+
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeOwnEntries); err != nil {
+		return err
+	}
+
+	// Check if request is pending
+	if err := s.checkVacationRequestPending(request); err != nil {
+		return err
+	}
+
+	// Delete request
+	return s.vRepo.DeleteVacationRequestById(ctx, id)
+}
+
+func (s *VacationService) getPendingVacationRequest(ctx context.Context, id int) (
+	*model.VacationRequest, error) {
+	request, err := s.vRepo.GetVacationRequestById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, s.createVacationRequestNotFoundError(id)
+	}
+	if err := s.checkVacationRequestPending(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *VacationService) setVacationRequestDecision(ctx context.Context,
+	request *model.VacationRequest, status string, comment string) {
+	request.Status = status
+	request.DecidedByUserId = getCurrentUserId(ctx)
+	request.DecidedAt = time.Now()
+	request.DecisionComment = comment
+}
+
+// updateVacationRequestDecision stores the decision of a request. If the request was decided in the
+// meantime (e.g. by a concurrent approval), an error is returned.
+func (s *VacationService) updateVacationRequestDecision(ctx context.Context,
+	request *model.VacationRequest) error {
+	updated, err := s.vRepo.UpdateVacationRequestDecision(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		err := e.NewError(e.LogicVacationRequestNotPending, fmt.Sprintf("Vacation request %d was "+
+			"already decided.", request.Id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *VacationService) createVacationAbsence(request *model.VacationRequest) *model.Absence {
+	absence := model.NewAbsence()
+	absence.UserId = request.UserId
+	absence.TypeId = model.EntryTypeIdVacation
+	absence.StartDate = request.StartDate
+	absence.EndDate = request.EndDate
+	absence.DayFraction = request.DayFraction
+	absence.Description = request.Description
+	return absence
+}
+
+func (s *VacationService) calculateRequestedDays(ctx context.Context,
+	request *model.VacationRequest) (float32, error) {
+	absence := s.createVacationAbsence(request)
+	if err := s.aServ.checkAbsence(ctx, absence); err != nil {
+		return 0, err
+	}
+	entries, err := s.aServ.createAbsenceEntries(ctx, absence)
+	if err != nil {
+		return 0, err
+	}
+	return float32(len(entries)) * absence.GetDayFactor(), nil
+}
+
+func (s *VacationService) setVacationRequestsWarnings(ctx context.Context,
+	requests []*model.VacationRequest) error {
+	now := time.Now()
+	balances := make(map[int]*model.VacationBalance)
+	for _, request := range requests {
+		request.Warnings = []string{}
+
+		// Only pending requests can exceed the balance (approved requests are already booked)
+		if !request.IsPending() {
+			continue
+		}
+
+		// Get balance of user
+		balance, ok := balances[request.UserId]
+		if !ok {
+			var err error
+			balance, err = s.getVacationBalance(ctx, request.UserId, now)
+			if err != nil {
+				return err
+			}
+			balances[request.UserId] = balance
+		}
+
+		// Check balance (the days of the request are part of the pending days, the other pending
+		// requests of the user could be approved as well)
+		if balance.PendingDays > balance.RemainingDays {
+			request.Warnings = append(request.Warnings, model.VacationRequestWarningBalanceExceeded)
+		}
+	}
+	return nil
+}
+
+func (s *VacationService) checkVacationRequestPending(request *model.VacationRequest) error {
+	if !request.IsPending() {
+		err := e.NewError(e.LogicVacationRequestNotPending, fmt.Sprintf("Vacation request %d is "+
+			"already %s.", request.Id, request.Status))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+// checkVacationRequestNotOwn checks that the current user does not decide on their own request.
+func (s *VacationService) checkVacationRequestNotOwn(ctx context.Context,
+	request *model.VacationRequest) error {
+	if request.UserId == getCurrentUserId(ctx) {
+		err := e.NewError(e.PermDecideOwnVacations, fmt.Sprintf("Vacation request %d is a request "+
+			"of the current user. (Own requests can not be approved or denied.)", request.Id))
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}
+
+func (s *VacationService) createVacationRequestNotFoundError(id int) error {
+	err := e.NewError(e.LogicVacationRequestNotFound, fmt.Sprintf("Could not find vacation "+
+		"request %d.", id))
+	log.Debug(err.StackTrace())
+	return err
+}
+
+// --- Vacation balance helper functions ---
+
+func (s *VacationService) getVacationBalance(ctx context.Context, userId int, now time.Time) (
+	*model.VacationBalance, error) {
+	// Get location of the user (days and years are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, err
+	}
+	now = now.In(loc)
+
+	balance := model.NewVacationBalance()
+	balance.UserId = userId
+	balance.Year = now.Year()
+
+	// Get ledger
+	ledger, entryDays, err := s.getVacationLedger(ctx, userId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pending vacation days
+	requests, err := s.vRepo.GetVacationRequests(ctx, userId, model.VacationRequestStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		balance.PendingDays = balance.PendingDays + request.Days
+	}
+
+	// Abort if there is no ledger for the current year
+	if len(ledger) == 0 || ledger[len(ledger)-1].Year != now.Year() {
+		return balance, nil
+	}
+	cur := ledger[len(ledger)-1]
+
+	// Calculate planned vacation days (the ledger also contains days booked after today)
+	tomorrow := getDayStart(now).AddDate(0, 0, 1)
+	plannedDaysInYear := float32(0.0)
+	for _, ed := range entryDays {
+		if ed.date.Before(tomorrow) {
+			continue
+		}
+		balance.PlannedDays = balance.PlannedDays + ed.days
+		if ed.date.Year() == now.Year() {
+			plannedDaysInYear = plannedDaysInYear + ed.days
+		}
+	}
+
+	// Calculate balance
+	balance.EntitledDays = cur.CarriedOverDays + cur.AccruedDays + cur.AdjustedDays -
+		cur.ExpiredDays
+	balance.TakenDays = cur.TakenDays - plannedDaysInYear
+	balance.RemainingDays = balance.EntitledDays - balance.TakenDays - balance.PlannedDays
+
+	return balance, nil
+}
+
+func (s *VacationService) getVacationLedger(ctx context.Context, userId int, now time.Time) (
+	[]*model.VacationLedgerYear, []*vacationEntryDays, error) {
+	// Get location of the user (days and years are determined in the time zone of the user)
+	loc, err := getUserLocation(ctx, s.uRepo, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	now = now.In(loc)
+
+	// Get contract
+	contract, err := s.cRepo.GetContractByUserId(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Abort if no vacation days or working hours were set
+	if contract == nil || len(contract.VacationDays) == 0 || len(contract.WorkingHours) == 0 {
+		return []*model.VacationLedgerYear{}, nil, nil
+	}
+
+	// Get vacation days of entries
+	workingHours := sortContractWorkingHours(contract.WorkingHours)
+	entryDays, err := s.getVacationEntryDays(ctx, userId, workingHours, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get vacation adjustments
+	adjustments, err := s.bRepo.GetBalanceAdjustmentsByUserId(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get year closings
+	closings, err := s.yRepo.GetYearClosingsByUserId(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Calculate ledger
+	return calculateVacationLedger(contract, entryDays, adjustments, closings, now), entryDays, nil
+}
+
+func (s *VacationService) getVacationEntryDays(ctx context.Context, userId int,
+	workingHours []model.ContractWorkingHours, loc *time.Location) ([]*vacationEntryDays, error) {
+	// Get entry types
+	entryTypes, err := getEntryTypes(ctx, s.eRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entries of types which consume vacation
+	var entries []*model.Entry
+	for _, entryType := range entryTypes {
+		if !entryType.ConsumesVacation {
+			continue
+		}
+		filter := model.NewFieldEntryFilter()
+		filter.SetUserFilter(userId)
+		filter.ByType = true
+		filter.TypeId = entryType.Id
+		typeEntries, err := s.eRepo.GetEntries(ctx, filter, nil, nil, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, typeEntries...)
+	}
+
+	// Convert entry durations to vacation days (entries before the first working hours are
+	// converted with the first working hours)
+	entryDays := make([]*vacationEntryDays, 0, len(entries))
+	for _, entry := range entries {
+		// The days of the entries are determined in the time zone of the user
+		st := entry.StartTime.In(loc)
+		wh := findWorkingHoursForDate(workingHours, st)
+		if wh <= 0 {
+			wh = workingHours[0].Hours
+		}
+		if wh <= 0 {
+			continue
+		}
+		hours := float32(entry.EndTime.Sub(entry.StartTime).Hours())
+		date := time.Date(st.Year(), st.Month(), st.Day(), 0, 0, 0, 0, loc)
+		entryDays = append(entryDays, &vacationEntryDays{date, hours / wh})
+	}
+	return entryDays, nil
+}
+
+// vacationEntryDays stores the vacation days recorded by an entry.
+type vacationEntryDays struct {
+	date time.Time
+	days float32
+}
+
+// calculateVacationLedger calculates the vacation ledger from the first year of the contract until
+// the year of now. Closed years are taken from the closings, the calculation starts after the
+// latest closed year. Days taken or adjusted before the first year are accounted to the first
+// year, days booked after the year of now are ignored. Adjustments are only considered from their
+// effective date. Days and years are determined in the location of now.
+func calculateVacationLedger(contract *model.Contract, entryDays []*vacationEntryDays,
+	adjustments []*model.BalanceAdjustment, closings []*model.YearClosing,
+	now time.Time) []*model.VacationLedgerYear {
+	vacationDays := sortContractVacationDays(contract.VacationDays)
+	rules := contract.VacationRules
+	firstYear := contract.FirstDay.Year()
+
+	ledger := make([]*model.VacationLedgerYear, 0, 10)
+	startYear := firstYear
+	carriedOverDays := float32(0.0)
+	for _, closing := range closings {
+		if closing.Year > now.Year() {
+			break
+		}
+		ledger = append(ledger, closing.Vacation)
+		startYear = closing.Year + 1
+		carriedOverDays = closing.Vacation.RemainingDays
+	}
+	for year := startYear; year <= now.Year(); year++ {
+		ly := model.NewVacationLedgerYear()
+		ly.Year = year
+		ly.CarriedOverDays = carriedOverDays
+
+		// Calculate accrued days (the days of the whole year are available from its start)
+		curMonth := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+		if year == firstYear {
+			ly.AccruedDays = contract.InitVacationDays
+			curMonth = time.Date(year, contract.FirstDay.Month(), 1, 0, 0, 0, 0, now.Location())
+		}
+		endMonth := time.Date(year+1, time.January, 1, 0, 0, 0, 0, now.Location())
+		for curMonth.Before(endMonth) {
+			ly.AccruedDays = ly.AccruedDays + findVacationDaysForDate(vacationDays, curMonth)
+			curMonth = curMonth.AddDate(0, 1, 0)
+		}
+
+		// Calculate adjusted days
+		for _, a := range adjustments {
+			if a.Type != model.BalanceAdjustmentTypeVacation || !a.IsEffective(now) {
+				continue
+			}
+			aYear := a.Date.Year()
+			if aYear == year || (aYear < year && year == firstYear) {
+				ly.AdjustedDays = ly.AdjustedDays + a.Value
+			}
+		}
+
+		// Calculate taken days
+		var expiryDate time.Time
+		if rules != nil && year > firstYear {
+			expiryDate = rules.GetCarryOverExpiryDate(year, now.Location())
+		}
+		takenDaysBeforeExpiry := float32(0.0)
+		for _, ed := range entryDays {
+			edYear := ed.date.Year()
+			if edYear > year || (edYear < year && year != firstYear) {
+				continue
+			}
+			ly.TakenDays = ly.TakenDays + ed.days
+			if !expiryDate.IsZero() && ed.date.Before(expiryDate) {
+				takenDaysBeforeExpiry = takenDaysBeforeExpiry + ed.days
+			}
+		}
+
+		// Expire carried over days which were not taken until the expiry date (carried over days
+		// are taken first)
+		if !expiryDate.IsZero() && !now.Before(expiryDate) &&
+			carriedOverDays > takenDaysBeforeExpiry {
+			ly.ExpiredDays = carriedOverDays - takenDaysBeforeExpiry
+		}
+
+		// Calculate remaining days
+		ly.RemainingDays = ly.CarriedOverDays + ly.AccruedDays + ly.AdjustedDays - ly.TakenDays -
+			ly.ExpiredDays
+
+		// Expire days which exceed the carry-over limit at the end of the year
+		if rules != nil && year < now.Year() && ly.RemainingDays > rules.CarryOverMaxDays {
+			ly.ExpiredDays = ly.ExpiredDays + ly.RemainingDays - rules.CarryOverMaxDays
+			ly.RemainingDays = rules.CarryOverMaxDays
+		}
+
+		carriedOverDays = ly.RemainingDays
+		ledger = append(ledger, ly)
+	}
+	return ledger
+}
+
+func sortContractWorkingHours(in []model.ContractWorkingHours) []model.ContractWorkingHours {
+	out := make([]model.ContractWorkingHours, len(in))
+	copy(out, in)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].FirstDay.Before(out[j].FirstDay)
+	})
+	return out
+}
+
+func sortContractVacationDays(in []model.ContractVacationDays) []model.ContractVacationDays {
+	out := make([]model.ContractVacationDays, len(in))
+	copy(out, in)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].FirstDay.Before(out[j].FirstDay)
+	})
+	return out
+}
+
+func findVacationDaysForDate(vacationDays []model.ContractVacationDays, date time.Time) float32 {
+	d := float32(0.0)
+	for _, vd := range vacationDays {
+		if vd.FirstDay.After(util.ToCivilDate(date)) {
+			break
+		}
+		d = vd.Days
+	}
+	return d
+}
+
+// --- Permission helper functions ---
+
+func (s *VacationService) checkHasCurrentUserGetRight(ctx context.Context, userId int) error {
+	if userId == getCurrentUserId(ctx) {
+		return checkHasCurrentUserRight(ctx, model.RightGetOwnEntries)
+	} else {
+		return checkHasCurrentUserRight(ctx, model.RightGetAllEntries)
+	}
+}
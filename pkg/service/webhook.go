@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/constant"
+	"kellnhofer.com/work-log/pkg/db/repo"
+	"kellnhofer.com/work-log/pkg/db/tx"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/webhook"
+)
+
+const webhookDeliveriesBatchSize = 100
+const webhookRetryBaseDelay = 1 * time.Minute
+const webhookMaxErrorLength = 500
+
+// webhookEvent is the JSON payload sent to webhooks.
+type webhookEvent struct {
+	Event     string `json:"event"`
+	Timestamp string `json:"timestamp"`
+	Data      any    `json:"data"`
+}
+
+// webhookEntryData holds the entry information of entry events.
+type webhookEntryData struct {
+	Id          int      `json:"id"`
+	UserId      int      `json:"userId"`
+	TypeId      int      `json:"typeId"`
+	StartTime   string   `json:"startTime"`
+	EndTime     string   `json:"endTime"`
+	ActivityId  int      `json:"activityId"`
+	Project     string   `json:"project"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+// webhookUserData holds the user information of user events.
+type webhookUserData struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// webhookPingData holds the information of ping events.
+type webhookPingData struct {
+	WebhookId int `json:"webhookId"`
+}
+
+// WebhookService contains webhook related logic.
+type WebhookService struct {
+	service
+	wRepo  *repo.WebhookRepo
+	sender *webhook.Sender
+
+	maxAttempts int
+}
+
+// NewWebhookService create a new webhook service.
+func NewWebhookService(tm *tx.TransactionManager, wr *repo.WebhookRepo, ws *webhook.Sender,
+	maxAttempts int) *WebhookService {
+	return &WebhookService{service{tm}, wr, ws, maxAttempts}
+}
+
+// --- Webhook functions ---
+
+// GetWebhooks gets all webhooks.
+func (s *WebhookService) GetWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetWebhooks); err != nil {
+		return nil, err
+	}
+
+	// Get webhooks
+	return s.wRepo.GetWebhooks(ctx)
+}
+
+// GetWebhookById gets a webhook by its ID.
+func (s *WebhookService) GetWebhookById(ctx context.Context, id int) (*model.Webhook, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetWebhooks); err != nil {
+		return nil, err
+	}
+
+	// Get webhook
+	return s.getWebhookById(ctx, id)
+}
+
+// CreateWebhook creates a new webhook.
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeWebhooks); err != nil {
+		return err
+	}
+
+	// Create webhook
+	return s.wRepo.CreateWebhook(ctx, webhook)
+}
+
+// UpdateWebhook updates a webhook. If no secret is provided, the existing secret is kept.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeWebhooks); err != nil {
+		return err
+	}
+
+	// Get existing webhook
+	existingWebhook, err := s.getWebhookById(ctx, webhook.Id)
+	if err != nil {
+		return err
+	}
+
+	// Keep existing secret
+	if webhook.Secret == "" {
+		webhook.Secret = existingWebhook.Secret
+	}
+
+	// Update webhook
+	return s.wRepo.UpdateWebhook(ctx, webhook)
+}
+
+// DeleteWebhookById deletes a webhook by its ID.
+func (s *WebhookService) DeleteWebhookById(ctx context.Context, id int) error {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeWebhooks); err != nil {
+		return err
+	}
+
+	// Check if webhook exists
+	if _, err := s.getWebhookById(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete webhook
+	return s.wRepo.DeleteWebhookById(ctx, id)
+}
+
+func (s *WebhookService) getWebhookById(ctx context.Context, id int) (*model.Webhook, error) {
+	webhook, err := s.wRepo.GetWebhookById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		err := e.NewError(e.LogicWebhookNotFound, fmt.Sprintf("Could not find webhook %d.", id))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// --- Webhook delivery functions ---
+
+// GetWebhookDeliveries gets the deliveries of a webhook (newest first).
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, webhookId int, offset int,
+	limit int) ([]*model.WebhookDelivery, int, error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightGetWebhooks); err != nil {
+		return nil, 0, err
+	}
+
+	// Check if webhook exists
+	if _, err := s.getWebhookById(ctx, webhookId); err != nil {
+		return nil, 0, err
+	}
+
+	// Get deliveries
+	cnt, err := s.wRepo.CountWebhookDeliveriesByWebhookId(ctx, webhookId)
+	if err != nil {
+		return nil, 0, err
+	}
+	deliveries, err := s.wRepo.GetWebhookDeliveriesByWebhookId(ctx, webhookId, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, cnt, nil
+}
+
+// TestWebhook sends a ping event to a webhook immediately and returns the resulting delivery.
+func (s *WebhookService) TestWebhook(ctx context.Context, id int) (*model.WebhookDelivery,
+	error) {
+	// Check permissions
+	if err := checkHasCurrentUserRight(ctx, model.RightChangeWebhooks); err != nil {
+		return nil, err
+	}
+
+	// Get webhook
+	webhook, err := s.getWebhookById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create delivery
+	payload, err := createWebhookPayload(model.WebhookEventPing, &webhookPingData{webhook.Id})
+	if err != nil {
+		return nil, err
+	}
+	delivery := model.NewWebhookDelivery(webhook.Id, model.WebhookEventPing, payload)
+	if err := s.wRepo.CreateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	// Send delivery (a failed ping is not retried)
+	s.sendDelivery(webhook, delivery, 1)
+	if err := s.wRepo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// --- Event functions ---
+
+// PublishEntryEvent creates deliveries of an entry event for all subscribed webhooks.
+func (s *WebhookService) PublishEntryEvent(ctx context.Context, eventType string,
+	entry *model.Entry) error {
+	labels := entry.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	data := &webhookEntryData{
+		Id:          entry.Id,
+		UserId:      entry.UserId,
+		TypeId:      entry.TypeId,
+		StartTime:   entry.StartTime.Format(constant.ApiTimestampFormat),
+		EndTime:     entry.EndTime.Format(constant.ApiTimestampFormat),
+		ActivityId:  entry.ActivityId,
+		Project:     entry.Project,
+		Description: entry.Description,
+		Labels:      labels,
+	}
+	return s.publish(ctx, eventType, data)
+}
+
+// PublishUserEvent creates deliveries of a user event for all subscribed webhooks.
+func (s *WebhookService) PublishUserEvent(ctx context.Context, eventType string,
+	user *model.User) error {
+	data := &webhookUserData{
+		Id:       user.Id,
+		Name:     user.Name,
+		Username: user.Username,
+	}
+	return s.publish(ctx, eventType, data)
+}
+
+func (s *WebhookService) publish(ctx context.Context, eventType string, data any) error {
+	// Get subscribed webhooks
+	webhooks, err := s.wRepo.GetActiveWebhooksByEventType(ctx, eventType)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	// Create payload
+	payload, err := createWebhookPayload(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	// Create deliveries (they are sent by the webhook deliveries job)
+	for _, webhook := range webhooks {
+		delivery := model.NewWebhookDelivery(webhook.Id, eventType, payload)
+		if err := s.wRepo.CreateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createWebhookPayload(eventType string, data any) (string, error) {
+	event := &webhookEvent{
+		Event:     eventType,
+		Timestamp: time.Now().Format(constant.ApiTimestampFormat),
+		Data:      data,
+	}
+	payload, mErr := json.Marshal(event)
+	if mErr != nil {
+		err := e.WrapError(e.SysUnknown, fmt.Sprintf("Could not create payload for event '%s'.",
+			eventType), mErr)
+		log.Error(err.StackTrace())
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// --- Job functions ---
+
+// ProcessWebhookDeliveries sends all due webhook deliveries. Failed deliveries are retried with
+// an exponential backoff until the maximum number of attempts is reached.
+func (s *WebhookService) ProcessWebhookDeliveries(ctx context.Context) error {
+	// Get due deliveries
+	deliveries, err := s.wRepo.GetDueWebhookDeliveries(ctx, time.Now(),
+		webhookDeliveriesBatchSize)
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[int]*model.Webhook)
+	for _, delivery := range deliveries {
+		// Get webhook
+		webhook, ok := webhooks[delivery.WebhookId]
+		if !ok {
+			webhook, err = s.wRepo.GetWebhookById(ctx, delivery.WebhookId)
+			if err != nil {
+				return err
+			}
+			webhooks[delivery.WebhookId] = webhook
+		}
+
+		// Send delivery
+		if webhook == nil || !webhook.Active {
+			delivery.Status = model.WebhookDeliveryStatusFailed
+			delivery.NextAttemptAt = time.Time{}
+			delivery.LastError = "Webhook is not active."
+		} else {
+			s.sendDelivery(webhook, delivery, s.maxAttempts)
+		}
+
+		// Update delivery
+		if err := s.wRepo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) sendDelivery(webhook *model.Webhook, delivery *model.WebhookDelivery,
+	maxAttempts int) {
+	log.Debugf("Sending webhook delivery %d to webhook %d.", delivery.Id, webhook.Id)
+
+	now := time.Now()
+	statusCode, sErr := s.sender.Send(webhook.Url, webhook.Secret, delivery.Id,
+		delivery.EventType, delivery.Payload)
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.LastStatusCode = statusCode
+
+	// Delivery was successful
+	if sErr == nil {
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = time.Time{}
+		delivery.LastError = ""
+		return
+	}
+
+	log.Infof("Webhook delivery %d to webhook %d failed: %s", delivery.Id, webhook.Id, sErr)
+	delivery.LastError = truncateWebhookError(sErr.Error())
+
+	// Give up if maximum number of attempts is reached
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = time.Time{}
+		return
+	}
+
+	// Schedule retry
+	delay := webhookRetryBaseDelay * time.Duration(1<<(delivery.Attempts-1))
+	delivery.NextAttemptAt = now.Add(delay)
+}
+
+func truncateWebhookError(msg string) string {
+	r := []rune(msg)
+	if len(r) > webhookMaxErrorLength {
+		return string(r[:webhookMaxErrorLength])
+	}
+	return msg
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/config"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/webhook"
+)
+
+func TestSendDeliveryRetriesWithBackoffUntilFailed(t *testing.T) {
+	const secret = "s3cr3t"
+	const maxAttempts = 3
+
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get(webhook.HeaderSignature) == "" {
+			t.Errorf("request %d has no signature header", requests.Load())
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	s := &WebhookService{sender: webhook.NewSender(&config.Config{WebhookTimeout: 5})}
+	hook := &model.Webhook{Id: 1, Url: receiver.URL, Secret: secret, Active: true}
+	delivery := model.NewWebhookDelivery(hook.Id, "ping", "{}")
+
+	// Every failed attempt but the last one schedules a retry with a doubled delay
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		before := time.Now()
+		s.sendDelivery(hook, delivery, maxAttempts)
+
+		if delivery.Status != model.WebhookDeliveryStatusPending {
+			t.Fatalf("attempt %d: status = %q, want %q", attempt, delivery.Status,
+				model.WebhookDeliveryStatusPending)
+		}
+		if delivery.Attempts != attempt {
+			t.Errorf("attempt %d: attempts = %d", attempt, delivery.Attempts)
+		}
+		if delivery.LastStatusCode != http.StatusServiceUnavailable {
+			t.Errorf("attempt %d: last status code = %d", attempt, delivery.LastStatusCode)
+		}
+		if delivery.LastError == "" {
+			t.Errorf("attempt %d: last error is empty", attempt)
+		}
+		wantDelay := webhookRetryBaseDelay * time.Duration(1<<(attempt-1))
+		delay := delivery.NextAttemptAt.Sub(delivery.LastAttemptAt)
+		if delay != wantDelay {
+			t.Errorf("attempt %d: retry delay = %s, want %s", attempt, delay, wantDelay)
+		}
+		if delivery.LastAttemptAt.Before(before) {
+			t.Errorf("attempt %d: last attempt time was not updated", attempt)
+		}
+	}
+
+	// The last attempt marks the delivery as failed
+	s.sendDelivery(hook, delivery, maxAttempts)
+	if delivery.Status != model.WebhookDeliveryStatusFailed {
+		t.Errorf("status = %q, want %q", delivery.Status, model.WebhookDeliveryStatusFailed)
+	}
+	if !delivery.NextAttemptAt.IsZero() {
+		t.Errorf("next attempt = %s, want none", delivery.NextAttemptAt)
+	}
+	if got := requests.Load(); got != maxAttempts {
+		t.Errorf("receiver got %d requests, want %d", got, maxAttempts)
+	}
+}
+
+func TestSendDeliverySucceedsAfterRetry(t *testing.T) {
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	s := &WebhookService{sender: webhook.NewSender(&config.Config{WebhookTimeout: 5})}
+	hook := &model.Webhook{Id: 1, Url: receiver.URL, Secret: "secret", Active: true}
+	delivery := model.NewWebhookDelivery(hook.Id, "ping", "{}")
+
+	s.sendDelivery(hook, delivery, 5)
+	s.sendDelivery(hook, delivery, 5)
+
+	if delivery.Status != model.WebhookDeliveryStatusSucceeded {
+		t.Errorf("status = %q, want %q", delivery.Status, model.WebhookDeliveryStatusSucceeded)
+	}
+	if delivery.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", delivery.Attempts)
+	}
+	if !delivery.NextAttemptAt.IsZero() || delivery.LastError != "" {
+		t.Errorf("succeeded delivery must not have a next attempt or an error")
+	}
+}
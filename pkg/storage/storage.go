@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"kellnhofer.com/work-log/pkg/config"
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+)
+
+var keyRegex = regexp.MustCompile("^[0-9a-zA-Z_-]+$")
+
+// Storage stores files under unique keys.
+type Storage interface {
+	// Save stores the content of the reader under the supplied key.
+	Save(key string, r io.Reader) error
+	// Open opens the file with the supplied key for reading.
+	Open(key string) (io.ReadCloser, error)
+	// Delete deletes the file with the supplied key. Deleting a missing file is not an error.
+	Delete(key string) error
+}
+
+// LocalStorage stores files in a directory of the local filesystem.
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage creates a new local storage for the configured attachment directory.
+func NewLocalStorage(conf *config.Config) *LocalStorage {
+	return &LocalStorage{conf.AttachmentDir}
+}
+
+// Save stores the content of the reader under the supplied key.
+func (s *LocalStorage) Save(key string, r io.Reader) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	if mErr := os.MkdirAll(s.dir, 0o750); mErr != nil {
+		return s.createError(fmt.Sprintf("Could not create storage directory '%s'.", s.dir), mErr)
+	}
+
+	f, cErr := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if cErr != nil {
+		return s.createError(fmt.Sprintf("Could not create file '%s'.", key), cErr)
+	}
+	_, wErr := io.Copy(f, r)
+	clErr := f.Close()
+	if wErr == nil {
+		wErr = clErr
+	}
+	if wErr != nil {
+		os.Remove(path)
+		return s.createError(fmt.Sprintf("Could not write file '%s'.", key), wErr)
+	}
+	return nil
+}
+
+// Open opens the file with the supplied key for reading.
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, oErr := os.Open(path)
+	if oErr != nil {
+		return nil, s.createError(fmt.Sprintf("Could not open file '%s'.", key), oErr)
+	}
+	return f, nil
+}
+
+// Delete deletes the file with the supplied key. Deleting a missing file is not an error.
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	if rErr := os.Remove(path); rErr != nil && !os.IsNotExist(rErr) {
+		return s.createError(fmt.Sprintf("Could not delete file '%s'.", key), rErr)
+	}
+	return nil
+}
+
+func (s *LocalStorage) getPath(key string) (string, error) {
+	// Keys must not contain path elements
+	if !keyRegex.MatchString(key) {
+		err := e.NewError(e.SysStorageFailed, fmt.Sprintf("Invalid file key '%s'.", key))
+		log.Error(err.StackTrace())
+		return "", err
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+func (s *LocalStorage) createError(msg string, cause error) error {
+	err := e.WrapError(e.SysStorageFailed, msg, cause)
+	log.Error(err.StackTrace())
+	return err
+}
@@ -64,6 +64,11 @@ var permissionErrorCodes = map[model.Right]int{
 	model.RightChangeAllEntries:    e.PermChangeAllEntries,
 	model.RightGetOwnEntries:       e.PermGetOwnEntries,
 	model.RightChangeOwnEntries:    e.PermChangeOwnEntries,
+	model.RightGetWebhooks:         e.PermGetWebhooks,
+	model.RightChangeWebhooks:      e.PermChangeWebhooks,
+	model.RightApproveVacations:    e.PermApproveVacations,
+	model.RightGetInvoices:         e.PermGetInvoices,
+	model.RightChangeInvoices:      e.PermChangeInvoices,
 }
 
 func getPermissionErrorCode(right model.Right) int {
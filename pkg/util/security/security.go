@@ -2,6 +2,7 @@ package security
 
 import (
 	"context"
+	"time"
 
 	"kellnhofer.com/work-log/pkg/constant"
 	"kellnhofer.com/work-log/pkg/model"
@@ -23,3 +24,13 @@ func GetCurrentUserId(ctx context.Context) int {
 	sc := GetSecurityContext(ctx)
 	return sc.UserId
 }
+
+// GetCurrentUserLocation returns the location of the time zone of the user from the security
+// context.
+func GetCurrentUserLocation(ctx context.Context) *time.Location {
+	sc := GetSecurityContext(ctx)
+	if sc.UserLocation == nil {
+		return time.Local
+	}
+	return sc.UserLocation
+}
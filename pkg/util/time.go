@@ -5,6 +5,13 @@ import (
 	"time"
 )
 
+// ToCivilDate returns the calendar day of a time (in its location) as civil date (midnight UTC).
+// Dates without time (e.g. the first day of a contract) are civil dates, so times must be converted
+// before they are compared with such dates.
+func ToCivilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // CalculateWorkingDays calulates the number of working days between two dates
 func CalculateWorkingDays(startTime time.Time, endTime time.Time) int {
 	// Reduce dates to previous Mondays
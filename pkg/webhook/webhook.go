@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"kellnhofer.com/work-log/pkg/config"
+)
+
+// Request headers of webhook deliveries.
+const (
+	HeaderEvent     = "X-Work-Log-Event"
+	HeaderDelivery  = "X-Work-Log-Delivery"
+	HeaderSignature = "X-Work-Log-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sender sends webhook deliveries via HTTP.
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a new webhook sender for the supplied configuration.
+func NewSender(conf *config.Config) *Sender {
+	timeout := time.Duration(conf.WebhookTimeout) * time.Second
+	return &Sender{&http.Client{Timeout: timeout}}
+}
+
+// Send posts the supplied payload to the webhook URL. It returns the HTTP status code of the
+// response (or 0 if no response was received) and an error if the delivery was not successful.
+func (s *Sender) Send(url string, secret string, deliveryId int, eventType string,
+	payload string) (int, error) {
+	body := []byte(payload)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Work-Log-Webhook")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(deliveryId))
+	req.Header.Set(HeaderSignature, signaturePrefix+CreateSignature(secret, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("receiver responded with status code %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// CreateSignature creates the hex encoded HMAC-SHA256 signature of a payload.
+func CreateSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
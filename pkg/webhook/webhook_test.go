@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kellnhofer.com/work-log/pkg/config"
+)
+
+func TestSendSignsPayload(t *testing.T) {
+	const secret = "s3cr3t"
+	const payload = `{"event":"ping","data":{"webhookId":1}}`
+
+	var gotBody []byte
+	var gotHeader http.Header
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := NewSender(&config.Config{WebhookTimeout: 5})
+	statusCode, err := sender.Send(receiver.URL, secret, 7, "ping", payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if statusCode != http.StatusNoContent {
+		t.Errorf("status code = %d, want %d", statusCode, http.StatusNoContent)
+	}
+
+	if string(gotBody) != payload {
+		t.Errorf("body = %q, want %q", gotBody, payload)
+	}
+	if got := gotHeader.Get(HeaderEvent); got != "ping" {
+		t.Errorf("event header = %q, want %q", got, "ping")
+	}
+	if got := gotHeader.Get(HeaderDelivery); got != "7" {
+		t.Errorf("delivery header = %q, want %q", got, "7")
+	}
+	// The receiver verifies the signature with the shared secret
+	wantSignature := signaturePrefix + CreateSignature(secret, gotBody)
+	if got := gotHeader.Get(HeaderSignature); got != wantSignature {
+		t.Errorf("signature header = %q, want %q", got, wantSignature)
+	}
+	if got := gotHeader.Get(HeaderSignature); got == signaturePrefix+CreateSignature("other",
+		gotBody) {
+		t.Errorf("signature header must depend on the secret")
+	}
+}
+
+func TestSendFailsOnErrorStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	sender := NewSender(&config.Config{WebhookTimeout: 5})
+	statusCode, err := sender.Send(receiver.URL, "secret", 1, "ping", "{}")
+	if err == nil {
+		t.Fatalf("expected error for status code %d", statusCode)
+	}
+	if statusCode != http.StatusInternalServerError {
+		t.Errorf("status code = %d, want %d", statusCode, http.StatusInternalServerError)
+	}
+}
@@ -10,6 +10,22 @@ DROP TABLE IF EXISTS session;
 DROP TABLE IF EXISTS token;
 DROP TABLE IF EXISTS entry_type;
 DROP TABLE IF EXISTS entry_activity;
+DROP TABLE IF EXISTS entry_type_activity;
+DROP TABLE IF EXISTS project_rounding_policy;
 DROP TABLE IF EXISTS entry;
+DROP TABLE IF EXISTS expense_rate;
+DROP TABLE IF EXISTS expense;
+DROP TABLE IF EXISTS attachment;
+DROP TABLE IF EXISTS client;
+DROP TABLE IF EXISTS client_project;
+DROP TABLE IF EXISTS hourly_rate;
+DROP TABLE IF EXISTS invoice;
+DROP TABLE IF EXISTS invoice_item;
+DROP TABLE IF EXISTS invoice_number_sequence;
+DROP TABLE IF EXISTS absence;
+DROP TABLE IF EXISTS vacation_request;
+DROP TABLE IF EXISTS webhook;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS export_profile;
 
 SET FOREIGN_KEY_CHECKS = 1;
\ No newline at end of file
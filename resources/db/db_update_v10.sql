@@ -0,0 +1 @@
+ALTER TABLE entry ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER deleted_at;
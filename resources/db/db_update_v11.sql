@@ -0,0 +1,17 @@
+ALTER TABLE contract ADD COLUMN deduct_missing_breaks TINYINT(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE contract_break_rules (
+  user_id INT NOT NULL,
+  work_hours FLOAT NOT NULL,
+  break_minutes INT NOT NULL,
+  PRIMARY KEY (user_id, work_hours),
+  KEY fk_contractbreakrules_contract (user_id),
+  CONSTRAINT fk_contractbreakrules_contract FOREIGN KEY (user_id)
+    REFERENCES contract (user_id) ON DELETE CASCADE ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT INTO contract_break_rules (user_id, work_hours, break_minutes)
+  SELECT user_id, 6, 30 FROM contract;
+
+INSERT INTO contract_break_rules (user_id, work_hours, break_minutes)
+  SELECT user_id, 9, 45 FROM contract;
@@ -0,0 +1,21 @@
+CREATE TABLE absence (
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  type_id INT NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  day_fraction VARCHAR(10) NOT NULL,
+  description VARCHAR(200),
+  PRIMARY KEY (id),
+  KEY fk_absence_user (user_id),
+  KEY fk_absence_entrytype (type_id),
+  CONSTRAINT fk_absence_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_absence_entrytype FOREIGN KEY (type_id)
+    REFERENCES entry_type (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+ALTER TABLE entry ADD COLUMN absence_id INT NULL DEFAULT NULL AFTER description;
+
+ALTER TABLE entry ADD CONSTRAINT fk_entry_absence FOREIGN KEY (absence_id)
+  REFERENCES absence (id) ON DELETE SET NULL ON UPDATE NO ACTION;
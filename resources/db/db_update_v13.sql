@@ -0,0 +1,26 @@
+CREATE TABLE vacation_request (
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  day_fraction VARCHAR(10) NOT NULL,
+  description VARCHAR(200),
+  days FLOAT NOT NULL,
+  status VARCHAR(10) NOT NULL,
+  requested_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00',
+  decided_by_user_id INT NULL DEFAULT NULL,
+  decided_at TIMESTAMP NULL DEFAULT NULL,
+  decision_comment VARCHAR(200),
+  absence_id INT NULL DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY fk_vacationrequest_user (user_id),
+  KEY fk_vacationrequest_decidedbyuser (decided_by_user_id),
+  KEY fk_vacationrequest_absence (absence_id),
+  KEY idx_vacationrequest_status (status),
+  CONSTRAINT fk_vacationrequest_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_vacationrequest_decidedbyuser FOREIGN KEY (decided_by_user_id)
+    REFERENCES user (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT fk_vacationrequest_absence FOREIGN KEY (absence_id)
+    REFERENCES absence (id) ON DELETE SET NULL ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
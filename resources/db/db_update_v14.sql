@@ -0,0 +1,2 @@
+ALTER TABLE contract ADD COLUMN vacation_carry_over_max_days FLOAT NULL DEFAULT NULL;
+ALTER TABLE contract ADD COLUMN vacation_expiry_months INT NULL DEFAULT NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE balance_adjustment (
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  type VARCHAR(10) NOT NULL,
+  date DATE NOT NULL,
+  value FLOAT NOT NULL,
+  reason VARCHAR(200) NOT NULL,
+  created_by_user_id INT NULL DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00',
+  PRIMARY KEY (id),
+  KEY fk_balanceadjustment_user (user_id),
+  KEY fk_balanceadjustment_createdbyuser (created_by_user_id),
+  CONSTRAINT fk_balanceadjustment_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_balanceadjustment_createdbyuser FOREIGN KEY (created_by_user_id)
+    REFERENCES user (id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,19 @@
+CREATE TABLE year_closing (
+  user_id INT NOT NULL,
+  year INT NOT NULL,
+  overtime_hours FLOAT NOT NULL,
+  vacation_carried_over_days FLOAT NOT NULL,
+  vacation_accrued_days FLOAT NOT NULL,
+  vacation_adjusted_days FLOAT NOT NULL,
+  vacation_taken_days FLOAT NOT NULL,
+  vacation_expired_days FLOAT NOT NULL,
+  vacation_remaining_days FLOAT NOT NULL,
+  closed_by_user_id INT NULL DEFAULT NULL,
+  closed_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00',
+  PRIMARY KEY (user_id, year),
+  KEY fk_yearclosing_closedbyuser (closed_by_user_id),
+  CONSTRAINT fk_yearclosing_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_yearclosing_closedbyuser FOREIGN KEY (closed_by_user_id)
+    REFERENCES user (id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
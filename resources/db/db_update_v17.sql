@@ -0,0 +1,15 @@
+ALTER TABLE entry_type MODIFY name VARCHAR(50) NULL;
+ALTER TABLE entry_type ADD COLUMN description VARCHAR(50) NULL DEFAULT NULL AFTER name;
+ALTER TABLE entry_type ADD COLUMN counts_as_work TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE entry_type ADD COLUMN reduces_target TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE entry_type ADD COLUMN consumes_vacation TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE entry_type ADD COLUMN allows_activities TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE entry_type ADD COLUMN color VARCHAR(7) NOT NULL DEFAULT '#6c757d';
+
+UPDATE entry_type SET counts_as_work = 1, allows_activities = 1, color = '#0c63e4'
+  WHERE name = 'work';
+UPDATE entry_type SET counts_as_work = 1, color = '#6aa6ff' WHERE name = 'travel';
+UPDATE entry_type SET reduces_target = 1, consumes_vacation = 1, color = '#04a17a'
+  WHERE name = 'vacation';
+UPDATE entry_type SET reduces_target = 1, color = '#12dfab' WHERE name = 'holiday';
+UPDATE entry_type SET reduces_target = 1, color = '#e8571e' WHERE name = 'illness';
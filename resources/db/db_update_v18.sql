@@ -0,0 +1,15 @@
+ALTER TABLE entry_activity ADD COLUMN archived TINYINT(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE entry_type_activity (
+  entry_type_id INT NOT NULL,
+  entry_activity_id INT NOT NULL,
+  PRIMARY KEY (entry_type_id, entry_activity_id),
+  KEY fk_entrytypeactivity_entryactivity (entry_activity_id),
+  CONSTRAINT fk_entrytypeactivity_entrytype FOREIGN KEY (entry_type_id)
+    REFERENCES entry_type (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_entrytypeactivity_entryactivity FOREIGN KEY (entry_activity_id)
+    REFERENCES entry_activity (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT INTO entry_type_activity (entry_type_id, entry_activity_id)
+  SELECT t.id, a.id FROM entry_type t, entry_activity a WHERE t.allows_activities = 1;
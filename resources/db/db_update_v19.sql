@@ -0,0 +1,11 @@
+ALTER TABLE contract ADD COLUMN rounding_mode VARCHAR(10) NULL DEFAULT NULL;
+ALTER TABLE contract ADD COLUMN rounding_interval_minutes INT NULL DEFAULT NULL;
+ALTER TABLE contract ADD COLUMN rounding_scope VARCHAR(10) NULL DEFAULT NULL;
+
+CREATE TABLE project_rounding_policy (
+  project VARCHAR(30) NOT NULL,
+  mode VARCHAR(10) NOT NULL,
+  interval_minutes INT NOT NULL,
+  scope VARCHAR(10) NOT NULL,
+  PRIMARY KEY (project)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1 @@
+ALTER TABLE user ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '' AFTER must_change_password;
@@ -0,0 +1,35 @@
+ALTER TABLE entry_type ADD COLUMN allows_expenses TINYINT(1) NOT NULL DEFAULT 0
+  AFTER allows_activities;
+
+UPDATE entry_type SET allows_expenses = 1 WHERE name = 'travel';
+
+CREATE TABLE expense_rate (
+  id INT NOT NULL AUTO_INCREMENT,
+  type VARCHAR(10) NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  amount DECIMAL(10,3) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT INTO expense_rate (type, name, amount, currency)
+  VALUES ('mileage', 'Car', 0.30, 'EUR'), ('per_diem', 'Full day', 28.00, 'EUR'),
+    ('per_diem', 'Partial day', 14.00, 'EUR');
+
+CREATE TABLE expense (
+  id INT NOT NULL AUTO_INCREMENT,
+  entry_id INT NOT NULL,
+  type VARCHAR(10) NOT NULL,
+  rate_id INT NULL DEFAULT NULL,
+  quantity FLOAT NOT NULL,
+  amount DECIMAL(10,2) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  description VARCHAR(200) NOT NULL,
+  PRIMARY KEY (id),
+  KEY fk_expense_entry (entry_id),
+  KEY fk_expense_expenserate (rate_id),
+  CONSTRAINT fk_expense_entry FOREIGN KEY (entry_id)
+    REFERENCES entry (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_expense_expenserate FOREIGN KEY (rate_id)
+    REFERENCES expense_rate (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,14 @@
+CREATE TABLE attachment (
+  id INT NOT NULL AUTO_INCREMENT,
+  entry_id INT NOT NULL,
+  file_name VARCHAR(255) NOT NULL,
+  mime_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL,
+  storage_key VARCHAR(64) NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY unique_attachment_storage_key (storage_key),
+  KEY fk_attachment_entry (entry_id),
+  CONSTRAINT fk_attachment_entry FOREIGN KEY (entry_id)
+    REFERENCES entry (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,77 @@
+CREATE TABLE client (
+  id INT NOT NULL AUTO_INCREMENT,
+  name VARCHAR(100) NOT NULL,
+  address VARCHAR(500) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY unique_client_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE client_project (
+  project VARCHAR(30) NOT NULL,
+  client_id INT NOT NULL,
+  PRIMARY KEY (project),
+  KEY fk_clientproject_client (client_id),
+  CONSTRAINT fk_clientproject_client FOREIGN KEY (client_id)
+    REFERENCES client (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE hourly_rate (
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id INT NULL DEFAULT NULL,
+  project VARCHAR(30) NULL DEFAULT NULL,
+  activity_id INT NULL DEFAULT NULL,
+  amount DECIMAL(10,2) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  valid_from DATE NOT NULL,
+  valid_to DATE NULL DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY fk_hourlyrate_user (user_id),
+  KEY fk_hourlyrate_entryactivity (activity_id),
+  CONSTRAINT fk_hourlyrate_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_hourlyrate_entryactivity FOREIGN KEY (activity_id)
+    REFERENCES entry_activity (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE invoice (
+  id INT NOT NULL AUTO_INCREMENT,
+  number VARCHAR(20) NOT NULL,
+  client_id INT NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  currency CHAR(3) NOT NULL,
+  net_amount DECIMAL(12,2) NOT NULL,
+  tax_rate DECIMAL(5,2) NOT NULL,
+  tax_amount DECIMAL(12,2) NOT NULL,
+  total_amount DECIMAL(12,2) NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY unique_invoice_number (number),
+  KEY fk_invoice_client (client_id),
+  CONSTRAINT fk_invoice_client FOREIGN KEY (client_id)
+    REFERENCES client (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE invoice_item (
+  id INT NOT NULL AUTO_INCREMENT,
+  invoice_id INT NOT NULL,
+  entry_id INT NOT NULL,
+  user_id INT NOT NULL,
+  date DATE NOT NULL,
+  project VARCHAR(30) NOT NULL,
+  description VARCHAR(300) NOT NULL,
+  hours DECIMAL(8,2) NOT NULL,
+  rate DECIMAL(10,2) NOT NULL,
+  amount DECIMAL(12,2) NOT NULL,
+  PRIMARY KEY (id),
+  KEY fk_invoiceitem_invoice (invoice_id),
+  CONSTRAINT fk_invoiceitem_invoice FOREIGN KEY (invoice_id)
+    REFERENCES invoice (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+ALTER TABLE entry ADD COLUMN billable TINYINT(1) NOT NULL DEFAULT 0 AFTER description;
+ALTER TABLE entry ADD COLUMN invoice_id INT NULL DEFAULT NULL AFTER absence_id;
+ALTER TABLE entry ADD KEY fk_entry_invoice (invoice_id);
+ALTER TABLE entry ADD CONSTRAINT fk_entry_invoice FOREIGN KEY (invoice_id)
+  REFERENCES invoice (id) ON DELETE SET NULL ON UPDATE CASCADE;
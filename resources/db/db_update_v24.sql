@@ -0,0 +1,17 @@
+CREATE TABLE export_profile (
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  export_columns VARCHAR(200) NOT NULL,
+  delimiter VARCHAR(1) NOT NULL,
+  date_format VARCHAR(30) NOT NULL,
+  duration_unit VARCHAR(10) NOT NULL,
+  decimal_separator VARCHAR(1) NOT NULL,
+  language VARCHAR(10) NOT NULL,
+  label_separator VARCHAR(5) NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY unique_export_profile_name (user_id, name),
+  KEY fk_exportprofile_user (user_id),
+  CONSTRAINT fk_exportprofile_user FOREIGN KEY (user_id)
+    REFERENCES user (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,10 @@
+CREATE TABLE invoice_number_sequence (
+  prefix VARCHAR(10) NOT NULL,
+  last_number INT NOT NULL,
+  PRIMARY KEY (prefix)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT INTO invoice_number_sequence (prefix, last_number)
+  SELECT SUBSTRING(number, 1, 5), MAX(CAST(SUBSTRING(number, 6) AS UNSIGNED))
+  FROM invoice
+  GROUP BY SUBSTRING(number, 1, 5);
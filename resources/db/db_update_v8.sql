@@ -0,0 +1,27 @@
+CREATE TABLE webhook (
+  id INT NOT NULL AUTO_INCREMENT,
+  url VARCHAR(500) NOT NULL,
+  secret VARCHAR(100) NOT NULL,
+  event_types VARCHAR(200) NOT NULL,
+  active TINYINT(1) NOT NULL,
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE webhook_delivery (
+  id INT NOT NULL AUTO_INCREMENT,
+  webhook_id INT NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00',
+  next_attempt_at TIMESTAMP NULL DEFAULT NULL,
+  last_attempt_at TIMESTAMP NULL DEFAULT NULL,
+  last_status_code INT DEFAULT NULL,
+  last_error VARCHAR(500) DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY fk_webhook_delivery_webhook (webhook_id),
+  KEY idx_webhook_delivery_status (status, next_attempt_at),
+  CONSTRAINT fk_webhook_delivery_webhook FOREIGN KEY (webhook_id)
+    REFERENCES webhook (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
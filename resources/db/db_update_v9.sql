@@ -0,0 +1,9 @@
+ALTER TABLE entry ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00' AFTER description;
+
+ALTER TABLE entry ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '0000-00-00 00:00:00' AFTER created_at;
+
+ALTER TABLE entry ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at;
+
+UPDATE entry SET created_at = NOW(), updated_at = NOW();
+
+CREATE INDEX idx_entry_updatedat ON entry(updated_at, id);
@@ -133,12 +133,64 @@ func (c *baseEntryController) getEntryTypes(ctx context.Context) ([]*model.Entry
 	return c.eServ.GetEntryTypes(ctx)
 }
 
+func (c *baseEntryController) getEntryModalMasterData(ctx context.Context, entryTypeId int,
+	keptActId int) ([]*model.EntryType, []*model.EntryActivity, error) {
+	entryTypes, err := c.getEntryTypes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	entryActivities, err := c.getSelectableEntryActivities(ctx, entryTypeId, keptActId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entryTypes, entryActivities, nil
+}
+
+// getEntryActivities returns all activities (including archived ones) which are allowed for the
+// entry type.
 func (c *baseEntryController) getEntryActivities(ctx context.Context, entryTypeId int,
 ) ([]*model.EntryActivity, error) {
-	if entryTypeId != model.EntryTypeIdWork {
+	return c.filterEntryActivities(ctx, entryTypeId, true, 0)
+}
+
+// getSelectableEntryActivities returns the activities which can be selected for an entry of the
+// entry type. Archived activities are omitted, unless the activity is kept from the existing
+// entry.
+func (c *baseEntryController) getSelectableEntryActivities(ctx context.Context, entryTypeId int,
+	keptActId int) ([]*model.EntryActivity, error) {
+	return c.filterEntryActivities(ctx, entryTypeId, false, keptActId)
+}
+
+func (c *baseEntryController) filterEntryActivities(ctx context.Context, entryTypeId int,
+	includeArchived bool, keptActId int) ([]*model.EntryActivity, error) {
+	entryTypesMap, err := c.getEntryTypesMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entryType, ok := entryTypesMap[entryTypeId]
+	if !ok || !entryType.AllowsActivities {
 		return []*model.EntryActivity{}, nil
 	}
-	return c.eServ.GetEntryActivities(ctx)
+
+	entryActivities, err := c.eServ.GetEntryActivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filteredEntryActivities := make([]*model.EntryActivity, 0, len(entryActivities))
+	for _, entryActivity := range entryActivities {
+		if entryActivity.Id == keptActId {
+			filteredEntryActivities = append(filteredEntryActivities, entryActivity)
+			continue
+		}
+		if !entryActivity.IsAllowedForType(entryTypeId) {
+			continue
+		}
+		if entryActivity.Archived && !includeArchived {
+			continue
+		}
+		filteredEntryActivities = append(filteredEntryActivities, entryActivity)
+	}
+	return filteredEntryActivities, nil
 }
 
 func (c *baseEntryController) getEntryMasterDataMap(ctx context.Context) (map[int]*model.EntryType,
@@ -237,12 +289,12 @@ func (fh *entryFilterHelper) buildAdvancedQueryString(filter *model.FieldEntryFi
 	return strings.Join(qps[:], "|")
 }
 
-func (fh *entryFilterHelper) parseQueryString(userId int, isAdvanced bool, query string) (
-	model.EntryFilter, error) {
+func (fh *entryFilterHelper) parseQueryString(userId int, loc *time.Location, isAdvanced bool,
+	query string) (model.EntryFilter, error) {
 	if !isAdvanced {
 		return fh.parseBasicQueryString(userId, query)
 	}
-	return fh.parseAdvancedQueryString(userId, query)
+	return fh.parseAdvancedQueryString(userId, loc, query)
 }
 
 func (fh *entryFilterHelper) parseBasicQueryString(userId int, query string) (*model.TextEntryFilter,
@@ -277,8 +329,8 @@ func (fh *entryFilterHelper) parseBasicQueryString(userId int, query string) (*m
 	return filter, nil
 }
 
-func (fh *entryFilterHelper) parseAdvancedQueryString(userId int, query string,
-	) (*model.FieldEntryFilter, error) {
+func (fh *entryFilterHelper) parseAdvancedQueryString(userId int, loc *time.Location,
+	query string) (*model.FieldEntryFilter, error) {
 	filter := model.NewFieldEntryFilter()
 	filter.ByUser = true
 	filter.UserId = userId
@@ -299,7 +351,7 @@ func (fh *entryFilterHelper) parseAdvancedQueryString(userId int, query string,
 		// Convert values for entry start/end time
 		case "tim":
 			filter.ByTime = true
-			filter.StartTime, filter.EndTime, cErr = fh.parseQueryDateRange(v)
+			filter.StartTime, filter.EndTime, cErr = fh.parseQueryDateRange(v, loc)
 		// Convert value for entry activity
 		case "act":
 			filter.ByActivity = true
@@ -364,16 +416,17 @@ func (fh *entryFilterHelper) formatQueryDateRange(startDate time.Time, endDate t
 	return fmt.Sprintf("%s-%s", fh.formatQueryDate(startDate), fh.formatQueryDate(endDate))
 }
 
-func (fh *entryFilterHelper) parseQueryDateRange(dateRange string) (time.Time, time.Time, error) {
+func (fh *entryFilterHelper) parseQueryDateRange(dateRange string, loc *time.Location) (time.Time,
+	time.Time, error) {
 	se := strings.Split(dateRange, "-")
 	if len(se) < 2 {
 		return time.Time{}, time.Time{}, errors.New("invalid range")
 	}
-	startTime, err := fh.parseQueryDate(se[0])
+	startTime, err := fh.parseQueryDate(se[0], loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
-	endTime, err := fh.parseQueryDate(se[1])
+	endTime, err := fh.parseQueryDate(se[1], loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
@@ -384,8 +437,8 @@ func (fh *entryFilterHelper) formatQueryDate(date time.Time) string {
 	return date.Format(queryDateTimeFormat)
 }
 
-func (fh *entryFilterHelper) parseQueryDate(date string) (time.Time, error) {
-	return time.ParseInLocation(queryDateTimeFormat, date, time.Local)
+func (fh *entryFilterHelper) parseQueryDate(date string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(queryDateTimeFormat, date, loc)
 }
 
 func (fh *entryFilterHelper) formatQueryLabels(labels []string) string {
@@ -26,6 +26,10 @@ func getCurrentUserId(ctx context.Context) int {
 	return security.GetCurrentUserId(ctx)
 }
 
+func getCurrentUserLocation(ctx context.Context) *time.Location {
+	return security.GetCurrentUserLocation(ctx)
+}
+
 func getErrorCode(err error) int {
 	code := e.SysUnknown
 	if er, ok := err.(*e.Error); ok {
@@ -90,6 +94,21 @@ func parseId(in string, allowZero bool) (int, error) {
 	return id, nil
 }
 
+func parseVersion(in string) (int, error) {
+	version, cErr := strconv.Atoi(in)
+	if cErr != nil {
+		err := e.WrapError(e.ValVersionInvalid, "Invalid version. (Version must be numeric.)", cErr)
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+	if version <= 0 {
+		err := e.NewError(e.ValVersionInvalid, "Invalid version. (Version must be positive.)")
+		log.Debug(err.StackTrace())
+		return 0, err
+	}
+	return version, nil
+}
+
 func buildPageNumberQueryParam(pageNum int) string {
 	return "page=" + strconv.Itoa(pageNum)
 }
@@ -181,13 +200,35 @@ func parseMonth(v string) (int, int, bool, error) {
 	return year, month, true, nil
 }
 
+func buildYearQueryParam(year int) string {
+	return "year=" + strconv.Itoa(year)
+}
+
+func getYearQueryParam(ctx echo.Context) (int, bool, error) {
+	v := ctx.QueryParam("year")
+	if v == "" {
+		return 0, false, nil
+	}
+
+	year, err := strconv.Atoi(v)
+	if err != nil || len(v) != 4 || year < 1 {
+		err := e.NewError(e.ValYearInvalid, "Invalid year. (Variable must be a year with 4 "+
+			"digits.)")
+		log.Debug(err.StackTrace())
+		return 0, false, err
+	}
+
+	return year, true, nil
+}
+
 func formatMonth(year int, month int) string {
 	return fmt.Sprintf("%d%02d", year, month)
 }
 
-func parseDateTime(inDate string, inTime string, code int) (time.Time, error) {
+func parseDateTime(inDate string, inTime string, loc *time.Location, code int) (time.Time,
+	error) {
 	dt := inDate + " " + inTime
-	out, pErr := time.ParseInLocation(dateTimeFormat, dt, time.Local)
+	out, pErr := time.ParseInLocation(dateTimeFormat, dt, loc)
 	if pErr != nil {
 		err := e.WrapError(code, fmt.Sprintf("Could not parse time %s.", inTime), pErr)
 		log.Debug(err.StackTrace())
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -11,6 +13,7 @@ import (
 
 	e "kellnhofer.com/work-log/pkg/error"
 	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/log"
 	"kellnhofer.com/work-log/pkg/model"
 	"kellnhofer.com/work-log/pkg/service"
 	"kellnhofer.com/work-log/web"
@@ -26,6 +29,16 @@ type entryInput struct {
 	description string
 	project     string
 	labels      string
+	billable    string
+	version     string
+}
+
+type absenceInput struct {
+	typeId      string
+	startDate   string
+	endDate     string
+	dayFraction string
+	description string
 }
 
 // EntryController handles requests for entry endpoints.
@@ -33,13 +46,18 @@ type EntryController struct {
 	handlerHelper
 	baseUserController
 	baseEntryController
+	aServ *service.AbsenceService
+	fServ *service.AttachmentService
 }
 
 // NewEntryController creates a new entry controller.
-func NewEntryController(uServ *service.UserService, eServ *service.EntryService) *EntryController {
+func NewEntryController(uServ *service.UserService, eServ *service.EntryService,
+	aServ *service.AbsenceService, fServ *service.AttachmentService) *EntryController {
 	return &EntryController{
 		baseUserController: *newBaseUserController(uServ),
 		baseEntryController: *newBaseEntryController(eServ),
+		aServ: aServ,
+		fServ: fServ,
 	}
 }
 
@@ -53,7 +71,7 @@ func (c *EntryController) GetHxActivitiesHandler() echo.HandlerFunc {
 			return err
 		}
 
-		entryActivities, err := c.getEntryActivities(ctx, entryTypeId)
+		entryActivities, err := c.getSelectableEntryActivities(ctx, entryTypeId, 0)
 		if err != nil {
 			return err
 		}
@@ -67,16 +85,19 @@ func (c *EntryController) GetHxActivitiesHandler() echo.HandlerFunc {
 // GetHxCreateHandler returns a handler for "GET /hx/entry-modal/create".
 func (c *EntryController) GetHxCreateHandler() echo.HandlerFunc {
 	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
-		entryTypes, entryActivities, err := c.getEntryMasterData(ctx, model.EntryTypeIdWork)
+		entryTypes, entryActivities, err := c.getEntryModalMasterData(ctx, model.EntryTypeIdWork,
+			0)
 		if err != nil {
 			return err
 		}
 
 		entry := model.NewEntry()
 		entry.TypeId = model.EntryTypeIdWork
-		entry.StartTime = time.Now()
-		entry.EndTime = time.Now()
-		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, entryTypes, entryActivities)
+		now := time.Now().In(getCurrentUserLocation(ctx))
+		entry.StartTime = now
+		entry.EndTime = now
+		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, getCurrentUserLocation(ctx),
+			entryTypes, entryActivities)
 
 		return c.handleShowSuccess(eCtx, hx.EntryModalCreate(entryViewData))
 	})
@@ -88,7 +109,7 @@ func (c *EntryController) PostHxCreateHandler() echo.HandlerFunc {
 		userId := getCurrentUserId(ctx)
 		input := c.getEntryInput(eCtx)
 
-		entry, err := c.createEntryModel(0, userId, input)
+		entry, err := c.createEntryModel(0, userId, getCurrentUserLocation(ctx), input)
 		if err != nil {
 			return c.handleExecuteError(eCtx, err)
 		}
@@ -114,12 +135,13 @@ func (c *EntryController) GetHxCopyHandler() echo.HandlerFunc {
 		if err != nil {
 			return err
 		}
-		entryTypes, entryActivities, err := c.getEntryMasterData(ctx, entry.TypeId)
+		entryTypes, entryActivities, err := c.getEntryModalMasterData(ctx, entry.TypeId, 0)
 		if err != nil {
 			return err
 		}
 
-		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, entryTypes, entryActivities)
+		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, getCurrentUserLocation(ctx),
+			entryTypes, entryActivities)
 
 		return c.handleShowSuccess(eCtx, hx.EntryModalCopy(entryViewData))
 	})
@@ -138,12 +160,26 @@ func (c *EntryController) GetHxEditHandler() echo.HandlerFunc {
 		if err != nil {
 			return err
 		}
-		entryTypes, entryActivities, err := c.getEntryMasterData(ctx, entry.TypeId)
+
+		// Entries of an absence are edited via their absence
+		if entry.AbsenceId != 0 {
+			return c.showEditAbsence(eCtx, ctx, entry.AbsenceId, userId)
+		}
+
+		entryTypes, entryActivities, err := c.getEntryModalMasterData(ctx, entry.TypeId,
+			entry.ActivityId)
+		if err != nil {
+			return err
+		}
+
+		attachments, err := c.fServ.GetAttachmentsByEntryId(ctx, entry.Id)
 		if err != nil {
 			return err
 		}
 
-		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, entryTypes, entryActivities)
+		entryViewData := c.eMapper.CreateEntryDataViewModel(entry, getCurrentUserLocation(ctx),
+			entryTypes, entryActivities)
+		entryViewData.Attachments = c.eMapper.CreateAttachmentsViewModel(entry.Id, attachments)
 
 		return c.handleShowSuccess(eCtx, hx.EntryModalEdit(entryViewData))
 	})
@@ -159,12 +195,19 @@ func (c *EntryController) PostHxEditHandler() echo.HandlerFunc {
 		}
 		input := c.getEntryInput(eCtx)
 
-		entry, err := c.createEntryModel(entryId, userId, input)
+		entry, err := c.createEntryModel(entryId, userId, getCurrentUserLocation(ctx), input)
+		if err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+		entry.Version, err = parseVersion(input.version)
 		if err != nil {
 			return c.handleExecuteError(eCtx, err)
 		}
 
 		if err := c.eServ.UpdateEntry(ctx, entry); err != nil {
+			if getErrorCode(err) == e.LogicEntryVersionConflict {
+				return c.handleConflictError(eCtx, entryId)
+			}
 			return c.handleExecuteError(eCtx, err)
 		}
 
@@ -186,6 +229,11 @@ func (c *EntryController) GetHxDeleteHandler() echo.HandlerFunc {
 			return err
 		}
 
+		// Entries of an absence are deleted via their absence
+		if entry.AbsenceId != 0 {
+			return c.handleShowSuccess(eCtx, hx.EntryModalDeleteAbsence(entry.AbsenceId))
+		}
+
 		return c.handleShowSuccess(eCtx, hx.EntryModalDelete(entry.Id))
 	})
 }
@@ -207,6 +255,195 @@ func (c *EntryController) PostHxDeleteHandler() echo.HandlerFunc {
 	})
 }
 
+// PostHxCreateAttachmentHandler returns a handler for "POST /hx/entry-modal/attachments/{id}".
+func (c *EntryController) PostHxCreateAttachmentHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		entryId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		entry, err := c.getEntry(ctx, entryId, userId)
+		if err != nil {
+			return err
+		}
+
+		// Limit request size (too large requests are aborted while the form is read)
+		eCtx.Request().Body = http.MaxBytesReader(eCtx.Response(), eCtx.Request().Body,
+			c.fServ.MaxRequestSize())
+
+		fh, fErr := eCtx.FormFile("attachment")
+		if fErr != nil {
+			return c.handleExecuteError(eCtx, createAttachmentFormError(fErr))
+		}
+		f, oErr := fh.Open()
+		if oErr != nil {
+			err := e.WrapError(e.ValFileMissing, "Invalid file. (File not readable.)", oErr)
+			log.Debug(err.StackTrace())
+			return c.handleExecuteError(eCtx, err)
+		}
+		defer f.Close()
+
+		attachment := model.NewAttachment()
+		attachment.EntryId = entry.Id
+		attachment.FileName = fh.Filename
+		if err := c.fServ.CreateAttachment(ctx, attachment, f); err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		return c.handleAttachmentsChanged(eCtx, ctx, entry.Id)
+	})
+}
+
+// PostHxDeleteAttachmentHandler returns a handler for
+// "POST /hx/entry-modal/delete-attachment/{id}".
+func (c *EntryController) PostHxDeleteAttachmentHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		attachmentId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		attachment, err := c.getAttachment(ctx, attachmentId)
+		if err != nil {
+			return err
+		}
+
+		if err := c.fServ.DeleteAttachmentById(ctx, attachment.Id); err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		return c.handleAttachmentsChanged(eCtx, ctx, attachment.EntryId)
+	})
+}
+
+// GetAttachmentHandler returns a handler for "GET /attachment/{id}".
+func (c *EntryController) GetAttachmentHandler() echo.HandlerFunc {
+	return c.handler(func(eCtx echo.Context, ctx context.Context) error {
+		attachmentId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		attachment, r, err := c.fServ.OpenAttachmentById(ctx, attachmentId)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		return web.WriteAttachment(eCtx, attachment.FileName, attachment.MimeType, r)
+	})
+}
+
+// GetHxCreateAbsenceHandler returns a handler for "GET /hx/entry-modal/create-absence".
+func (c *EntryController) GetHxCreateAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		entryTypes, err := c.getEntryTypes(ctx)
+		if err != nil {
+			return err
+		}
+
+		absence := model.NewAbsence()
+		absence.TypeId = model.EntryTypeIdVacation
+		absence.StartDate = time.Now()
+		absence.EndDate = time.Now()
+		absenceViewData := c.eMapper.CreateAbsenceDataViewModel(absence, entryTypes)
+
+		return c.handleShowSuccess(eCtx, hx.EntryModalCreateAbsence(absenceViewData))
+	})
+}
+
+// PostHxCreateAbsenceHandler returns a handler for "POST /hx/entry-modal/create-absence".
+func (c *EntryController) PostHxCreateAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		input := c.getAbsenceInput(eCtx)
+
+		absence, err := c.createAbsenceModel(0, userId, input)
+		if err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		if err := c.aServ.CreateAbsence(ctx, absence); err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		return c.handleExecuteSuccess(eCtx)
+	})
+}
+
+// GetHxEditAbsenceHandler returns a handler for "GET /hx/entry-modal/edit-absence/{id}".
+func (c *EntryController) GetHxEditAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		absenceId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		return c.showEditAbsence(eCtx, ctx, absenceId, userId)
+	})
+}
+
+// PostHxEditAbsenceHandler returns a handler for "POST /hx/entry-modal/edit-absence/{id}".
+func (c *EntryController) PostHxEditAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		absenceId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+		input := c.getAbsenceInput(eCtx)
+
+		absence, err := c.createAbsenceModel(absenceId, userId, input)
+		if err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		if err := c.aServ.UpdateAbsence(ctx, absence); err != nil {
+			return c.handleExecuteError(eCtx, err)
+		}
+
+		return c.handleExecuteSuccess(eCtx)
+	})
+}
+
+// GetHxDeleteAbsenceHandler returns a handler for "GET /hx/entry-modal/delete-absence/{id}".
+func (c *EntryController) GetHxDeleteAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		absenceId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		absence, err := c.getAbsence(ctx, absenceId, userId)
+		if err != nil {
+			return err
+		}
+
+		return c.handleShowSuccess(eCtx, hx.EntryModalDeleteAbsence(absence.Id))
+	})
+}
+
+// PostHxDeleteAbsenceHandler returns a handler for "POST /hx/entry-modal/delete-absence/{id}".
+func (c *EntryController) PostHxDeleteAbsenceHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		userId := getCurrentUserId(ctx)
+		absenceId, err := getIdPathVar(eCtx)
+		if err != nil {
+			return err
+		}
+
+		if err := c.aServ.DeleteAbsenceByIdAndUserId(ctx, absenceId, userId); err != nil {
+			return err
+		}
+
+		return c.handleExecuteSuccess(eCtx)
+	})
+}
+
 // PostHxCancelHandler returns a handler for "POST /hx/entry-modal/cancel".
 func (c *EntryController) PostHxCancelHandler() echo.HandlerFunc {
 	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
@@ -224,7 +461,65 @@ func (c *EntryController) getEntryInput(eCtx echo.Context) *entryInput {
 		project:     eCtx.FormValue("project"),
 		description: eCtx.FormValue("description"),
 		labels:      eCtx.FormValue("labels"),
+		billable:    eCtx.FormValue("billable"),
+		version:     eCtx.FormValue("version"),
+	}
+}
+
+func (c *EntryController) getAbsenceInput(eCtx echo.Context) *absenceInput {
+	return &absenceInput{
+		typeId:      eCtx.FormValue("type"),
+		startDate:   eCtx.FormValue("start-date"),
+		endDate:     eCtx.FormValue("end-date"),
+		dayFraction: eCtx.FormValue("day-fraction"),
+		description: eCtx.FormValue("description"),
+	}
+}
+
+func (c *EntryController) getAbsence(ctx context.Context, absenceId int, userId int) (
+	*model.Absence, error) {
+	absence, err := c.aServ.GetAbsenceByIdAndUserId(ctx, absenceId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if absence == nil {
+		err := e.NewError(e.LogicAbsenceNotFound, fmt.Sprintf("Could not find absence %d.",
+			absenceId))
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	return absence, nil
+}
+
+func (c *EntryController) getAttachment(ctx context.Context, attachmentId int) (
+	*model.Attachment, error) {
+	attachment, err := c.fServ.GetAttachmentById(ctx, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		err := e.NewError(e.LogicAttachmentNotFound, fmt.Sprintf("Could not find attachment %d.",
+			attachmentId))
+		log.Debug(err.StackTrace())
+		return nil, err
 	}
+	return attachment, nil
+}
+
+func (c *EntryController) showEditAbsence(eCtx echo.Context, ctx context.Context, absenceId int,
+	userId int) error {
+	absence, err := c.getAbsence(ctx, absenceId, userId)
+	if err != nil {
+		return err
+	}
+	entryTypes, err := c.getEntryTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	absenceViewData := c.eMapper.CreateAbsenceDataViewModel(absence, entryTypes)
+
+	return c.handleShowSuccess(eCtx, hx.EntryModalEditAbsence(absenceViewData))
 }
 
 func (c *EntryController) handleShowSuccess(eCtx echo.Context, t templ.Component) error {
@@ -239,6 +534,20 @@ func (c *EntryController) handleExecuteSuccess(eCtx echo.Context) error {
 	return eCtx.NoContent(http.StatusOK)
 }
 
+func (c *EntryController) handleAttachmentsChanged(eCtx echo.Context, ctx context.Context,
+	entryId int) error {
+	attachments, err := c.fServ.GetAttachmentsByEntryId(ctx, entryId)
+	if err != nil {
+		return err
+	}
+	attachmentsViewData := c.eMapper.CreateAttachmentsViewModel(entryId, attachments)
+
+	// Set HTMX triggers (the attachment indicators of the entries must be updated)
+	web.HtmxTrigger(eCtx, "wlChangedEntries")
+	// Render
+	return web.RenderHx(eCtx, http.StatusOK, hx.EntryModalAttachments(attachmentsViewData))
+}
+
 func (c *EntryController) handleExecuteError(eCtx echo.Context, err error) error {
 	// Get error message
 	ec := getErrorCode(err)
@@ -248,10 +557,30 @@ func (c *EntryController) handleExecuteError(eCtx echo.Context, err error) error
 	return web.RenderHx(eCtx, http.StatusOK, hx.ModalError(em))
 }
 
+func (c *EntryController) handleConflictError(eCtx echo.Context, entryId int) error {
+	// Set HTMX triggers (the entry was changed elsewhere, so the entries must be reloaded)
+	web.HtmxTrigger(eCtx, "wlChangedEntries")
+	// Render
+	return web.RenderHx(eCtx, http.StatusOK, hx.EntryModalConflict(entryId))
+}
+
+func createAttachmentFormError(fErr error) error {
+	var mbErr *http.MaxBytesError
+	if errors.As(fErr, &mbErr) {
+		err := e.WrapError(e.LogicAttachmentTooLarge, fmt.Sprintf("Attachment too large. "+
+			"(Request must not be larger than %d bytes.)", mbErr.Limit), fErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	err := e.WrapError(e.ValFileMissing, "Invalid file. (File missing.)", fErr)
+	log.Debug(err.StackTrace())
+	return err
+}
+
 // --- Model converter functions ---
 
-func (c *EntryController) createEntryModel(id int, userId int, input *entryInput) (*model.Entry,
-	error) {
+func (c *EntryController) createEntryModel(id int, userId int, loc *time.Location,
+	input *entryInput) (*model.Entry, error) {
 	entry := model.NewEntry()
 	entry.Id = id
 	entry.UserId = userId
@@ -265,14 +594,14 @@ func (c *EntryController) createEntryModel(id int, userId int, input *entryInput
 	}
 
 	// Convert start/end time
-	if _, err := parseDateTime(input.date, "00:00", e.ValDateInvalid); err != nil {
+	if _, err := parseDateTime(input.date, "00:00", loc, e.ValDateInvalid); err != nil {
 		return nil, err
 	}
-	entry.StartTime, err = parseDateTime(input.date, input.startTime, e.ValStartTimeInvalid)
+	entry.StartTime, err = parseDateTime(input.date, input.startTime, loc, e.ValStartTimeInvalid)
 	if err != nil {
 		return nil, err
 	}
-	entry.EndTime, err = parseDateTime(input.date, input.endTime, e.ValEndTimeInvalid)
+	entry.EndTime, err = parseDateTime(input.date, input.endTime, loc, e.ValEndTimeInvalid)
 	if err != nil {
 		return nil, err
 	}
@@ -320,5 +649,51 @@ func (c *EntryController) createEntryModel(id int, userId int, input *entryInput
 		}
 	}
 
+	// Convert billable flag
+	entry.Billable = input.billable == "on"
+
 	return entry, nil
 }
+
+func (c *EntryController) createAbsenceModel(id int, userId int, input *absenceInput) (
+	*model.Absence, error) {
+	absence := model.NewAbsence()
+	absence.Id = id
+	absence.UserId = userId
+
+	var err error
+
+	// Convert type ID
+	absence.TypeId, err = parseId(input.typeId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert start/end date (absence dates are civil dates)
+	absence.StartDate, err = parseDateTime(input.startDate, "00:00", time.UTC,
+		e.ValStartDateInvalid)
+	if err != nil {
+		return nil, err
+	}
+	absence.EndDate, err = parseDateTime(input.endDate, "00:00", time.UTC, e.ValEndDateInvalid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate day fraction
+	if !model.IsValidAbsenceDayFraction(input.dayFraction) {
+		err := e.NewError(e.ValDayFractionInvalid, "Invalid day fraction.")
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+	absence.DayFraction = input.dayFraction
+
+	// Validate description
+	if err = validateMaxStringLength(input.description, model.MaxLengthEntryDescription,
+		e.ValDescriptionTooLong); err != nil {
+		return nil, err
+	}
+	absence.Description = input.description
+
+	return absence, nil
+}
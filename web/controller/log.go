@@ -33,14 +33,24 @@ type LogController struct {
 	baseEntryController
 	entryFilterHelper
 
+	cServ  *service.ComplianceService
+	vServ  *service.VacationService
+	yServ  *service.ClosingService
+	fServ  *service.AttachmentService
 	mapper *mapper.LogMapper
 }
 
 // NewLogController creates a new log controller.
-func NewLogController(uServ *service.UserService, eServ *service.EntryService) *LogController {
+func NewLogController(uServ *service.UserService, eServ *service.EntryService,
+	cServ *service.ComplianceService, vServ *service.VacationService,
+	yServ *service.ClosingService, fServ *service.AttachmentService) *LogController {
 	return &LogController{
 		baseUserController:  *newBaseUserController(uServ),
 		baseEntryController: *newBaseEntryController(eServ),
+		cServ:               cServ,
+		vServ:               vServ,
+		yServ:               yServ,
+		fServ:               fServ,
 		mapper:              mapper.NewLogMapper(),
 	}
 }
@@ -96,7 +106,7 @@ func (c *LogController) GetHxContentHandler() echo.HandlerFunc {
 // GetHxExportModalHandler returns a handler for "GET /hx/log-export-modal".
 func (c *LogController) GetHxExportModalHandler() echo.HandlerFunc {
 	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
-		now := time.Now()
+		now := time.Now().In(getCurrentUserLocation(ctx))
 		startDate := now.Format(view.DateStringFormat)
 		endDate := now.Format(view.DateStringFormat)
 		return web.RenderHx(eCtx, http.StatusOK, hx.LogExportModal(startDate, endDate))
@@ -107,7 +117,8 @@ func (c *LogController) GetHxExportModalHandler() echo.HandlerFunc {
 func (c *LogController) PostHxExportModalHandler() echo.HandlerFunc {
 	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
 		exportInput := c.getPostExportInput(eCtx)
-		exportFilter, err := c.createExportFilter(getCurrentUserId(ctx), exportInput)
+		exportFilter, err := c.createExportFilter(getCurrentUserId(ctx),
+			getCurrentUserLocation(ctx), exportInput)
 		if err != nil {
 			searchErrorMessage := loc.GetErrorMessageString(getErrorCode(err))
 			web.HtmxRetarget(eCtx, "#wl-modal-error-container")
@@ -157,23 +168,29 @@ func (c *LogController) getLogViewData(ctx context.Context, pageNum int) (*vm.Lo
 
 func (c *LogController) getLogSummaryViewData(ctx context.Context, userId int,
 	userContract *model.Contract) (*vm.LogSummary, error) {
-	// Get total work summary data
-	totalWorkSummary, err := c.eServ.GetTotalWorkSummaryByUserId(ctx, userId)
+	// Get month work summary data
+	now := time.Now().In(getCurrentUserLocation(ctx))
+	year, month := now.Year(), now.Month()
+	monthWorkSummary, err := c.eServ.GetMonthWorkSummaryByUserId(ctx, userId, year, month)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get month work summary data
-	now := time.Now()
-	year, month := now.Year(), now.Month()
-	monthWorkSummary, err := c.eServ.GetMonthWorkSummaryByUserId(ctx, userId, year, month)
+	// Get vacation balance
+	vacationBalance, err := c.vServ.GetVacationBalanceByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get overtime balance
+	overtimeHours, err := c.yServ.GetOvertimeBalanceByUserId(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create view model
-	return c.mapper.CreateLogSummaryViewModel(userContract, now, totalWorkSummary, monthWorkSummary),
-		nil
+	return c.mapper.CreateLogSummaryViewModel(userContract, now, monthWorkSummary,
+		vacationBalance, overtimeHours), nil
 }
 
 func (c *LogController) getLogEntriesViewData(ctx context.Context, userId int,
@@ -184,10 +201,39 @@ func (c *LogController) getLogEntriesViewData(ctx context.Context, userId int,
 		return nil, err
 	}
 
+	// Get attachment counts
+	attachmentCounts, err := c.fServ.GetAttachmentCountsByEntries(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get compliance violations
+	violations, err := c.getComplianceViolations(ctx, userId, entries)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create view model
 	totPageNum := calculateNumberOfTotalPages(cnt, pageSize)
 	return c.mapper.CreateLogEntriesViewModel(userContract, pageNum, totPageNum, entries,
-		entryTypesMap, entryActivitiesMap), nil
+		getCurrentUserLocation(ctx), entryTypesMap, entryActivitiesMap, attachmentCounts,
+		violations), nil
+}
+
+func (c *LogController) getComplianceViolations(ctx context.Context, userId int,
+	entries []*model.Entry) ([]*model.ComplianceViolation, error) {
+	// If there are no entries: Abort
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// Get violations of the days of the entries (entries are sorted descending)
+	loc := getCurrentUserLocation(ctx)
+	last := entries[0].StartTime.In(loc)
+	first := entries[len(entries)-1].StartTime.In(loc)
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc)
+	return c.cServ.GetComplianceViolationsByUserId(ctx, userId, start, end)
 }
 
 func (c *LogController) getEntryData(ctx context.Context, userId, pageNum int) (int, []*model.Entry,
@@ -217,8 +263,8 @@ func (c *LogController) getPostExportInput(eCtx echo.Context) *exportInput {
 	}
 }
 
-func (c *LogController) createExportFilter(userId int, input *exportInput) (*model.FieldEntryFilter,
-	error) {
+func (c *LogController) createExportFilter(userId int, loc *time.Location, input *exportInput) (
+	*model.FieldEntryFilter, error) {
 	filter := model.NewFieldEntryFilter()
 	filter.ByUser = true
 	filter.UserId = userId
@@ -227,11 +273,11 @@ func (c *LogController) createExportFilter(userId int, input *exportInput) (*mod
 
 	// Create start/end time filter
 	filter.ByTime = true
-	filter.StartTime, err = parseDateTime(input.startDate, "00:00", e.ValStartDateInvalid)
+	filter.StartTime, err = parseDateTime(input.startDate, "00:00", loc, e.ValStartDateInvalid)
 	if err != nil {
 		return nil, err
 	}
-	filter.EndTime, err = parseDateTime(input.endDate, "23:59", e.ValEndDateInvalid)
+	filter.EndTime, err = parseDateTime(input.endDate, "23:59", loc, e.ValEndDateInvalid)
 	if err != nil {
 		return nil, err
 	}
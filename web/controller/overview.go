@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"kellnhofer.com/work-log/pkg/constant"
+	"kellnhofer.com/work-log/pkg/export"
+	"kellnhofer.com/work-log/pkg/model"
 	"kellnhofer.com/work-log/pkg/service"
 	"kellnhofer.com/work-log/web"
-	"kellnhofer.com/work-log/web/export"
+	wexport "kellnhofer.com/work-log/web/export"
 	"kellnhofer.com/work-log/web/mapper"
 	vm "kellnhofer.com/work-log/web/model"
 	"kellnhofer.com/work-log/web/view/hx"
@@ -24,20 +27,31 @@ type OverviewController struct {
 	baseUserController
 	baseEntryController
 
-	mapper   *mapper.OverviewMapper
-	exporter *export.OverviewExporter
+	xServ *service.ExpenseService
+	yServ *service.ClosingService
+
+	mapper      *mapper.OverviewMapper
+	exporter    *wexport.OverviewExporter
+	pdfExporter *export.TimesheetPdfExporter
 }
 
-// NewOverviewController creates a new overview controller.
+// NewOverviewController creates a new overview controller. The timesheet header, logo and
+// signature lines are used for the PDF export.
 func NewOverviewController(uServ *service.UserService, eServ *service.EntryService,
-) *OverviewController {
+	xServ *service.ExpenseService, yServ *service.ClosingService, timesheetHeader string,
+	timesheetLogo string, timesheetSignatureLines bool) *OverviewController {
 	overviewMapper := mapper.NewOverviewMapper()
-	overviewExporter := export.NewOverviewExporter()
+	overviewExporter := wexport.NewOverviewExporter()
+	overviewPdfExporter := export.NewTimesheetPdfExporter(timesheetHeader, timesheetLogo,
+		timesheetSignatureLines)
 	return &OverviewController{
 		baseUserController:  *newBaseUserController(uServ),
 		baseEntryController: *newBaseEntryController(eServ),
+		xServ:               xServ,
+		yServ:               yServ,
 		mapper:              overviewMapper,
 		exporter:            overviewExporter,
+		pdfExporter:         overviewPdfExporter,
 	}
 }
 
@@ -98,13 +112,110 @@ func (c *OverviewController) GetOverviewExportHandler() echo.HandlerFunc {
 			return err
 		}
 
+		// Was a PDF timesheet requested?
+		if eCtx.QueryParam("format") == "pdf" {
+			user, err := c.getUser(ctx, getCurrentUserId(ctx))
+			if err != nil {
+				return err
+			}
+			entryTypesMap, entryActivitiesMap, err := c.getEntryMasterDataMap(ctx)
+			if err != nil {
+				return err
+			}
+			data, err := c.getTimesheetData(ctx, user.Name, year, month, entryTypesMap,
+				entryActivitiesMap)
+			if err != nil {
+				return err
+			}
+
+			fileName := fmt.Sprintf(constant.ExportFileNameTemplate, formatMonth(year, month),
+				"pdf")
+			file := c.pdfExporter.ExportTimesheet(data)
+
+			return web.WriteFile(eCtx, fileName, file)
+		}
+
 		overviewEntries, err := c.getOverviewViewData(ctx, year, month)
 		if err != nil {
 			return err
 		}
 
+		overviewExpenses, err := c.getOverviewExpensesViewData(ctx, year, month)
+		if err != nil {
+			return err
+		}
+
 		fileName := fmt.Sprintf(constant.ExportFileNameTemplate, overviewEntries.CurrMonth, "xlsx")
-		file := c.exporter.ExportOverviewEntries(overviewEntries)
+		file := c.exporter.ExportOverviewEntries(overviewEntries, overviewExpenses)
+
+		return web.WriteFile(eCtx, fileName, file)
+	})
+}
+
+// GetOverviewYearHandler returns a handler for "GET /overview/year".
+func (c *OverviewController) GetOverviewYearHandler() echo.HandlerFunc {
+	return c.handler(func(eCtx echo.Context, ctx context.Context) error {
+		userInfo, err := c.getUserInfoViewData(ctx)
+		if err != nil {
+			return err
+		}
+
+		year, err := c.getGetOverviewYearParams(eCtx)
+		if err != nil {
+			return err
+		}
+		yearStr := strconv.Itoa(year)
+
+		return web.RenderPage(eCtx, http.StatusOK, page.OverviewYear(userInfo, yearStr))
+	})
+}
+
+// GetHxYearNavHandler returns a handler for "GET /hx/overview/year".
+func (c *OverviewController) GetHxYearNavHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		year, err := c.getGetOverviewYearParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		web.HtmxPushUrl(eCtx, c.buildOverviewYearUrl(year))
+		return web.RenderHx(eCtx, http.StatusOK, hx.OverviewYear())
+	})
+}
+
+// GetHxYearContentHandler returns a handler for "GET /hx/overview/year/content".
+func (c *OverviewController) GetHxYearContentHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		year, err := c.getGetOverviewYearParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		overviewYear, err := c.getOverviewYearViewData(ctx, year)
+		if err != nil {
+			return err
+		}
+
+		web.HtmxPushUrl(eCtx, c.buildOverviewYearUrl(year))
+		return web.RenderHx(eCtx, http.StatusOK, hx.OverviewYearContent(overviewYear))
+	})
+}
+
+// GetOverviewYearExportHandler returns a handler for "GET /overview/year/export".
+func (c *OverviewController) GetOverviewYearExportHandler() echo.HandlerFunc {
+	return c.resourceHandler(func(eCtx echo.Context, ctx context.Context) error {
+		year, err := c.getGetOverviewYearParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		overviewYear, err := c.getOverviewYearViewData(ctx, year)
+		if err != nil {
+			return err
+		}
+
+		fileName := fmt.Sprintf(constant.ExportFileNameTemplate, overviewYear.CurrYear, "xlsx")
+		file := c.exporter.ExportOverviewYear(overviewYear)
 
 		return web.WriteFile(eCtx, fileName, file)
 	})
@@ -112,6 +223,24 @@ func (c *OverviewController) GetOverviewExportHandler() echo.HandlerFunc {
 
 func (c *OverviewController) getOverviewViewData(ctx context.Context, year int, month int,
 ) (*vm.OverviewEntries, error) {
+	// Get entry master data
+	entryTypesMap, entryActivitiesMap, err := c.getEntryMasterDataMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Get timesheet data
+	data, err := c.getTimesheetData(ctx, "", year, month, entryTypesMap, entryActivitiesMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create view model
+	return c.mapper.CreateOverviewEntriesViewModel(data, entryTypesMap), nil
+}
+
+func (c *OverviewController) getTimesheetData(ctx context.Context, userName string, year int,
+	month int, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity) (*export.TimesheetData, error) {
 	// Get current user information
 	userId := getCurrentUserId(ctx)
 	userContract, err := c.getUserContract(ctx, userId)
@@ -124,15 +253,39 @@ func (c *OverviewController) getOverviewViewData(ctx context.Context, year int,
 	if err != nil {
 		return nil, err
 	}
-	// Get entry master data
-	entryTypesMap, entryActivitiesMap, err := c.getEntryMasterDataMap(ctx)
+	// Get rounding policies
+	roundingPolicies, err := c.eServ.GetRoundingPoliciesByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create timesheet data
+	return export.NewTimesheetData(userName, userContract, year, month, entries,
+		getCurrentUserLocation(ctx), entryTypesMap, entryActivitiesMap, roundingPolicies), nil
+}
+
+func (c *OverviewController) getOverviewExpensesViewData(ctx context.Context, year int,
+	month int) (*vm.OverviewExpenses, error) {
+	// Get expense report of current user
+	report, err := c.xServ.GetMonthExpenseReportByUserId(ctx, getCurrentUserId(ctx), year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create view model
+	return c.mapper.CreateOverviewExpensesViewModel(report), nil
+}
+
+func (c *OverviewController) getOverviewYearViewData(ctx context.Context, year int,
+) (*vm.OverviewYear, error) {
+	// Get year overview of current user
+	overview, err := c.yServ.GetYearOverviewByUserId(ctx, getCurrentUserId(ctx), year)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create view model
-	return c.mapper.CreateOverviewEntriesViewModel(userContract, year, month, entries,
-		entryTypesMap, entryActivitiesMap), nil
+	return c.mapper.CreateOverviewYearViewModel(overview), nil
 }
 
 // --- Helper functions ---
@@ -154,10 +307,34 @@ func (c *OverviewController) getGetOverviewParams(ctx echo.Context) (int, int, e
 	// Was a year and month provided?
 	if !avail {
 		// Get current year/month
-		t := time.Now()
+		t := time.Now().In(getCurrentUserLocation(getContext(ctx)))
 		return t.Year(), int(t.Month()), nil
 	} else {
 		// Use these
 		return y, m, nil
 	}
 }
+
+func (c *OverviewController) buildOverviewYearUrl(year int) string {
+	if year != 0 {
+		return "/overview/year?" + buildYearQueryParam(year)
+	}
+	return "/overview/year"
+}
+
+func (c *OverviewController) getGetOverviewYearParams(ctx echo.Context) (int, error) {
+	// Get year
+	y, avail, err := getYearQueryParam(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Was a year provided?
+	if !avail {
+		// Get current year
+		return time.Now().In(getCurrentUserLocation(getContext(ctx))).Year(), nil
+	} else {
+		// Use this
+		return y, nil
+	}
+}
@@ -45,14 +45,17 @@ type SearchController struct {
 	baseEntryController
 	entryFilterHelper
 
+	fServ  *service.AttachmentService
 	mapper *mapper.SearchMapper
 }
 
 // NewSearchController creates a new search controller.
-func NewSearchController(uServ *service.UserService, eServ *service.EntryService) *SearchController {
+func NewSearchController(uServ *service.UserService, eServ *service.EntryService,
+	fServ *service.AttachmentService) *SearchController {
 	return &SearchController{
 		baseUserController:  *newBaseUserController(uServ),
 		baseEntryController: *newBaseEntryController(eServ),
+		fServ:               fServ,
 		mapper:              mapper.NewSearchMapper(),
 	}
 }
@@ -70,7 +73,8 @@ func (c *SearchController) GetSearchHandler() echo.HandlerFunc {
 			return err
 		}
 
-		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), isAdvanced, query)
+		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), getCurrentUserLocation(ctx),
+			isAdvanced, query)
 		if err != nil {
 			return err
 		}
@@ -94,7 +98,8 @@ func (c *SearchController) GetHxContentHandler() echo.HandlerFunc {
 			return err
 		}
 
-		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), isAdvanced, query)
+		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), getCurrentUserLocation(ctx),
+			isAdvanced, query)
 		if err != nil {
 			return err
 		}
@@ -120,7 +125,8 @@ func (c *SearchController) GetHxModalHandler() echo.HandlerFunc {
 			return err
 		}
 
-		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), isAdvanced, query)
+		searchFilter, err := c.parseQueryString(getCurrentUserId(ctx), getCurrentUserLocation(ctx),
+			isAdvanced, query)
 		if err != nil {
 			return err
 		}
@@ -141,7 +147,8 @@ func (c *SearchController) PostHxModalHandler() echo.HandlerFunc {
 
 		userId := getCurrentUserId(ctx)
 		searchInput := c.getPostSearchInput(eCtx)
-		searchFilter, err := c.createSearchFilter(userId, isAdvanced, searchInput)
+		searchFilter, err := c.createSearchFilter(userId, getCurrentUserLocation(ctx), isAdvanced,
+			searchInput)
 		if err != nil {
 			searchErrorMessage := loc.GetErrorMessageString(getErrorCode(err))
 			web.HtmxRetarget(eCtx, "#wl-modal-error-container")
@@ -222,8 +229,9 @@ func (c *SearchController) getAdvancedSearchQueryViewData(ctx context.Context,
 		filter.TypeId = model.EntryTypeIdWork
 	}
 	if !filter.ByTime {
-		filter.StartTime = time.Now()
-		filter.EndTime = time.Now()
+		now := time.Now().In(getCurrentUserLocation(ctx))
+		filter.StartTime = now
+		filter.EndTime = now
 	}
 
 	// Get entry master data
@@ -258,10 +266,16 @@ func (c *SearchController) getSearchEntriesViewData(ctx context.Context,
 		return nil, err
 	}
 
+	// Get attachment counts
+	attachmentCounts, err := c.fServ.GetAttachmentCountsByEntries(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create view model
 	totPageNum := calculateNumberOfTotalPages(cnt, pageSize)
-	return c.mapper.CreateSearchEntriesViewModel(pageNum, totPageNum, entries, entryTypesMap,
-		entryActivitiesMap), nil
+	return c.mapper.CreateSearchEntriesViewModel(pageNum, totPageNum, entries,
+		getCurrentUserLocation(ctx), entryTypesMap, entryActivitiesMap, attachmentCounts), nil
 }
 
 // --- Search query functions ---
@@ -285,12 +299,12 @@ func (c *SearchController) getPostSearchInput(eCtx echo.Context) *searchInput {
 	}
 }
 
-func (c *SearchController) createSearchFilter(userId int, isAdvanced bool, input *searchInput,
-) (model.EntryFilter, error) {
+func (c *SearchController) createSearchFilter(userId int, loc *time.Location, isAdvanced bool,
+	input *searchInput) (model.EntryFilter, error) {
 	if !isAdvanced {
 		return c.createSearchTextFilter(userId, input)
 	}
-	return c.createSearchFieldFilter(userId, input)
+	return c.createSearchFieldFilter(userId, loc, input)
 }
 
 func (c *SearchController) createSearchTextFilter(userId int, input *searchInput) (model.EntryFilter,
@@ -311,8 +325,8 @@ func (c *SearchController) createSearchTextFilter(userId int, input *searchInput
 	return filter, nil
 }
 
-func (c *SearchController) createSearchFieldFilter(userId int, input *searchInput) (model.EntryFilter,
-	error) {
+func (c *SearchController) createSearchFieldFilter(userId int, loc *time.Location,
+	input *searchInput) (model.EntryFilter, error) {
 	filter := model.NewFieldEntryFilter()
 	filter.ByUser = true
 	filter.UserId = userId
@@ -328,11 +342,11 @@ func (c *SearchController) createSearchFieldFilter(userId int, input *searchInpu
 
 	// Create start/end time filter
 	filter.ByTime = input.byDate == "on"
-	filter.StartTime, err = parseDateTime(input.startDate, "00:00", e.ValStartDateInvalid)
+	filter.StartTime, err = parseDateTime(input.startDate, "00:00", loc, e.ValStartDateInvalid)
 	if err != nil {
 		return nil, err
 	}
-	filter.EndTime, err = parseDateTime(input.endDate, "23:59", e.ValEndDateInvalid)
+	filter.EndTime, err = parseDateTime(input.endDate, "23:59", loc, e.ValEndDateInvalid)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	e "kellnhofer.com/work-log/pkg/error"
+	"kellnhofer.com/work-log/pkg/log"
+	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/service"
+	"kellnhofer.com/work-log/web"
+	"kellnhofer.com/work-log/web/mapper"
+	vm "kellnhofer.com/work-log/web/model"
+	"kellnhofer.com/work-log/web/view"
+	"kellnhofer.com/work-log/web/view/hx"
+	"kellnhofer.com/work-log/web/view/page"
+)
+
+// StatisticsController handles requests for statistics endpoints.
+type StatisticsController struct {
+	handlerHelper
+	baseUserController
+	baseEntryController
+
+	sServ *service.StatisticsService
+
+	mapper *mapper.StatisticsMapper
+}
+
+// NewStatisticsController creates a new statistics controller.
+func NewStatisticsController(uServ *service.UserService, eServ *service.EntryService,
+	sServ *service.StatisticsService) *StatisticsController {
+	return &StatisticsController{
+		baseUserController:  *newBaseUserController(uServ),
+		baseEntryController: *newBaseEntryController(eServ),
+		sServ:               sServ,
+		mapper:              mapper.NewStatisticsMapper(),
+	}
+}
+
+// GetStatisticsHandler returns a handler for "GET /statistics".
+func (c *StatisticsController) GetStatisticsHandler() echo.HandlerFunc {
+	return c.handler(func(eCtx echo.Context, ctx context.Context) error {
+		userInfo, err := c.getUserInfoViewData(ctx)
+		if err != nil {
+			return err
+		}
+
+		params, err := c.getGetStatisticsParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		return web.RenderPage(eCtx, http.StatusOK, page.Statistics(userInfo,
+			c.buildStatisticsUrlParams(params)))
+	})
+}
+
+// GetHxNavHandler returns a handler for "GET /hx/statistics".
+func (c *StatisticsController) GetHxNavHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		params, err := c.getGetStatisticsParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		web.HtmxPushUrl(eCtx, c.buildStatisticsUrl(params))
+		return web.RenderHx(eCtx, http.StatusOK, hx.Statistics(c.buildStatisticsUrlParams(params)))
+	})
+}
+
+// GetHxContentHandler returns a handler for "GET /hx/statistics/content".
+func (c *StatisticsController) GetHxContentHandler() echo.HandlerFunc {
+	return c.hxHandler(func(eCtx echo.Context, ctx context.Context) error {
+		params, err := c.getGetStatisticsParams(eCtx)
+		if err != nil {
+			return err
+		}
+
+		statistics, err := c.getStatisticsViewData(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		web.HtmxPushUrl(eCtx, c.buildStatisticsUrl(params))
+		return web.RenderHx(eCtx, http.StatusOK, hx.StatisticsContent(statistics))
+	})
+}
+
+func (c *StatisticsController) getStatisticsViewData(ctx context.Context,
+	params *statisticsParams) (*vm.Statistics, error) {
+	// Get statistics of all entries the current user can see
+	stats, err := c.sServ.GetStatistics(ctx, nil, params.start, params.end, params.grouping,
+		params.interval)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get entry types (for colors)
+	entryTypesMap, _, err := c.getEntryMasterDataMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create view model
+	return c.mapper.CreateStatisticsViewModel(stats, entryTypesMap), nil
+}
+
+// --- Helper functions ---
+
+type statisticsParams struct {
+	start    time.Time
+	end      time.Time
+	grouping model.StatisticsGrouping
+	interval model.StatisticsInterval
+}
+
+func (c *StatisticsController) buildStatisticsUrl(params *statisticsParams) string {
+	return "/statistics?" + c.buildStatisticsUrlParams(params)
+}
+
+func (c *StatisticsController) buildStatisticsUrlParams(params *statisticsParams) string {
+	v := url.Values{}
+	v.Set("start", params.start.Format(view.DateStringFormat))
+	v.Set("end", params.end.Format(view.DateStringFormat))
+	v.Set("group", string(params.grouping))
+	v.Set("interval", string(params.interval))
+	return v.Encode()
+}
+
+func (c *StatisticsController) getGetStatisticsParams(eCtx echo.Context) (*statisticsParams,
+	error) {
+	// Default period is the current month
+	now := time.Now().In(getCurrentUserLocation(getContext(eCtx)))
+	params := &statisticsParams{
+		start:    time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+		grouping: model.StatisticsGroupingType,
+		interval: model.StatisticsIntervalDay,
+	}
+	params.end = params.start.AddDate(0, 1, -1)
+
+	// Get period
+	var err error
+	if v := eCtx.QueryParam("start"); v != "" {
+		params.start, err = parseDateTime(v, "00:00", now.Location(), e.ValStartDateInvalid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if v := eCtx.QueryParam("end"); v != "" {
+		params.end, err = parseDateTime(v, "00:00", now.Location(), e.ValEndDateInvalid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.end.Before(params.start) {
+		err := e.NewError(e.ValEndDateInvalid, "Invalid end date. (End must not be before start.)")
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+
+	// Get grouping and interval
+	if v := eCtx.QueryParam("group"); v != "" {
+		params.grouping = model.StatisticsGrouping(v)
+		if !slices.Contains(model.StatisticsGroupings, params.grouping) {
+			err := e.NewError(e.ValGroupingInvalid, "Invalid grouping.")
+			log.Debug(err.StackTrace())
+			return nil, err
+		}
+	}
+	if v := eCtx.QueryParam("interval"); v != "" {
+		params.interval = model.StatisticsInterval(v)
+		if !slices.Contains(model.StatisticsIntervals, params.interval) {
+			err := e.NewError(e.ValIntervalInvalid, "Invalid interval.")
+			log.Debug(err.StackTrace())
+			return nil, err
+		}
+	}
+	if !model.IsValidStatisticsPeriod(params.start, params.end, params.interval) {
+		err := e.NewError(e.ValPeriodTooLong, "Invalid period. (Period is too long.)")
+		log.Debug(err.StackTrace())
+		return nil, err
+	}
+
+	return params, nil
+}
@@ -16,11 +16,17 @@ import (
 type UserController struct {
 	handlerHelper
 	baseUserController
+
+	vServ *service.VacationService
+	bServ *service.AdjustmentService
 }
 
-func NewUserController(uServ *service.UserService) *UserController {
+func NewUserController(uServ *service.UserService, vServ *service.VacationService,
+	bServ *service.AdjustmentService) *UserController {
 	return &UserController{
 		baseUserController: *newBaseUserController(uServ),
+		vServ:              vServ,
+		bServ:              bServ,
 	}
 }
 
@@ -52,5 +58,14 @@ func (c *UserController) getUserProfileInfoViewData(ctx context.Context) (*vm.Us
 	if err != nil {
 		return nil, err
 	}
-	return c.uMapper.CreateUserProfileInfoViewModel(user, userContract), nil
+	vacationLedger, err := c.vServ.GetVacationLedgerByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	adjustments, err := c.bServ.GetBalanceAdjustmentsByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return c.uMapper.CreateUserProfileInfoViewModel(user, userContract, vacationLedger,
+		adjustments), nil
 }
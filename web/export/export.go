@@ -3,7 +3,6 @@ package export
 import (
 	"io"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -129,145 +128,6 @@ func (e *exporter) createWriterTo(exp *export) io.WriterTo {
 	}
 }
 
-// --- Entries exporter ---
-
-// EntriesExporter exports the data to an Excel file.
-type EntriesExporter struct {
-	exporter
-}
-
-// NewEntriesExporter creates a new entries exporter.
-func NewEntriesExporter() *EntriesExporter {
-	return &EntriesExporter{}
-}
-
-// ExportEntries creates the Excel file for the supplied data and returns it as an io.WriterTo that
-// can be used to write the file to a writer.
-func (e *EntriesExporter) ExportEntries(filterDetails vm.EntryFilterDetails,
-	entries *vm.ListEntries) io.WriterTo {
-	exp := e.createNewExport()
-
-	// Configure document properties
-	e.configureDocProps(exp)
-
-	// Configure work sheet
-	e.configureWorkSheet(exp)
-
-	// Write title
-	e.writeTitle(exp, filterDetails)
-	// Write entries
-	e.writeEntries(exp, entries)
-
-	return e.createWriterTo(exp)
-}
-
-func (e *EntriesExporter) configureWorkSheet(exp *export) {
-	f := exp.file
-	sheet := exp.sheet
-	styles := exp.styles
-
-	f.SetColWidth(sheet, "A", "A", 12)
-	f.SetColWidth(sheet, "B", "B", 10.5)
-	f.SetColWidth(sheet, "C", "E", 7.5)
-	f.SetColWidth(sheet, "F", "F", 16.5)
-	f.SetColWidth(sheet, "G", "G", 16.5)
-	f.SetColWidth(sheet, "H", "H", 42)
-	f.SetColStyle(sheet, "A:H", styles.base)
-}
-
-func (e *EntriesExporter) writeTitle(exp *export, filterDetails vm.EntryFilterDetails) {
-	f := exp.file
-	sheet := exp.sheet
-	styles := exp.styles
-
-	f.MergeCell(sheet, "A1", "H1")
-	f.MergeCell(sheet, "A2", "H2")
-	f.MergeCell(sheet, "A3", "H3")
-	f.SetCellValue(sheet, "A1", createString("entryExportTitle", createString("appName")))
-	f.SetCellValue(sheet, "A2", e.buildExportDetailsString(filterDetails))
-	f.SetCellStyle(sheet, "A1", "A1", styles.title)
-	f.SetCellStyle(sheet, "A2", "A2", styles.textBold)
-}
-
-func (e *EntriesExporter) buildExportDetailsString(filterDetails vm.EntryFilterDetails) string {
-	if filterDetails == nil {
-		return ""
-	}
-
-	switch fd := filterDetails.(type) {
-	case *vm.BasicEntryFilterDetails:
-		return createString("formLabelText") + " " + createQuotedString(fd.Text)
-	case *vm.AdvancedEntryFilterDetails:
-		var details []string
-		if fd.ByType {
-			details = append(details, createString("formLabelType")+" "+fd.Type)
-		}
-		if fd.ByDate {
-			details = append(details, createString("formLabelDate")+" "+fd.Date)
-		}
-		if fd.ByActivity {
-			details = append(details, createString("formLabelActivity")+" "+fd.Activity)
-		}
-		if fd.ByProject {
-			project := createQuotedString(fd.Project)
-			details = append(details, createString("formLabelProject")+" "+project)
-		}
-		if fd.ByDescription {
-			description := createQuotedString(fd.Description)
-			details = append(details, createString("formLabelDescription")+" "+description)
-		}
-		if fd.ByLabels {
-			labels := strings.Join(createQuotedStrings(fd.Labels), ", ")
-			details = append(details, createString("formLabelLabels")+" "+labels)
-		}
-		return strings.Join(details, " | ")
-	default:
-		return ""
-	}
-}
-
-func (e *EntriesExporter) writeEntries(exp *export, entries *vm.ListEntries) {
-	f := exp.file
-	sheet := exp.sheet
-	styles := exp.styles
-
-	// Create heading
-	f.MergeCell(sheet, "A4", "H4")
-	f.SetCellValue(sheet, "A4", createString("entryExportHeadingEntries"))
-	f.SetCellStyle(sheet, "A4", "A4", styles.textBold)
-
-	// Create table header
-	f.SetCellValue(sheet, "A5", createString("tableColDate"))
-	f.SetCellValue(sheet, "B5", createString("tableColType"))
-	f.SetCellValue(sheet, "C5", createString("tableColStart"))
-	f.SetCellValue(sheet, "D5", createString("tableColEnd"))
-	f.SetCellValue(sheet, "E5", createString("tableColNet"))
-	f.SetCellValue(sheet, "F5", createString("tableColActivity"))
-	f.SetCellValue(sheet, "G5", createString("tableColProject"))
-	f.SetCellValue(sheet, "H5", createString("tableColDescription"))
-	f.SetCellStyle(sheet, "A5", "E5", styles.tableHeader)
-	f.SetCellStyle(sheet, "F5", "H5", styles.tableHeader)
-
-	// Create table body
-	startRow := 6
-	curRow := startRow
-	for _, day := range entries.Days {
-		for _, entry := range day.Entries {
-			f.SetCellValue(sheet, getCellName("A", curRow), day.Date)
-			f.SetCellValue(sheet, getCellName("B", curRow), entry.EntryType)
-			f.SetCellValue(sheet, getCellName("C", curRow), entry.StartTime)
-			f.SetCellValue(sheet, getCellName("D", curRow), entry.EndTime)
-			f.SetCellValue(sheet, getCellName("E", curRow), entry.Duration)
-			f.SetCellValue(sheet, getCellName("F", curRow), entry.EntryActivity)
-			f.SetCellValue(sheet, getCellName("G", curRow), entry.Project)
-			f.SetCellValue(sheet, getCellName("H", curRow), entry.Description)
-			curRow++
-		}
-	}
-	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("E", curRow-1), styles.tableBody)
-	f.SetCellStyle(sheet, getCellName("F", startRow), getCellName("H", curRow-1), styles.tableBody)
-}
-
 // --- Overview exporter ---
 
 // OverviewExporter exports the overview data to an Excel file.
@@ -281,8 +141,10 @@ func NewOverviewExporter() *OverviewExporter {
 }
 
 // ExportOverviewEntries creates the Excel file for the supplied data and returns it as an
-// io.WriterTo that can be used to write the file to a writer.
-func (e *OverviewExporter) ExportOverviewEntries(overviewEntries *vm.OverviewEntries) io.WriterTo {
+// io.WriterTo that can be used to write the file to a writer. If expenses are supplied, they are
+// written to a second sheet.
+func (e *OverviewExporter) ExportOverviewEntries(overviewEntries *vm.OverviewEntries,
+	overviewExpenses *vm.OverviewExpenses) io.WriterTo {
 	exp := e.createNewExport()
 
 	// Configure document properties
@@ -294,9 +156,18 @@ func (e *OverviewExporter) ExportOverviewEntries(overviewEntries *vm.OverviewEnt
 	// Write title
 	e.writeTitle(exp, overviewEntries)
 	// Write summary
-	e.writeSummary(exp, overviewEntries)
+	nextRow := e.writeSummary(exp, overviewEntries)
+	// Write rounding
+	if overviewEntries.Rounding != nil {
+		nextRow = e.writeRounding(exp, overviewEntries.Rounding, nextRow)
+	}
 	// Write entries
-	e.writeEntries(exp, overviewEntries)
+	e.writeEntries(exp, overviewEntries, nextRow)
+
+	// Write expenses
+	if overviewExpenses != nil && len(overviewExpenses.Items) > 0 {
+		e.writeExpensesSheet(exp, overviewEntries, overviewExpenses)
+	}
 
 	return e.createWriterTo(exp)
 }
@@ -312,7 +183,8 @@ func (e *OverviewExporter) configureWorkSheet(exp *export) {
 	f.SetColWidth(sheet, "F", "F", 16.5)
 	f.SetColWidth(sheet, "G", "G", 16.5)
 	f.SetColWidth(sheet, "H", "H", 42)
-	f.SetColStyle(sheet, "A:H", styles.base)
+	f.SetColWidth(sheet, "I", "I", 10.5)
+	f.SetColStyle(sheet, "A:I", styles.base)
 }
 
 func (e *OverviewExporter) writeTitle(exp *export, overviewEntries *vm.OverviewEntries) {
@@ -329,7 +201,7 @@ func (e *OverviewExporter) writeTitle(exp *export, overviewEntries *vm.OverviewE
 	f.SetCellStyle(sheet, "A2", "A2", styles.textBold)
 }
 
-func (e *OverviewExporter) writeSummary(exp *export, overviewEntries *vm.OverviewEntries) {
+func (e *OverviewExporter) writeSummary(exp *export, overviewEntries *vm.OverviewEntries) int {
 	f := exp.file
 	sheet := exp.sheet
 	styles := exp.styles
@@ -343,20 +215,6 @@ func (e *OverviewExporter) writeSummary(exp *export, overviewEntries *vm.Overvie
 	f.MergeCell(sheet, "B7", "C7")
 	f.MergeCell(sheet, "D7", "H7")
 	f.MergeCell(sheet, "A8", "H8")
-	f.MergeCell(sheet, "B9", "C9")
-	f.MergeCell(sheet, "D9", "H9")
-	f.MergeCell(sheet, "B10", "C10")
-	f.MergeCell(sheet, "D10", "H10")
-	f.MergeCell(sheet, "B11", "C11")
-	f.MergeCell(sheet, "D11", "H11")
-	f.MergeCell(sheet, "B12", "C12")
-	f.MergeCell(sheet, "D12", "H12")
-	f.MergeCell(sheet, "B13", "C13")
-	f.MergeCell(sheet, "D13", "H13")
-	f.MergeCell(sheet, "B14", "C14")
-	f.MergeCell(sheet, "D14", "H14")
-	f.MergeCell(sheet, "A15", "H15")
-	f.MergeCell(sheet, "D15", "H15")
 
 	// Create heading
 	f.SetCellValue(sheet, "A4", createString("overviewExportHeadingSummary"))
@@ -372,46 +230,105 @@ func (e *OverviewExporter) writeSummary(exp *export, overviewEntries *vm.Overvie
 	f.SetCellStyle(sheet, "A5", "A7", styles.tableHeader)
 	f.SetCellStyle(sheet, "B5", "C7", styles.tableBodyAlignmentRight)
 
-	// Create types table
-	f.SetCellValue(sheet, "A9", createString("entryTypeWork"))
-	f.SetCellValue(sheet, "A10", createString("entryTypeTravel"))
-	f.SetCellValue(sheet, "A11", createString("entryTypeVacation"))
-	f.SetCellValue(sheet, "A12", createString("entryTypeHoliday"))
-	f.SetCellValue(sheet, "A13", createString("entryTypeIllness"))
-	f.SetCellValue(sheet, "B9", overviewEntries.Summary.TypeHours[vm.EntryTypeIdWork])
-	f.SetCellValue(sheet, "B10", overviewEntries.Summary.TypeHours[vm.EntryTypeIdTravel])
-	f.SetCellValue(sheet, "B11", overviewEntries.Summary.TypeHours[vm.EntryTypeIdVacation])
-	f.SetCellValue(sheet, "B12", overviewEntries.Summary.TypeHours[vm.EntryTypeIdHoliday])
-	f.SetCellValue(sheet, "B13", overviewEntries.Summary.TypeHours[vm.EntryTypeIdIllness])
-	f.SetCellValue(sheet, "B14", overviewEntries.Summary.MonthActualHours)
-	f.SetCellStyle(sheet, "A9", "A14", styles.tableHeader)
-	f.SetCellStyle(sheet, "B9", "C14", styles.tableBodyAlignmentRight)
+	// Create types table (one row per type with hours and a total row)
+	startRow := 9
+	curRow := startRow
+	for _, t := range overviewEntries.Summary.Types {
+		f.MergeCell(sheet, getCellName("B", curRow), getCellName("C", curRow))
+		f.MergeCell(sheet, getCellName("D", curRow), getCellName("H", curRow))
+		f.SetCellValue(sheet, getCellName("A", curRow), t.Description)
+		f.SetCellValue(sheet, getCellName("B", curRow), t.Hours)
+		curRow++
+	}
+	f.MergeCell(sheet, getCellName("B", curRow), getCellName("C", curRow))
+	f.MergeCell(sheet, getCellName("D", curRow), getCellName("H", curRow))
+	f.SetCellValue(sheet, getCellName("B", curRow), overviewEntries.Summary.MonthActualHours)
+	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("A", curRow), styles.tableHeader)
+	f.SetCellStyle(sheet, getCellName("B", startRow), getCellName("C", curRow),
+		styles.tableBodyAlignmentRight)
+	curRow++
+	f.MergeCell(sheet, getCellName("A", curRow), getCellName("H", curRow))
+
+	// Return next free row
+	return curRow + 1
+}
+
+func (e *OverviewExporter) writeRounding(exp *export, rounding *vm.OverviewRounding,
+	headingRow int) int {
+	f := exp.file
+	sheet := exp.sheet
+	styles := exp.styles
+
+	// Create heading
+	f.MergeCell(sheet, getCellName("A", headingRow), getCellName("H", headingRow))
+	f.SetCellValue(sheet, getCellName("A", headingRow),
+		createString("overviewExportHeadingRounding"))
+	f.SetCellStyle(sheet, getCellName("A", headingRow), getCellName("A", headingRow),
+		styles.textBold)
+
+	// Create policies rows
+	curRow := headingRow + 1
+	for _, policy := range rounding.Policies {
+		f.MergeCell(sheet, getCellName("A", curRow), getCellName("H", curRow))
+		f.SetCellValue(sheet, getCellName("A", curRow), policy)
+		curRow++
+	}
+
+	// Create billed hours row
+	if rounding.IsReportScope {
+		f.MergeCell(sheet, getCellName("B", curRow), getCellName("C", curRow))
+		f.SetCellValue(sheet, getCellName("A", curRow), createString("overviewRoundingBilledHours"))
+		f.SetCellValue(sheet, getCellName("B", curRow), rounding.MonthBilledHours)
+		f.SetCellStyle(sheet, getCellName("A", curRow), getCellName("A", curRow),
+			styles.tableHeader)
+		f.SetCellStyle(sheet, getCellName("B", curRow), getCellName("C", curRow),
+			styles.tableBodyAlignmentRight)
+		curRow++
+	}
+	f.MergeCell(sheet, getCellName("A", curRow), getCellName("H", curRow))
+
+	// Return next free row
+	return curRow + 1
 }
 
-func (e *OverviewExporter) writeEntries(exp *export, overviewEntries *vm.OverviewEntries) {
+func (e *OverviewExporter) writeEntries(exp *export, overviewEntries *vm.OverviewEntries,
+	headingRow int) {
 	f := exp.file
 	sheet := exp.sheet
 	styles := exp.styles
 
+	showBilled := overviewEntries.Rounding != nil && overviewEntries.Rounding.IsReportScope
+	lastCol := "H"
+	if showBilled {
+		lastCol = "I"
+	}
+
 	// Create heading
-	f.MergeCell(sheet, "A16", "H16")
-	f.SetCellValue(sheet, "A16", createString("overviewExportHeadingEntries"))
-	f.SetCellStyle(sheet, "A16", "A16", styles.textBold)
+	f.MergeCell(sheet, getCellName("A", headingRow), getCellName(lastCol, headingRow))
+	f.SetCellValue(sheet, getCellName("A", headingRow), createString("overviewExportHeadingEntries"))
+	f.SetCellStyle(sheet, getCellName("A", headingRow), getCellName("A", headingRow),
+		styles.textBold)
 
 	// Create table header
-	f.SetCellValue(sheet, "A17", createString("tableColDate"))
-	f.SetCellValue(sheet, "B17", createString("tableColType"))
-	f.SetCellValue(sheet, "C17", createString("tableColStart"))
-	f.SetCellValue(sheet, "D17", createString("tableColEnd"))
-	f.SetCellValue(sheet, "E17", createString("tableColNet"))
-	f.SetCellValue(sheet, "F17", createString("tableColActivity"))
-	f.SetCellValue(sheet, "G17", createString("tableColProject"))
-	f.SetCellValue(sheet, "H17", createString("tableColDescription"))
-	f.SetCellStyle(sheet, "A17", "E17", styles.tableHeader)
-	f.SetCellStyle(sheet, "F17", "H17", styles.tableHeader)
+	headerRow := headingRow + 1
+	f.SetCellValue(sheet, getCellName("A", headerRow), createString("tableColDate"))
+	f.SetCellValue(sheet, getCellName("B", headerRow), createString("tableColType"))
+	f.SetCellValue(sheet, getCellName("C", headerRow), createString("tableColStart"))
+	f.SetCellValue(sheet, getCellName("D", headerRow), createString("tableColEnd"))
+	f.SetCellValue(sheet, getCellName("E", headerRow), createString("tableColNet"))
+	f.SetCellValue(sheet, getCellName("F", headerRow), createString("tableColActivity"))
+	f.SetCellValue(sheet, getCellName("G", headerRow), createString("tableColProject"))
+	f.SetCellValue(sheet, getCellName("H", headerRow), createString("tableColDescription"))
+	if showBilled {
+		f.SetCellValue(sheet, getCellName("I", headerRow), createString("tableColBilled"))
+	}
+	f.SetCellStyle(sheet, getCellName("A", headerRow), getCellName("E", headerRow),
+		styles.tableHeader)
+	f.SetCellStyle(sheet, getCellName("F", headerRow), getCellName(lastCol, headerRow),
+		styles.tableHeader)
 
 	// Create table body
-	startRow := 18
+	startRow := headerRow + 1
 	curRow := startRow
 	for _, day := range overviewEntries.EntriesDays {
 		f.SetCellValue(sheet, getCellName("A", curRow), day.Weekday+" "+day.Date)
@@ -433,34 +350,183 @@ func (e *OverviewExporter) writeEntries(exp *export, overviewEntries *vm.Overvie
 				f.SetCellValue(sheet, getCellName("F", curRow), entry.Activity)
 				f.SetCellValue(sheet, getCellName("G", curRow), entry.Project)
 				f.SetCellValue(sheet, getCellName("H", curRow), entry.Description)
+				if showBilled {
+					f.SetCellValue(sheet, getCellName("I", curRow), entry.BilledDuration)
+				}
 				curRow++
 			}
 		}
-		if len(day.Entries) > 1 {
+		if len(day.Entries) > 1 || day.IsBreakRuleViolated {
 			f.SetCellValue(sheet, getCellName("E", curRow), day.Hours)
+			if day.IsBreakRuleViolated {
+				f.SetCellValue(sheet, getCellName("F", curRow), day.MissingBreakHours+" "+
+					createString("labelMissingBreak"))
+			}
 			curRow++
 		}
 	}
 	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("E", curRow-1), styles.tableBody)
-	f.SetCellStyle(sheet, getCellName("F", startRow), getCellName("H", curRow-1), styles.tableBody)
+	f.SetCellStyle(sheet, getCellName("F", startRow), getCellName(lastCol, curRow-1),
+		styles.tableBody)
 }
 
-// --- Helper functions ---
+func (e *OverviewExporter) writeExpensesSheet(exp *export, overviewEntries *vm.OverviewEntries,
+	overviewExpenses *vm.OverviewExpenses) {
+	f := exp.file
+	sheet := createString("overviewExportSheetNameExpenses")
+	styles := exp.styles
 
-func createString(key string, args ...any) string {
-	return loc.CreateString(key, args...)
-}
+	// Create and configure sheet
+	f.NewSheet(sheet)
+	f.SetColWidth(sheet, "A", "A", 12)
+	f.SetColWidth(sheet, "B", "B", 16.5)
+	f.SetColWidth(sheet, "C", "C", 12)
+	f.SetColWidth(sheet, "D", "E", 10.5)
+	f.SetColWidth(sheet, "F", "F", 7.5)
+	f.SetColWidth(sheet, "G", "G", 16.5)
+	f.SetColWidth(sheet, "H", "H", 42)
+	f.SetColStyle(sheet, "A:H", styles.base)
 
-func createQuotedString(value string) string {
-	return "\"" + value + "\""
+	// Write title
+	f.MergeCell(sheet, "A1", "H1")
+	f.MergeCell(sheet, "A2", "H2")
+	f.MergeCell(sheet, "A3", "H3")
+	f.SetCellValue(sheet, "A1", createString("overviewExportTitle", createString("appName")))
+	f.SetCellValue(sheet, "A2", overviewEntries.CurrMonthName)
+	f.SetCellStyle(sheet, "A1", "A1", styles.title)
+	f.SetCellStyle(sheet, "A2", "A2", styles.textBold)
+
+	// Create heading
+	f.MergeCell(sheet, "A4", "H4")
+	f.SetCellValue(sheet, "A4", createString("overviewExportHeadingExpenses"))
+	f.SetCellStyle(sheet, "A4", "A4", styles.textBold)
+
+	// Create table header
+	f.SetCellValue(sheet, "A5", createString("tableColDate"))
+	f.SetCellValue(sheet, "B5", createString("tableColType"))
+	f.SetCellValue(sheet, "C5", createString("tableColRate"))
+	f.SetCellValue(sheet, "D5", createString("tableColQuantity"))
+	f.SetCellValue(sheet, "E5", createString("tableColAmount"))
+	f.SetCellValue(sheet, "F5", createString("tableColCurrency"))
+	f.SetCellValue(sheet, "G5", createString("tableColProject"))
+	f.SetCellValue(sheet, "H5", createString("tableColDescription"))
+	f.SetCellStyle(sheet, "A5", "H5", styles.tableHeader)
+
+	// Create table body
+	startRow := 6
+	curRow := startRow
+	for _, expense := range overviewExpenses.Items {
+		f.SetCellValue(sheet, getCellName("A", curRow), expense.Weekday+" "+expense.Date)
+		f.SetCellValue(sheet, getCellName("B", curRow), expense.Type)
+		f.SetCellValue(sheet, getCellName("C", curRow), expense.Rate)
+		f.SetCellValue(sheet, getCellName("D", curRow), expense.Quantity)
+		f.SetCellValue(sheet, getCellName("E", curRow), expense.Amount)
+		f.SetCellValue(sheet, getCellName("F", curRow), expense.Currency)
+		f.SetCellValue(sheet, getCellName("G", curRow), expense.Project)
+		f.SetCellValue(sheet, getCellName("H", curRow), expense.Description)
+		curRow++
+	}
+	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("C", curRow-1), styles.tableBody)
+	f.SetCellStyle(sheet, getCellName("D", startRow), getCellName("E", curRow-1),
+		styles.tableBodyAlignmentRight)
+	f.SetCellStyle(sheet, getCellName("F", startRow), getCellName("H", curRow-1), styles.tableBody)
+	f.MergeCell(sheet, getCellName("A", curRow), getCellName("H", curRow))
+
+	// Create totals table (one row per currency)
+	headingRow := curRow + 1
+	f.MergeCell(sheet, getCellName("A", headingRow), getCellName("H", headingRow))
+	f.SetCellValue(sheet, getCellName("A", headingRow),
+		createString("overviewExportHeadingExpenseTotals"))
+	f.SetCellStyle(sheet, getCellName("A", headingRow), getCellName("A", headingRow),
+		styles.textBold)
+	startRow = headingRow + 1
+	curRow = startRow
+	for _, total := range overviewExpenses.Totals {
+		f.SetCellValue(sheet, getCellName("A", curRow), total.Currency)
+		f.SetCellValue(sheet, getCellName("B", curRow), total.Amount)
+		curRow++
+	}
+	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("A", curRow-1),
+		styles.tableHeader)
+	f.SetCellStyle(sheet, getCellName("B", startRow), getCellName("B", curRow-1),
+		styles.tableBodyAlignmentRight)
 }
 
-func createQuotedStrings(values []string) []string {
-	quotedValues := make([]string, len(values))
-	for i, value := range values {
-		quotedValues[i] = createQuotedString(value)
+// ExportOverviewYear creates the Excel file for the supplied year overview data and returns it as
+// an io.WriterTo that can be used to write the file to a writer.
+func (e *OverviewExporter) ExportOverviewYear(overviewYear *vm.OverviewYear) io.WriterTo {
+	exp := e.createNewExport()
+	f := exp.file
+	sheet := exp.sheet
+	styles := exp.styles
+
+	// Configure document properties
+	e.configureDocProps(exp)
+
+	// Configure work sheet
+	f.SetColWidth(sheet, "A", "A", 16.5)
+	f.SetColWidth(sheet, "B", "H", 12)
+	f.SetColStyle(sheet, "A:H", styles.base)
+
+	// Write title
+	f.MergeCell(sheet, "A1", "H1")
+	f.MergeCell(sheet, "A2", "H2")
+	f.MergeCell(sheet, "A3", "H3")
+	f.SetCellValue(sheet, "A1", createString("overviewExportTitle", createString("appName")))
+	f.SetCellValue(sheet, "A2", overviewYear.CurrYear)
+	f.SetCellStyle(sheet, "A1", "A1", styles.title)
+	f.SetCellStyle(sheet, "A2", "A2", styles.textBold)
+
+	// Write summary
+	f.MergeCell(sheet, "A4", "H4")
+	f.SetCellValue(sheet, "A4", createString("overviewExportHeadingSummary"))
+	f.SetCellStyle(sheet, "A4", "A4", styles.textBold)
+	f.SetCellValue(sheet, "A5", createString("overviewYearLabelStartOvertime"))
+	f.SetCellValue(sheet, "A6", createString("overviewYearLabelVacationEntitled"))
+	f.SetCellValue(sheet, "B5", overviewYear.StartOvertimeHours)
+	f.SetCellValue(sheet, "B6", overviewYear.VacationEntitledDays)
+	f.SetCellStyle(sheet, "A5", "A6", styles.tableHeader)
+	f.SetCellStyle(sheet, "B5", "B6", styles.tableBodyAlignmentRight)
+	f.MergeCell(sheet, "A7", "H7")
+
+	// Write months
+	f.MergeCell(sheet, "A8", "H8")
+	f.SetCellValue(sheet, "A8", createString("overviewYearExportHeadingMonths"))
+	f.SetCellStyle(sheet, "A8", "A8", styles.textBold)
+	f.SetCellValue(sheet, "A9", createString("overviewYearColMonth"))
+	f.SetCellValue(sheet, "B9", createString("overviewYearColTarget"))
+	f.SetCellValue(sheet, "C9", createString("overviewYearColActual"))
+	f.SetCellValue(sheet, "D9", createString("overviewYearColBalance"))
+	f.SetCellValue(sheet, "E9", createString("overviewYearColOvertime"))
+	f.SetCellValue(sheet, "F9", createString("overviewYearColVacationTaken"))
+	f.SetCellValue(sheet, "G9", createString("overviewYearColVacationRemaining"))
+	f.SetCellValue(sheet, "H9", createString("overviewYearColIllness"))
+	f.SetCellStyle(sheet, "A9", "H9", styles.tableHeader)
+	startRow := 10
+	curRow := startRow
+	for _, month := range append(overviewYear.Months, overviewYear.Total) {
+		f.SetCellValue(sheet, getCellName("A", curRow), month.Name)
+		f.SetCellValue(sheet, getCellName("B", curRow), month.TargetHours)
+		f.SetCellValue(sheet, getCellName("C", curRow), month.ActualHours)
+		f.SetCellValue(sheet, getCellName("D", curRow), month.BalanceHours)
+		f.SetCellValue(sheet, getCellName("E", curRow), month.OvertimeHours)
+		f.SetCellValue(sheet, getCellName("F", curRow), month.VacationTakenDays)
+		f.SetCellValue(sheet, getCellName("G", curRow), month.VacationRemainingDays)
+		f.SetCellValue(sheet, getCellName("H", curRow), month.IllnessDays)
+		curRow++
 	}
-	return quotedValues
+	f.SetCellStyle(sheet, getCellName("A", startRow), getCellName("A", curRow-1),
+		styles.tableHeader)
+	f.SetCellStyle(sheet, getCellName("B", startRow), getCellName("H", curRow-1),
+		styles.tableBodyAlignmentRight)
+
+	return e.createWriterTo(exp)
+}
+
+// --- Helper functions ---
+
+func createString(key string, args ...any) string {
+	return loc.CreateString(key, args...)
 }
 
 func getCellName(col string, row int) string {
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"kellnhofer.com/work-log/pkg/model"
 	vm "kellnhofer.com/work-log/web/model"
 )
@@ -16,23 +18,65 @@ func NewEntryMapper() *EntryMapper {
 }
 
 // CreateEntryDataViewModel creates a view model for the entry modal.
-func (m *EntryMapper) CreateEntryDataViewModel(entry *model.Entry, types []*model.EntryType,
-	activities []*model.EntryActivity) *vm.EntryData {
+func (m *EntryMapper) CreateEntryDataViewModel(entry *model.Entry, loc *time.Location,
+	types []*model.EntryType, activities []*model.EntryActivity) *vm.EntryData {
 	return &vm.EntryData{
-		Entry:           m.CreateEntryViewModel(entry),
+		Entry:           m.CreateEntryViewModel(m.toLocationEntry(entry, loc)),
 		EntryTypes:      m.CreateEntryTypesViewModel(types),
 		EntryActivities: m.CreateEntryActivitiesViewModel(activities),
 	}
 }
 
+// CreateAttachmentsViewModel creates a view model for the attachments of a entry.
+func (m *EntryMapper) CreateAttachmentsViewModel(entryId int,
+	attachments []*model.Attachment) *vm.Attachments {
+	asvm := &vm.Attachments{
+		EntryId: entryId,
+		Items:   make([]*vm.Attachment, 0, len(attachments)),
+	}
+	for _, attachment := range attachments {
+		asvm.Items = append(asvm.Items, &vm.Attachment{
+			Id:       attachment.Id,
+			FileName: attachment.FileName,
+			Size:     getFileSizeString(attachment.Size),
+		})
+	}
+	return asvm
+}
+
+// CreateAbsenceDataViewModel creates a view model for the absence modal. Only entry types which
+// can be used for absences are offered.
+func (m *EntryMapper) CreateAbsenceDataViewModel(absence *model.Absence,
+	types []*model.EntryType) *vm.AbsenceData {
+	absenceTypes := make([]*model.EntryType, 0, len(types))
+	for _, t := range types {
+		if t.IsAbsenceType() {
+			absenceTypes = append(absenceTypes, t)
+		}
+	}
+
+	return &vm.AbsenceData{
+		Absence: &vm.Absence{
+			Id:             absence.Id,
+			TypeId:         absence.TypeId,
+			StartDateValue: getDateString(absence.StartDate),
+			EndDateValue:   getDateString(absence.EndDate),
+			DayFraction:    absence.DayFraction,
+			Description:    absence.Description,
+		},
+		EntryTypes: m.CreateEntryTypesViewModel(absenceTypes),
+	}
+}
+
 // CreateListEntriesViewModel creates a view model for the entries list.
-func (m *EntryMapper) CreateListEntriesViewModel(entries []*model.Entry,
+func (m *EntryMapper) CreateListEntriesViewModel(entries []*model.Entry, loc *time.Location,
 	entryTypesMap map[int]*model.EntryType,
 	entryActivitiesMap map[int]*model.EntryActivity) *vm.ListEntries {
 	lesvm := &vm.ListEntries{}
 
 	// Create entries
-	lesvm.Days = m.createEntriesViewModel(nil, entries, entryTypesMap, entryActivitiesMap, false)
+	lesvm.Days = m.createEntriesViewModel(nil, m.toLocationEntries(entries, loc), entryTypesMap,
+		entryActivitiesMap, nil, false)
 
 	return lesvm
 }
@@ -3,6 +3,7 @@ package mapper
 import (
 	"time"
 
+	"kellnhofer.com/work-log/pkg/loc"
 	"kellnhofer.com/work-log/pkg/model"
 	"kellnhofer.com/work-log/pkg/util"
 	vm "kellnhofer.com/work-log/web/model"
@@ -20,18 +21,21 @@ func NewLogMapper() *LogMapper {
 
 // CreateLogSummaryViewModel creates a summary view model for the log page.
 func (m *LogMapper) CreateLogSummaryViewModel(userContract *model.Contract, now time.Time,
-	totalWorkSummary *model.WorkSummary, monthWorkSummary *model.WorkSummary) *vm.LogSummary {
-	// If no user contract or work summary was provided: Skip calculation
-	if userContract == nil || totalWorkSummary == nil || monthWorkSummary == nil {
+	monthWorkSummary *model.WorkSummary, vacationBalance *model.VacationBalance,
+	totalOvertimeHours float32) *vm.LogSummary {
+	// If no user contract, work summary or vacation balance was provided: Skip calculation
+	if userContract == nil || monthWorkSummary == nil || vacationBalance == nil {
 		return nil
 	}
-	return m.createSummaryViewModel(userContract, now, totalWorkSummary, monthWorkSummary)
+	return m.createSummaryViewModel(userContract, now, monthWorkSummary, vacationBalance,
+		totalOvertimeHours)
 }
 
 // CreateLogEntriesViewModel creates a entries view model for the log page.
 func (m *LogMapper) CreateLogEntriesViewModel(userContract *model.Contract, curPageNum int,
-	totPageNum int, entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
-	entryActivitiesMap map[int]*model.EntryActivity) *vm.ListEntries {
+	totPageNum int, entries []*model.Entry, loc *time.Location,
+	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
+	attachmentCounts map[int]int, violations []*model.ComplianceViolation) *vm.ListEntries {
 	lesvm := &vm.ListEntries{}
 
 	// Calculate paging nav numbers
@@ -40,14 +44,45 @@ func (m *LogMapper) CreateLogEntriesViewModel(userContract *model.Contract, curP
 		vm.PageNavItems)
 
 	// Create entries
-	lesvm.Days = m.createEntriesViewModel(userContract, entries, entryTypesMap, entryActivitiesMap,
-		true)
+	lesvm.Days = m.createEntriesViewModel(userContract, m.toLocationEntries(entries, loc),
+		entryTypesMap, entryActivitiesMap, attachmentCounts, true)
+
+	// Add compliance warnings
+	dayWarnings := make(map[string][]string)
+	for _, v := range violations {
+		date := formatDate(v.Date)
+		dayWarnings[date] = append(dayWarnings[date], m.createComplianceWarning(v))
+	}
+	for _, day := range lesvm.Days {
+		day.ComplianceWarnings = dayWarnings[day.Date]
+	}
 
 	return lesvm
 }
 
+func (m *LogMapper) createComplianceWarning(v *model.ComplianceViolation) string {
+	switch v.Rule {
+	case model.ComplianceRuleMaxDailyHours:
+		return loc.CreateString("complianceMaxDailyHours", formatHours(v.Actual),
+			formatHours(v.Limit))
+	case model.ComplianceRuleMaxWeeklyHours:
+		return loc.CreateString("complianceMaxWeeklyHours", formatHours(v.Actual),
+			formatHours(v.Limit))
+	case model.ComplianceRuleMinRestPeriod:
+		return loc.CreateString("complianceMinRestPeriod", formatHours(v.Actual),
+			formatHours(v.Limit))
+	case model.ComplianceRuleSundayWork:
+		return loc.CreateString("complianceSundayWork", formatHours(v.Actual))
+	case model.ComplianceRuleNightWork:
+		return loc.CreateString("complianceNightWork", formatHours(v.Actual))
+	default:
+		return string(v.Rule)
+	}
+}
+
 func (m *LogMapper) createSummaryViewModel(userContract *model.Contract, now time.Time,
-	totalWorkSummary *model.WorkSummary, monthWorkSummary *model.WorkSummary) *vm.LogSummary {
+	monthWorkSummary *model.WorkSummary, vacationBalance *model.VacationBalance,
+	totalOvertimeHours float32) *vm.LogSummary {
 	// Calculate monthly actual and target
 	monthActualHours := m.calculateMonthActualHours(monthWorkSummary)
 	monthTargetHours := m.calculateMonthTargetHours(userContract, now)
@@ -66,10 +101,8 @@ func (m *LogMapper) createSummaryViewModel(userContract *model.Contract, now tim
 	curUndertimePercent := m.calculatePercentage(curUndertimeHours, monthTotalHours)
 	curRemainingPercent := 100 - curLoggedPercent - curUndertimePercent
 
-	// Calulate total overtime and remaining vacation
-	totalOvertimeHours := m.calculateTotalOvertimeHours(userContract, now, totalWorkSummary)
-	totalRemainingVacationDays := m.calculateTotalRemainingVacationDays(userContract, now,
-		totalWorkSummary)
+	// Calulate remaining vacation
+	totalRemainingVacationDays := m.calculateTotalRemainingVacationDays(vacationBalance)
 
 	// Create summary
 	return &vm.LogSummary{
@@ -94,6 +127,7 @@ func (m *LogMapper) calculateMonthActualHours(monthWorkSummary *model.WorkSummar
 	for _, wd := range monthWorkSummary.WorkDurations {
 		workDuration = workDuration + wd.WorkDuration
 	}
+	workDuration = workDuration - monthWorkSummary.BreakDeduction
 
 	// Return rounded hours
 	return getRoundedHours(workDuration)
@@ -108,8 +142,8 @@ func (m *LogMapper) calculateMonthTargetHours(userContract *model.Contract, now
 	}
 
 	// Create month interval
-	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
-	end := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.Local)
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
 
 	// Calculate work days
 	workDays := util.CalculateWorkingDays(start, end)
@@ -150,8 +184,8 @@ func (m *LogMapper) calculateCurrentRequiredHours(userContract *model.Contract,
 	}
 
 	// Create month interval
-	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
-	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
 
 	// Calculate work days
 	workDays := util.CalculateWorkingDays(start, end)
@@ -179,102 +213,11 @@ func (m *LogMapper) calculateCurrentOvertimeUndertimeHours(actualHours float32,
 	return overtimeHours, undertimeHours
 }
 
-func (m *LogMapper) calculateTotalOvertimeHours(userContract *model.Contract, now time.Time,
-	workSummary *model.WorkSummary) float32 {
-	// Get target working durations
-	targetWorkDurations := m.convertWorkingHours(userContract.WorkingHours)
-	// Abort if no target working durations were set
-	if len(targetWorkDurations) == 0 {
-		return 0.0
-	}
-
-	// Calculate initial overtime duration
-	initOvertimeDuration := time.Duration(int(userContract.InitOvertimeHours*60.0)) * time.Minute
-
-	// Calculate actual duration
-	var actualWorkDuration time.Duration
-	for _, workDuration := range workSummary.WorkDurations {
-		actualWorkDuration = actualWorkDuration + workDuration.WorkDuration
-	}
-
-	// Calculate target duration
-	start := userContract.FirstDay
-	end := now
-	targetWorkDuration := time.Duration(0)
-	for i := 0; i < len(targetWorkDurations); i++ {
-		// Calculate interval start/end
-		intStart := start
-		if i > 0 {
-			intStart = targetWorkDurations[i].fromDate
-		}
-		intEnd := end
-		if i+1 < len(targetWorkDurations) {
-			intEnd = targetWorkDurations[i+1].fromDate.AddDate(0, 0, -1)
-		}
-
-		// Calculate interval work days
-		intWorkDays := util.CalculateWorkingDays(intStart, intEnd)
-
-		// Calculate interval target duration
-		intTargetWorkDuration := time.Duration(intWorkDays) * targetWorkDurations[i].duration
-
-		// Update target duration
-		targetWorkDuration = targetWorkDuration + intTargetWorkDuration
-	}
-
-	// Calculate overtime
-	overtimeDuration := initOvertimeDuration + actualWorkDuration - targetWorkDuration
-
-	// Return rounded hours
-	return getRoundedHours(overtimeDuration)
-}
-
-func (m *LogMapper) calculateTotalRemainingVacationDays(userContract *model.Contract, now time.Time,
-	workSummary *model.WorkSummary) float32 {
-	// Get monthly vacation days
-	vacationDays := m.convertVacationDays(userContract.VacationDays)
-	// Get daily working durations
-	workDurations := m.convertWorkingHours(userContract.WorkingHours)
-	// Abort if no vacation days or working durations were set
-	if len(vacationDays) == 0 || len(workDurations) == 0 {
-		return 0.0
-	}
-
-	// Calculate initial vacation hours
-	initVacationHours := userContract.InitVacationDays * float32(workDurations[0].duration.Hours())
-
-	// Calculate available vacation hours (month by month)
-	start := userContract.FirstDay
-	curMonth := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.Local)
-	endMonth := time.Date(now.Year()+1, time.January, 1, 0, 0, 0, 0, time.Local)
-	availableVacationHours := float32(0.0)
-	for curMonth.Before(endMonth) {
-		// Get vacation days and working duration for current month
-		vd := m.findVacationDaysForDate(vacationDays, curMonth)
-		wd := m.findWorkingDurationForDate(workDurations, curMonth)
-		// Calculate vacation hours for current month
-		availableVacationHours = availableVacationHours + vd*float32(wd.Hours())
-		// Calculate next month
-		curMonth = curMonth.AddDate(0, 1, 0)
-	}
-
-	// Calculate taken vacation hours
-	takenVacationHours := float32(0.0)
-	for _, workDuration := range workSummary.WorkDurations {
-		if workDuration.TypeId == model.EntryTypeIdVacation {
-			takenVacationHours = takenVacationHours + float32(workDuration.WorkDuration.Hours())
-		}
-	}
-
-	// Calculate remaining vacation hours
-	remainingVacationHours := initVacationHours + availableVacationHours - takenVacationHours
-
-	// Convert vacation hours to vacation days
-	wd := m.findWorkingDurationForDate(workDurations, now)
-	var remainingVacationDays float32
-	if remainingVacationHours > 0 {
-		remainingVacationDays = remainingVacationHours / float32(wd.Hours())
+func (m *LogMapper) calculateTotalRemainingVacationDays(vacationBalance *model.VacationBalance,
+) float32 {
+	// The balance already considers carried over and expired days
+	if vacationBalance.RemainingDays > 0 {
+		return vacationBalance.RemainingDays
 	}
-
-	return remainingVacationDays
+	return 0.0
 }
@@ -11,6 +11,7 @@ import (
 
 	"kellnhofer.com/work-log/pkg/loc"
 	"kellnhofer.com/work-log/pkg/model"
+	"kellnhofer.com/work-log/pkg/util"
 	vm "kellnhofer.com/work-log/web/model"
 	"kellnhofer.com/work-log/web/view"
 )
@@ -20,16 +21,19 @@ type dailyWorkingDuration struct {
 	duration time.Duration
 }
 
-type monthlyVacationDays struct {
-	fromDate time.Time
-	days     float32
+type listEntriesDay struct {
+	vm                 *vm.ListEntriesDay
+	date               time.Time
+	entries            []*model.Entry
+	workDuration       time.Duration
+	targetWorkDuration time.Duration
 }
 
 type mapper struct {}
 
 func (m *mapper) createEntriesViewModel(userContract *model.Contract, entries []*model.Entry,
 	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
-	checkMissingOrOverlapping bool) []*vm.ListEntriesDay {
+	attachmentCounts map[int]int, checkMissingOrOverlapping bool) []*vm.ListEntriesDay {
 	ldsvm := make([]*vm.ListEntriesDay, 0, 10)
 
 	var calcTargetWorkDurationReached bool
@@ -43,6 +47,7 @@ func (m *mapper) createEntriesViewModel(userContract *model.Contract, entries []
 	}
 
 	var ldvm *vm.ListEntriesDay
+	var lds []*listEntriesDay
 	prevDate := ""
 	var prevStartTime *time.Time
 	var totalWorkDuration time.Duration
@@ -76,7 +81,11 @@ func (m *mapper) createEntriesViewModel(userContract *model.Contract, entries []
 				Entries: make([]*vm.ListEntry, 0, 10),
 			}
 			ldsvm = append(ldsvm, ldvm)
+			lds = append(lds, &listEntriesDay{vm: ldvm, date: entry.StartTime,
+				targetWorkDuration: targetWorkDuration})
 		}
+		ld := lds[len(lds)-1]
+		ld.entries = append(ld.entries, entry)
 
 		// Calculate work duration
 		duration := entry.EndTime.Sub(entry.StartTime)
@@ -110,26 +119,53 @@ func (m *mapper) createEntriesViewModel(userContract *model.Contract, entries []
 
 		// Create and add new entry
 		ldvm.Entries = append(ldvm.Entries, &vm.ListEntry{
-			Id:            entry.Id,
-			EntryType:     m.getEntryTypeDescription(entryTypesMap, entry.TypeId),
-			StartTime:     formatTime(entry.StartTime),
-			EndTime:       formatTime(entry.EndTime),
-			Duration:      formatHours(duration),
-			EntryActivity: m.getEntryActivityDescription(entryActivitiesMap, entry.ActivityId),
-			Project:       entry.Project,
-			Description:   entry.Description,
-			Labels:        entry.Labels,
+			Id:              entry.Id,
+			EntryType:       m.getEntryTypeDescription(entryTypesMap, entry.TypeId),
+			StartTime:       formatTime(entry.StartTime),
+			EndTime:         formatTime(entry.EndTime),
+			Duration:        formatHours(duration),
+			EntryActivity:   m.getEntryActivityDescription(entryActivitiesMap, entry.ActivityId),
+			Project:         entry.Project,
+			Description:     entry.Description,
+			Labels:          entry.Labels,
+			AttachmentCount: attachmentCounts[entry.Id],
 		})
 
 		// Set work/break durations
 		ldvm.WorkDuration = formatHours(totalWorkDuration)
 		ldvm.BreakDuration = formatHours(totalBreakDuration)
 		ldvm.WasTargetWorkDurationReached = wasTargetWorkDurationReached
+		ld.workDuration = totalWorkDuration
+	}
+
+	// Check breaks
+	if userContract != nil && len(userContract.BreakRules) > 0 {
+		for _, ld := range lds {
+			m.checkEntriesDayBreaks(userContract, ld, entryTypesMap)
+		}
 	}
 
 	return ldsvm
 }
 
+func (m *mapper) checkEntriesDayBreaks(userContract *model.Contract, ld *listEntriesDay,
+	entryTypesMap map[int]*model.EntryType) {
+	// Check breaks
+	check := userContract.CheckBreaks(model.NewWorkDay(ld.date, ld.entries, entryTypesMap))
+	if !check.IsViolated() {
+		return
+	}
+	ld.vm.IsBreakRuleViolated = true
+	ld.vm.MissingBreakDuration = formatHours(check.MissingBreakDuration)
+
+	// If missing breaks should be deducted: Update work duration
+	if userContract.DeductMissingBreaks {
+		workDuration := ld.workDuration - check.DeductionDuration
+		ld.vm.WorkDuration = formatHours(workDuration)
+		ld.vm.WasTargetWorkDurationReached = (workDuration - ld.targetWorkDuration) >= 0
+	}
+}
+
 // CreateEntryTypesViewModel creates a list of entry type view models.
 func (m *mapper) CreateEntryTypesViewModel(entryTypes []*model.EntryType) []*vm.EntryType {
 	etsvm := make([]*vm.EntryType, 0, 10)
@@ -165,6 +201,23 @@ func (m *mapper) createEntryActivityViewModel(id int, description string) *vm.En
 	}
 }
 
+// toLocationEntries creates copies of entries whose start and end times are in the supplied
+// location. This way days and times are determined in the time zone of the user.
+func (m *mapper) toLocationEntries(entries []*model.Entry, loc *time.Location) []*model.Entry {
+	outs := make([]*model.Entry, len(entries))
+	for i, entry := range entries {
+		outs[i] = m.toLocationEntry(entry, loc)
+	}
+	return outs
+}
+
+func (m *mapper) toLocationEntry(entry *model.Entry, loc *time.Location) *model.Entry {
+	out := *entry
+	out.StartTime = entry.StartTime.In(loc)
+	out.EndTime = entry.EndTime.In(loc)
+	return &out
+}
+
 // CreateEntryViewModel creates a entry view model.
 func (m *mapper) CreateEntryViewModel(entry *model.Entry) *vm.Entry {
 	return &vm.Entry{
@@ -177,6 +230,8 @@ func (m *mapper) CreateEntryViewModel(entry *model.Entry) *vm.Entry {
 		Project:        entry.Project,
 		Description:    entry.Description,
 		Labels:         entry.Labels,
+		Billable:       entry.Billable,
+		Version:        entry.Version,
 	}
 }
 
@@ -188,6 +243,14 @@ func (m *mapper) getEntryTypeDescription(entryTypesMap map[int]*model.EntryType,
 	return ""
 }
 
+func (m *mapper) getEntryTypeColor(entryTypesMap map[int]*model.EntryType, id int) string {
+	et, ok := entryTypesMap[id]
+	if ok {
+		return et.Color
+	}
+	return ""
+}
+
 func (m *mapper) getEntryActivityDescription(entryActivitiesMap map[int]*model.EntryActivity,
 	id int) string {
 	ea, ok := entryActivitiesMap[id]
@@ -197,38 +260,6 @@ func (m *mapper) getEntryActivityDescription(entryActivitiesMap map[int]*model.E
 	return ""
 }
 
-func (m *mapper) convertVacationDays(vacationDays []model.ContractVacationDays,
-) []monthlyVacationDays {
-	mds := make([]monthlyVacationDays, 0, 10)
-
-	// Create monthly days
-	for _, vds := range vacationDays {
-		mds = append(mds, monthlyVacationDays{vds.FirstDay, vds.Days})
-	}
-
-	// Sort monthly days
-	sort.SliceStable(mds, func(i, j int) bool {
-		return mds[i].fromDate.Before(mds[j].fromDate)
-	})
-
-	return mds
-}
-
-func (m *mapper) findVacationDaysForDate(monthlyDays []monthlyVacationDays, date time.Time,
-) float32 {
-	d := float32(0.0)
-
-	// Find monthly days for supplied date
-	for _, md := range monthlyDays {
-		if md.fromDate.After(date) {
-			break
-		}
-		d = md.days
-	}
-
-	return d
-}
-
 func (m *mapper) convertWorkingHours(workingHours []model.ContractWorkingHours,
 ) []dailyWorkingDuration {
 	dds := make([]dailyWorkingDuration, 0, 10)
@@ -252,7 +283,8 @@ func (m *mapper) findWorkingDurationForDate(dailyDurations []dailyWorkingDuratio
 ) time.Duration {
 	d := time.Duration(0)
 
-	// Find daily duration for supplied date
+	// Find daily duration for supplied date (the day is determined in the location of the date)
+	date = util.ToCivilDate(date)
 	for _, dd := range dailyDurations {
 		if dd.fromDate.After(date) {
 			break
@@ -406,6 +438,17 @@ func getDaysString(days float32) string {
 	return printer.Sprintf("%.1f", days)
 }
 
+func getSortedEntryTypes(entryTypesMap map[int]*model.EntryType) []*model.EntryType {
+	entryTypes := make([]*model.EntryType, 0, len(entryTypesMap))
+	for _, entryType := range entryTypesMap {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool {
+		return entryTypes[i].Id < entryTypes[j].Id
+	})
+	return entryTypes
+}
+
 func getRoundedHours(d time.Duration) float32 {
 	rd := d.Round(time.Minute)
 	return float32(rd.Hours())
@@ -422,6 +465,30 @@ func formatHours(d time.Duration) string {
 	return printer.Sprintf("%.2f", h)
 }
 
+func getAmountString(amount float64) string {
+	printer := message.NewPrinter(loc.LngTag)
+	return printer.Sprintf("%.2f", amount)
+}
+
+func getFileSizeString(size int64) string {
+	printer := message.NewPrinter(loc.LngTag)
+	if size < 1024*1024 {
+		return printer.Sprintf("%.0f KB", math.Ceil(float64(size)/1024))
+	}
+	return printer.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
+
+func getExpenseTypeName(expType string) string {
+	switch expType {
+	case model.ExpenseTypeMileage:
+		return loc.CreateString("expenseTypeMileage")
+	case model.ExpenseTypePerDiem:
+		return loc.CreateString("expenseTypePerDiem")
+	default:
+		return loc.CreateString("expenseTypeReceipt")
+	}
+}
+
 // --- Misc helpers ---
 
 func (m *mapper) calculatePercentage(actual float32, total float32) int {
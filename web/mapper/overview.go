@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"kellnhofer.com/work-log/pkg/export"
+	"kellnhofer.com/work-log/pkg/loc"
 	"kellnhofer.com/work-log/pkg/model"
-	"kellnhofer.com/work-log/pkg/util"
 	vm "kellnhofer.com/work-log/web/model"
 )
 
@@ -19,11 +20,12 @@ func NewOverviewMapper() *OverviewMapper {
 	return &OverviewMapper{}
 }
 
-// CreateOverviewEntriesViewModel creates a view model for the overview page.
-func (m *OverviewMapper) CreateOverviewEntriesViewModel(userContract *model.Contract, year int,
-	month int, entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
-	entryActivitiesMap map[int]*model.EntryActivity) *vm.OverviewEntries {
+// CreateOverviewEntriesViewModel creates a view model for the overview page from the timesheet
+// data of the month.
+func (m *OverviewMapper) CreateOverviewEntriesViewModel(data *export.TimesheetData,
+	entryTypesMap map[int]*model.EntryType) *vm.OverviewEntries {
 	oesvm := &vm.OverviewEntries{}
+	year, month := data.Year, data.Month
 
 	// Get current month name
 	oesvm.CurrMonthName = fmt.Sprintf("%s %d", getMonthName(month), year)
@@ -51,111 +53,113 @@ func (m *OverviewMapper) CreateOverviewEntriesViewModel(userContract *model.Cont
 	oesvm.NextMonth = fmt.Sprintf("%d%02d", ny, nm)
 
 	// Calculate summary
-	oesvm.Summary = m.createSummaryViewModel(userContract, year, month, entries)
+	oesvm.Summary = m.createSummaryViewModel(data)
+
+	// Create rounding
+	oesvm.Rounding = m.createRoundingViewModel(data.Rounding)
 
 	// Create weeks
-	oesvm.Weeks = m.createWeeksViewModel(year, month, entries)
+	oesvm.Weeks = m.createWeeksViewModel(data, entryTypesMap)
 
-	// Create entry das
-	oesvm.EntriesDays = m.createEntriesDaysViewModel(year, month, entries, entryTypesMap,
-		entryActivitiesMap)
+	// Create entry days
+	oesvm.EntriesDays = m.createEntriesDaysViewModel(data, entryTypesMap)
 
 	return oesvm
 }
 
-func (m *OverviewMapper) createSummaryViewModel(userContract *model.Contract, year int, month int,
-	entries []*model.Entry) *vm.OverviewEntriesSummary {
-	// Calculate monthly actual hours per type
-	monthTypeActualHours := m.calculateMonthTypeActualHours(entries)
-
-	// Calculate monthly target, actual and balance
-	monthTargetHours := m.calculateMonthTargetHours(userContract, year, month)
-	monthActualHours := m.calculateMonthActualHours(monthTypeActualHours)
-	monthBalanceHours := monthActualHours - monthTargetHours
-	monthTotalHours := m.calculateMonthTotalHours(monthActualHours, monthTargetHours)
-	monthRemainingHours := m.calculateMonthRemainingHours(monthActualHours, monthTargetHours)
-
-	// Calculate monthly actual percentages per type
-	monthTypeActualPercent := m.calculateMonthTypeActualPercentages(monthTypeActualHours,
-		monthTotalHours)
-	monthActualPercent := m.calculateMonthActualPercentage(monthTypeActualPercent)
-	monthRemainingPercent := 100 - monthActualPercent
-
-	// Create summary
-	return &vm.OverviewEntriesSummary{
-		MonthTargetHours:    getHoursString(monthTargetHours),
-		MonthActualHours:    getHoursString(monthActualHours),
-		MonthBalanceHours:   getHoursString(monthBalanceHours),
-		TypePercentages:     monthTypeActualPercent,
-		RemainingPercentage: monthRemainingPercent,
-		TypeHours: map[int]string{
-			model.EntryTypeIdWork:     getHoursString(monthTypeActualHours[model.EntryTypeIdWork]),
-			model.EntryTypeIdTravel:   getHoursString(monthTypeActualHours[model.EntryTypeIdTravel]),
-			model.EntryTypeIdVacation: getHoursString(monthTypeActualHours[model.EntryTypeIdVacation]),
-			model.EntryTypeIdHoliday:  getHoursString(monthTypeActualHours[model.EntryTypeIdHoliday]),
-			model.EntryTypeIdIllness:  getHoursString(monthTypeActualHours[model.EntryTypeIdIllness]),
-		},
-		RemainingHours: getHoursString(monthRemainingHours),
+// CreateOverviewYearViewModel creates a view model for the year overview page.
+func (m *OverviewMapper) CreateOverviewYearViewModel(overview *model.YearOverview,
+) *vm.OverviewYear {
+	oyvm := &vm.OverviewYear{
+		CurrYear:             fmt.Sprintf("%d", overview.Year),
+		PrevYear:             fmt.Sprintf("%d", overview.Year-1),
+		NextYear:             fmt.Sprintf("%d", overview.Year+1),
+		StartOvertimeHours:   getHoursString(overview.StartOvertimeHours),
+		VacationEntitledDays: getDaysString(overview.VacationEntitledDays),
+		Months:               make([]*vm.OverviewYearMonth, 0, len(overview.Months)),
+		OvertimeValues:       []float32{overview.StartOvertimeHours},
 	}
-}
 
-func (m *OverviewMapper) calculateMonthTypeActualHours(entries []*model.Entry) map[int]float32 {
-	// Calculate actual durations
-	var workDuration, travDuration, vacaDuration, holiDuration, illnDuration time.Duration
-	for _, entry := range entries {
-		duration := entry.EndTime.Sub(entry.StartTime)
-		switch entry.TypeId {
-		case model.EntryTypeIdWork:
-			workDuration = workDuration + duration
-		case model.EntryTypeIdTravel:
-			travDuration = travDuration + duration
-		case model.EntryTypeIdVacation:
-			vacaDuration = vacaDuration + duration
-		case model.EntryTypeIdHoliday:
-			holiDuration = holiDuration + duration
-		case model.EntryTypeIdIllness:
-			illnDuration = illnDuration + duration
-		}
+	// Create months (months which are not yet due have no balance)
+	var targetHours, actualHours, balanceHours, vacationTakenDays, illnessDays float32
+	total := &vm.OverviewYearMonth{
+		Name:                  loc.CreateString("overviewYearRowTotal"),
+		IsDue:                 true,
+		OvertimeHours:         getHoursString(overview.StartOvertimeHours),
+		VacationRemainingDays: getDaysString(overview.VacationEntitledDays),
 	}
+	for _, om := range overview.Months {
+		mvm := &vm.OverviewYearMonth{
+			Name:                  getMonthName(int(om.Month)),
+			IsDue:                 om.IsDue,
+			TargetHours:           getHoursString(om.TargetHours),
+			ActualHours:           getHoursString(om.ActualHours),
+			BalanceHours:          "-",
+			OvertimeHours:         "-",
+			VacationTakenDays:     getDaysString(om.VacationTakenDays),
+			VacationRemainingDays: getDaysString(om.VacationRemainDays),
+			IllnessDays:           getDaysString(om.IllnessDays),
+		}
+		if om.IsDue {
+			mvm.BalanceHours = getHoursString(om.GetBalanceHours())
+			mvm.OvertimeHours = getHoursString(om.OvertimeHours)
+			balanceHours = balanceHours + om.GetBalanceHours()
+			total.OvertimeHours = mvm.OvertimeHours
+			oyvm.OvertimeValues = append(oyvm.OvertimeValues, om.OvertimeHours)
+		}
+		oyvm.Months = append(oyvm.Months, mvm)
 
-	// Return rounded hours
-	return map[int]float32{
-		model.EntryTypeIdWork:     getRoundedHours(workDuration),
-		model.EntryTypeIdTravel:   getRoundedHours(travDuration),
-		model.EntryTypeIdVacation: getRoundedHours(vacaDuration),
-		model.EntryTypeIdHoliday:  getRoundedHours(holiDuration),
-		model.EntryTypeIdIllness:  getRoundedHours(illnDuration),
+		targetHours = targetHours + om.TargetHours
+		actualHours = actualHours + om.ActualHours
+		vacationTakenDays = vacationTakenDays + om.VacationTakenDays
+		illnessDays = illnessDays + om.IllnessDays
+		total.VacationRemainingDays = mvm.VacationRemainingDays
 	}
-}
 
-func (m *OverviewMapper) calculateMonthActualHours(actualHours map[int]float32) float32 {
-	return actualHours[model.EntryTypeIdWork] +
-		actualHours[model.EntryTypeIdTravel] +
-		actualHours[model.EntryTypeIdVacation] +
-		actualHours[model.EntryTypeIdHoliday] +
-		actualHours[model.EntryTypeIdIllness]
+	// Create total
+	total.TargetHours = getHoursString(targetHours)
+	total.ActualHours = getHoursString(actualHours)
+	total.BalanceHours = getHoursString(balanceHours)
+	total.VacationTakenDays = getDaysString(vacationTakenDays)
+	total.IllnessDays = getDaysString(illnessDays)
+	oyvm.Total = total
+
+	return oyvm
 }
 
-func (m *OverviewMapper) calculateMonthTargetHours(userContract *model.Contract, year int,
-	month int) float32 {
-	// Get target working durations
-	targetWorkDurations := m.convertWorkingHours(userContract.WorkingHours)
-	// Abort if no target working durations were set
-	if len(targetWorkDurations) == 0 {
-		return 0.0
+func (m *OverviewMapper) createSummaryViewModel(data *export.TimesheetData,
+) *vm.OverviewEntriesSummary {
+	// Calculate monthly total and remaining hours
+	monthTotalHours := m.calculateMonthTotalHours(data.ActualHours, data.TargetHours)
+	monthRemainingHours := m.calculateMonthRemainingHours(data.ActualHours, data.TargetHours)
+
+	// Create type summaries (only credited types are part of the progress)
+	monthActualPercent := 0
+	types := make([]*vm.OverviewTypeSummary, 0, len(data.Types))
+	for _, t := range data.Types {
+		percent := 0
+		if t.Type.IsCredited() {
+			percent = m.calculatePercentage(t.Hours, monthTotalHours)
+		}
+		monthActualPercent = monthActualPercent + percent
+		types = append(types, &vm.OverviewTypeSummary{
+			Description: t.Type.Description,
+			Color:       t.Type.Color,
+			Hours:       getHoursString(t.Hours),
+			Percentage:  percent,
+		})
 	}
+	monthRemainingPercent := 100 - monthActualPercent
 
-	// Calculate days
-	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
-	end := start.AddDate(0, 1, 0)
-	workDays := util.CalculateWorkingDays(start, end)
-
-	// Calculate actual and target durations
-	monthTargetWorkDuration := time.Duration(workDays) * m.findWorkingDurationForDate(
-		targetWorkDurations, start)
-
-	// Return rounded hours
-	return getRoundedHours(monthTargetWorkDuration)
+	// Create summary
+	return &vm.OverviewEntriesSummary{
+		MonthTargetHours:    getHoursString(data.TargetHours),
+		MonthActualHours:    getHoursString(data.ActualHours),
+		MonthBalanceHours:   getHoursString(data.GetBalanceHours()),
+		Types:               types,
+		RemainingPercentage: monthRemainingPercent,
+		RemainingHours:      getHoursString(monthRemainingHours),
+	}
 }
 
 func (m *OverviewMapper) calculateMonthTotalHours(actualHours float32, targetHours float32) float32 {
@@ -175,215 +179,162 @@ func (m *OverviewMapper) calculateMonthRemainingHours(actualHours float32, targe
 	return remainingHours
 }
 
-func (m *OverviewMapper) calculateMonthTypeActualPercentages(actualHours map[int]float32,
-	totalHours float32) map[int]int {
-	wk, wv := m.calculateMonthTypeActualPercentage(model.EntryTypeIdWork, actualHours, totalHours)
-	tk, tv := m.calculateMonthTypeActualPercentage(model.EntryTypeIdTravel, actualHours, totalHours)
-	vk, vv := m.calculateMonthTypeActualPercentage(model.EntryTypeIdVacation, actualHours, totalHours)
-	hk, hv := m.calculateMonthTypeActualPercentage(model.EntryTypeIdHoliday, actualHours, totalHours)
-	ik, iv := m.calculateMonthTypeActualPercentage(model.EntryTypeIdIllness, actualHours, totalHours)
-	return map[int]int{
-		wk: wv,
-		tk: tv,
-		vk: vv,
-		hk: hv,
-		ik: iv,
+func (m *OverviewMapper) createRoundingViewModel(rounding *export.TimesheetRounding,
+) *vm.OverviewRounding {
+	// If no policy applies: Abort
+	if rounding == nil {
+		return nil
 	}
-}
 
-func (m *OverviewMapper) calculateMonthTypeActualPercentage(id int, actualHours map[int]float32,
-	totalHours float32) (int, int) {
-	return id, m.calculatePercentage(actualHours[id], totalHours)
+	return &vm.OverviewRounding{
+		Policies:         rounding.Policies,
+		IsReportScope:    rounding.IsReportScope,
+		MonthBilledHours: formatHours(rounding.BilledDuration),
+	}
 }
 
-func (m *OverviewMapper) calculateMonthActualPercentage(typeActualPercent map[int]int) int {
-	return typeActualPercent[model.EntryTypeIdWork] +
-		typeActualPercent[model.EntryTypeIdTravel] +
-		typeActualPercent[model.EntryTypeIdVacation] +
-		typeActualPercent[model.EntryTypeIdHoliday] +
-		typeActualPercent[model.EntryTypeIdIllness]
-}
+// CreateOverviewExpensesViewModel creates a view model for the expenses of the overview export.
+func (m *OverviewMapper) CreateOverviewExpensesViewModel(report *model.ExpenseReport,
+) *vm.OverviewExpenses {
+	oxvm := &vm.OverviewExpenses{
+		Items:  make([]*vm.OverviewExpense, 0, len(report.Items)),
+		Totals: make([]*vm.OverviewExpenseTotal, 0, len(report.Totals)),
+	}
 
-func (m *OverviewMapper) createWeeksViewModel(year int, month int, entries []*model.Entry,
-) []*vm.OverviewWeek {
-	curDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	for _, item := range report.Items {
+		xvm := &vm.OverviewExpense{
+			Date:        formatShortDate(item.Date),
+			Weekday:     getShortWeekdayName(item.Date),
+			Type:        getExpenseTypeName(item.Expense.Type),
+			Amount:      getAmountString(item.Expense.Amount),
+			Currency:    item.Expense.Currency,
+			Project:     item.Entry.Project,
+			Description: item.Expense.Description,
+		}
+		if item.Rate != nil {
+			xvm.Rate = item.Rate.Name
+		}
+		if item.Expense.IsRateBased() {
+			xvm.Quantity = getAmountString(item.Expense.Quantity)
+		}
+		oxvm.Items = append(oxvm.Items, xvm)
+	}
+
+	for _, total := range report.Totals {
+		oxvm.Totals = append(oxvm.Totals, &vm.OverviewExpenseTotal{
+			Currency: total.Currency,
+			Amount:   getAmountString(total.Amount),
+		})
+	}
 
+	return oxvm
+}
+
+func (m *OverviewMapper) createWeeksViewModel(data *export.TimesheetData,
+	entryTypesMap map[int]*model.EntryType) []*vm.OverviewWeek {
 	// Create weeks
 	wsvm := make([]*vm.OverviewWeek, 0, 6)
-	curEntryIndex := 0
-	for {
-		// Create and add new week
-		wvm := &vm.OverviewWeek{
-			WeekDays: make([]*vm.OverviewWeekDay, 7),
-		}
-		wsvm = append(wsvm, wvm)
-
-		// Create days
-		for di := 0; di < 7; di++ {
-			// Create and add new day
-			if m.getIsoWeekdayIndex(curDate) == di {
-				curEntryIndex, wvm.WeekDays[di] = m.createWeekDay(curDate, curEntryIndex, entries)
-				curDate = curDate.Add(24 * time.Hour)
-			}
-
-			// If next month is reached: Abort
-			if curDate.Month() != time.Month(month) {
-				return wsvm
+	var wvm *vm.OverviewWeek
+	for _, day := range data.Days {
+		// Create and add new week (at the first day of the month and on every Monday)
+		di := m.getIsoWeekdayIndex(day.Date)
+		if wvm == nil || di == 0 {
+			wvm = &vm.OverviewWeek{
+				WeekDays: make([]*vm.OverviewWeekDay, 7),
 			}
+			wsvm = append(wsvm, wvm)
 		}
+
+		// Create and add new day
+		wvm.WeekDays[di] = m.createWeekDay(day, entryTypesMap)
 	}
+	return wsvm
 }
 
-func (m *OverviewMapper) createWeekDay(curDate time.Time, curEntryIndex int, entries []*model.Entry,
-) (int, *vm.OverviewWeekDay) {
-	// Create entries
-	entryIndex := curEntryIndex
-
+func (m *OverviewMapper) createWeekDay(day *export.TimesheetDay,
+	entryTypesMap map[int]*model.EntryType) *vm.OverviewWeekDay {
 	// Create day
 	dvm := &vm.OverviewWeekDay{
-		Date:         formatShorterDate(curDate),
-		IsWeekendDay: curDate.Weekday() == time.Saturday || curDate.Weekday() == time.Sunday,
-		IsType:       make(map[int]bool),
+		Date:         formatShorterDate(day.Date),
+		IsWeekendDay: day.Date.Weekday() == time.Saturday || day.Date.Weekday() == time.Sunday,
 	}
 
-	// Calculate start/end time and daily duration
-	isType := make(map[int]bool)
-	var startTime, endTime time.Time
-	var dailyDuration time.Duration
-	for {
-		// If there are no entries: Abort (No more entries exist for this day)
-		if len(entries) == 0 || len(entries) == entryIndex {
-			break
-		}
-
-		// Get entry
-		entry := entries[entryIndex]
-		entryDate := entry.StartTime
-
-		// If entry date does not match: Abort (All enties have been added for this day)
-		_, _, cd := curDate.Date()
-		_, _, ed := entryDate.Date()
-		if cd != ed {
-			break
+	// Set start/end time and hours
+	if day.HasEntries() {
+		isType := make(map[int]bool)
+		for _, te := range day.Entries {
+			isType[te.Entry.TypeId] = true
 		}
-
-		// Set flag for type
-		isType[entry.TypeId] = true
-
-		// Calculate duration
-		if startTime.IsZero() {
-			startTime = entry.StartTime
+		for _, entryType := range getSortedEntryTypes(entryTypesMap) {
+			if isType[entryType.Id] {
+				dvm.TypeColors = append(dvm.TypeColors, entryType.Color)
+			}
 		}
-		endTime = entry.EndTime
-		duration := entry.EndTime.Sub(entry.StartTime)
-		dailyDuration = dailyDuration + duration
-
-		// Update entry index
-		entryIndex++
-	}
-
-	// Set start/end time and hours
-	if !startTime.IsZero() && !endTime.IsZero() {
-		dvm.IsType = isType
-		dvm.StartTime = formatTime(startTime)
-		dvm.EndTime = formatTime(endTime)
-		dvm.Hours = formatHours(dailyDuration)
-		dailyBreakDuration := endTime.Sub(startTime) - dailyDuration
-		if dailyBreakDuration > 0 {
-			dvm.BreakHours = formatHours(dailyBreakDuration)
+		dvm.StartTime = formatTime(day.StartTime)
+		dvm.EndTime = formatTime(day.EndTime)
+		if day.BreakDuration > 0 {
+			dvm.BreakHours = formatHours(day.BreakDuration)
 		}
+		dvm.Hours = formatHours(day.Duration)
+		dvm.IsBreakRuleViolated = day.BreakCheck != nil
 	}
 
-	// Retrn upaded entry index and new day
-	return entryIndex, dvm
+	return dvm
 }
 
-func (m *OverviewMapper) createEntriesDaysViewModel(year int, month int, entries []*model.Entry,
-	entryTypesMap map[int]*model.EntryType, entryActivitiesMap map[int]*model.EntryActivity,
-) []*vm.OverviewEntriesDay {
-	curDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
-
-	// Create days
-	dsvm := make([]*vm.OverviewEntriesDay, 0, 31)
-	curEntryIndex := 0
-	for {
-		// Create and add new day
-		var dvm *vm.OverviewEntriesDay
-		curEntryIndex, dvm = m.createEntriesDay(curDate, curEntryIndex, entries, entryTypesMap,
-			entryActivitiesMap)
-		dsvm = append(dsvm, dvm)
-
-		// If next month is reached: Abort
-		curDate = curDate.Add(24 * time.Hour)
-		if curDate.Month() != time.Month(month) {
-			return dsvm
-		}
+func (m *OverviewMapper) createEntriesDaysViewModel(data *export.TimesheetData,
+	entryTypesMap map[int]*model.EntryType) []*vm.OverviewEntriesDay {
+	dsvm := make([]*vm.OverviewEntriesDay, 0, len(data.Days))
+	for _, day := range data.Days {
+		dsvm = append(dsvm, m.createEntriesDay(day, entryTypesMap))
 	}
+	return dsvm
 }
 
-func (m *OverviewMapper) createEntriesDay(curDate time.Time, curEntryIndex int,
-	entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
-	entryActivitiesMap map[int]*model.EntryActivity) (int, *vm.OverviewEntriesDay) {
+func (m *OverviewMapper) createEntriesDay(day *export.TimesheetDay,
+	entryTypesMap map[int]*model.EntryType) *vm.OverviewEntriesDay {
 	// Create new day
 	dvm := &vm.OverviewEntriesDay{
-		Date:         formatShortDate(curDate),
-		Weekday:      getShortWeekdayName(curDate),
-		IsWeekendDay: curDate.Weekday() == time.Saturday || curDate.Weekday() == time.Sunday,
+		Date:         formatShortDate(day.Date),
+		Weekday:      getShortWeekdayName(day.Date),
+		IsWeekendDay: day.Date.Weekday() == time.Saturday || day.Date.Weekday() == time.Sunday,
 		Entries:      make([]*vm.OverviewEntry, 0, 10),
+		Hours:        formatHours(day.Duration),
 	}
 
 	// Create entries
-	entryIndex := curEntryIndex
 	var prevEntry *model.Entry
-	var dailyDuration time.Duration
-	for {
-		// If there are no entries: Abort (No more entries exist for this day)
-		if len(entries) == 0 || len(entries) == entryIndex {
-			break
-		}
-
-		// Get entry
-		entry := entries[entryIndex]
-		entryDate := entry.StartTime
-
-		// If entry date does not match: Abort (All enties have been added for this day)
-		_, _, cd := curDate.Date()
-		_, _, ed := entryDate.Date()
-		if cd != ed {
-			prevEntry = nil
-			break
-		}
+	for _, te := range day.Entries {
+		entry := te.Entry
 
 		// If there is a time gap to the pervious entry: Create and add new blank entry
 		if prevEntry != nil && prevEntry.EndTime != entry.StartTime {
 			dvm.Entries = append(dvm.Entries, &vm.OverviewEntry{IsMissing: true})
 		}
 
-		// Calculate daily duration
-		duration := entry.EndTime.Sub(entry.StartTime)
-		dailyDuration = dailyDuration + duration
-
 		// Create and add new entry
 		evm := &vm.OverviewEntry{
-			Id:          entry.Id,
-			TypeId:      entry.TypeId,
-			Type:        m.getEntryTypeDescription(entryTypesMap, entry.TypeId),
-			StartTime:   formatTime(entry.StartTime),
-			EndTime:     formatTime(entry.EndTime),
-			Duration:    formatHours(duration),
-			Activity:    m.getEntryActivityDescription(entryActivitiesMap, entry.ActivityId),
-			Project:     entry.Project,
-			Description: entry.Description,
-			Labels:      entry.Labels,
+			Id:             entry.Id,
+			TypeId:         entry.TypeId,
+			Type:           te.Type,
+			TypeColor:      m.getEntryTypeColor(entryTypesMap, entry.TypeId),
+			StartTime:      formatTime(entry.StartTime),
+			EndTime:        formatTime(entry.EndTime),
+			Duration:       formatHours(entry.EndTime.Sub(entry.StartTime)),
+			BilledDuration: formatHours(te.BilledDuration),
+			Activity:       te.Activity,
+			Project:        entry.Project,
+			Description:    entry.Description,
+			Labels:         entry.Labels,
 		}
 		dvm.Entries = append(dvm.Entries, evm)
 
-		// Update entry index
-		entryIndex++
 		prevEntry = entry
 	}
-	dvm.Hours = formatHours(dailyDuration)
+	if day.BreakCheck != nil {
+		dvm.IsBreakRuleViolated = true
+		dvm.MissingBreakHours = formatHours(day.BreakCheck.MissingBreakDuration)
+	}
 
-	// Retrn upaded entry index and new day
-	return entryIndex, dvm
+	return dvm
 }
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"kellnhofer.com/work-log/pkg/model"
 	vm "kellnhofer.com/work-log/web/model"
 )
@@ -51,8 +53,8 @@ func (m *SearchMapper) CreateAdvancedSearchQueryViewModel(filter *model.FieldEnt
 
 // CreateSearchEntriesViewModel creates a view model for the search result page.
 func (m *SearchMapper) CreateSearchEntriesViewModel(curPageNum int, totPageNum int,
-	entries []*model.Entry, entryTypesMap map[int]*model.EntryType,
-	entryActivitiesMap map[int]*model.EntryActivity) *vm.ListEntries {
+	entries []*model.Entry, loc *time.Location, entryTypesMap map[int]*model.EntryType,
+	entryActivitiesMap map[int]*model.EntryActivity, attachmentCounts map[int]int) *vm.ListEntries {
 	sesvm := &vm.ListEntries{}
 
 	// Calculate paging nav numbers
@@ -61,7 +63,8 @@ func (m *SearchMapper) CreateSearchEntriesViewModel(curPageNum int, totPageNum i
 		vm.PageNavItems)
 
 	// Create entries
-	sesvm.Days = m.createEntriesViewModel(nil, entries, entryTypesMap, entryActivitiesMap, false)
+	sesvm.Days = m.createEntriesViewModel(nil, m.toLocationEntries(entries, loc), entryTypesMap,
+		entryActivitiesMap, attachmentCounts, false)
 
 	return sesvm
 }
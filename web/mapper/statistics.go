@@ -0,0 +1,128 @@
+package mapper
+
+import (
+	"time"
+
+	"kellnhofer.com/work-log/pkg/loc"
+	"kellnhofer.com/work-log/pkg/model"
+	vm "kellnhofer.com/work-log/web/model"
+	"kellnhofer.com/work-log/web/view"
+)
+
+// statisticsMaxAxisLabels is the maximum number of labels on the time axis of the chart.
+const statisticsMaxAxisLabels = 12
+
+// StatisticsMapper creates view models for the statistics page.
+type StatisticsMapper struct {
+	mapper
+}
+
+// NewStatisticsMapper creates a new statistics mapper.
+func NewStatisticsMapper() *StatisticsMapper {
+	return &StatisticsMapper{}
+}
+
+// CreateStatisticsViewModel creates a view model for the statistics page. Groups are colored like
+// their entry types if durations are grouped by type. Otherwise the largest groups get a color of
+// their own and the remaining groups are combined into a single series of the chart.
+func (m *StatisticsMapper) CreateStatisticsViewModel(stats *model.Statistics,
+	entryTypesMap map[int]*model.EntryType) *vm.Statistics {
+	svm := &vm.Statistics{
+		StartDate:  getDateString(stats.StartDate),
+		EndDate:    getDateString(stats.EndDate),
+		Grouping:   string(stats.Grouping),
+		Interval:   string(stats.Interval),
+		TotalHours: formatHours(stats.TotalDuration),
+		Buckets:    len(stats.Buckets),
+		AxisLabels: m.createAxisLabelsViewModel(stats.Buckets, stats.Interval),
+		Series:     make([]*vm.StatisticsSeries, 0, len(view.StatisticsColors)+1),
+		Groups:     make([]*vm.StatisticsGroup, 0, len(stats.Groups)),
+	}
+
+	// Create groups and chart series
+	var otherSeries *vm.StatisticsSeries
+	var otherDuration time.Duration
+	totalHours := float32(stats.TotalDuration.Hours())
+	for i, group := range stats.Groups {
+		name := group.Name
+		if name == "" {
+			name = loc.CreateString("statisticsGroupNone")
+		}
+		color := m.getGroupColor(stats.Grouping, entryTypesMap, group, i)
+
+		svm.Groups = append(svm.Groups, &vm.StatisticsGroup{
+			Name:       name,
+			Color:      color,
+			Hours:      formatHours(group.TotalDuration),
+			Percentage: m.calculatePercentage(float32(group.TotalDuration.Hours()), totalHours),
+		})
+
+		values := m.toHours(group.Durations)
+		if color != view.StatisticsColorOther {
+			svm.Series = append(svm.Series, &vm.StatisticsSeries{
+				Name:   name,
+				Color:  color,
+				Hours:  formatHours(group.TotalDuration),
+				Values: values,
+			})
+			continue
+		}
+		if otherSeries == nil {
+			otherSeries = &vm.StatisticsSeries{
+				Name:   loc.CreateString("statisticsGroupOther"),
+				Color:  view.StatisticsColorOther,
+				Values: make([]float32, len(stats.Buckets)),
+			}
+		}
+		for b, value := range values {
+			otherSeries.Values[b] = otherSeries.Values[b] + value
+		}
+		otherDuration = otherDuration + group.TotalDuration
+	}
+	if otherSeries != nil {
+		otherSeries.Hours = formatHours(otherDuration)
+		svm.Series = append(svm.Series, otherSeries)
+	}
+
+	return svm
+}
+
+func (m *StatisticsMapper) getGroupColor(grouping model.StatisticsGrouping,
+	entryTypesMap map[int]*model.EntryType, group *model.StatisticsGroup, index int) string {
+	if grouping == model.StatisticsGroupingType {
+		if color := m.getEntryTypeColor(entryTypesMap, group.Id); color != "" {
+			return color
+		}
+	}
+	if index < len(view.StatisticsColors) {
+		return view.StatisticsColors[index]
+	}
+	return view.StatisticsColorOther
+}
+
+func (m *StatisticsMapper) createAxisLabelsViewModel(buckets []time.Time,
+	interval model.StatisticsInterval) []*vm.StatisticsAxisLabel {
+	// Only every n-th bucket is labeled, so that the labels do not overlap
+	step := (len(buckets) + statisticsMaxAxisLabels - 1) / statisticsMaxAxisLabels
+	labels := make([]*vm.StatisticsAxisLabel, 0, statisticsMaxAxisLabels)
+	for i := 0; i < len(buckets); i = i + step {
+		text := formatShortDate(buckets[i])
+		if interval == model.StatisticsIntervalMonth {
+			text = buckets[i].Format(view.MonthFormat)
+		}
+		labels = append(labels, &vm.StatisticsAxisLabel{
+			Text:   text,
+			Column: i + 1,
+			Span:   min(step, len(buckets)-i),
+		})
+	}
+	return labels
+}
+
+func (m *StatisticsMapper) toHours(durations []time.Duration) []float32 {
+	hours := make([]float32, len(durations))
+	for i, duration := range durations {
+		hours[i] = float32(duration.Hours())
+	}
+	return hours
+}
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"strconv"
+
+	"kellnhofer.com/work-log/pkg/loc"
 	"kellnhofer.com/work-log/pkg/model"
 	vm "kellnhofer.com/work-log/web/model"
 )
@@ -25,7 +28,8 @@ func (m *mapper) CreateUserInfoViewModel(user *model.User) *vm.UserInfo {
 
 // CreateUserProfileInfoViewModel creates a view model for detailed user information.
 func (m *UserMapper) CreateUserProfileInfoViewModel(user *model.User, contract *model.Contract,
-	) *vm.UserProfileInfo {
+	vacationLedger []*model.VacationLedgerYear, adjustments []*model.BalanceAdjustment,
+) *vm.UserProfileInfo {
 	profileInfo := &vm.UserProfileInfo{
 		Id:       user.Id,
 		Initials: getUserInitials(user.Name),
@@ -50,7 +54,51 @@ func (m *UserMapper) CreateUserProfileInfoViewModel(user *model.User, contract *
 				Days:     getDaysString(vd.Days),
 			})
 		}
+		for _, br := range contract.BreakRules {
+			ci.BreakRules = append(ci.BreakRules, &vm.ContractBreakRule{
+				WorkHours:    getHoursString(br.WorkHours),
+				BreakMinutes: strconv.Itoa(br.BreakMinutes),
+			})
+		}
+		if contract.VacationRules != nil {
+			ci.VacationRules = &vm.ContractVacationRules{
+				CarryOverMaxDays: getDaysString(contract.VacationRules.CarryOverMaxDays),
+				ExpiryMonths:     strconv.Itoa(contract.VacationRules.ExpiryMonths),
+			}
+		}
 		profileInfo.Contract = ci
 	}
+	for _, ly := range vacationLedger {
+		profileInfo.VacationLedger = append(profileInfo.VacationLedger, &vm.VacationLedgerYear{
+			Year:            strconv.Itoa(ly.Year),
+			CarriedOverDays: getDaysString(ly.CarriedOverDays),
+			AccruedDays:     getDaysString(ly.AccruedDays),
+			AdjustedDays:    getDaysString(ly.AdjustedDays),
+			TakenDays:       getDaysString(ly.TakenDays),
+			ExpiredDays:     getDaysString(ly.ExpiredDays),
+			RemainingDays:   getDaysString(ly.RemainingDays),
+		})
+	}
+	for _, a := range adjustments {
+		profileInfo.BalanceAdjustments = append(profileInfo.BalanceAdjustments,
+			m.createBalanceAdjustmentViewModel(a))
+	}
 	return profileInfo
 }
+
+func (m *UserMapper) createBalanceAdjustmentViewModel(a *model.BalanceAdjustment,
+) *vm.BalanceAdjustment {
+	ba := &vm.BalanceAdjustment{
+		Date:   formatDate(a.Date),
+		Reason: a.Reason,
+	}
+	switch a.Type {
+	case model.BalanceAdjustmentTypeOvertime:
+		ba.Type = loc.CreateString("balanceAdjustmentTypeOvertime")
+		ba.Value = getHoursString(a.Value) + " " + loc.CreateString("hoursUnit")
+	case model.BalanceAdjustmentTypeVacation:
+		ba.Type = loc.CreateString("balanceAdjustmentTypeVacation")
+		ba.Value = getDaysString(a.Value) + " " + loc.CreateString("daysUnit")
+	}
+	return ba
+}
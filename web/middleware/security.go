@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -61,7 +62,11 @@ func (m *SecurityMiddleware) process(next echo.HandlerFunc, c echo.Context) erro
 		if err != nil {
 			return err
 		}
-		secCtx = model.NewSecurityContext(userId, userRoles)
+		userLocation, err := m.getUserLocation(sysCtx, userId)
+		if err != nil {
+			return err
+		}
+		secCtx = model.NewSecurityContext(userId, userRoles, userLocation)
 	}
 
 	// Update context
@@ -80,6 +85,18 @@ func (m *SecurityMiddleware) getUserRoles(sysCtx context.Context, userId int) ([
 	return userRoles, nil
 }
 
+func (m *SecurityMiddleware) getUserLocation(sysCtx context.Context, userId int) (*time.Location,
+	error) {
+	user, err := m.uServ.GetUserById(sysCtx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return time.Local, nil
+	}
+	return user.GetLocation(), nil
+}
+
 // AuthCheckMiddleware ensures that a user was authenticated.
 type AuthCheckMiddleware struct {
 	uServ *service.UserService
@@ -0,0 +1,9 @@
+package model
+
+// BalanceAdjustment stores view data of a balance adjustment.
+type BalanceAdjustment struct {
+	Date   string
+	Type   string
+	Value  string
+	Reason string
+}
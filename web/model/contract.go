@@ -7,6 +7,8 @@ type ContractInfo struct {
 	InitVacationDays  string
 	WorkingHours      []*ContractWorkingHours
 	VacationDays      []*ContractVacationDays
+	BreakRules        []*ContractBreakRule
+	VacationRules     *ContractVacationRules
 }
 
 // ContractWorkingHours stores view data of the user contract working hours.
@@ -20,3 +22,15 @@ type ContractVacationDays struct {
 	FirstDay string
 	Days     string
 }
+
+// ContractBreakRule stores view data of the user contract break rule.
+type ContractBreakRule struct {
+	WorkHours    string
+	BreakMinutes string
+}
+
+// ContractVacationRules stores view data of the user contract vacation carry-over rules.
+type ContractVacationRules struct {
+	CarryOverMaxDays string
+	ExpiryMonths     string
+}
@@ -16,19 +16,23 @@ type ListEntriesDay struct {
 	WorkDuration                 string
 	BreakDuration                string
 	WasTargetWorkDurationReached bool
+	IsBreakRuleViolated          bool
+	MissingBreakDuration         string
+	ComplianceWarnings           []string
 }
 
 // ListEntry stores view data for a entry.
 type ListEntry struct {
-	IsMissing     bool
-	IsOverlapping bool
-	Id            int
-	EntryType     string
-	StartTime     string
-	EndTime       string
-	Duration      string
-	EntryActivity string
-	Project       string
-	Description   string
-	Labels        []string
+	IsMissing       bool
+	IsOverlapping   bool
+	Id              int
+	EntryType       string
+	StartTime       string
+	EndTime         string
+	Duration        string
+	EntryActivity   string
+	Project         string
+	Description     string
+	Labels          []string
+	AttachmentCount int
 }
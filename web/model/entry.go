@@ -5,6 +5,7 @@ type EntryData struct {
 	Entry           *Entry
 	EntryTypes      []*EntryType
 	EntryActivities []*EntryActivity
+	Attachments     *Attachments
 }
 
 // Entry stores view data of a entry.
@@ -18,25 +19,44 @@ type Entry struct {
 	Project        string
 	Description    string
 	Labels         []string
+	Billable       bool
+	Version        int
 }
 
-const (
-	EntryTypeIdWork     int = 1
-	EntryTypeIdTravel   int = 2
-	EntryTypeIdVacation int = 3
-	EntryTypeIdHoliday  int = 4
-	EntryTypeIdIllness  int = 5
-)
+// Attachments stores view data of the attachments of a entry.
+type Attachments struct {
+	EntryId int
+	Items   []*Attachment
+}
 
-// EntryTypeColors defines colors for each entry type.
-var EntryTypeColors = map[int]string{
-	EntryTypeIdWork:     "#0c63e4",
-	EntryTypeIdTravel:   "#6aa6ff",
-	EntryTypeIdVacation: "#04a17a",
-	EntryTypeIdHoliday:  "#12dfab",
-	EntryTypeIdIllness:  "#e8571e",
+// Attachment stores view data of a attachment.
+type Attachment struct {
+	Id       int
+	FileName string
+	Size     string
 }
 
+// AbsenceData stores data for the create/edit absence view.
+type AbsenceData struct {
+	Absence    *Absence
+	EntryTypes []*EntryType
+}
+
+// Absence stores view data of a absence.
+type Absence struct {
+	Id             int
+	TypeId         int
+	StartDateValue string
+	EndDateValue   string
+	DayFraction    string
+	Description    string
+}
+
+const (
+	AbsenceDayFractionFull = "full"
+	AbsenceDayFractionHalf = "half"
+)
+
 // EntryType stores view data for a entry type.
 type EntryType struct {
 	Id          int
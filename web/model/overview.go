@@ -7,6 +7,7 @@ type OverviewEntries struct {
 	PrevMonth     string
 	NextMonth     string
 	Summary       *OverviewEntriesSummary
+	Rounding      *OverviewRounding
 	Weeks         []*OverviewWeek
 	EntriesDays   []*OverviewEntriesDay
 }
@@ -17,12 +18,27 @@ type OverviewEntriesSummary struct {
 	MonthActualHours  string
 	MonthBalanceHours string
 
-	TypePercentages     map[int]int
+	Types               []*OverviewTypeSummary
 	RemainingPercentage int
-	TypeHours           map[int]string
 	RemainingHours      string
 }
 
+// OverviewTypeSummary stores view data for the hours of an entry type in the summary.
+type OverviewTypeSummary struct {
+	Description string
+	Color       string
+	Hours       string
+	Percentage  int
+}
+
+// OverviewRounding stores view data for the rounding policies which apply to the entries of the
+// month.
+type OverviewRounding struct {
+	Policies         []string
+	IsReportScope    bool
+	MonthBilledHours string
+}
+
 // OverviewWeek stores view data for a week.
 type OverviewWeek struct {
 	WeekDays []*OverviewWeekDay
@@ -32,11 +48,13 @@ type OverviewWeek struct {
 type OverviewWeekDay struct {
 	Date         string
 	IsWeekendDay bool
-	IsType       map[int]bool
+	TypeColors   []string
 	StartTime    string
 	EndTime      string
 	Hours        string
 	BreakHours   string
+
+	IsBreakRuleViolated bool
 }
 
 // OverviewEntriesDay stores view data for a entries day.
@@ -46,6 +64,9 @@ type OverviewEntriesDay struct {
 	IsWeekendDay bool
 	Entries      []*OverviewEntry
 	Hours        string
+
+	IsBreakRuleViolated bool
+	MissingBreakHours   string
 }
 
 // OverviewEntry stores view data for a entry.
@@ -54,11 +75,63 @@ type OverviewEntry struct {
 	Id          int
 	TypeId      int
 	Type        string
+	TypeColor   string
 	StartTime   string
 	EndTime     string
 	Duration    string
+	BilledDuration string
 	Activity    string
 	Project string
 	Description string
 	Labels      []string
 }
+
+// OverviewExpenses stores view data for the expenses of the month.
+type OverviewExpenses struct {
+	Items  []*OverviewExpense
+	Totals []*OverviewExpenseTotal
+}
+
+// OverviewExpense stores view data for an expense.
+type OverviewExpense struct {
+	Date        string
+	Weekday     string
+	Type        string
+	Rate        string
+	Quantity    string
+	Amount      string
+	Currency    string
+	Project     string
+	Description string
+}
+
+// OverviewExpenseTotal stores view data for the total amount of expenses in a currency.
+type OverviewExpenseTotal struct {
+	Currency string
+	Amount   string
+}
+
+// OverviewYear stores data for the year overview view.
+type OverviewYear struct {
+	CurrYear             string
+	PrevYear             string
+	NextYear             string
+	StartOvertimeHours   string
+	VacationEntitledDays string
+	Months               []*OverviewYearMonth
+	Total                *OverviewYearMonth
+	OvertimeValues       []float32
+}
+
+// OverviewYearMonth stores view data for a month (or the total) of the year overview.
+type OverviewYearMonth struct {
+	Name                  string
+	IsDue                 bool
+	TargetHours           string
+	ActualHours           string
+	BalanceHours          string
+	OvertimeHours         string
+	VacationTakenDays     string
+	VacationRemainingDays string
+	IllnessDays           string
+}
@@ -0,0 +1,37 @@
+package model
+
+// Statistics stores data for the statistics view.
+type Statistics struct {
+	StartDate  string
+	EndDate    string
+	Grouping   string
+	Interval   string
+	TotalHours string
+	Buckets    int
+	AxisLabels []*StatisticsAxisLabel
+	Series     []*StatisticsSeries
+	Groups     []*StatisticsGroup
+}
+
+// StatisticsAxisLabel stores view data for a label of the time axis of the statistics chart.
+type StatisticsAxisLabel struct {
+	Text   string
+	Column int
+	Span   int
+}
+
+// StatisticsSeries stores view data for the stacked bars of a group in the statistics chart.
+type StatisticsSeries struct {
+	Name   string
+	Color  string
+	Hours  string
+	Values []float32
+}
+
+// StatisticsGroup stores view data for a group in the statistics table.
+type StatisticsGroup struct {
+	Name       string
+	Color      string
+	Hours      string
+	Percentage int
+}
@@ -13,4 +13,6 @@ type UserProfileInfo struct {
 	Name string
 	Username string
 	Contract *ContractInfo
+	VacationLedger []*VacationLedgerYear
+	BalanceAdjustments []*BalanceAdjustment
 }
@@ -0,0 +1,12 @@
+package model
+
+// VacationLedgerYear stores view data of a vacation ledger year.
+type VacationLedgerYear struct {
+	Year            string
+	CarriedOverDays string
+	AccruedDays     string
+	AdjustedDays    string
+	TakenDays       string
+	ExpiredDays     string
+	RemainingDays   string
+}
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"html"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,7 @@ const (
 	ShortDateFormat   string = "02.01."
 	ShorterDateFormat string = "_2."
 	TimeFormat        string = "15:04"
+	MonthFormat       string = "01/2006"
 )
 
 // GetText returns a localized text.
@@ -107,19 +109,137 @@ func createLogSummaryProgressSvgOvrUnd(logged int, overtime int, undertime int)
 var OverviewSummaryProgressColorRem = "#d6d6d6"
 
 // CreateOverviewSummaryProgressSvg creates a overview summary progress bar.
-func CreateOverviewSummaryProgressSvg(typeProgress map[int]int) string {
+func CreateOverviewSummaryProgressSvg(types []*model.OverviewTypeSummary) string {
 	return createProgressSvg(func() string {
-		ws, we := 0, typeProgress[model.EntryTypeIdWork]
-		ts, te := we, we+typeProgress[model.EntryTypeIdTravel]
-		vs, ve := te, te+typeProgress[model.EntryTypeIdVacation]
-		hs, he := ve, ve+typeProgress[model.EntryTypeIdHoliday]
-		is, ie := he, he+typeProgress[model.EntryTypeIdIllness]
-		return createProgressSvgRect(0, 100, OverviewSummaryProgressColorRem) +
-			createProgressSvgRect(ws, we, model.EntryTypeColors[model.EntryTypeIdWork]) +
-			createProgressSvgRect(ts, te, model.EntryTypeColors[model.EntryTypeIdTravel]) +
-			createProgressSvgRect(vs, ve, model.EntryTypeColors[model.EntryTypeIdVacation]) +
-			createProgressSvgRect(hs, he, model.EntryTypeColors[model.EntryTypeIdHoliday]) +
-			createProgressSvgRect(is, ie, model.EntryTypeColors[model.EntryTypeIdIllness])
+		svg := createProgressSvgRect(0, 100, OverviewSummaryProgressColorRem)
+		start := 0
+		for _, t := range types {
+			if t.Percentage == 0 {
+				continue
+			}
+			svg = svg + createProgressSvgRect(start, start+t.Percentage, t.Color)
+			start = start + t.Percentage
+		}
+		return svg
+	})
+}
+
+// --- Functions to render the year overview overtime SVG ---
+
+var OverviewYearOvertimeColorLine = "#0c63e4"
+var OverviewYearOvertimeColorZero = "#999999"
+
+// CreateOverviewYearOvertimeSvg creates a overview year overtime curve. The first value is the
+// balance at the start of the year, the following values are the balances at the end of the
+// months.
+func CreateOverviewYearOvertimeSvg(values []float32) string {
+	// Calculate value range (the zero line is always visible)
+	minValue, maxValue := float32(0.0), float32(0.0)
+	for _, v := range values {
+		minValue = min(minValue, v)
+		maxValue = max(maxValue, v)
+	}
+	if maxValue-minValue < 1.0 {
+		maxValue = minValue + 1.0
+	}
+
+	// Calculate y coordinate (with a margin at the top and the bottom)
+	getY := func(v float32) string {
+		y := 10.0 + (maxValue-v)/(maxValue-minValue)*180.0
+		return strconv.FormatFloat(float64(y), 'f', 1, 32)
+	}
+
+	return createSvg("0 0 1200 200", "100%", "150", func() string {
+		points := make([]string, 0, len(values))
+		for i, v := range values {
+			points = append(points, strconv.Itoa(i*100)+","+getY(v))
+		}
+		return `
+			<line
+				x1="0" y1="` + getY(0) + `" x2="1200" y2="` + getY(0) + `"
+				stroke="` + OverviewYearOvertimeColorZero + `"
+				stroke-dasharray="4"
+				vector-effect="non-scaling-stroke" />
+			<polyline
+				points="` + strings.Join(points, " ") + `"
+				fill="none"
+				stroke="` + OverviewYearOvertimeColorLine + `"
+				stroke-width="2"
+				vector-effect="non-scaling-stroke" />
+		`
+	})
+}
+
+// --- Functions to render the statistics SVGs ---
+
+// StatisticsColors holds the colors of the groups which are shown separately in the statistics
+// chart.
+var StatisticsColors = []string{
+	"#439dde", "#f7a951", "#57aa5a", "#df5755", "#977dc8",
+	"#3eaabd", "#f7c04c", "#e24970", "#8dbe5a",
+}
+
+var StatisticsColorOther = "#999999"
+var StatisticsColorRem = "#d6d6d6"
+
+// CreateStatisticsChartSvg creates a statistics chart with a stacked bar per bucket. Each bar
+// segment shows the name and the hours of its series as tooltip.
+func CreateStatisticsChartSvg(buckets int, series []*model.StatisticsSeries) string {
+	// Calculate highest bar
+	maxValue := float32(0.0)
+	for b := 0; b < buckets; b++ {
+		value := float32(0.0)
+		for _, s := range series {
+			value = value + s.Values[b]
+		}
+		maxValue = max(maxValue, value)
+	}
+	if maxValue == 0.0 {
+		maxValue = 1.0
+	}
+
+	// Every bucket is 10 units wide (with a gap of 2 units between the bars)
+	width := strconv.Itoa(max(buckets, 1) * 10)
+	printer := message.NewPrinter(loc.LngTag)
+	return createSvg("0 0 "+width+" 200", "100%", "200", func() string {
+		var body strings.Builder
+		for b := 0; b < buckets; b++ {
+			y := float32(200.0)
+			for _, s := range series {
+				if s.Values[b] == 0.0 {
+					continue
+				}
+				height := s.Values[b] / maxValue * 200.0
+				y = y - height
+				body.WriteString(`
+					<rect
+						x="` + strconv.Itoa(b*10+1) + `"
+						y="` + strconv.FormatFloat(float64(y), 'f', 2, 32) + `"
+						width="8"
+						height="` + strconv.FormatFloat(float64(height), 'f', 2, 32) + `"
+						fill="` + s.Color + `"
+					>
+						<title>` + html.EscapeString(s.Name) + `: ` +
+					printer.Sprintf("%.2f", s.Values[b]) + `</title>
+					</rect>
+				`)
+			}
+		}
+		body.WriteString(`
+			<line
+				x1="0" y1="200" x2="` + width + `" y2="200"
+				stroke="` + StatisticsColorOther + `"
+				vector-effect="non-scaling-stroke" />
+		`)
+		return body.String()
+	})
+}
+
+// CreateStatisticsShareSvg creates a progress bar which shows the share of a group.
+func CreateStatisticsShareSvg(percentage int, color string) string {
+	return createProgressSvg(func() string {
+		return createProgressSvgRect(0, 100, StatisticsColorRem) +
+			createProgressSvgRect(0, min(percentage, 100), color)
 	})
 }
 
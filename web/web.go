@@ -3,6 +3,7 @@ package web
 import (
 	"fmt"
 	"io"
+	"mime"
 	"strings"
 
 	"github.com/a-h/templ"
@@ -109,3 +110,21 @@ func WriteFile(ctx echo.Context, fileName string, file io.WriterTo) error {
 	}
 	return nil
 }
+
+// WriteAttachment writes the content of an attached file.
+func WriteAttachment(ctx echo.Context, fileName string, mimeType string, r io.Reader) error {
+	res := ctx.Response()
+
+	res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment",
+		map[string]string{"filename": fileName}))
+	res.Header().Set(echo.HeaderContentType, mimeType)
+	res.Header().Add(echo.HeaderCacheControl, "no-store")
+
+	_, wErr := io.Copy(res.Writer, r)
+	if wErr != nil {
+		err := e.WrapError(e.SysUnknown, "Could not write response.", wErr)
+		log.Debug(err.StackTrace())
+		return err
+	}
+	return nil
+}